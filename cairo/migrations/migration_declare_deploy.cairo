@@ -4,9 +4,14 @@
 func up() {
      
     %{
+        import os
+
         declare("./build/main.json", config={
                  "max_fee" : "auto"})
-        deploy_contract("./build/main.json",  config={"wait_for_acceptance": True})
+        # the owner is the account allowed to initialize the verifier
+        deploy_contract("./build/main.json",
+                 constructor_args=[int(os.environ["VERIFIER_OWNER"], 16)],
+                 config={"wait_for_acceptance": True})
     %}
 
     return ();
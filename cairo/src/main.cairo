@@ -1,5 +1,13 @@
 %lang starknet
-from starkware.cairo.common.math import assert_nn, split_felt, unsigned_div_rem, assert_lt
+from starkware.cairo.common.math import (
+    assert_nn,
+    split_felt,
+    unsigned_div_rem,
+    assert_lt,
+    assert_le,
+    assert_not_zero,
+    assert_not_equal,
+)
 from starkware.cairo.common.cairo_builtins import HashBuiltin, SignatureBuiltin
 from starkware.cairo.common.cairo_builtins import BitwiseBuiltin
 
@@ -13,6 +21,7 @@ from starkware.cairo.common.uint256 import (
 from starkware.cairo.common.hash import hash2
 from starkware.cairo.common.bitwise import bitwise_and
 from starkware.cairo.common.alloc import alloc
+from starkware.starknet.common.syscalls import get_block_timestamp, get_caller_address
 
 from src.structs import (
     TENDERMINTLIGHT_PROTO_GLOBAL_ENUMSSignedMsgType,
@@ -51,7 +60,14 @@ from src.utils import (
     blockIDEqual,
 )
 from src.hashing import  hash_felt_array, hash_int128_array
-from src.merkle import get_split_point, leafHash, innerHash, merkleRootHash
+from src.merkle import (
+    get_split_point,
+    leafHash,
+    innerHash,
+    merkleRootHash,
+    leafHash_int128_array,
+    computeHashFromAunts,
+)
 from src.struct_hasher import (
     hashHeader,
     canonicalPartSetHeaderHasher,
@@ -290,6 +306,190 @@ func verifyCommitLight{range_check_ptr, pedersen_ptr: HashBuiltin*, ecdsa_ptr: S
     return (0,);
 }
 
+// finds the validator with the given address, returning whether it was found
+// and its index in the array
+func find_validator(address: felt, validators_len: felt, validators: ValidatorData*) -> (
+    found: felt, index: felt
+) {
+    if (validators_len == 0) {
+        return (0, 0);
+    }
+
+    if (validators[0].Address == address) {
+        return (1, 0);
+    }
+
+    let (found: felt, index: felt) = find_validator(
+        address, validators_len - 1, validators + ValidatorData.SIZE
+    );
+    return (found, index + 1);
+}
+
+// asserts that index is not one of the seen_len first entries of seen
+func assert_not_seen(index: felt, seen_len: felt, seen: felt*) {
+    if (seen_len == 0) {
+        return ();
+    }
+    assert_not_equal(seen[0], index);
+    return assert_not_seen(index, seen_len - 1, seen + 1);
+}
+
+// like get_tallied_voting_power, but the signers are looked up by address in
+// a validator set other than the one that signed the commit. The indices of
+// the trusted validators already counted are recorded in seen, and a
+// validator signing twice is rejected, as in Tendermint's
+// VerifyCommitLightTrusting
+func get_tallied_voting_power_trusting{
+    pedersen_ptr: HashBuiltin*, ecdsa_ptr: SignatureBuiltin*, range_check_ptr
+}(
+    counter: felt,
+    commit: CommitData,
+    signatures_len: felt,
+    signatures: CommitSigData*,
+    validators_len: felt,
+    validators: ValidatorData*,
+    chain_id: ChainID,
+    seen_len: felt,
+    seen: felt*,
+) -> (res: felt) {
+    alloc_locals;
+
+    if (signatures_len == 0) {
+        return (0,);
+    }
+
+    local signature: CommitSigData = signatures[0];
+
+    tempvar BlockIDFlag = signature.block_id_flag.BlockIDFlag;
+
+    if (BlockIDFlag != BLOCK_ID_FLAG_COMMIT) {
+        let (rest_of_voting_power: felt) = get_tallied_voting_power_trusting(
+            counter + 1,
+            commit,
+            signatures_len - 1,
+            signatures + CommitSigData.SIZE,
+            validators_len,
+            validators,
+            chain_id=chain_id,
+            seen_len=seen_len,
+            seen=seen,
+        );
+        return (rest_of_voting_power,);
+    }
+
+    let (found: felt, index: felt) = find_validator(
+        signature.validator_address, validators_len, validators
+    );
+
+    // signers that are not part of the trusted set do not count
+    if (found == 0) {
+        let (rest_of_voting_power: felt) = get_tallied_voting_power_trusting(
+            counter + 1,
+            commit,
+            signatures_len - 1,
+            signatures + CommitSigData.SIZE,
+            validators_len,
+            validators,
+            chain_id=chain_id,
+            seen_len=seen_len,
+            seen=seen,
+        );
+        return (rest_of_voting_power,);
+    }
+
+    local val: ValidatorData = validators[index];
+
+    // each trusted validator counts once
+    assert seen[seen_len] = index;
+    assert_not_seen(seen[seen_len], seen_len, seen);
+
+    let (timestamp: TimestampData, res_hash: felt) = voteSignBytes(counter, commit, chain_id);
+
+    local timestamp_nanos: felt = timestamp.nanos;
+
+    let (local voteSB_array: felt*) = alloc();
+    let (high_res: felt, low_res:felt) = split_felt(res_hash);
+    assert voteSB_array[0] = 0;
+    assert voteSB_array[1] = timestamp_nanos;
+    assert voteSB_array[2] = high_res;
+    assert voteSB_array[3] = low_res;
+
+    let message1: felt = hash_int128_array(voteSB_array, 4);
+
+    local commit_sig_signature: SignatureData = signature.signature;
+    verifySig(val, message1, commit_sig_signature);
+
+    let (rest_of_voting_power: felt) = get_tallied_voting_power_trusting(
+        counter + 1,
+        commit,
+        signatures_len - 1,
+        signatures + CommitSigData.SIZE,
+        validators_len,
+        validators,
+        chain_id=chain_id,
+        seen_len=seen_len + 1,
+        seen=seen,
+    );
+    return (val.voting_power + rest_of_voting_power,);
+}
+
+// checks that more than trustLevel of the voting power of trustedVals signed
+// the commit
+func verifyCommitLightTrusting{
+    range_check_ptr, pedersen_ptr: HashBuiltin*, ecdsa_ptr: SignatureBuiltin*
+}(
+    trustedVals: ValidatorSetData,
+    chain_id: ChainID,
+    commit: CommitData,
+    trustLevel: FractionData,
+) -> (res: felt) {
+    alloc_locals;
+
+    // the trust level must be within [1/3, 1]
+    local numerator: felt = trustLevel.numerator;
+    local denominator: felt = trustLevel.denominator;
+    assert_lt(0, denominator);
+    assert_le(denominator, numerator * 3);
+    assert_le(numerator, denominator);
+
+    local vals_validators_length: felt = trustedVals.validators.len;
+    tempvar vals_validators_array: ValidatorData* = trustedVals.validators.array;
+    tempvar commit_signatures_length: felt = commit.signatures.len;
+    tempvar commit_signatures_array: CommitSigData* = commit.signatures.array;
+
+    let (seen: felt*) = alloc();
+    let (tallied_voting_power: felt) = get_tallied_voting_power_trusting{ecdsa_ptr=ecdsa_ptr}(
+        counter=0,
+        commit=commit,
+        signatures_len=commit_signatures_length,
+        signatures=commit_signatures_array,
+        validators_len=vals_validators_length,
+        validators=vals_validators_array,
+        chain_id=chain_id,
+        seen_len=0,
+        seen=seen,
+    );
+
+    let (total_voting_power: felt) = get_total_voting_power(
+        validators_len=vals_validators_length, validators=vals_validators_array
+    );
+
+    // tallied / total > numerator / denominator, compared without division
+    let tallied_voting_power_uint = Uint256(low=tallied_voting_power, high=0);
+    let total_voting_power_uint = Uint256(low=total_voting_power, high=0);
+    let numerator_uint = Uint256(low=numerator, high=0);
+    let denominator_uint = Uint256(low=denominator, high=0);
+
+    let (needed, needed_high) = uint256_mul(a=total_voting_power_uint, b=numerator_uint);
+    let (tallied, tallied_high) = uint256_mul(a=tallied_voting_power_uint, b=denominator_uint);
+
+    let (more_tallied_votes: felt) = uint256_lt(needed, tallied);
+
+    assert more_tallied_votes = 1;
+
+    return (1,);
+}
+
 func verifyAdjacent{
     range_check_ptr,
     pedersen_ptr: HashBuiltin*,
@@ -308,6 +508,10 @@ func verifyAdjacent{
     // check if the headers come from adjacent blocks
     assert untrustedHeader.header.height = trustedHeader.header.height + 1;
 
+    // check that the untrusted validators are the next validators of the
+    // trusted header, which differ from its own after a validator update
+    assert untrustedHeader.header.validators_hash = trustedHeader.header.next_validators_hash;
+
     // check that header is expired
     let (expired: felt) = isExpired(
         header=untrustedHeader, trustingPeriod=trustingPeriod, currentTime=currentTime
@@ -410,11 +614,209 @@ struct VerificationArgs {
 // ){
 // }
 
+// the account allowed to initialize the verifier with its trusted header
+@storage_var
+func owner() -> (address: felt) {
+}
+
+@constructor
+func constructor{syscall_ptr: felt*, pedersen_ptr: HashBuiltin*, range_check_ptr}(
+    owner_address: felt
+) {
+    assert_not_zero(owner_address);
+    owner.write(owner_address);
+    return ();
+}
+
 @storage_var
 func save_block() -> (untrusted_signed_header_hash: felt
 ){
 }
 
+// height of the block whose header hash is in save_block
+@storage_var
+func trusted_height() -> (height: felt) {
+}
+
+// hash of the chain ID and trusting period, in nanoseconds, of the trusted
+// header of initBlockData, which the evidence entry points check their
+// arguments against
+@storage_var
+func chain_id_hash() -> (hash: felt) {
+}
+
+@storage_var
+func trusting_period_nanos() -> (nanos: felt) {
+}
+
+// height of the highest block verified by the external entry points
+@storage_var
+func latest_settled_height() -> (height: felt) {
+}
+
+// header hash and validators hash of the blocks verified by the external
+// entry points, by height
+@storage_var
+func settled_header_hash(height: felt) -> (hash: felt) {
+}
+
+@storage_var
+func settled_validators_hash(height: felt) -> (hash: felt) {
+}
+
+// records the hashes of the block at height and raises the latest settled
+// height to height, blocks may be settled out of order when validators take
+// over from each other
+func record_settled_header{syscall_ptr: felt*, pedersen_ptr: HashBuiltin*, range_check_ptr}(
+    height: felt, header_hash: felt, validators_hash: felt
+) {
+    settled_header_hash.write(height, header_hash);
+    settled_validators_hash.write(height, validators_hash);
+    let (latest: felt) = latest_settled_height.read();
+    let higher = is_le(latest + 1, height);
+    if (higher == 1) {
+        latest_settled_height.write(height);
+        return ();
+    }
+    return ();
+}
+
+// asserts that chain_id is the chain ID of the trusted header of initBlockData
+func assert_chain_id{pedersen_ptr: HashBuiltin*, range_check_ptr, syscall_ptr: felt*}(
+    chain_id: ChainID
+) {
+    alloc_locals;
+
+    let (local hash: felt) = hash_int128_array(chain_id.chain_id_array, chain_id.len);
+    let (stored_hash: felt) = chain_id_hash.read();
+    assert_not_zero(stored_hash);
+    assert hash = stored_hash;
+    return ();
+}
+
+// asserts that header is the one settled at its height, so that the headers
+// verified against it are anchored in the trusted header of initBlockData
+func assert_settled_header{
+    syscall_ptr: felt*, pedersen_ptr: HashBuiltin*, range_check_ptr, bitwise_ptr: BitwiseBuiltin*
+}(header: SignedHeaderData) {
+    alloc_locals;
+
+    let (local header_hash: felt) = hashHeader(header);
+    let (settled_hash: felt) = settled_header_hash.read(header.header.height);
+    assert_not_zero(settled_hash);
+    assert header_hash = settled_hash;
+    return ();
+}
+
+// header hash and validators hash of the block settled at height, zero if it
+// was not settled
+@view
+func settledHeader{syscall_ptr: felt*, pedersen_ptr: HashBuiltin*, range_check_ptr}(
+    height: felt
+) -> (hash: felt, validators_hash: felt) {
+    let (hash: felt) = settled_header_hash.read(height);
+    let (validators_hash: felt) = settled_validators_hash.read(height);
+    return (hash, validators_hash);
+}
+
+@view
+func latestSettledHeight{syscall_ptr: felt*, pedersen_ptr: HashBuiltin*, range_check_ptr}() -> (
+    height: felt
+) {
+    return latest_settled_height.read();
+}
+
+// hash and height of the trusted header the saved entry points verify against
+@view
+func trustedHeader{syscall_ptr: felt*, pedersen_ptr: HashBuiltin*, range_check_ptr}() -> (
+    hash: felt, height: felt
+) {
+    let (hash: felt) = save_block.read();
+    let (height: felt) = trusted_height.read();
+    return (hash, height);
+}
+
+// height of the conflicting header of the first light client attack proven
+// with submitLightClientAttack, zero if there was none. Nothing is verified
+// once it is set.
+@storage_var
+func frozen_height() -> (height: felt) {
+}
+
+// height of the first duplicate vote proven against a validator with
+// submitDuplicateVote, zero if there was none
+@storage_var
+func slashed_height(address: felt) -> (height: felt) {
+}
+
+func assert_not_frozen{syscall_ptr: felt*, pedersen_ptr: HashBuiltin*, range_check_ptr}() {
+    let (height: felt) = frozen_height.read();
+    assert height = 0;
+    return ();
+}
+
+@view
+func frozenHeight{syscall_ptr: felt*, pedersen_ptr: HashBuiltin*, range_check_ptr}() -> (
+    height: felt
+) {
+    return frozen_height.read();
+}
+
+@view
+func slashedHeight{syscall_ptr: felt*, pedersen_ptr: HashBuiltin*, range_check_ptr}(
+    address: felt
+) -> (height: felt) {
+    return slashed_height.read(address);
+}
+
+// field indices of the header Merkle tree, see hashHeader
+const HEADER_FIELD_DATA_HASH = 6;
+const HEADER_FIELD_APP_HASH = 10;
+const HEADER_FIELD_LAST_RESULTS_HASH = 11;
+const HEADER_FIELDS = 14;
+
+// proves that leaf, split in 128 bit integers, is committed to by the field
+// of the header settled at height: a transaction hash under DataHash, a result
+// under LastResultsHash or a key and value under AppHash. aunts are the inner
+// hashes of the tree of the field, header_aunts those of the header tree.
+// Fails if the proof does not hold.
+@view
+func verifyInclusion{
+    syscall_ptr: felt*, pedersen_ptr: HashBuiltin*, range_check_ptr, bitwise_ptr: BitwiseBuiltin*
+}(
+    height: felt,
+    field: felt,
+    leaf_len: felt,
+    leaf: felt*,
+    index: felt,
+    total: felt,
+    aunts_len: felt,
+    aunts: felt*,
+    header_aunts_len: felt,
+    header_aunts: felt*,
+) -> (res: felt) {
+    alloc_locals;
+
+    assert (field - HEADER_FIELD_DATA_HASH) * (field - HEADER_FIELD_APP_HASH) * (
+        field - HEADER_FIELD_LAST_RESULTS_HASH) = 0;
+
+    let (local settled_hash: felt) = settled_header_hash.read(height);
+    assert_not_zero(settled_hash);
+
+    let (leaf_hash: felt) = leafHash_int128_array(leaf_len, leaf);
+    let (local root: felt) = computeHashFromAunts(index, total, leaf_hash, aunts_len, aunts, 1);
+
+    let (root_leaf_hash: felt) = leafHash(root);
+    let (header_hash: felt) = computeHashFromAunts(
+        field, HEADER_FIELDS, root_leaf_hash, header_aunts_len, header_aunts, 0
+    );
+    assert header_hash = settled_hash;
+    return (1,);
+}
+
+// trusts the header of trusted, against which the following headers are
+// verified, and stores its chain ID and the trusting period the evidence
+// entry points verify with. Only the owner may call it, once.
 @external
 func initBlockData{
     range_check_ptr,
@@ -432,9 +834,21 @@ func initBlockData{
     validator_array: ValidatorData*,
     trusted: SignedHeaderArgs,
     validator_set_args: ValidatorSetArgs,
+    trusting_period: DurationData,
 ) -> (res: felt) {
-    let chain_id = ChainID(chain_id_array=chain_id_array, len=chain_id_array_len);
+    alloc_locals;
+    assert_not_frozen();
 
+    // only the owner initializes the verifier, and only once: every header
+    // settled afterwards is verified against this one
+    let (caller: felt) = get_caller_address();
+    let (owner_address: felt) = owner.read();
+    assert caller = owner_address;
+    let (initialized_height: felt) = trusted_height.read();
+    assert initialized_height = 0;
+    assert_not_zero(trusted.header.height);
+
+    let chain_id = ChainID(chain_id_array=chain_id_array, len=chain_id_array_len);
 
     let trusted_signed_header: SignedHeaderData= createSignedHeader(
         commit_sig_array_len=trusted_commit_sig_array_len,
@@ -457,6 +871,13 @@ func initBlockData{
     let (header_hash:felt) = hashHeader(trusted_signed_header);
 
     save_block.write(header_hash);
+    trusted_height.write(trusted.header.height);
+    record_settled_header(trusted.header.height, header_hash, trusted.header.validators_hash);
+
+    assert_not_zero(trusting_period.nanos);
+    let (chain_id_hash_value: felt) = hash_int128_array(chain_id_array, chain_id_array_len);
+    chain_id_hash.write(chain_id_hash_value);
+    trusting_period_nanos.write(trusting_period.nanos);
     return(1,);
 }
 
@@ -484,7 +905,7 @@ func savedVerifyAdjacent{
     verification_args: VerificationArgs,
 ) -> (res: felt) {
     alloc_locals;
-
+    assert_not_frozen();
 
     let chain_id = ChainID(chain_id_array=chain_id_array, len=chain_id_array_len);
 
@@ -535,6 +956,7 @@ func savedVerifyAdjacent{
     let (untrusted_signed_header_hash :felt) = hashHeader(untrusted_signed_header);
 
     save_block.write(untrusted_signed_header_hash);
+    trusted_height.write(untrusted.header.height);
 
 
     return(1,);
@@ -547,6 +969,7 @@ func externalVerifyAdjacent{
     pedersen_ptr: HashBuiltin*,
     bitwise_ptr: BitwiseBuiltin*,
     ecdsa_ptr: SignatureBuiltin*,
+    syscall_ptr: felt*,
 }(
     chain_id_array_len: felt,
     chain_id_array: felt*,
@@ -561,6 +984,8 @@ func externalVerifyAdjacent{
     validator_set_args: ValidatorSetArgs,
     verification_args: VerificationArgs,
 ) -> (res: felt) {
+    alloc_locals;
+    assert_not_frozen();
     let chain_id = ChainID(chain_id_array=chain_id_array, len=chain_id_array_len);
 
     let trusted_signed_header = createSignedHeader(
@@ -569,6 +994,7 @@ func externalVerifyAdjacent{
         chain_id=chain_id,
         args=trusted,
     );
+    assert_settled_header(trusted_signed_header);
     let untrusted_signed_header = createSignedHeader(
         commit_sig_array_len=untrusted_commit_sig_array_len,
         commit_sig_array=untrusted_commit_sig_array,
@@ -585,7 +1011,7 @@ func externalVerifyAdjacent{
         total_voting_power=validator_set_args.total_voting_power,
     );
 
-    return verifyAdjacent(
+    verifyAdjacent(
         trustedHeader=trusted_signed_header,
         untrustedHeader=untrusted_signed_header,
         untrustedVals=untrusted_vals,
@@ -593,6 +1019,12 @@ func externalVerifyAdjacent{
         currentTime=verification_args.current_time,
         maxClockDrift=verification_args.max_clock_drift,
     );
+
+    let (untrusted_hash: felt) = hashHeader(untrusted_signed_header);
+    record_settled_header(
+        untrusted.header.height, untrusted_hash, untrusted.header.validators_hash
+    );
+    return (1,);
 }
 
 func verifyNonAdjacent{
@@ -618,13 +1050,10 @@ func verifyNonAdjacent{
         assert 1 = 2;
     }
 
-    //##############
-    // TODO Hash check
-    //    require(
-    //        trustedVals.hash() == trustedHeader.header.next_validators_hash.toBytes32(),
-    //        "LC: headers trusted validators does not hash to latest trusted validators"
-    //    );
-    //##############
+    // check that the trusted validators are the ones of the trusted header
+    tempvar trusted_header_valhash: felt = trustedHeader.header.validators_hash;
+    let (trusted_valhash: felt) = hashValidatorSet(trustedVals);
+    assert trusted_header_valhash = trusted_valhash;
 
     let (expired: felt) = isExpired(
         header=untrustedHeader, trustingPeriod=trustingPeriod, currentTime=currentTime
@@ -637,6 +1066,15 @@ func verifyNonAdjacent{
         untrustedHeader, trustedHeader, untrustedVals, currentTime, maxClockDrift
     );
 
+    // ensure that more than trustLevel of the last trusted validators signed
+    verifyCommitLightTrusting{ecdsa_ptr=ecdsa_ptr}(
+        trustedVals=trustedVals,
+        chain_id=trustedHeader.header.chain_id,
+        commit=untrustedHeader.commit,
+        trustLevel=trustLevel,
+    );
+
+    // ensure that more than 2/3 of the new validators signed
     verifyCommitLight{ecdsa_ptr=ecdsa_ptr}(
         vals=untrustedVals,
         chain_id=trustedHeader.header.chain_id,
@@ -644,5 +1082,330 @@ func verifyNonAdjacent{
         height=untrustedHeader.header.height,
         commit=untrustedHeader.commit,
     );
-    return (0,);
+    return (1,);
+}
+
+@external
+func externalVerifyNonAdjacent{
+    range_check_ptr,
+    pedersen_ptr: HashBuiltin*,
+    bitwise_ptr: BitwiseBuiltin*,
+    ecdsa_ptr: SignatureBuiltin*,
+    syscall_ptr: felt*,
+}(
+    chain_id_array_len: felt,
+    chain_id_array: felt*,
+    trusted_commit_sig_array_len: felt,
+    trusted_commit_sig_array: CommitSigData*,
+    untrusted_commit_sig_array_len: felt,
+    untrusted_commit_sig_array: CommitSigData*,
+    trusted_validator_array_len: felt,
+    trusted_validator_array: ValidatorData*,
+    untrusted_validator_array_len: felt,
+    untrusted_validator_array: ValidatorData*,
+    trusted: SignedHeaderArgs,
+    untrusted: SignedHeaderArgs,
+    trusted_validator_set_args: ValidatorSetArgs,
+    untrusted_validator_set_args: ValidatorSetArgs,
+    verification_args: VerificationArgs,
+    trust_level: FractionData,
+) -> (res: felt) {
+    alloc_locals;
+    assert_not_frozen();
+    let chain_id = ChainID(chain_id_array=chain_id_array, len=chain_id_array_len);
+
+    let trusted_signed_header = createSignedHeader(
+        commit_sig_array_len=trusted_commit_sig_array_len,
+        commit_sig_array=trusted_commit_sig_array,
+        chain_id=chain_id,
+        args=trusted,
+    );
+    assert_settled_header(trusted_signed_header);
+    let untrusted_signed_header = createSignedHeader(
+        commit_sig_array_len=untrusted_commit_sig_array_len,
+        commit_sig_array=untrusted_commit_sig_array,
+        chain_id=chain_id,
+        args=untrusted,
+    );
+
+    let trusted_vals = ValidatorSetData(
+        validators=ValidatorDataArray(
+            array=trusted_validator_array, len=trusted_validator_array_len
+        ),
+        proposer=trusted_validator_set_args.proposer,
+        total_voting_power=trusted_validator_set_args.total_voting_power,
+    );
+    let untrusted_vals = ValidatorSetData(
+        validators=ValidatorDataArray(
+            array=untrusted_validator_array, len=untrusted_validator_array_len
+        ),
+        proposer=untrusted_validator_set_args.proposer,
+        total_voting_power=untrusted_validator_set_args.total_voting_power,
+    );
+
+    verifyNonAdjacent(
+        trustedHeader=trusted_signed_header,
+        trustedVals=trusted_vals,
+        untrustedHeader=untrusted_signed_header,
+        untrustedVals=untrusted_vals,
+        trustingPeriod=verification_args.trusting_period,
+        currentTime=verification_args.current_time,
+        maxClockDrift=verification_args.max_clock_drift,
+        trustLevel=trust_level,
+    );
+
+    let (untrusted_hash: felt) = hashHeader(untrusted_signed_header);
+    record_settled_header(
+        untrusted.header.height, untrusted_hash, untrusted.header.validators_hash
+    );
+    return (1,);
+}
+
+struct VoteArgs {
+    type: felt,
+    height: felt,
+    round: felt,
+    block_id: BlockIDData,
+    timestamp: TimestampData,
+    validator_address: felt,
+    signature: SignatureData,
+}
+
+// verifies that val signed vote, the message being built like the one of the
+// signatures of a commit
+func verifyVoteSig{pedersen_ptr: HashBuiltin*, ecdsa_ptr: SignatureBuiltin*, range_check_ptr}(
+    vote: VoteArgs, chain_id: ChainID, val: ValidatorData
+) {
+    alloc_locals;
+
+    let CVData: CanonicalVoteData = CanonicalVoteData(
+        TENDERMINTLIGHT_PROTO_GLOBAL_ENUMSSignedMsgType=vote.type,
+        height=vote.height,
+        round=vote.round,
+        block_id=vote.block_id,
+        timestamp=vote.timestamp,
+        chain_id=chain_id,
+    );
+    let res_hash: felt = hashCanonicalVoteNoTime(CVData=CVData);
+
+    let (local voteSB_array: felt*) = alloc();
+    let (high_res: felt, low_res: felt) = split_felt(res_hash);
+    assert voteSB_array[0] = 0;
+    assert voteSB_array[1] = vote.timestamp.nanos;
+    assert voteSB_array[2] = high_res;
+    assert voteSB_array[3] = low_res;
+
+    let message: felt = hash_int128_array(voteSB_array, 4);
+    verifySig(val, message, vote.signature);
+    return ();
+}
+
+// proves that a validator of the set settled at the height of the votes
+// signed two votes for different blocks of the chain at the same height and
+// round, and records that height as the one the validator was slashed at
+@external
+func submitDuplicateVote{
+    range_check_ptr,
+    pedersen_ptr: HashBuiltin*,
+    bitwise_ptr: BitwiseBuiltin*,
+    ecdsa_ptr: SignatureBuiltin*,
+    syscall_ptr: felt*,
+}(
+    chain_id_array_len: felt,
+    chain_id_array: felt*,
+    validator_array_len: felt,
+    validator_array: ValidatorData*,
+    validator_set_args: ValidatorSetArgs,
+    vote_a: VoteArgs,
+    vote_b: VoteArgs,
+) -> (res: felt) {
+    alloc_locals;
+
+    // the votes are for the chain of the verifier
+    let chain_id = ChainID(chain_id_array=chain_id_array, len=chain_id_array_len);
+    assert_chain_id(chain_id);
+    let vals = ValidatorSetData(
+        validators=ValidatorDataArray(array=validator_array, len=validator_array_len),
+        proposer=validator_set_args.proposer,
+        total_voting_power=validator_set_args.total_voting_power,
+    );
+
+    // the validators are the ones the verifier settled at the height of the
+    // votes, which signed them
+    let (local settled_valhash: felt) = settled_validators_hash.read(vote_a.height);
+    assert_not_zero(settled_valhash);
+    let (valhash: felt) = hashValidatorSet(vals);
+    assert valhash = settled_valhash;
+
+    // the votes are for different blocks at the same step
+    assert vote_a.type = vote_b.type;
+    assert vote_a.height = vote_b.height;
+    assert vote_a.round = vote_b.round;
+    assert vote_a.validator_address = vote_b.validator_address;
+    let (local block_id_a: felt) = hashBlockID(vote_a.block_id);
+    let (local block_id_b: felt) = hashBlockID(vote_b.block_id);
+    assert_not_equal(block_id_a, block_id_b);
+
+    // and both are signed by the validator
+    let (found: felt, index: felt) = find_validator(
+        vote_a.validator_address, validator_array_len, validator_array
+    );
+    assert found = 1;
+    local val: ValidatorData = validator_array[index];
+    verifyVoteSig(vote_a, chain_id, val);
+    verifyVoteSig(vote_b, chain_id, val);
+
+    let (slashed: felt) = slashed_height.read(vote_a.validator_address);
+    if (slashed == 0) {
+        slashed_height.write(vote_a.validator_address, vote_a.height);
+        return (1,);
+    }
+    return (1,);
+}
+
+// records the height of conflicting, a header other than the one settled at
+// its height, as the height the verifier is frozen at
+func record_attack{
+    syscall_ptr: felt*, pedersen_ptr: HashBuiltin*, range_check_ptr, bitwise_ptr: BitwiseBuiltin*
+}(conflicting: SignedHeaderData) {
+    alloc_locals;
+
+    let (local conflicting_hash: felt) = hashHeader(conflicting);
+    let (local settled_hash: felt) = settled_header_hash.read(conflicting.header.height);
+    assert_not_zero(settled_hash);
+    assert_not_equal(conflicting_hash, settled_hash);
+
+    frozen_height.write(conflicting.header.height);
+    return ();
+}
+
+// proves a light client attack: the untrusted header verifies against the
+// trusted one, which was settled, but it is not the header settled at its
+// height. The verifier is frozen at the height of the untrusted header.
+// The current time is the timestamp of the block, and the trusting period
+// the one of initBlockData, so that the caller cannot revive expired
+// validators.
+@external
+func submitLightClientAttack{
+    range_check_ptr,
+    pedersen_ptr: HashBuiltin*,
+    bitwise_ptr: BitwiseBuiltin*,
+    ecdsa_ptr: SignatureBuiltin*,
+    syscall_ptr: felt*,
+}(
+    chain_id_array_len: felt,
+    chain_id_array: felt*,
+    trusted_commit_sig_array_len: felt,
+    trusted_commit_sig_array: CommitSigData*,
+    untrusted_commit_sig_array_len: felt,
+    untrusted_commit_sig_array: CommitSigData*,
+    trusted_validator_array_len: felt,
+    trusted_validator_array: ValidatorData*,
+    untrusted_validator_array_len: felt,
+    untrusted_validator_array: ValidatorData*,
+    trusted: SignedHeaderArgs,
+    untrusted: SignedHeaderArgs,
+    trusted_validator_set_args: ValidatorSetArgs,
+    untrusted_validator_set_args: ValidatorSetArgs,
+    max_clock_drift: DurationData,
+    trust_level: FractionData,
+) -> (res: felt) {
+    alloc_locals;
+    assert_not_frozen();
+    let chain_id = ChainID(chain_id_array=chain_id_array, len=chain_id_array_len);
+
+    let (block_timestamp: felt) = get_block_timestamp();
+    let (trusting_period: felt) = trusting_period_nanos.read();
+    assert_not_zero(trusting_period);
+    local verification_args: VerificationArgs = VerificationArgs(
+        current_time=DurationData(nanos=block_timestamp * 1000000000),
+        max_clock_drift=max_clock_drift,
+        trusting_period=DurationData(nanos=trusting_period),
+    );
+
+    let trusted_signed_header = createSignedHeader(
+        commit_sig_array_len=trusted_commit_sig_array_len,
+        commit_sig_array=trusted_commit_sig_array,
+        chain_id=chain_id,
+        args=trusted,
+    );
+    let untrusted_signed_header = createSignedHeader(
+        commit_sig_array_len=untrusted_commit_sig_array_len,
+        commit_sig_array=untrusted_commit_sig_array,
+        chain_id=chain_id,
+        args=untrusted,
+    );
+
+    let trusted_vals = ValidatorSetData(
+        validators=ValidatorDataArray(
+            array=trusted_validator_array, len=trusted_validator_array_len
+        ),
+        proposer=trusted_validator_set_args.proposer,
+        total_voting_power=trusted_validator_set_args.total_voting_power,
+    );
+    let untrusted_vals = ValidatorSetData(
+        validators=ValidatorDataArray(
+            array=untrusted_validator_array, len=untrusted_validator_array_len
+        ),
+        proposer=untrusted_validator_set_args.proposer,
+        total_voting_power=untrusted_validator_set_args.total_voting_power,
+    );
+
+    verifyLightClientAttack(
+        trustedHeader=trusted_signed_header,
+        trustedVals=trusted_vals,
+        untrustedHeader=untrusted_signed_header,
+        untrustedVals=untrusted_vals,
+        verification_args=verification_args,
+        trust_level=trust_level,
+    );
+    return (1,);
+}
+
+func verifyLightClientAttack{
+    range_check_ptr,
+    pedersen_ptr: HashBuiltin*,
+    bitwise_ptr: BitwiseBuiltin*,
+    ecdsa_ptr: SignatureBuiltin*,
+    syscall_ptr: felt*,
+}(
+    trustedHeader: SignedHeaderData,
+    trustedVals: ValidatorSetData,
+    untrustedHeader: SignedHeaderData,
+    untrustedVals: ValidatorSetData,
+    verification_args: VerificationArgs,
+    trust_level: FractionData,
+) {
+    alloc_locals;
+
+    // the trusted header was settled
+    assert_settled_header(trustedHeader);
+
+    tempvar untrusted_header_height = untrustedHeader.header.height;
+    tempvar trusted_header_height = trustedHeader.header.height;
+    if (untrusted_header_height == trusted_header_height + 1) {
+        verifyAdjacent(
+            trustedHeader=trustedHeader,
+            untrustedHeader=untrustedHeader,
+            untrustedVals=untrustedVals,
+            trustingPeriod=verification_args.trusting_period,
+            currentTime=verification_args.current_time,
+            maxClockDrift=verification_args.max_clock_drift,
+        );
+        record_attack(untrustedHeader);
+        return ();
+    }
+
+    verifyNonAdjacent(
+        trustedHeader=trustedHeader,
+        trustedVals=trustedVals,
+        untrustedHeader=untrustedHeader,
+        untrustedVals=untrustedVals,
+        trustingPeriod=verification_args.trusting_period,
+        currentTime=verification_args.current_time,
+        maxClockDrift=verification_args.max_clock_drift,
+        trustLevel=trust_level,
+    );
+    record_attack(untrustedHeader);
+    return ();
 }
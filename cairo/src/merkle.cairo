@@ -212,3 +212,94 @@ func merkleRootHash_int128{pedersen_ptr: HashBuiltin*, range_check_ptr, bitwise_
         }
     }
 }
+
+// leaf hash of the Merkle trees of transactions, results and application
+// state, whose leaves are split in 128 bit integers. An empty leaf hashes as
+// the prefix alone.
+func leafHash_int128_array{pedersen_ptr: HashBuiltin*, range_check_ptr}(
+    leaf_len: felt, leaf: felt*
+) -> (res_hash: felt) {
+    alloc_locals;
+
+    if (leaf_len == 0) {
+        let (res_hash: felt) = hash2{hash_ptr=pedersen_ptr}(0, 0);
+        return (res_hash,);
+    }
+
+    let res_hash: felt = hash_int128_array_with_prefix(
+        array_pointer=leaf, array_pointer_len=leaf_len, prefix=0
+    );
+
+    return (res_hash,);
+}
+
+// inner hash of the same trees: the prefix and the children are split in 128
+// bit integers
+func innerHash_split{range_check_ptr, pedersen_ptr: HashBuiltin*}(left: felt, right: felt) -> (
+    res_hash: felt
+) {
+    alloc_locals;
+
+    let (left_high: felt, left_low: felt) = split_felt(left);
+    let (right_high: felt, right_low: felt) = split_felt(right);
+
+    let (local to_hash_array: felt*) = alloc();
+    assert to_hash_array[0] = 1;
+    assert to_hash_array[1] = left_high;
+    assert to_hash_array[2] = left_low;
+    assert to_hash_array[3] = right_high;
+    assert to_hash_array[4] = right_low;
+
+    let res_hash: felt = hash_int128_array_with_prefix(
+        array_pointer=to_hash_array, array_pointer_len=5, prefix=0
+    );
+
+    return (res_hash,);
+}
+
+// root of the Merkle tree of total leaves whose leaf at index hashes to
+// leaf_hash, aunts are the inner hashes from the bottom of the tree up.
+// is_split selects innerHash_split over innerHash.
+func computeHashFromAunts{pedersen_ptr: HashBuiltin*, range_check_ptr, bitwise_ptr: BitwiseBuiltin*}(
+    index: felt, total: felt, leaf_hash: felt, aunts_len: felt, aunts: felt*, is_split: felt
+) -> (res_hash: felt) {
+    alloc_locals;
+
+    assert_nn(index);
+    assert_lt(index, total);
+
+    if (total == 1) {
+        assert aunts_len = 0;
+        return (leaf_hash,);
+    }
+
+    assert_nn(aunts_len - 1);
+    local aunt: felt = aunts[aunts_len - 1];
+    let split_point: felt = get_split_point(total);
+    let is_left: felt = is_le(index + 1, split_point);
+
+    if (is_left == 1) {
+        let left: felt = computeHashFromAunts(
+            index, split_point, leaf_hash, aunts_len - 1, aunts, is_split
+        );
+        let res_hash: felt = innerHash_select(left, aunt, is_split);
+        return (res_hash,);
+    }
+
+    let right: felt = computeHashFromAunts(
+        index - split_point, total - split_point, leaf_hash, aunts_len - 1, aunts, is_split
+    );
+    let res_hash: felt = innerHash_select(aunt, right, is_split);
+    return (res_hash,);
+}
+
+func innerHash_select{range_check_ptr, pedersen_ptr: HashBuiltin*}(
+    left: felt, right: felt, is_split: felt
+) -> (res_hash: felt) {
+    if (is_split == 1) {
+        let res_hash: felt = innerHash_split(left, right);
+        return (res_hash,);
+    }
+    let res_hash: felt = innerHash(left, right);
+    return (res_hash,);
+}
@@ -9,6 +9,11 @@ from src.main import (
     verifyAdjacent,
     verifyNonAdjacent,
     externalVerifyAdjacent,
+    settled_header_hash,
+    latestSettledHeight,
+    settledHeader,
+    frozenHeight,
+    slashedHeight,
     HeaderArgs,
     CommitArgs,
     SignedHeaderArgs,
@@ -63,6 +68,7 @@ from starkware.cairo.common.cairo_builtins import HashBuiltin, SignatureBuiltin
 from starkware.cairo.common.cairo_builtins import BitwiseBuiltin
 
 from starkware.cairo.common.alloc import alloc
+from starkware.starknet.common.syscalls import get_contract_address
 from starkware.cairo.common.registers import get_ap, get_fp_and_pc
 from starkware.cairo.common.hash import hash2
 from starkware.cairo.common.math import assert_nn, split_felt, unsigned_div_rem
@@ -517,12 +523,14 @@ func test_real_data{
     return ();
 }
 
-@external
-func test_external{
+// verifies the header at height 3 against the one at height 2 with
+// externalVerifyAdjacent
+func verify_adjacent_3{
     range_check_ptr,
     pedersen_ptr: HashBuiltin*,
     bitwise_ptr: BitwiseBuiltin*,
     ecdsa_ptr: SignatureBuiltin*,
+    syscall_ptr: felt*,
 }() -> () {
     alloc_locals;
 
@@ -674,7 +682,60 @@ func test_external{
         trusting_period=DurationData(nanos=99999999999999999999),
         ),
     );
+    return ();
+}
+
+@external
+func test_external{
+    range_check_ptr,
+    pedersen_ptr: HashBuiltin*,
+    bitwise_ptr: BitwiseBuiltin*,
+    ecdsa_ptr: SignatureBuiltin*,
+    syscall_ptr: felt*,
+}() -> () {
+    alloc_locals;
+
+    // the header at height 2 was settled
+    settled_header_hash.write(
+        2, 2059766791315474971233242291515003944317013849850428055013818287621749261948
+    );
+    verify_adjacent_3();
+
+    let (settled_height: felt) = latestSettledHeight();
+    assert settled_height = 3;
+
+    let (settled_hash: felt, settled_validators_hash: felt) = settledHeader(3);
+    assert settled_hash = 490484232464039218793463646794795012959740951355156173400258415888395419419;
+    assert settled_validators_hash = 2831012649517925635638083284349758092553206116379646415063645608642406529898;
+    let (trusted_hash: felt, trusted_validators_hash: felt) = settledHeader(2);
+    assert trusted_hash = 2059766791315474971233242291515003944317013849850428055013818287621749261948;
+    let (unsettled_hash: felt, unsettled_validators_hash: felt) = settledHeader(4);
+    assert unsettled_hash = 0;
+
+    // no evidence was submitted
+    let (frozen_height: felt) = frozenHeight();
+    assert frozen_height = 0;
+    let (slashed_height: felt) = slashedHeight(
+        335674479734934146889037038263903380498452542860978104900782795296756624142
+    );
+    assert slashed_height = 0;
+
+    return ();
+}
 
+@external
+func test_external_unsettled_trusted{
+    range_check_ptr,
+    pedersen_ptr: HashBuiltin*,
+    bitwise_ptr: BitwiseBuiltin*,
+    ecdsa_ptr: SignatureBuiltin*,
+    syscall_ptr: felt*,
+}() -> () {
+    alloc_locals;
+
+    // the trusted header was never settled, so nothing is anchored in it
+    %{ expect_revert() %}
+    verify_adjacent_3();
     return ();
 }
 
@@ -690,7 +751,8 @@ namespace Contract {
     validator_array_len: felt,
     validator_array: ValidatorData*,
     trusted: SignedHeaderArgs,
-    validator_set_args: ValidatorSetArgs) -> (res: felt){
+    validator_set_args: ValidatorSetArgs,
+    trusting_period: DurationData) -> (res: felt){
     }
 
     func savedVerifyAdjacent(
@@ -708,16 +770,19 @@ namespace Contract {
     verification_args: VerificationArgs
 ) -> (res: felt){
 }
+
+    func trustedHeader() -> (hash: felt, height: felt){
+    }
 }
 
-@external
-func test_saved{
+// initializes the verifier at contract_address with the header at height 2
+func init_block_data{
     range_check_ptr,
     pedersen_ptr: HashBuiltin*,
     bitwise_ptr: BitwiseBuiltin*,
     ecdsa_ptr: SignatureBuiltin*,
     syscall_ptr: felt*,
-}() -> () {
+}(contract_address: felt) {
     alloc_locals;
 
     // chain_id_array
@@ -746,20 +811,6 @@ func test_saved{
     assert trusted_commit_sig_array[0] = trusted_commit_sig;
     let trusted_commit_sig_array_len = 1;
 
-    // untrusted commit_sig_array
-    let untrusted_signature_data: SignatureData = SignatureData(
-        signature_r=3605504498823257379762570133327870210455706278164450482388963404778814325454,
-        signature_s=3133371732092557530256163168714261110099475276750495027673839161202089731597,
-    );
-
-    local untrusted_commit_sig: CommitSigData = CommitSigData(
-        block_id_flag=Tendermint_BlockIDFLag_Commit, validator_address=335674479734934146889037038263903380498452542860978104900782795296756624142,
-        timestamp=TimestampData(nanos=1665753889554053779), signature=untrusted_signature_data);
-
-    let (local untrusted_commit_sig_array: CommitSigData*) = alloc();
-    assert untrusted_commit_sig_array[0] = untrusted_commit_sig;
-    let untrusted_commit_sig_array_len = 1;
-
     // create validator array
     let (local validator_array: ValidatorData*) = alloc();
     let public_key0: PublicKeyData = PublicKeyData(
@@ -774,15 +825,7 @@ func test_saved{
     assert validator_array[0] = validator;
     let validator_array_len = 1;
 
-    local contract_address;
- 
-    %{
-        declared = declare("src/main.cairo")
-        prepared = prepare(declared)
-        ids.contract_address = deploy(prepared).contract_address
-    %}
-    
-    let (res_init) = Contract.initBlockData(
+    Contract.initBlockData(
         contract_address,
         chain_id_array_len=chain_id_array_len,
         chain_id_array=chain_id_array,
@@ -837,14 +880,93 @@ func test_saved{
             ),
         total_voting_power=10,
         ),
+        trusting_period=DurationData(nanos=604800000000000),
+    );
+    return ();
+}
+
+@external
+func test_saved{
+    range_check_ptr,
+    pedersen_ptr: HashBuiltin*,
+    bitwise_ptr: BitwiseBuiltin*,
+    ecdsa_ptr: SignatureBuiltin*,
+    syscall_ptr: felt*,
+}() -> () {
+    alloc_locals;
+
+    // chain_id_array
+    let (local chain_id_array: felt*) = alloc();
+    assert chain_id_array[0] = 116;
+    assert chain_id_array[1] = 7310314358442582377;
+    assert chain_id_array[2] = 7939082473277174873;
+    let chain_id_array_len = 3;
+
+    // commit_sig_array
+    let Tendermint_BlockIDFLag_Commit = TENDERMINTLIGHT_PROTO_GLOBAL_ENUMSBlockIDFlag(
+        BlockIDFlag=2
+    );
+
+    // trusted commit_sig_array
+    let trusted_signature_data: SignatureData = SignatureData(
+        signature_r=1834131662309943167060654729634590738983734585222746799362362058903754262332,
+        signature_s=1745065597501682152537867859965459308365142243262023073853228716084356784546,
+    );
+
+    local trusted_commit_sig: CommitSigData = CommitSigData(
+        block_id_flag=Tendermint_BlockIDFLag_Commit, validator_address=335674479734934146889037038263903380498452542860978104900782795296756624142,
+        timestamp=TimestampData(nanos=1665753877127453388), signature=trusted_signature_data);
+
+    let (local trusted_commit_sig_array: CommitSigData*) = alloc();
+    assert trusted_commit_sig_array[0] = trusted_commit_sig;
+    let trusted_commit_sig_array_len = 1;
 
+    // untrusted commit_sig_array
+    let untrusted_signature_data: SignatureData = SignatureData(
+        signature_r=3605504498823257379762570133327870210455706278164450482388963404778814325454,
+        signature_s=3133371732092557530256163168714261110099475276750495027673839161202089731597,
     );
 
+    local untrusted_commit_sig: CommitSigData = CommitSigData(
+        block_id_flag=Tendermint_BlockIDFLag_Commit, validator_address=335674479734934146889037038263903380498452542860978104900782795296756624142,
+        timestamp=TimestampData(nanos=1665753889554053779), signature=untrusted_signature_data);
+
+    let (local untrusted_commit_sig_array: CommitSigData*) = alloc();
+    assert untrusted_commit_sig_array[0] = untrusted_commit_sig;
+    let untrusted_commit_sig_array_len = 1;
+
+    // create validator array
+    let (local validator_array: ValidatorData*) = alloc();
+    let public_key0: PublicKeyData = PublicKeyData(
+        ecdsa=3334500756028199475433036722527134417926233723147766471089429384364098171865
+    );
+    let validator: ValidatorData = ValidatorData(
+        Address=335674479734934146889037038263903380498452542860978104900782795296756624142,
+        pub_key=public_key0,
+        voting_power=10,
+        proposer_priority=0,
+    );
+    assert validator_array[0] = validator;
+    let validator_array_len = 1;
+
+    let (local self: felt) = get_contract_address();
+    local contract_address;
+    %{
+        declared = declare("src/main.cairo")
+        prepared = prepare(declared, [ids.self])
+        ids.contract_address = deploy(prepared).contract_address
+    %}
+
+    init_block_data(contract_address);
+
     %{
         felt_val = load(ids.contract_address, "save_block", "felt")
         print(felt_val)
     %}
 
+    let (trusted_hash: felt, trusted_height: felt) = Contract.trustedHeader(contract_address);
+    assert trusted_height = 2;
+
     let (res_saved ) = Contract.savedVerifyAdjacent(
         contract_address,
         chain_id_array_len=chain_id_array_len,
@@ -941,5 +1063,35 @@ func test_saved{
         ),
     );
 
+    let (saved_hash: felt, saved_height: felt) = Contract.trustedHeader(contract_address);
+    assert saved_height = 3;
+
+    // the verifier is initialized only once
+    %{ expect_revert() %}
+    init_block_data(contract_address);
+
     return ();
-}
\ No newline at end of file
+}
+
+@external
+func test_init_owner{
+    range_check_ptr,
+    pedersen_ptr: HashBuiltin*,
+    bitwise_ptr: BitwiseBuiltin*,
+    ecdsa_ptr: SignatureBuiltin*,
+    syscall_ptr: felt*,
+}() -> () {
+    alloc_locals;
+
+    // the verifier is owned by another account
+    local contract_address;
+    %{
+        declared = declare("src/main.cairo")
+        prepared = prepare(declared, [1])
+        ids.contract_address = deploy(prepared).contract_address
+    %}
+
+    %{ expect_revert() %}
+    init_block_data(contract_address);
+    return ();
+}
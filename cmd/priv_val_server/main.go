@@ -10,6 +10,7 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
@@ -18,6 +19,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 
+	"github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/libs/log"
 	tmnet "github.com/tendermint/tendermint/libs/net"
 	tmos "github.com/tendermint/tendermint/libs/os"
@@ -40,11 +42,18 @@ func main() {
 		chainID          = flag.String("chain-id", "mychain", "chain id")
 		privValKeyPath   = flag.String("priv-key", "", "priv val key file path")
 		privValStatePath = flag.String("priv-state", "", "priv val state file path")
+		passphrasePath   = flag.String("priv-key-passphrase", "", "encrypted priv val key passphrase file path")
 		insecure         = flag.Bool("insecure", false, "allow server to run insecurely (no TLS)")
 		certFile         = flag.String("certfile", "", "absolute path to server certificate")
 		keyFile          = flag.String("keyfile", "", "absolute path to server key")
 		rootCA           = flag.String("rootcafile", "", "absolute path to root CA")
 		prometheusAddr   = flag.String("prometheus-addr", "", "address for prometheus endpoint (host:port)")
+		starknetKeyPath  = flag.String("starknet-key", "", "settlement starknet account key file path")
+		starknetCalls    = flag.String("starknet-allowed-calls", "",
+			"comma separated <contract address>:<function> calls the starknet transactions may make")
+		starknetAccount = flag.String("starknet-account", "", "settlement starknet account address the key signs for")
+		starknetChainID = flag.String("starknet-chain-id", "", "chain id of the settlement starknet network")
+		starknetMaxFee  = flag.String("starknet-max-fee", "", "maximum fee of the starknet transactions, in wei")
 
 		logger = log.MustNewDefaultLogger(log.LogFormatPlain, log.LogLevelInfo, false).
 			With("module", "priv_val")
@@ -61,14 +70,37 @@ func main() {
 		"certFile", *certFile,
 		"keyFile", *keyFile,
 		"rootCA", *rootCA,
+		"starknetKeyPath", *starknetKeyPath,
 	)
 
-	pv, err := privval.LoadFilePV(*privValKeyPath, *privValStatePath)
+	passphrase, err := (&config.PrivValidatorConfig{KeyPassphrase: *passphrasePath}).ReadKeyPassphrase()
 	if err != nil {
 		fmt.Fprint(os.Stderr, err)
 		os.Exit(1)
 	}
 
+	pv, err := privval.LoadFilePVWithPassphrase(*privValKeyPath, *privValStatePath, passphrase)
+	if err != nil {
+		fmt.Fprint(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if *starknetKeyPath != "" {
+		var calls []string
+		if *starknetCalls != "" {
+			calls = strings.Split(*starknetCalls, ",")
+		}
+		limits, err := privval.ParseStarknetLimits(*starknetAccount, *starknetChainID, *starknetMaxFee)
+		if err != nil {
+			fmt.Fprint(os.Stderr, err)
+			os.Exit(1)
+		}
+		if err := pv.LoadStarknetKey(*starknetKeyPath, calls, limits); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to load starknet key: %v", err)
+			os.Exit(1)
+		}
+	}
+
 	opts := []grpc.ServerOption{}
 	if !*insecure {
 		certificate, err := tls.LoadX509KeyPair(*certFile, *keyFile)
@@ -14,11 +14,23 @@ import (
 var GenNodeKeyCmd = &cobra.Command{
 	Use:   "gen-node-key",
 	Short: "Generate a new node key",
-	RunE:  genNodeKey,
+	Long: `Generate a new node key. A stark node key authenticates the node with the
+key family of the validators, and has a 64-character node ID instead of 40.`,
+	RunE: genNodeKey,
+}
+
+var nodeKeyType string
+
+func init() {
+	GenNodeKeyCmd.Flags().StringVar(&nodeKeyType, "key", types.ABCIPubKeyTypeEd25519,
+		"Key type to generate the node key with. Options: ed25519, stark")
 }
 
 func genNodeKey(cmd *cobra.Command, args []string) error {
-	nodeKey := types.GenNodeKey()
+	nodeKey, err := types.GenNodeKeyOfType(nodeKeyType)
+	if err != nil {
+		return err
+	}
 
 	bz, err := tmjson.Marshal(nodeKey)
 	if err != nil {
@@ -15,18 +15,43 @@ import (
 var GenValidatorCmd = &cobra.Command{
 	Use:   "gen-validator",
 	Short: "Generate new validator keypair",
-	RunE:  genValidator,
+	Long: `Generate a new validator keypair and print it as JSON.
+
+With --mnemonic, the stark key is derived from a BIP-39 mnemonic along the
+EIP-2645 path m/2645'/<starknet>'/<slush>'/0'/0'/0, and the mnemonic is printed
+to stderr with the public key of the Starknet account at m/44'/9004'/0'/0/0, the
+first account of the Starknet wallets importing the mnemonic. With --recover,
+the mnemonic is read from stdin.`,
+	Example: `
+	slush gen-validator --mnemonic > priv_validator_key.json
+	slush gen-validator --mnemonic --recover < mnemonic.txt > priv_validator_key.json
+	`,
+	RunE: genValidator,
 }
 
 func init() {
 	GenValidatorCmd.Flags().StringVar(&keyType, "key", types.ABCIPubKeyTypeStark,
 		"Key type to generate privval file with. Options: ed25519, secp256k1")
+	addMnemonicFlags(GenValidatorCmd)
 }
 
 func genValidator(cmd *cobra.Command, args []string) error {
-	pv, err := privval.GenFilePV("", "", keyType)
-	if err != nil {
-		return err
+	var (
+		pv  *privval.FilePV
+		err error
+	)
+	if useMnemonic {
+		keys, err := deriveMnemonicKeys(cmd.InOrStdin())
+		if err != nil {
+			return err
+		}
+		keys.print(cmd.ErrOrStderr())
+		pv = privval.NewFilePV(keys.validator, "", "")
+	} else {
+		pv, err = privval.GenFilePV("", "", keyType)
+		if err != nil {
+			return err
+		}
 	}
 
 	jsbz, err := tmjson.Marshal(pv)
@@ -4,12 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"path/filepath"
+	"os"
 
 	"github.com/spf13/cobra"
 	cfg "github.com/tendermint/tendermint/config"
-	"github.com/tendermint/tendermint/internal/settlement/protostar"
-	"github.com/tendermint/tendermint/libs/log"
 	tmos "github.com/tendermint/tendermint/libs/os"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
 	tmtime "github.com/tendermint/tendermint/libs/time"
@@ -36,6 +34,7 @@ var (
 func init() {
 	InitFilesCmd.Flags().StringVar(&network, "network", "devnet", "Network to deploy on: testnet or devnet.")
 	InitFilesCmd.Flags().StringVar(&accountAddress, "account-address", "", "Account address to use for the node.")
+	addMnemonicFlags(InitFilesCmd)
 }
 
 func initFiles(cmd *cobra.Command, args []string) error {
@@ -49,18 +48,80 @@ func initFiles(cmd *cobra.Command, args []string) error {
 			return errors.New("must specify an account address: slush init --account-address <address>")
 		}
 
+		// the keys are derived and checked before the verifier is deployed,
+		// so that a wrong mnemonic does not waste a deployment
+		var keys *mnemonicKeys
+		if useMnemonic {
+			var err error
+			if keys, err = deriveMnemonicKeys(cmd.InOrStdin()); err != nil {
+				return err
+			}
+			if err := checkMnemonicKeys(config); err != nil {
+				return err
+			}
+		}
+
 		if err := initProtostarConfig(config, accountAddress, network); err != nil {
 			return err
 		}
 
-		if err := initVerifierAddress(config, logger); err != nil {
+		if err := initVerifierAddress(config); err != nil {
 			return err
 		}
+
+		if keys != nil {
+			if err := initMnemonicKeys(config, keys); err != nil {
+				return err
+			}
+			keys.print(cmd.ErrOrStderr())
+		}
 	}
 
 	return initFilesWithConfig(config)
 }
 
+// checkMnemonicKeys checks that the validator key derived from a mnemonic can
+// be saved: the private validator key must not exist yet.
+func checkMnemonicKeys(config *cfg.Config) error {
+	privValKeyFile := config.PrivValidator.KeyFile()
+	if tmos.FileExists(privValKeyFile) {
+		return fmt.Errorf("private validator key %s exists already, remove it to derive one from the mnemonic", privValKeyFile)
+	}
+	_, err := config.PrivValidator.ReadKeyPassphrase()
+	return err
+}
+
+// initMnemonicKeys saves the validator key derived from a mnemonic, and the
+// account key to the starknet key file of the priv validator if one is
+// configured. Existing key files are left as they are.
+func initMnemonicKeys(config *cfg.Config, keys *mnemonicKeys) error {
+	if err := checkMnemonicKeys(config); err != nil {
+		return err
+	}
+	privValKeyFile := config.PrivValidator.KeyFile()
+	passphrase, err := config.PrivValidator.ReadKeyPassphrase()
+	if err != nil {
+		return err
+	}
+	pv := privval.NewFilePV(keys.validator, privValKeyFile, config.PrivValidator.StateFile())
+	pv.Key.SetPassphrase(passphrase)
+	pv.Save()
+	logger.Info("Derived private validator from the mnemonic", "keyFile", privValKeyFile)
+
+	accountKeyFile := config.PrivValidator.StarknetKeyFile()
+	switch {
+	case accountKeyFile == "":
+	case tmos.FileExists(accountKeyFile):
+		logger.Info("Found starknet key, not overwritten with the one of the mnemonic", "path", accountKeyFile)
+	default:
+		if err := os.WriteFile(accountKeyFile, []byte(keys.accountKeyHex()+"\n"), 0600); err != nil {
+			return err
+		}
+		logger.Info("Derived starknet key from the mnemonic", "path", accountKeyFile)
+	}
+	return nil
+}
+
 func initProtostarConfig(conf *cfg.Config, accountAddress, network string) error {
 	switch network {
 	case "testnet":
@@ -82,39 +143,38 @@ func initProtostarConfig(conf *cfg.Config, accountAddress, network string) error
 	return nil
 }
 
-func initVerifierAddress(conf *cfg.Config, logger log.Logger) (err error) {
-	classHashHex, transactionHashHex, err := protostar.Declare(logger, conf.Protostar, filepath.Join(conf.CairoDir, "build/main.json"))
+// initVerifierAddress deploys a verifier on the default settlement target and
+// records it in conf.
+func initVerifierAddress(conf *cfg.Config) error {
+	target, err := settlementTarget(conf, cfg.DefaultSettlementTarget)
 	if err != nil {
-		return
-	}
-	logger.Info(fmt.Sprintf("Successfully sent declare tx with classHash: %s and transactionHash: %s", classHashHex, transactionHashHex))
-
-	contractAddressHex, transactionHex, err := protostar.Deploy(logger, conf.Protostar, classHashHex)
-	if err != nil {
-		return
+		return err
 	}
-	logger.Info(fmt.Sprintf("Successfully sent deploy tx with contractAddress: %s and transactionHash: %s", contractAddressHex, transactionHex))
-
+	classHashHex, contractAddressHex, err := deployVerifier(context.Background(), conf, target)
 	if err != nil {
-		return
+		return err
 	}
-
-	conf.VerifierAddress = contractAddressHex
-	return
+	recordVerifier(conf, target.Name, classHashHex, contractAddressHex)
+	return nil
 }
 
 func initFilesWithConfig(config *cfg.Config) error {
 	var (
-		pv  *privval.FilePV
-		err error
+		pv         *privval.FilePV
+		passphrase []byte
+		err        error
 	)
 
 	if config.Mode == cfg.ModeValidator {
 		// private validator
 		privValKeyFile := config.PrivValidator.KeyFile()
 		privValStateFile := config.PrivValidator.StateFile()
+		passphrase, err = config.PrivValidator.ReadKeyPassphrase()
+		if err != nil {
+			return err
+		}
 		if tmos.FileExists(privValKeyFile) {
-			pv, err = privval.LoadFilePV(privValKeyFile, privValStateFile)
+			pv, err = privval.LoadFilePVWithPassphrase(privValKeyFile, privValStateFile, passphrase)
 			if err != nil {
 				return err
 			}
@@ -126,6 +186,7 @@ func initFilesWithConfig(config *cfg.Config) error {
 			if err != nil {
 				return err
 			}
+			pv.Key.SetPassphrase(passphrase)
 			pv.Save()
 			logger.Info("Generated private validator", "keyFile", privValKeyFile,
 				"stateFile", privValStateFile)
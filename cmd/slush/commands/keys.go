@@ -0,0 +1,122 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/privval"
+)
+
+var keysPassphraseFile string
+
+// KeysCmd groups the commands converting the private validator key file
+// between its plaintext and encrypted forms.
+var KeysCmd = &cobra.Command{
+	Use:   "keys",
+	Short: "Encrypt and decrypt the private validator key file",
+	Long: `Encrypt and decrypt the private validator key file.
+
+The passphrase is read from --passphrase-file, or else from the key-passphrase-file
+of [priv-validator] in config.toml, or else from the ` + cfg.PrivValidatorKeyPassphraseEnv + `
+environment variable. The key is encrypted with xchacha20poly1305 under a key
+derived from the passphrase with scrypt; its address and public key stay readable.`,
+}
+
+var keysEncryptCmd = &cobra.Command{
+	Use:   "encrypt",
+	Short: "Encrypt the private validator key file with a passphrase",
+	Long: `Encrypt the private validator key file in place with a passphrase. Set the same
+passphrase source for "slush start" to run the node with the encrypted key.`,
+	Example: `
+	slush keys encrypt --passphrase-file /run/secrets/validator-passphrase
+	TM_PRIV_VALIDATOR_KEY_PASSPHRASE=... slush keys encrypt
+	`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		passphrase, err := readKeysPassphrase()
+		if err != nil {
+			return err
+		}
+		keyFile := config.PrivValidator.KeyFile()
+		if err := encryptKeyFile(keyFile, passphrase); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Encrypted private validator key %s\n", keyFile)
+		return nil
+	},
+}
+
+var keysDecryptCmd = &cobra.Command{
+	Use:   "decrypt",
+	Short: "Decrypt the private validator key file",
+	Long:  `Decrypt the encrypted private validator key file in place, storing the key in the clear.`,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		passphrase, err := readKeysPassphrase()
+		if err != nil {
+			return err
+		}
+		keyFile := config.PrivValidator.KeyFile()
+		if err := decryptKeyFile(keyFile, passphrase); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Decrypted private validator key %s\n", keyFile)
+		return nil
+	},
+}
+
+func init() {
+	KeysCmd.PersistentFlags().StringVar(&keysPassphraseFile, "passphrase-file", "",
+		"file containing the passphrase of the key, overriding the configured source")
+
+	KeysCmd.AddCommand(keysEncryptCmd)
+	KeysCmd.AddCommand(keysDecryptCmd)
+}
+
+// readKeysPassphrase returns the passphrase of --passphrase-file, or else the
+// configured one.
+func readKeysPassphrase() ([]byte, error) {
+	pvConfig := *config.PrivValidator
+	if keysPassphraseFile != "" {
+		pvConfig.KeyPassphrase = keysPassphraseFile
+	}
+	passphrase, err := pvConfig.ReadKeyPassphrase()
+	if err != nil {
+		return nil, err
+	}
+	if passphrase == nil {
+		return nil, fmt.Errorf("no passphrase, set --passphrase-file or %s", cfg.PrivValidatorKeyPassphraseEnv)
+	}
+	return passphrase, nil
+}
+
+// encryptKeyFile encrypts the plaintext key file keyFile with passphrase.
+func encryptKeyFile(keyFile string, passphrase []byte) error {
+	pv, err := privval.LoadFilePVEmptyState(keyFile, "")
+	if errors.Is(err, privval.ErrKeyEncrypted) {
+		return fmt.Errorf("private validator key %s is encrypted already", keyFile)
+	}
+	if err != nil {
+		return err
+	}
+	pv.Key.SetPassphrase(passphrase)
+	pv.Key.Save()
+	return nil
+}
+
+// decryptKeyFile decrypts the key file keyFile encrypted with passphrase.
+func decryptKeyFile(keyFile string, passphrase []byte) error {
+	pv, err := privval.LoadFilePVEmptyStateWithPassphrase(keyFile, "", passphrase)
+	if err != nil {
+		return err
+	}
+	if !pv.Key.IsEncrypted() {
+		return fmt.Errorf("private validator key %s is not encrypted", keyFile)
+	}
+	pv.Key.SetPassphrase(nil)
+	pv.Key.Save()
+	return nil
+}
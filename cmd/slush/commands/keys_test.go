@@ -0,0 +1,32 @@
+package commands
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/privval"
+)
+
+func TestEncryptDecryptKeyFile(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "priv_validator_key.json")
+	pv, err := privval.GenFilePV(keyFile, filepath.Join(dir, "priv_validator_state.json"), "")
+	require.NoError(t, err)
+	pv.Save()
+	passphrase := []byte("passphrase")
+
+	require.Error(t, decryptKeyFile(keyFile, passphrase), "the key is not encrypted")
+	require.NoError(t, encryptKeyFile(keyFile, passphrase))
+	require.Error(t, encryptKeyFile(keyFile, passphrase), "the key is encrypted already")
+	_, err = privval.LoadFilePVEmptyState(keyFile, "")
+	require.ErrorIs(t, err, privval.ErrKeyEncrypted)
+
+	require.Error(t, decryptKeyFile(keyFile, []byte("wrong")))
+	require.NoError(t, decryptKeyFile(keyFile, passphrase))
+	loaded, err := privval.LoadFilePVEmptyState(keyFile, "")
+	require.NoError(t, err)
+	assert.Equal(t, pv.Key.PrivKey, loaded.Key.PrivKey)
+}
@@ -13,10 +13,12 @@ import (
 	"github.com/spf13/cobra"
 	dbm "github.com/tendermint/tm-db"
 
+	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/libs/log"
 	tmmath "github.com/tendermint/tendermint/libs/math"
 	tmos "github.com/tendermint/tendermint/libs/os"
 	"github.com/tendermint/tendermint/light"
+	lstarknet "github.com/tendermint/tendermint/light/provider/starknet"
 	lproxy "github.com/tendermint/tendermint/light/proxy"
 	lrpc "github.com/tendermint/tendermint/light/rpc"
 	dbs "github.com/tendermint/tendermint/light/store/db"
@@ -38,6 +40,12 @@ need a primary RPC address, a trusted hash and height and witness RPC addresses
 (if not using sequential verification). To restart the node, thereafter
 only the chainID is required.
 
+With --starknet-url and --verifier-address, the headers settled on the verifier
+contract are used as an extra witness: the primary's light blocks at settled
+heights are checked against the header and validators hashes the verifier
+stores. This witness has no light blocks at the heights that are not settled,
+so verifying them still needs --witnesses.
+
 When /abci_query is called, the Merkle key path format is:
 
 	/{store name}/{key}
@@ -59,6 +67,10 @@ var (
 	dir                string
 	maxOpenConnections int
 
+	starknetURL     string
+	verifierAddress string
+	commitmentHash  string
+
 	sequential     bool
 	trustingPeriod time.Duration
 	trustedHeight  int64
@@ -98,6 +110,12 @@ func init() {
 	LightCmd.Flags().BoolVar(&sequential, "sequential", false,
 		"sequential verification. Verify all headers sequentially as opposed to using skipping verification",
 	)
+	LightCmd.Flags().StringVar(&starknetURL, "starknet-url", "",
+		"StarkNet JSON-RPC endpoint to read the settled headers from")
+	LightCmd.Flags().StringVar(&verifierAddress, "verifier-address", "",
+		"address of the verifier contract the chain settles to, used as a witness with --starknet-url")
+	LightCmd.Flags().StringVar(&commitmentHash, "commitment-hash", "",
+		"commitment hash of the chain (pedersen|poseidon), the one of the genesis file in --home by default")
 }
 
 func runProxy(cmd *cobra.Command, args []string) error {
@@ -109,6 +127,12 @@ func runProxy(cmd *cobra.Command, args []string) error {
 	chainID = args[0]
 	logger.Info("Creating client...", "chainID", chainID)
 
+	if commitmentHash != "" {
+		if err := crypto.SetCommitmentHash(commitmentHash); err != nil {
+			return err
+		}
+	}
+
 	witnessesAddrs := []string{}
 	if witnessAddrsJoined != "" {
 		witnessesAddrs = strings.Split(witnessAddrsJoined, ",")
@@ -170,6 +194,18 @@ func runProxy(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if starknetURL != "" {
+		if verifierAddress == "" {
+			return errors.New("--verifier-address is required with --starknet-url")
+		}
+		witness, err := lstarknet.New(chainID, starknetURL, verifierAddress, primaryAddr)
+		if err != nil {
+			return fmt.Errorf("failed to create StarkNet witness: %w", err)
+		}
+		logger.Info("Cross-checking with settled headers", "witness", witness)
+		c.AddProvider(witness)
+	}
+
 	cfg := rpcserver.DefaultConfig()
 	cfg.MaxBodyBytes = config.RPC.MaxBodyBytes
 	cfg.MaxHeaderBytes = config.RPC.MaxHeaderBytes
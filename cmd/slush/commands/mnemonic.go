@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/tendermint/crypto/stark"
+	"github.com/tendermint/tendermint/crypto/stark/hd"
+	"github.com/tendermint/tendermint/internal/settlement/starknet"
+	"github.com/tendermint/tendermint/types"
+)
+
+var (
+	useMnemonic     bool
+	recoverMnemonic bool
+)
+
+// addMnemonicFlags adds the flags deriving the validator key from a mnemonic
+// to cmd.
+func addMnemonicFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&useMnemonic, "mnemonic", false,
+		"derive the validator key and the Starknet account key from a new BIP-39 mnemonic, printed once")
+	cmd.Flags().BoolVar(&recoverMnemonic, "recover", false,
+		"with --mnemonic, read the mnemonic from stdin instead of generating a new one")
+}
+
+// mnemonicKeys are the keys derived from a mnemonic.
+type mnemonicKeys struct {
+	mnemonic string
+	// whether the mnemonic was generated, and must be backed up
+	generated bool
+
+	// the key of the validator, at the EIP-2645 path hd.ValidatorPath(0)
+	validator stark.PrivKey
+	// the key of the Starknet settlement account, at hd.AccountPath(0), the
+	// first account of the wallets importing the mnemonic
+	account stark.PrivKey
+}
+
+// deriveMnemonicKeys derives the keys of the mnemonic read from in if
+// --recover is set, or else of a new mnemonic.
+func deriveMnemonicKeys(in io.Reader) (*mnemonicKeys, error) {
+	if keyType != types.ABCIPubKeyTypeStark {
+		return nil, fmt.Errorf("keys of type %s cannot be derived from a mnemonic", keyType)
+	}
+
+	keys := &mnemonicKeys{}
+	if recoverMnemonic {
+		line, err := bufio.NewReader(in).ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("failed to read the mnemonic: %w", err)
+		}
+		keys.mnemonic = hd.NormalizeMnemonic(line)
+	} else {
+		mnemonic, err := hd.NewMnemonic()
+		if err != nil {
+			return nil, err
+		}
+		keys.mnemonic = mnemonic
+		keys.generated = true
+	}
+
+	var err error
+	if keys.validator, err = hd.DerivePrivKey(keys.mnemonic, hd.ValidatorPath(0)); err != nil {
+		return nil, err
+	}
+	if keys.account, err = hd.DerivePrivKey(keys.mnemonic, hd.AccountPath(0)); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// accountKeyHex returns the hex encoded account key, the content of a
+// settlement key file.
+func (k *mnemonicKeys) accountKeyHex() string {
+	return starknet.FeltHex(new(big.Int).SetBytes(k.account))
+}
+
+// print writes the mnemonic, if it was generated, and the public key of the
+// account to w.
+func (k *mnemonicKeys) print(w io.Writer) {
+	if k.generated {
+		fmt.Fprintf(w, `Write down this mnemonic and keep it safe, it is the only way to recover the
+validator key and the Starknet account key:
+
+%s
+
+`, k.mnemonic)
+	}
+	pub := k.account.MakeFull().PublicKey
+	fmt.Fprintf(w, "Starknet account public key (%s): %s\n", hd.AccountPath(0), starknet.FeltHex(pub.X))
+}
@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto/stark/hd"
+	"github.com/tendermint/tendermint/internal/settlement/starknet"
+	"github.com/tendermint/tendermint/privval"
+)
+
+func TestInitMnemonicKeys(t *testing.T) {
+	recoverMnemonic = false
+	t.Cleanup(func() { recoverMnemonic = false })
+
+	keys, err := deriveMnemonicKeys(nil)
+	require.NoError(t, err)
+	assert.True(t, keys.generated)
+	var out bytes.Buffer
+	keys.print(&out)
+	assert.Contains(t, out.String(), keys.mnemonic)
+
+	// the keys are recovered from the mnemonic
+	recoverMnemonic = true
+	recovered, err := deriveMnemonicKeys(strings.NewReader(keys.mnemonic + "\n"))
+	require.NoError(t, err)
+	assert.False(t, recovered.generated)
+	assert.Equal(t, keys.validator, recovered.validator)
+	assert.Equal(t, keys.account, recovered.account)
+	expected, err := hd.DerivePrivKey(keys.mnemonic, hd.ValidatorPath(0))
+	require.NoError(t, err)
+	assert.Equal(t, expected, recovered.validator)
+
+	_, err = deriveMnemonicKeys(strings.NewReader("not a mnemonic"))
+	assert.Error(t, err)
+
+	config := cfg.TestConfig()
+	dir := t.TempDir()
+	config.SetRoot(dir)
+	cfg.EnsureRoot(dir)
+	config.PrivValidator.StarknetKey = "config/starknet_key"
+	require.NoError(t, checkMnemonicKeys(config))
+	require.NoError(t, initMnemonicKeys(config, recovered))
+
+	pv, err := privval.LoadFilePV(config.PrivValidator.KeyFile(), config.PrivValidator.StateFile())
+	require.NoError(t, err)
+	assert.Equal(t, keys.validator.PubKey(), pv.Key.PubKey)
+	accountKey, err := starknet.LoadPrivateKey(config.PrivValidator.StarknetKeyFile())
+	require.NoError(t, err)
+	assert.Equal(t, keys.account, accountKey)
+
+	assert.Error(t, checkMnemonicKeys(config), "the existing key should be reported before a deployment")
+	assert.Error(t, initMnemonicKeys(config, recovered), "the existing key should not be overwritten")
+}
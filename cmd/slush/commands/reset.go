@@ -57,11 +57,17 @@ func resetAllCmd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	passphrase, err := config.PrivValidator.ReadKeyPassphrase()
+	if err != nil {
+		return err
+	}
+
 	return resetAll(
 		config.DBDir(),
 		config.P2P.AddrBookFile(),
 		config.PrivValidator.KeyFile(),
 		config.PrivValidator.StateFile(),
+		passphrase,
 		logger,
 	)
 }
@@ -73,11 +79,15 @@ func resetPrivValidator(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	return resetFilePV(config.PrivValidator.KeyFile(), config.PrivValidator.StateFile(), logger, keyType)
+	passphrase, err := config.PrivValidator.ReadKeyPassphrase()
+	if err != nil {
+		return err
+	}
+	return resetFilePV(config.PrivValidator.KeyFile(), config.PrivValidator.StateFile(), passphrase, logger, keyType)
 }
 
 // resetAllCmd removes address book files plus all data, and resets the privValidator data.
-func resetAll(dbDir, addrBookFile, privValKeyFile, privValStateFile string, logger log.Logger) error {
+func resetAll(dbDir, addrBookFile, privValKeyFile, privValStateFile string, passphrase []byte, logger log.Logger) error {
 	if keepAddrBook {
 		logger.Info("The address book remains intact")
 	} else {
@@ -94,7 +104,7 @@ func resetAll(dbDir, addrBookFile, privValKeyFile, privValStateFile string, logg
 	}
 
 	// recreate the dbDir since the privVal state needs to live there
-	return resetFilePV(privValKeyFile, privValStateFile, logger, keyType)
+	return resetFilePV(privValKeyFile, privValStateFile, passphrase, logger, keyType)
 }
 
 // resetState removes address book files plus all databases.
@@ -160,9 +170,9 @@ func resetState(dbDir string, logger log.Logger, keyType string) error {
 	return nil
 }
 
-func resetFilePV(privValKeyFile, privValStateFile string, logger log.Logger, keyType string) error {
+func resetFilePV(privValKeyFile, privValStateFile string, passphrase []byte, logger log.Logger, keyType string) error {
 	if _, err := os.Stat(privValKeyFile); err == nil {
-		pv, err := privval.LoadFilePVEmptyState(privValKeyFile, privValStateFile)
+		pv, err := privval.LoadFilePVEmptyStateWithPassphrase(privValKeyFile, privValStateFile, passphrase)
 		if err != nil {
 			return err
 		}
@@ -174,6 +184,7 @@ func resetFilePV(privValKeyFile, privValStateFile string, logger log.Logger, key
 		if err != nil {
 			return err
 		}
+		pv.Key.SetPassphrase(passphrase)
 		pv.Save()
 		logger.Info("Generated private validator file", "keyFile", privValKeyFile,
 			"stateFile", privValStateFile)
@@ -1,7 +1,9 @@
 package commands
 
 import (
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"time"
@@ -10,6 +12,7 @@ import (
 	"github.com/spf13/viper"
 
 	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/libs/cli"
 	"github.com/tendermint/tendermint/libs/log"
 )
@@ -67,6 +70,27 @@ var RootCmd = &cobra.Command{
 		}
 
 		logger = logger.With("module", "main")
-		return nil
+
+		return setCommitmentHash(config.GenesisFile())
 	},
 }
+
+// setCommitmentHash selects the commitment hash of the chain of the genesis
+// file at path, if there is one, so that the commands hash headers,
+// transactions and merkle trees as the chain does.
+func setCommitmentHash(path string) error {
+	bz, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("couldn't read genesis file: %w", err)
+	}
+
+	var genDoc struct {
+		CommitmentHash string `json:"commitment_hash"`
+	}
+	if err := json.Unmarshal(bz, &genDoc); err != nil {
+		return fmt.Errorf("error reading genesis file %s: %w", path, err)
+	}
+	return crypto.SetCommitmentHash(genDoc.CommitmentHash)
+}
@@ -33,6 +33,10 @@ func AddNodeFlags(cmd *cobra.Command) {
 		"priv-validator-laddr",
 		config.PrivValidator.ListenAddr,
 		"socket address to listen on for connections from external priv-validator process")
+	cmd.Flags().String(
+		"priv-validator.key-passphrase-file",
+		config.PrivValidator.KeyPassphrase,
+		"file containing the passphrase of the encrypted priv validator key file")
 
 	// TODO (https://github.com/tendermint/tendermint/issues/6908): remove this check after the v0.35 release cycle
 	// This check was added to give users an upgrade prompt to use the new flag for syncing.
@@ -0,0 +1,341 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tendermint/tendermint/internal/settlement"
+	"github.com/tendermint/tendermint/internal/settlement/parser"
+	"github.com/tendermint/tendermint/internal/settlement/protostar"
+	"github.com/tendermint/tendermint/internal/settlement/verifier"
+	sm "github.com/tendermint/tendermint/internal/state"
+)
+
+var (
+	settlementHeight        int64
+	settlementTrustedHeight int64
+)
+
+// SettlementCmd groups the commands inspecting the calldata settled on
+// StarkNet.
+var SettlementCmd = &cobra.Command{
+	Use:   "settlement",
+	Short: "Inspect the calldata of settlement transactions",
+}
+
+var settlementEncodeCmd = &cobra.Command{
+	Use:   "encode",
+	Short: "Print the calldata settling a height of the local block store",
+	Long: `Print the calldata settling the block at --height against the block at
+--trusted-height, the previous one by default, as a JSON object with the
+height, the verifier entry point and the felts passed to it.`,
+	Example: `
+	slush settlement encode --height 10
+	slush settlement encode --height 10 --trusted-height 5 > calldata.json
+	`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		blockStore, stateStore, err := loadStateAndBlockStore(config)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = blockStore.Close()
+			_ = stateStore.Close()
+		}()
+
+		call, err := encodeSettlementCall(blockStore, stateStore)
+		if err != nil {
+			return err
+		}
+		return printJSON(cmd.OutOrStdout(), call)
+	},
+}
+
+var settlementDecodeCmd = &cobra.Command{
+	Use:   "decode <file>",
+	Short: "Decode the calldata of settlement calls into headers, commits and validators",
+	Long: `Decode the calldata of the settlement calls in file into the signed headers,
+commit signatures and validator sets passed to the verifier, printed as JSON.
+
+The file is either a multicall file of the protostar backend, the file written by
+the file backend, or the output of "slush settlement encode".`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		calls, err := readSettlementCalls(args[0])
+		if err != nil {
+			return err
+		}
+		vc, err := verificationConfig()
+		if err != nil {
+			return err
+		}
+
+		for i, call := range calls {
+			in, err := verifier.Decode(vc.EntryPoint(call.Function), call.Calldata)
+			if err != nil {
+				return fmt.Errorf("call %d: %w", i, err)
+			}
+			err = printJSON(cmd.OutOrStdout(), struct {
+				Function string      `json:"function"`
+				Input    interface{} `json:"input"`
+			}{call.Function, in})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+var settlementVerifyCmd = &cobra.Command{
+	Use:   "verify [file]",
+	Short: "Run the checks of the verifier on settlement calldata",
+	Long: `Run the checks of the verifier contract on the calldata of the settlement
+calls in file, or on the calldata settling --height if no file is given, and
+compare the headers it holds with the ones of the local block store.`,
+	Example: `
+	slush settlement verify --height 10
+	slush settlement verify data/multicalls/call3.toml
+	`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		blockStore, stateStore, err := loadStateAndBlockStore(config)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = blockStore.Close()
+			_ = stateStore.Close()
+		}()
+
+		var calls []settlementCall
+		if len(args) == 1 {
+			if calls, err = readSettlementCalls(args[0]); err != nil {
+				return err
+			}
+		} else {
+			call, err := encodeSettlementCall(blockStore, stateStore)
+			if err != nil {
+				return err
+			}
+			calls = append(calls, call)
+		}
+		vc, err := verificationConfig()
+		if err != nil {
+			return err
+		}
+		anchor, err := storeAnchor(blockStore, vc)
+		if err != nil {
+			return err
+		}
+
+		failed := 0
+		for i, call := range calls {
+			err := verifySettlementCall(blockStore, anchor, vc.EntryPoint(call.Function), call.Calldata)
+			if err != nil {
+				failed++
+				fmt.Fprintf(cmd.OutOrStdout(), "call %d (%s, height %d): %v\n", i, call.Function, call.Height, err)
+				continue
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "call %d (%s, height %d): ok\n", i, call.Function, call.Height)
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d calls fail verification", failed, len(calls))
+		}
+		return nil
+	},
+}
+
+func init() {
+	settlementEncodeCmd.Flags().Int64Var(&settlementHeight, "height", 0, "height of the block to settle")
+	settlementEncodeCmd.Flags().Int64Var(&settlementTrustedHeight, "trusted-height", 0,
+		"height of the block to verify against, the previous one if 0")
+	settlementVerifyCmd.Flags().AddFlagSet(settlementEncodeCmd.Flags())
+
+	SettlementCmd.AddCommand(settlementEncodeCmd)
+	SettlementCmd.AddCommand(settlementDecodeCmd)
+	SettlementCmd.AddCommand(settlementVerifyCmd)
+}
+
+// settlementCall is a call to the verifier. Its JSON encoding is the one of
+// the lines written by the file backend.
+type settlementCall struct {
+	Height   int64    `json:"height,omitempty"`
+	Function string   `json:"function"`
+	Calldata []string `json:"calldata"`
+}
+
+func verificationConfig() (parser.VerificationConfig, error) {
+	policy, err := settlement.NewPolicy(config.Settlement)
+	if err != nil {
+		return parser.VerificationConfig{}, err
+	}
+	return policy.VerificationConfig(time.Now()), nil
+}
+
+// encodeSettlementCall returns the call settling settlementHeight against
+// settlementTrustedHeight.
+func encodeSettlementCall(blockStore sm.BlockStore, stateStore sm.Store) (settlementCall, error) {
+	height, trustedHeight := settlementHeight, settlementTrustedHeight
+	if trustedHeight == 0 {
+		trustedHeight = height - 1
+	}
+	if trustedHeight <= 0 || trustedHeight >= height {
+		return settlementCall{}, fmt.Errorf("cannot settle height %d against height %d", height, trustedHeight)
+	}
+
+	trusted, err := settlement.LoadLightBlock(blockStore, stateStore, trustedHeight)
+	if err != nil {
+		return settlementCall{}, err
+	}
+	untrusted, err := settlement.LoadLightBlock(blockStore, stateStore, height)
+	if err != nil {
+		return settlementCall{}, err
+	}
+	vc, err := verificationConfig()
+	if err != nil {
+		return settlementCall{}, err
+	}
+	calldata, err := parser.ParseInput(trusted, untrusted, vc)
+	if err != nil {
+		return settlementCall{}, fmt.Errorf("failed to format for settlement: %w", err)
+	}
+	return settlementCall{
+		Height:   height,
+		Function: vc.Function(trusted, untrusted),
+		Calldata: calldata,
+	}, nil
+}
+
+// readSettlementCalls reads the calls of a multicall file, or of a stream of
+// JSON objects such as the file of the file backend.
+func readSettlementCalls(path string) ([]settlementCall, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var calls []settlementCall
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		multicall, err := protostar.ParseMulticallFile(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		for _, call := range multicall {
+			calls = append(calls, settlementCall{Function: call.Function, Calldata: call.Inputs})
+		}
+		return calls, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	for {
+		var call settlementCall
+		if err := dec.Decode(&call); errors.Is(err, io.EOF) {
+			return calls, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		calls = append(calls, call)
+	}
+}
+
+// storeAnchor returns the anchor of a verifier of the chain of the block store,
+// initialized with the trusting period of vc.
+func storeAnchor(blockStore sm.BlockStore, vc parser.VerificationConfig) (verifier.Anchor, error) {
+	meta := blockStore.LoadBaseMeta()
+	if meta == nil {
+		return verifier.Anchor{}, errors.New("the block store is empty")
+	}
+	return verifier.NewAnchor(meta.Header.ChainID, vc), nil
+}
+
+// verifySettlementCall runs the checks of the verifier entry point function
+// on calldata, the evidence entry points against anchor, and checks that the
+// headers it trusts or settles are the ones of the block store.
+func verifySettlementCall(blockStore sm.BlockStore, anchor verifier.Anchor, function string, calldata []string) error {
+	in, err := verifier.Decode(function, calldata)
+	if err != nil {
+		return err
+	}
+
+	// the conflicting header of a light client attack is not the one of the
+	// block store, and duplicate votes hold no header
+	var headers []verifier.SignedHeader
+	switch in := in.(type) {
+	case *verifier.AdjacentInput:
+		headers = []verifier.SignedHeader{in.Trusted, in.Untrusted}
+		err = verifier.VerifyAdjacent(in)
+	case *verifier.NonAdjacentInput:
+		headers = []verifier.SignedHeader{in.Trusted, in.Untrusted}
+		err = verifier.VerifyNonAdjacent(in)
+	case *verifier.LightClientAttackInput:
+		headers = []verifier.SignedHeader{in.Trusted}
+		err = verifier.VerifyLightClientAttack(in, anchor)
+	case *verifier.DuplicateVoteInput:
+		err = verifier.VerifyDuplicateVote(in, anchor)
+	case *verifier.InclusionInput:
+		err = verifyStoredInclusion(blockStore, in)
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, sh := range headers {
+		if err := checkStoredHeader(blockStore, sh); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// verifyStoredInclusion runs the checks of verifyInclusion on in, against the
+// block hash of its height in the block store.
+func verifyStoredInclusion(blockStore sm.BlockStore, in *verifier.InclusionInput) error {
+	if !in.Height.IsInt64() {
+		return fmt.Errorf("invalid height %s", in.Height)
+	}
+	meta := blockStore.LoadBlockMeta(in.Height.Int64())
+	if meta == nil {
+		return fmt.Errorf("height %s is not in the block store", in.Height)
+	}
+	return verifier.VerifyInclusion(in, new(big.Int).SetBytes(meta.BlockID.Hash))
+}
+
+// checkStoredHeader checks that sh hashes to the block hash of its height in
+// the block store.
+func checkStoredHeader(blockStore sm.BlockStore, sh verifier.SignedHeader) error {
+	height := sh.Header.Height
+	if !height.IsInt64() {
+		return fmt.Errorf("invalid height %s", height)
+	}
+	meta := blockStore.LoadBlockMeta(height.Int64())
+	if meta == nil {
+		return fmt.Errorf("height %s is not in the block store", height)
+	}
+	hash, err := verifier.HashHeader(sh)
+	if err != nil {
+		return fmt.Errorf("header at height %s: %w", height, err)
+	}
+	if stored := new(big.Int).SetBytes(meta.BlockID.Hash); hash.Cmp(stored) != 0 {
+		return fmt.Errorf("header at height %s hashes to %#x, the block store has %#x", height, hash, stored)
+	}
+	return nil
+}
+
+func printJSON(w io.Writer, v interface{}) error {
+	bz, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(bz))
+	return err
+}
@@ -0,0 +1,159 @@
+package commands
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/internal/settlement/parser"
+	"github.com/tendermint/tendermint/internal/settlement/verifier"
+	"github.com/tendermint/tendermint/internal/state/mocks"
+	"github.com/tendermint/tendermint/internal/test/factory"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// setupSettlementStores returns stores holding a chain of numBlocks blocks
+// signed by a single validator set.
+func setupSettlementStores(t *testing.T, numBlocks int64) (*mocks.BlockStore, *mocks.Store) {
+	t.Helper()
+
+	vals, privVals := factory.RandValidatorSet(4, 10)
+	blockStore := &mocks.BlockStore{}
+	stateStore := &mocks.Store{}
+	blockTime := time.Now().Add(-time.Minute)
+	var lastBlockID types.BlockID
+	for height := int64(1); height <= numBlocks; height++ {
+		header, err := factory.MakeHeader(&types.Header{
+			Height:             height,
+			Time:               blockTime,
+			LastBlockID:        lastBlockID,
+			ValidatorsHash:     vals.Hash(),
+			NextValidatorsHash: vals.Hash(),
+			ProposerAddress:    vals.Proposer.Address,
+		})
+		require.NoError(t, err)
+		blockID := factory.MakeBlockIDWithHash(header.Hash())
+		voteSet := types.NewVoteSet(header.ChainID, height, 0, tmproto.PrecommitType, vals)
+		commit, err := factory.MakeCommit(blockID, height, 0, voteSet, privVals, blockTime.Add(time.Second))
+		require.NoError(t, err)
+
+		blockStore.On("LoadBlockMeta", height).Return(&types.BlockMeta{BlockID: blockID, Header: *header})
+		blockStore.On("LoadBlockCommit", height).Return(commit)
+		stateStore.On("LoadValidators", height).Return(vals, nil)
+		lastBlockID = blockID
+		blockTime = blockTime.Add(time.Second)
+	}
+	return blockStore, stateStore
+}
+
+func TestSettlementEncodeVerify(t *testing.T) {
+	blockStore, stateStore := setupSettlementStores(t, 4)
+	t.Cleanup(func() { settlementHeight, settlementTrustedHeight = 0, 0 })
+
+	testCases := []struct {
+		height, trustedHeight int64
+		function              string
+		valid                 bool
+	}{
+		0: {3, 0, "externalVerifyAdjacent", true},
+		1: {4, 1, "externalVerifyNonAdjacent", true},
+		2: {3, 3, "", false},
+		3: {1, 0, "", false},
+	}
+	for i, tc := range testCases {
+		settlementHeight, settlementTrustedHeight = tc.height, tc.trustedHeight
+		call, err := encodeSettlementCall(blockStore, stateStore)
+		if !tc.valid {
+			require.Error(t, err, "testCase%d failed", i)
+			continue
+		}
+		require.NoError(t, err, "testCase%d failed", i)
+		require.Equal(t, tc.height, call.Height, "testCase%d failed", i)
+		require.Equal(t, tc.function, call.Function, "testCase%d failed", i)
+		require.NoError(t, verifySettlementCall(blockStore, verifier.Anchor{}, call.Function, call.Calldata), "testCase%d failed", i)
+	}
+}
+
+func TestSettlementVerifyAgainstBlockStore(t *testing.T) {
+	blockStore, stateStore := setupSettlementStores(t, 2)
+	t.Cleanup(func() { settlementHeight = 0 })
+
+	settlementHeight = 2
+	call, err := encodeSettlementCall(blockStore, stateStore)
+	require.NoError(t, err)
+
+	// a valid chain that is not the one of the block store
+	otherBlockStore, _ := setupSettlementStores(t, 2)
+	err = verifySettlementCall(otherBlockStore, verifier.Anchor{}, call.Function, call.Calldata)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "the block store has")
+
+	call.Calldata[len(call.Calldata)-1] = "1"
+	err = verifySettlementCall(blockStore, verifier.Anchor{}, call.Function, call.Calldata)
+	require.ErrorIs(t, err, verifier.ErrExpired)
+}
+
+func TestSettlementVerifyInclusion(t *testing.T) {
+	vals, _ := factory.RandValidatorSet(1, 10)
+	txs := types.Txs{types.Tx("a=1"), types.Tx("b=2")}
+	header, err := factory.MakeHeader(&types.Header{Height: 2, ValidatorsHash: vals.Hash(), DataHash: txs.Hash()})
+	require.NoError(t, err)
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("LoadBlockMeta", int64(2)).Return(&types.BlockMeta{
+		BlockID: factory.MakeBlockIDWithHash(header.Hash()),
+		Header:  *header,
+	})
+	blockStore.On("LoadBlockMeta", mock.Anything).Return(nil)
+
+	proof, err := txs.Proof(1).FeltProof(header)
+	require.NoError(t, err)
+	calldata, err := parser.ParseInclusionInput(proof)
+	require.NoError(t, err)
+	require.NoError(t, verifySettlementCall(blockStore, verifier.Anchor{}, parser.InclusionFunction, calldata))
+
+	// the proof holds against the header of another height only
+	calldata[0] = "3"
+	require.Error(t, verifySettlementCall(blockStore, verifier.Anchor{}, parser.InclusionFunction, calldata))
+}
+
+func TestReadSettlementCalls(t *testing.T) {
+	dir := t.TempDir()
+	calls := []settlementCall{
+		{Height: 2, Function: "externalVerifyAdjacent", Calldata: []string{"1", "2"}},
+		{Height: 5, Function: "externalVerifyNonAdjacent", Calldata: []string{"3"}},
+	}
+
+	// lines of the file backend, with fields that are not needed
+	jsonl := filepath.Join(dir, "settlement.jsonl")
+	var data []byte
+	for _, call := range calls {
+		bz, err := json.Marshal(struct {
+			ID string `json:"id"`
+			settlementCall
+		}{"id", call})
+		require.NoError(t, err)
+		data = append(append(data, bz...), '\n')
+	}
+	require.NoError(t, os.WriteFile(jsonl, data, 0600))
+	read, err := readSettlementCalls(jsonl)
+	require.NoError(t, err)
+	require.Equal(t, calls, read)
+
+	multicall := filepath.Join(dir, "call0.toml")
+	data = []byte("[[call]]\ntype = \"invoke\" \ncontract-address = 0x1234\n" +
+		"function = \"externalVerifyAdjacent\"\ninputs = [ 1,2]\n\n")
+	require.NoError(t, os.WriteFile(multicall, data, 0600))
+	read, err = readSettlementCalls(multicall)
+	require.NoError(t, err)
+	require.Equal(t, []settlementCall{{Function: "externalVerifyAdjacent", Calldata: []string{"1", "2"}}}, read)
+
+	require.NoError(t, os.WriteFile(jsonl, []byte("{\"height\": "), 0600))
+	_, err = readSettlementCalls(jsonl)
+	require.Error(t, err)
+}
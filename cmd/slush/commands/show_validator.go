@@ -55,7 +55,11 @@ func showValidator(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("private validator file %s does not exist", keyFilePath)
 		}
 
-		pv, err := privval.LoadFilePV(keyFilePath, config.PrivValidator.StateFile())
+		passphrase, err := config.PrivValidator.ReadKeyPassphrase()
+		if err != nil {
+			return err
+		}
+		pv, err := privval.LoadFilePVWithPassphrase(keyFilePath, config.PrivValidator.StateFile(), passphrase)
 		if err != nil {
 			return err
 		}
@@ -134,7 +134,7 @@ func testnetFiles(cmd *cobra.Command, args []string) error {
 			PrivateKeyPath: "pkey",
 		}
 	}
-	if err := initVerifierAddress(config, logger); err != nil {
+	if err := initVerifierAddress(config); err != nil {
 		return err
 	}
 
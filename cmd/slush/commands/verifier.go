@@ -0,0 +1,361 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/settlement"
+	"github.com/tendermint/tendermint/internal/settlement/parser"
+	sm "github.com/tendermint/tendermint/internal/state"
+)
+
+var (
+	verifierTrustedHeight int64
+	verifierTargetName    string
+)
+
+// VerifierCmd groups the commands managing the verifier contract the node
+// settles to.
+var VerifierCmd = &cobra.Command{
+	Use:   "verifier",
+	Short: "Deploy, initialize and upgrade the verifier contract",
+	Long: `Deploy, initialize and upgrade the verifier contract the node settles to.
+
+The commands manage the verifier of the settlement target named by --target,
+through the backend of the target: protostar or starknet. The verifier of the
+default target, settled on without targets in the [settlement] section, is
+recorded in config.toml with its class hash and trusted height. The verifier
+of a named target is recorded in its [[settlement.targets]] entry, with the
+height after its trusted one as start-height.`,
+}
+
+var verifierDeployCmd = &cobra.Command{
+	Use:   "deploy",
+	Short: "Declare and deploy a new verifier contract",
+	Long: `Declare the verifier class of cairo-dir/build/main.json and deploy a new,
+uninitialized contract of it, replacing the recorded verifier. The deploying
+account is the owner of the verifier, the only one allowed to initialize it.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		target, err := settlementTarget(config, verifierTargetName)
+		if err != nil {
+			return err
+		}
+		classHashHex, contractAddressHex, err := deployVerifier(cmd.Context(), config, target)
+		if err != nil {
+			return err
+		}
+		recordVerifier(config, target.Name, classHashHex, contractAddressHex)
+		if err := cfg.WriteConfigFile(config.RootDir, config); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Deployed verifier %s of class %s\n", contractAddressHex, classHashHex)
+		return nil
+	},
+}
+
+var verifierInitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Initialize the verifier with a trusted header of the local block store",
+	Long: `Initialize the recorded verifier with the header at --trusted-height, the
+latest height of the local block store by default. The verifier trusts this
+header without verifying it, and settlement starts at the height after it.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		target, err := recordedVerifier(config, verifierTargetName)
+		if err != nil {
+			return err
+		}
+		blockStore, stateStore, err := loadStateAndBlockStore(config)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = blockStore.Close()
+			_ = stateStore.Close()
+		}()
+
+		height := verifierTrustedHeight
+		if height == 0 {
+			height = blockStore.Height()
+		}
+		if err := initVerifier(cmd.Context(), blockStore, stateStore, target, height); err != nil {
+			return err
+		}
+		return showVerifier(cmd, blockStore, target.Name)
+	},
+}
+
+var verifierUpgradeCmd = &cobra.Command{
+	Use:   "upgrade",
+	Short: "Move settlement to a verifier of the current verifier class",
+	Long: `Declare the verifier class of cairo-dir/build/main.json, deploy a contract of
+it and initialize it with the header at --trusted-height, by default the latest
+height settled on the recorded verifier. The new verifier replaces the recorded
+one, and settlement resumes on it at the height after the trusted one.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		target, err := recordedVerifier(config, verifierTargetName)
+		if err != nil {
+			return err
+		}
+		blockStore, stateStore, err := loadStateAndBlockStore(config)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = blockStore.Close()
+			_ = stateStore.Close()
+		}()
+
+		height := verifierTrustedHeight
+		if height == 0 {
+			admin, err := settlement.NewVerifierAdmin(logger, config, target, nil)
+			if err != nil {
+				return err
+			}
+			if height, err = admin.LatestSettledHeight(cmd.Context()); err != nil {
+				return err
+			}
+		}
+		if height == 0 {
+			height = recordedTrustedHeight(config, target)
+		}
+		if height == 0 {
+			return errors.New("nothing was settled on the recorded verifier, set --trusted-height")
+		}
+
+		oldAddress := target.VerifierAddress
+		classHashHex, contractAddressHex, err := deployVerifier(cmd.Context(), config, target)
+		if err != nil {
+			return err
+		}
+		if target.Name == cfg.DefaultSettlementTarget && classHashHex == config.VerifierClassHash {
+			logger.Info("verifier class is unchanged, moving to a new contract anyway", "classHash", classHashHex)
+		}
+		// recorded right away, so that a failed initialization can be
+		// retried with slush verifier init
+		recordVerifier(config, target.Name, classHashHex, contractAddressHex)
+		if err := cfg.WriteConfigFile(config.RootDir, config); err != nil {
+			return err
+		}
+		if target, err = settlementTarget(config, target.Name); err != nil {
+			return err
+		}
+		if err := initVerifier(cmd.Context(), blockStore, stateStore, target, height); err != nil {
+			return err
+		}
+		logger.Info("upgraded verifier", "target", target.Name, "from", oldAddress, "to", contractAddressHex)
+		return showVerifier(cmd, blockStore, target.Name)
+	},
+}
+
+var verifierShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the recorded verifier and check its trusted header",
+	Long: `Show the class hash, contract address and trusted height of the recorded
+verifier, and check that the header it trusts is the one of the local block
+store.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if _, err := recordedVerifier(config, verifierTargetName); err != nil {
+			return err
+		}
+		blockStore, stateStore, err := loadStateAndBlockStore(config)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = blockStore.Close()
+			_ = stateStore.Close()
+		}()
+
+		return showVerifier(cmd, blockStore, verifierTargetName)
+	},
+}
+
+func init() {
+	VerifierCmd.PersistentFlags().StringVar(&verifierTargetName, "target", cfg.DefaultSettlementTarget,
+		"name of the settlement target whose verifier is managed")
+	verifierInitCmd.Flags().Int64Var(&verifierTrustedHeight, "trusted-height", 0,
+		"height of the header to initialize the verifier with, the latest one if 0")
+	verifierUpgradeCmd.Flags().Int64Var(&verifierTrustedHeight, "trusted-height", 0,
+		"height of the header to initialize the new verifier with, the latest settled one if 0")
+
+	VerifierCmd.AddCommand(verifierDeployCmd)
+	VerifierCmd.AddCommand(verifierInitCmd)
+	VerifierCmd.AddCommand(verifierUpgradeCmd)
+	VerifierCmd.AddCommand(verifierShowCmd)
+}
+
+// settlementTarget returns the settlement target of conf named name.
+func settlementTarget(conf *cfg.Config, name string) (*cfg.SettlementTargetConfig, error) {
+	targets := conf.SettlementTargets()
+	names := make([]string, len(targets))
+	for i, target := range targets {
+		if target.Name == name {
+			return target, nil
+		}
+		names[i] = target.Name
+	}
+	return nil, fmt.Errorf("unknown settlement target %q, the targets are: %s", name, strings.Join(names, ", "))
+}
+
+// recordedVerifier returns the settlement target of conf named name, which
+// must have a verifier.
+func recordedVerifier(conf *cfg.Config, name string) (*cfg.SettlementTargetConfig, error) {
+	target, err := settlementTarget(conf, name)
+	if err != nil {
+		return nil, err
+	}
+	if target.VerifierAddress == "" {
+		return nil, fmt.Errorf("no verifier is recorded for target %q, deploy one with: slush verifier deploy --target %s",
+			name, name)
+	}
+	return target, nil
+}
+
+// configuredTarget returns the target of the [settlement] section of conf
+// named name, or nil for the default target.
+func configuredTarget(conf *cfg.Config, name string) *cfg.SettlementTargetConfig {
+	for _, target := range conf.Settlement.Targets {
+		if target.Name == name {
+			return target
+		}
+	}
+	return nil
+}
+
+// recordVerifier records the uninitialized verifier at contractAddressHex,
+// of class classHashHex, as the verifier of the target named name.
+func recordVerifier(conf *cfg.Config, name, classHashHex, contractAddressHex string) {
+	if target := configuredTarget(conf, name); target != nil {
+		target.VerifierAddress = contractAddressHex
+		return
+	}
+	conf.VerifierClassHash = classHashHex
+	conf.VerifierAddress = contractAddressHex
+	conf.VerifierTrustedHeight = 0
+}
+
+// recordTrustedHeight records height as the trusted height of the verifier of
+// the target named name, and the height after it as the first one to settle.
+func recordTrustedHeight(conf *cfg.Config, name string, height int64) {
+	if target := configuredTarget(conf, name); target != nil {
+		target.StartHeight = height + 1
+		return
+	}
+	conf.VerifierTrustedHeight = height
+	conf.Settlement.StartHeight = height + 1
+}
+
+// recordedTrustedHeight returns the trusted height recorded for the verifier
+// of target, 0 if there is none.
+func recordedTrustedHeight(conf *cfg.Config, target *cfg.SettlementTargetConfig) int64 {
+	if configuredTarget(conf, target.Name) == nil {
+		return conf.VerifierTrustedHeight
+	}
+	if target.StartHeight > 0 {
+		return target.StartHeight - 1
+	}
+	return 0
+}
+
+// deployVerifier declares the verifier class of the cairo directory of conf
+// and deploys a contract of it through the backend of target.
+func deployVerifier(ctx context.Context, conf *cfg.Config, target *cfg.SettlementTargetConfig) (classHashHex, contractAddressHex string, err error) {
+	admin, err := settlement.NewVerifierAdmin(logger, conf, target, nil)
+	if err != nil {
+		return "", "", err
+	}
+	return admin.DeployVerifier(ctx, filepath.Join(conf.CairoDir, "build/main.json"))
+}
+
+// initVerifier initializes the verifier of target with the header at height,
+// and records height as trusted and the height after it as the first one to
+// settle.
+func initVerifier(
+	ctx context.Context,
+	blockStore sm.BlockStore,
+	stateStore sm.Store,
+	target *cfg.SettlementTargetConfig,
+	height int64,
+) error {
+	lightBlock, err := settlement.LoadLightBlock(blockStore, stateStore, height)
+	if err != nil {
+		return err
+	}
+	inputs, err := parser.ParseInitInput(lightBlock, config.Settlement.TrustingPeriod)
+	if err != nil {
+		return fmt.Errorf("failed to format for settlement: %w", err)
+	}
+	admin, err := settlement.NewVerifierAdmin(logger, config, target, nil)
+	if err != nil {
+		return err
+	}
+	txHash, err := admin.InitVerifier(ctx, inputs)
+	if err != nil {
+		return err
+	}
+	logger.Info("initialized verifier", "target", target.Name, "height", height, "txHash", txHash)
+
+	recordTrustedHeight(config, target.Name, height)
+	return cfg.WriteConfigFile(config.RootDir, config)
+}
+
+// showVerifier prints the recorded verifier of the target named name and
+// checks the header it trusts against the block store.
+func showVerifier(cmd *cobra.Command, blockStore sm.BlockStore, name string) error {
+	target, err := recordedVerifier(config, name)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Target:           %s\n", target.Name)
+	if configuredTarget(config, target.Name) == nil {
+		fmt.Fprintf(out, "Class hash:       %s\n", config.VerifierClassHash)
+	}
+	fmt.Fprintf(out, "Contract address: %s\n", target.VerifierAddress)
+	fmt.Fprintf(out, "Trusted height:   %d\n", recordedTrustedHeight(config, target))
+
+	admin, err := settlement.NewVerifierAdmin(logger, config, target, nil)
+	if err != nil {
+		return err
+	}
+	hash, height, err := admin.TrustedHeader(cmd.Context())
+	if err != nil {
+		return err
+	}
+	settledHeight, err := admin.LatestSettledHeight(cmd.Context())
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "On-chain trusted header: %#x at height %d\n", hash, height)
+	fmt.Fprintf(out, "Latest settled height:   %d\n", settledHeight)
+
+	return checkTrustedHeader(blockStore, hash, height)
+}
+
+// checkTrustedHeader checks that hash is the hash of the header at height in
+// the block store.
+func checkTrustedHeader(blockStore sm.BlockStore, hash *big.Int, height int64) error {
+	if height == 0 {
+		return errors.New("the verifier is not initialized, initialize it with: slush verifier init")
+	}
+	meta := blockStore.LoadBlockMeta(height)
+	if meta == nil {
+		return fmt.Errorf("trusted height %d is not in the block store", height)
+	}
+	if stored := new(big.Int).SetBytes(meta.BlockID.Hash); hash.Cmp(stored) != 0 {
+		return fmt.Errorf("the verifier trusts header %#x at height %d, the block store has %#x", hash, height, stored)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/tendermint/tendermint/config"
+)
+
+func TestCheckTrustedHeader(t *testing.T) {
+	blockStore, _ := setupSettlementStores(t, 2)
+	meta := blockStore.LoadBlockMeta(2)
+	stored := new(big.Int).SetBytes(meta.BlockID.Hash)
+
+	testCases := []struct {
+		hash    *big.Int
+		height  int64
+		wantErr string
+	}{
+		0: {stored, 2, ""},
+		1: {big.NewInt(1), 2, "the block store has"},
+		2: {stored, 0, "not initialized"},
+		3: {stored, 3, "not in the block store"},
+	}
+	blockStore.On("LoadBlockMeta", int64(3)).Return(nil)
+	for i, tc := range testCases {
+		err := checkTrustedHeader(blockStore, tc.hash, tc.height)
+		if tc.wantErr == "" {
+			require.NoError(t, err, "testCase%d failed", i)
+			continue
+		}
+		require.Error(t, err, "testCase%d failed", i)
+		require.Contains(t, err.Error(), tc.wantErr, "testCase%d failed", i)
+	}
+}
+
+func TestRecordVerifier(t *testing.T) {
+	conf := cfg.TestConfig()
+	conf.VerifierAddress = "0x1"
+	conf.VerifierTrustedHeight = 4
+	conf.Settlement.Targets = []*cfg.SettlementTargetConfig{
+		{Name: "backup", Backend: cfg.SettlementBackendStarknet},
+	}
+
+	_, err := recordedVerifier(conf, "backup")
+	require.ErrorContains(t, err, "slush verifier deploy --target backup")
+	_, err = settlementTarget(conf, "unknown")
+	require.ErrorContains(t, err, "backup")
+
+	recordVerifier(conf, "backup", "0xc", "0x2")
+	recordTrustedHeight(conf, "backup", 9)
+	target, err := recordedVerifier(conf, "backup")
+	require.NoError(t, err)
+	require.Equal(t, "0x2", target.VerifierAddress)
+	require.EqualValues(t, 10, target.StartHeight)
+	require.EqualValues(t, 9, recordedTrustedHeight(conf, target))
+	require.Equal(t, "0x1", conf.VerifierAddress)
+
+	// the default target is only settled on without named targets
+	conf.Settlement.Targets = nil
+	recordVerifier(conf, cfg.DefaultSettlementTarget, "0xd", "0x3")
+	require.EqualValues(t, 0, conf.VerifierTrustedHeight)
+	recordTrustedHeight(conf, cfg.DefaultSettlementTarget, 5)
+	target, err = recordedVerifier(conf, cfg.DefaultSettlementTarget)
+	require.NoError(t, err)
+	require.Equal(t, "0x3", target.VerifierAddress)
+	require.Equal(t, "0xd", conf.VerifierClassHash)
+	require.EqualValues(t, 5, recordedTrustedHeight(conf, target))
+	require.EqualValues(t, 6, conf.Settlement.StartHeight)
+}
@@ -33,6 +33,9 @@ func main() {
 		cmd.RollbackStateCmd,
 		cmd.MakeKeyMigrateCommand(),
 		cmd.MakeCompactDBCommand(),
+		cmd.SettlementCmd,
+		cmd.VerifierCmd,
+		cmd.KeysCmd,
 		debug.DebugCmd,
 		cli.NewCompletionCmd(rootCmd, true),
 	)
@@ -1,16 +1,20 @@
 package config
 
 import (
+	"bytes"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"math/big"
 	"net/http"
 	"os"
 	"path/filepath"
+	"regexp"
 	"time"
 
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	"github.com/tendermint/tendermint/libs/log"
+	tmmath "github.com/tendermint/tendermint/libs/math"
 	tmos "github.com/tendermint/tendermint/libs/os"
 	"github.com/tendermint/tendermint/types"
 )
@@ -53,6 +57,7 @@ var (
 	defaultMode             = ModeFull
 	defaultPrivValKeyName   = "priv_validator_key.json"
 	defaultPrivValStateName = "priv_validator_state.json"
+	defaultStarknetMaxFee   = "10000000000000000" // 0.01 ETH
 
 	defaultNodeKeyName  = "node_key.json"
 	defaultAddrBookName = "addrbook.json"
@@ -85,8 +90,9 @@ type Config struct {
 	Instrumentation *InstrumentationConfig `mapstructure:"instrumentation"`
 	PrivValidator   *PrivValidatorConfig   `mapstructure:"priv-validator"`
 
-	Starknet  *StarknetConfig  `mapstructure:"starknet"`
-	Protostar *ProtostarConfig `mapstructure:"protostar"`
+	Settlement *SettlementConfig `mapstructure:"settlement"`
+	Starknet   *StarknetConfig   `mapstructure:"starknet"`
+	Protostar  *ProtostarConfig  `mapstructure:"protostar"`
 }
 
 // DefaultConfig returns a default configuration for a Tendermint node
@@ -102,6 +108,7 @@ func DefaultConfig() *Config {
 		TxIndex:         DefaultTxIndexConfig(),
 		Instrumentation: DefaultInstrumentationConfig(),
 		PrivValidator:   DefaultPrivValidatorConfig(),
+		Settlement:      DefaultSettlementConfig(),
 		Starknet:        DefaultStarknetConfig(),
 		Protostar:       DefaultProtostarConfig(),
 	}
@@ -127,6 +134,7 @@ func TestConfig() *Config {
 		TxIndex:         TestTxIndexConfig(),
 		Instrumentation: TestInstrumentationConfig(),
 		PrivValidator:   DefaultPrivValidatorConfig(),
+		Settlement:      TestSettlementConfig(),
 		Starknet:        DefaultStarknetConfig(),
 		Protostar:       DefaultProtostarConfig(),
 	}
@@ -140,6 +148,7 @@ func (cfg *Config) SetRoot(root string) *Config {
 	cfg.Mempool.RootDir = root
 	cfg.Consensus.RootDir = root
 	cfg.PrivValidator.RootDir = root
+	cfg.Settlement.RootDir = root
 	return cfg
 }
 
@@ -170,6 +179,9 @@ func (cfg *Config) ValidateBasic() error {
 	if err := cfg.Instrumentation.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [instrumentation] section: %w", err)
 	}
+	if err := cfg.Settlement.ValidateBasic(); err != nil {
+		return fmt.Errorf("error in [settlement] section: %w", err)
+	}
 	if err := cfg.Starknet.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [starknet] section: %w", err)
 	}
@@ -193,6 +205,13 @@ type BaseConfig struct { //nolint: maligned
 	// Deployed verifier's contract address
 	VerifierAddress string `mapstructure:"verifier-address"`
 
+	// Class hash of the deployed verifier contract
+	VerifierClassHash string `mapstructure:"verifier-class-hash"`
+
+	// Height of the header the verifier was initialized with, which it trusts
+	// without verifying it
+	VerifierTrustedHeight int64 `mapstructure:"verifier-trusted-height"`
+
 	// The root directory for all data.
 	// This should be set in viper so it can unmarshal into this struct
 	RootDir string `mapstructure:"home"`
@@ -362,6 +381,10 @@ func (cfg BaseConfig) ValidateBasic() error {
 		return fmt.Errorf("unknown mode: %v", cfg.Mode)
 	}
 
+	if cfg.VerifierTrustedHeight < 0 {
+		return errors.New("verifier-trusted-height can't be negative")
+	}
+
 	// TODO (https://github.com/tendermint/tendermint/issues/6908) remove this check after the v0.35 release cycle.
 	// This check was added to give users an upgrade prompt to use the new
 	// configuration option in v0.35. In future release cycles they should no longer
@@ -400,6 +423,11 @@ type PrivValidatorConfig struct {
 	// Path to the JSON file containing the last sign state of a validator
 	State string `mapstructure:"state-file"`
 
+	// Path to the file containing the passphrase of the key file, if it is
+	// encrypted. If empty, the passphrase is read from the
+	// PrivValidatorKeyPassphraseEnv environment variable
+	KeyPassphrase string `mapstructure:"key-passphrase-file"`
+
 	// TCP or UNIX socket address for Tendermint to listen on for
 	// connections from an external PrivValidator process
 	ListenAddr string `mapstructure:"laddr"`
@@ -413,14 +441,34 @@ type PrivValidatorConfig struct {
 
 	// Path Root Certificate Authority used to sign both client and server certificates
 	RootCA string `mapstructure:"root-ca-file"`
+
+	// Path to the file containing the hex encoded stark key of the Starknet
+	// account settling commits, for the starknet settlement backend to sign
+	// through the validator. Only read by the local file validator.
+	StarknetKey string `mapstructure:"starknet-key-file"`
+
+	// Calls the transactions signed with the starknet key may make, as
+	// "<contract address>:<function>" entries, or "<contract address>:*" to
+	// allow all the functions of a contract
+	StarknetAllowedCalls []string `mapstructure:"starknet-allowed-calls"`
+
+	// Address of the account contract the starknet key signs for, and chain
+	// id of the StarkNet network, as hex or decimal felts. Transactions of
+	// other accounts or chains are refused.
+	StarknetAccountAddress string `mapstructure:"starknet-account-address"`
+	StarknetChainID        string `mapstructure:"starknet-chain-id"`
+
+	// Maximum fee of the transactions signed with the starknet key, in wei
+	StarknetMaxFee string `mapstructure:"starknet-max-fee"`
 }
 
 // DefaultBaseConfig returns a default private validator configuration
 // for a Tendermint node.
 func DefaultPrivValidatorConfig() *PrivValidatorConfig {
 	return &PrivValidatorConfig{
-		Key:   defaultPrivValKeyPath,
-		State: defaultPrivValStatePath,
+		Key:            defaultPrivValKeyPath,
+		State:          defaultPrivValStatePath,
+		StarknetMaxFee: defaultStarknetMaxFee,
 	}
 }
 
@@ -449,6 +497,53 @@ func (cfg *PrivValidatorConfig) StateFile() string {
 	return rootify(cfg.State, cfg.RootDir)
 }
 
+// PrivValidatorKeyPassphraseEnv is the environment variable holding the
+// passphrase of an encrypted priv validator key file, when no passphrase file
+// is configured.
+const PrivValidatorKeyPassphraseEnv = "TM_PRIV_VALIDATOR_KEY_PASSPHRASE"
+
+// KeyPassphraseFile returns the full path to the passphrase file of the
+// priv validator key, or an empty string if there is none
+func (cfg *PrivValidatorConfig) KeyPassphraseFile() string {
+	if cfg.KeyPassphrase == "" {
+		return ""
+	}
+	return rootify(cfg.KeyPassphrase, cfg.RootDir)
+}
+
+// ReadKeyPassphrase returns the passphrase of the priv validator key, read
+// from the passphrase file without its trailing newline, or else from the
+// PrivValidatorKeyPassphraseEnv environment variable. It returns nil if
+// neither is set, when the key is not encrypted.
+func (cfg *PrivValidatorConfig) ReadKeyPassphrase() ([]byte, error) {
+	file := cfg.KeyPassphraseFile()
+	if file == "" {
+		if passphrase := os.Getenv(PrivValidatorKeyPassphraseEnv); passphrase != "" {
+			return []byte(passphrase), nil
+		}
+		return nil, nil
+	}
+
+	bz, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the priv validator key passphrase: %w", err)
+	}
+	passphrase := bytes.TrimRight(bz, "\r\n")
+	if len(passphrase) == 0 {
+		return nil, fmt.Errorf("priv validator key passphrase file %s is empty", file)
+	}
+	return passphrase, nil
+}
+
+// StarknetKeyFile returns the full path to the starknet key file, or an
+// empty string if there is none
+func (cfg *PrivValidatorConfig) StarknetKeyFile() string {
+	if cfg.StarknetKey == "" {
+		return ""
+	}
+	return rootify(cfg.StarknetKey, cfg.RootDir)
+}
+
 func (cfg *PrivValidatorConfig) AreSecurityOptionsPresent() bool {
 	switch {
 	case cfg.RootCA == "":
@@ -1410,25 +1505,440 @@ func getDefaultMoniker() string {
 }
 
 // -----------------------------------------------------------------------------
-// StarknetConfig for network details
+// SettlementConfig
+
+const (
+	// SettlementBackendProtostar settles through the protostar CLI.
+	SettlementBackendProtostar = "protostar"
+	// SettlementBackendStarknet settles through the native StarkNet JSON-RPC
+	// client configured in the [starknet] section.
+	SettlementBackendStarknet = "starknet"
+	// SettlementBackendFile writes the settlement calldata to a file instead
+	// of sending it, for dry runs.
+	SettlementBackendFile = "file"
+	// SettlementBackendMock keeps submissions in memory. Only useful in tests.
+	SettlementBackendMock = "mock"
+
+	// StarknetSignerKeyFile signs starknet transactions with the key read from
+	// the private-key-path.
+	StarknetSignerKeyFile = "key-file"
+	// StarknetSignerPrivValidator signs starknet transactions through the
+	// priv validator of the node, which may be a remote signer.
+	StarknetSignerPrivValidator = "priv-validator"
+
+	// SettlementModeEveryHeight settles every height against the previous one.
+	SettlementModeEveryHeight = "every-height"
+	// SettlementModeInterval settles every interval heights, and whenever the
+	// validator set changes, skipping the heights in between.
+	SettlementModeInterval = "interval"
+	// SettlementModeValidatorSetChange only settles heights at which the
+	// validator set changes, skipping the heights in between.
+	SettlementModeValidatorSetChange = "validator-set-change"
+
+	// DefaultSettlementTarget is the name of the target commits are settled
+	// on when no targets are configured.
+	DefaultSettlementTarget = "default"
+)
+
+// settlementTargetName matches the valid names of settlement targets, which
+// name their databases and files.
+var settlementTargetName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// SettlementConfig defines how commits are settled on StarkNet
+type SettlementConfig struct {
+	RootDir string `mapstructure:"home"`
+
+	// Backend used to submit commits: protostar | starknet | file | mock
+	Backend string `mapstructure:"backend"`
+
+	// Path of the file the file backend writes to
+	FilePath string `mapstructure:"file-path"`
+
+	// Mode selecting the heights that are settled:
+	// every-height | interval | validator-set-change
+	Mode string `mapstructure:"mode"`
+
+	// Number of heights between two settled heights in the interval mode
+	Interval int64 `mapstructure:"interval"`
+
+	// Fraction of the last settled validator set that must have signed a
+	// skipped-to height, e.g. "1/3". Must be within [1/3, 1].
+	TrustLevel string `mapstructure:"trust-level"`
+
+	// How long each validator waits for the one before it in the failover
+	// order to settle a height, before settling it itself
+	FailoverTimeout time.Duration `mapstructure:"failover-timeout"`
+
+	// First height that is settled. 0 settles from the first height with a
+	// predecessor to verify it against. The verifier must have been
+	// initialized with the header before it, see slush verifier init.
+	StartHeight int64 `mapstructure:"start-height"`
+
+	// Trusting period the verifier checks the trusted header against. Should
+	// be shorter than the unbonding period of the chain.
+	TrustingPeriod time.Duration `mapstructure:"trusting-period"`
+
+	// Maximum clock drift the verifier allows between the untrusted header and
+	// the time of settlement
+	MaxClockDrift time.Duration `mapstructure:"max-clock-drift"`
+
+	// Number of commits batched into one transaction by backends that batch
+	BatchSize int `mapstructure:"batch-size"`
+
+	// Verifier entry points verifying a block against its predecessor, and
+	// against an earlier block
+	AdjacentFunction    string `mapstructure:"adjacent-function"`
+	NonAdjacentFunction string `mapstructure:"non-adjacent-function"`
+
+	// Maximum fee of a settlement transaction, in wei. Empty means no cap.
+	MaxFee string `mapstructure:"max-fee"`
+
+	// Margin applied to the estimated fee of a settlement transaction. Every
+	// retry of a transaction that ran out of fee applies it once more, up to
+	// max-fee.
+	FeeMultiplier float64 `mapstructure:"fee-multiplier"`
+
+	// Number of times a settlement transaction is sent before giving up, and
+	// the wait before the first retry, doubled after each one up to
+	// retry-max-backoff
+	MaxAttempts     int           `mapstructure:"max-attempts"`
+	RetryBackoff    time.Duration `mapstructure:"retry-backoff"`
+	RetryMaxBackoff time.Duration `mapstructure:"retry-max-backoff"`
+
+	// Verifier contracts commits are settled on, each independently of the
+	// others. Without targets, commits are settled on the verifier-address
+	// with the backend of this section.
+	Targets []*SettlementTargetConfig `mapstructure:"targets"`
+}
+
+// SettlementTargetConfig is a verifier contract commits are settled on. The
+// values it leaves empty are taken from the [settlement] section, and from the
+// [starknet] or [protostar] section of its backend.
+type SettlementTargetConfig struct {
+	// Unique name of the target, made of lowercase letters, digits, '-' and
+	// '_'
+	Name string `mapstructure:"name"`
+
+	// Backend used to submit commits: protostar | starknet | file | mock
+	Backend string `mapstructure:"backend"`
+
+	// Path of the file the file backend writes to
+	FilePath string `mapstructure:"file-path"`
+
+	// Address of the verifier contract
+	VerifierAddress string `mapstructure:"verifier-address"`
+
+	// Network of the target: the JSON-RPC endpoint of the starknet backend,
+	// the gateway or network of the protostar backend, and the chain id
+	RPCURL     string `mapstructure:"rpc-url"`
+	GatewayURL string `mapstructure:"gateway-url"`
+	Network    string `mapstructure:"network"`
+	ChainID    string `mapstructure:"chain-id"`
+
+	// Account sending the settlement transactions, and how the starknet
+	// backend signs them
+	AccountAddress string `mapstructure:"account-address"`
+	PrivateKeyPath string `mapstructure:"private-key-path"`
+	Signer         string `mapstructure:"signer"`
+
+	// Heights settled on the target, and the number of commits batched into
+	// one transaction
+	Mode      string `mapstructure:"mode"`
+	Interval  int64  `mapstructure:"interval"`
+	BatchSize int    `mapstructure:"batch-size"`
+
+	// First height settled on the target, the one after the header its
+	// verifier was initialized with, see slush verifier init --target
+	StartHeight int64 `mapstructure:"start-height"`
+}
+
+// ValidateBasic performs basic validation.
+func (cfg *SettlementTargetConfig) ValidateBasic() error {
+	if !settlementTargetName.MatchString(cfg.Name) {
+		return fmt.Errorf("invalid name %q", cfg.Name)
+	}
+	switch cfg.Backend {
+	case "", SettlementBackendProtostar, SettlementBackendStarknet, SettlementBackendFile, SettlementBackendMock:
+	default:
+		return fmt.Errorf("unknown backend %q", cfg.Backend)
+	}
+	switch cfg.Mode {
+	case "", SettlementModeEveryHeight, SettlementModeInterval, SettlementModeValidatorSetChange:
+	default:
+		return fmt.Errorf("unknown mode %q", cfg.Mode)
+	}
+	if cfg.Interval < 0 {
+		return errors.New("interval can't be negative")
+	}
+	if cfg.BatchSize < 0 {
+		return errors.New("batch-size can't be negative")
+	}
+	if cfg.StartHeight < 0 {
+		return errors.New("start-height can't be negative")
+	}
+	switch cfg.Signer {
+	case "", StarknetSignerKeyFile, StarknetSignerPrivValidator:
+	default:
+		return fmt.Errorf("unknown signer %q", cfg.Signer)
+	}
+	return nil
+}
+
+// Starknet returns the configuration of the starknet backend of the target.
+func (cfg *SettlementTargetConfig) Starknet() *StarknetConfig {
+	return &StarknetConfig{
+		RPCURL:         cfg.RPCURL,
+		AccountAddress: cfg.AccountAddress,
+		PrivateKeyPath: cfg.PrivateKeyPath,
+		Signer:         cfg.Signer,
+		ChainID:        cfg.ChainID,
+	}
+}
+
+// Protostar returns the configuration of the protostar backend of the
+// target.
+func (cfg *SettlementTargetConfig) Protostar() *ProtostarConfig {
+	return &ProtostarConfig{
+		AccountAddress: cfg.AccountAddress,
+		ChainId:        cfg.ChainID,
+		GatewayUrl:     cfg.GatewayURL,
+		Network:        cfg.Network,
+		PrivateKeyPath: cfg.PrivateKeyPath,
+	}
+}
+
+// SettlementTargets returns the targets commits are settled on, with the
+// values they leave empty filled in. Without targets in the [settlement]
+// section, it returns the DefaultSettlementTarget, made of the verifier
+// address and of the settlement sections.
+func (cfg *Config) SettlementTargets() []*SettlementTargetConfig {
+	targets := cfg.Settlement.Targets
+	if len(targets) == 0 {
+		targets = []*SettlementTargetConfig{{
+			Name:            DefaultSettlementTarget,
+			FilePath:        cfg.Settlement.FilePath,
+			VerifierAddress: cfg.VerifierAddress,
+		}}
+	}
+
+	resolved := make([]*SettlementTargetConfig, len(targets))
+	for i, target := range targets {
+		t := *target
+		if t.Backend == "" {
+			t.Backend = cfg.Settlement.Backend
+		}
+		if t.FilePath == "" {
+			t.FilePath = filepath.Join(defaultDataDir, "settlement-"+t.Name+".jsonl")
+		}
+		t.FilePath = rootify(t.FilePath, cfg.Settlement.RootDir)
+		if t.Mode == "" {
+			t.Mode = cfg.Settlement.Mode
+		}
+		if t.Interval == 0 {
+			t.Interval = cfg.Settlement.Interval
+		}
+		if t.BatchSize == 0 {
+			t.BatchSize = cfg.Settlement.BatchSize
+		}
+		if t.StartHeight == 0 {
+			t.StartHeight = cfg.Settlement.StartHeight
+		}
+
+		switch t.Backend {
+		case SettlementBackendStarknet:
+			t.RPCURL = valueOr(t.RPCURL, cfg.Starknet.RPCURL)
+			t.ChainID = valueOr(t.ChainID, cfg.Starknet.ChainID)
+			t.AccountAddress = valueOr(t.AccountAddress, cfg.Starknet.AccountAddress)
+			t.PrivateKeyPath = valueOr(t.PrivateKeyPath, cfg.Starknet.PrivateKeyPath)
+			t.Signer = valueOr(t.Signer, cfg.Starknet.Signer)
+		case SettlementBackendProtostar:
+			t.GatewayURL = valueOr(t.GatewayURL, cfg.Protostar.GatewayUrl)
+			t.Network = valueOr(t.Network, cfg.Protostar.Network)
+			t.ChainID = valueOr(t.ChainID, cfg.Protostar.ChainId)
+			t.AccountAddress = valueOr(t.AccountAddress, cfg.Protostar.AccountAddress)
+			t.PrivateKeyPath = valueOr(t.PrivateKeyPath, cfg.Protostar.PrivateKeyPath)
+		}
+		resolved[i] = &t
+	}
+	return resolved
+}
+
+// valueOr returns value, or def if value is empty.
+func valueOr(value, def string) string {
+	if value == "" {
+		return def
+	}
+	return value
+}
+
+// DefaultSettlementConfig returns a default configuration for settlement
+func DefaultSettlementConfig() *SettlementConfig {
+	return &SettlementConfig{
+		Backend:             SettlementBackendProtostar,
+		FilePath:            filepath.Join(defaultDataDir, "settlement.jsonl"),
+		Mode:                SettlementModeEveryHeight,
+		Interval:            100,
+		TrustLevel:          "1/3",
+		FailoverTimeout:     5 * time.Minute,
+		StartHeight:         0,
+		TrustingPeriod:      168 * time.Hour,
+		MaxClockDrift:       10 * time.Second,
+		BatchSize:           10,
+		AdjacentFunction:    "externalVerifyAdjacent",
+		NonAdjacentFunction: "externalVerifyNonAdjacent",
+		MaxFee:              "",
+		FeeMultiplier:       1.5,
+		MaxAttempts:         5,
+		RetryBackoff:        5 * time.Second,
+		RetryMaxBackoff:     2 * time.Minute,
+	}
+}
+
+// TestSettlementConfig returns a configuration for testing settlement, which
+// does not depend on external tools
+func TestSettlementConfig() *SettlementConfig {
+	cfg := DefaultSettlementConfig()
+	cfg.Backend = SettlementBackendMock
+	return cfg
+}
+
+// File returns the full path of the file backend's output file
+func (cfg *SettlementConfig) File() string {
+	return rootify(cfg.FilePath, cfg.RootDir)
+}
+
+// TrustLevelFraction returns the parsed trust level
+func (cfg *SettlementConfig) TrustLevelFraction() (tmmath.Fraction, error) {
+	return tmmath.ParseFraction(cfg.TrustLevel)
+}
+
+// MaxFeeWei returns the parsed maximum fee, or nil if there is no cap
+func (cfg *SettlementConfig) MaxFeeWei() (*big.Int, error) {
+	if cfg.MaxFee == "" {
+		return nil, nil
+	}
+	fee, ok := new(big.Int).SetString(cfg.MaxFee, 0)
+	if !ok || fee.Sign() < 0 {
+		return nil, fmt.Errorf("invalid fee %q", cfg.MaxFee)
+	}
+	return fee, nil
+}
+
+// ValidateBasic performs basic validation.
+func (cfg *SettlementConfig) ValidateBasic() error {
+	switch cfg.Backend {
+	case SettlementBackendProtostar, SettlementBackendStarknet, SettlementBackendMock:
+	case SettlementBackendFile:
+		if cfg.FilePath == "" {
+			return errors.New("file-path cannot be empty with the file backend")
+		}
+	default:
+		return fmt.Errorf("unknown backend %q", cfg.Backend)
+	}
+
+	switch cfg.Mode {
+	case SettlementModeEveryHeight, SettlementModeValidatorSetChange:
+	case SettlementModeInterval:
+		if cfg.Interval <= 0 {
+			return errors.New("interval must be positive in the interval mode")
+		}
+	default:
+		return fmt.Errorf("unknown mode %q", cfg.Mode)
+	}
+
+	trustLevel, err := cfg.TrustLevelFraction()
+	if err != nil {
+		return fmt.Errorf("invalid trust-level: %w", err)
+	}
+	// same bounds as the light client
+	if trustLevel.Numerator*3 < trustLevel.Denominator ||
+		trustLevel.Numerator > trustLevel.Denominator {
+		return fmt.Errorf("trust-level must be within [1/3, 1], given %v", trustLevel)
+	}
+
+	if cfg.FailoverTimeout <= 0 {
+		return errors.New("failover-timeout must be positive")
+	}
+	if cfg.StartHeight < 0 {
+		return errors.New("start-height can't be negative")
+	}
+	if cfg.TrustingPeriod <= 0 {
+		return errors.New("trusting-period must be positive")
+	}
+	if cfg.MaxClockDrift < 0 {
+		return errors.New("max-clock-drift can't be negative")
+	}
+	if cfg.BatchSize <= 0 {
+		return errors.New("batch-size must be positive")
+	}
+	if cfg.AdjacentFunction == "" || cfg.NonAdjacentFunction == "" {
+		return errors.New("adjacent-function and non-adjacent-function cannot be empty")
+	}
+	if _, err := cfg.MaxFeeWei(); err != nil {
+		return fmt.Errorf("invalid max-fee: %w", err)
+	}
+	if cfg.FeeMultiplier < 1 {
+		return errors.New("fee-multiplier can't be less than 1")
+	}
+	if cfg.MaxAttempts <= 0 {
+		return errors.New("max-attempts must be positive")
+	}
+	if cfg.RetryBackoff <= 0 {
+		return errors.New("retry-backoff must be positive")
+	}
+	if cfg.RetryMaxBackoff < cfg.RetryBackoff {
+		return errors.New("retry-max-backoff can't be less than retry-backoff")
+	}
+
+	names := make(map[string]bool, len(cfg.Targets))
+	for i, target := range cfg.Targets {
+		if err := target.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid target #%d: %w", i, err)
+		}
+		if names[target.Name] {
+			return fmt.Errorf("duplicate target %q", target.Name)
+		}
+		names[target.Name] = true
+		mode, interval := valueOr(target.Mode, cfg.Mode), target.Interval
+		if interval == 0 {
+			interval = cfg.Interval
+		}
+		if mode == SettlementModeInterval && interval <= 0 {
+			return fmt.Errorf("target %q: interval must be positive in the interval mode", target.Name)
+		}
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// StarknetConfig for the native StarkNet JSON-RPC client
 type StarknetConfig struct {
-	Account          string `mapstructure:"account"`
-	AccountDir       string `mapstructure:"wallets-dir"`
-	FeederGatewayURL string `mapstructure:"feeder-gateway-url"`
-	GatewayURL       string `mapstructure:"gateway-url"`
-	Network          string `mapstructure:"network"`
-	Wallet           string `mapstructure:"wallet"`
+	// URL of the StarkNet JSON-RPC endpoint
+	RPCURL string `mapstructure:"rpc-url"`
+
+	// Address of the account contract sending settlement transactions
+	AccountAddress string `mapstructure:"account-address"`
+
+	// Path to the file containing the hex encoded private key of the account
+	PrivateKeyPath string `mapstructure:"private-key-path"`
+
+	// How transactions are signed: key-file | priv-validator
+	Signer string `mapstructure:"signer"`
+
+	// Chain id of the StarkNet network, as a hex or decimal felt. If empty
+	// it is queried from the RPC endpoint.
+	ChainID string `mapstructure:"chain-id"`
 }
 
-// DefaultStarknetConfig returns a default configuration for starknet
+// DefaultStarknetConfig returns a default configuration for starknet,
+// matching a starknet-devnet started with --seed 42
 func DefaultStarknetConfig() *StarknetConfig {
 	return &StarknetConfig{
-		Account:          "devnet",
-		AccountDir:       ".starknet_accounts",
-		FeederGatewayURL: "http://127.0.0.1:5050/",
-		GatewayURL:       "http://127.0.0.1:5050/",
-		Network:          "alpha-goerli",
-		Wallet:           "starkware.starknet.wallets.open_zeppelin.OpenZeppelinAccount",
+		RPCURL:         "http://127.0.0.1:5050/rpc",
+		AccountAddress: "0x347be35996a21f6bf0623e75dbce52baba918ad5ae8d83b6f416045ab22961a",
+		PrivateKeyPath: "seed42pkey",
+		Signer:         StarknetSignerKeyFile,
+		ChainID:        "",
 	}
 }
 
@@ -1439,17 +1949,20 @@ func TestStarknetConfig() *StarknetConfig {
 
 // ValidateBasic performs basic validation.
 func (cfg *StarknetConfig) ValidateBasic() error {
-	if cfg.Account == "" {
-		return errors.New("account cannot be empty")
-	}
-	if cfg.AccountDir == "" {
-		return errors.New("account dir cannot be empty")
+	if cfg.RPCURL == "" {
+		return errors.New("rpc-url cannot be empty")
 	}
-	if cfg.Network == "" {
-		return errors.New("network cannot be empty")
+	if cfg.AccountAddress == "" {
+		return errors.New("account address cannot be empty")
 	}
-	if cfg.Wallet == "" {
-		return errors.New("wallet cannot be empty")
+	switch cfg.Signer {
+	case "", StarknetSignerKeyFile:
+		if cfg.PrivateKeyPath == "" {
+			return errors.New("private key path cannot be empty")
+		}
+	case StarknetSignerPrivValidator:
+	default:
+		return fmt.Errorf("unknown signer %q", cfg.Signer)
 	}
 
 	return nil
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 	"time"
@@ -59,6 +61,10 @@ func TestBaseConfigValidateBasic(t *testing.T) {
 	// tamper with log format
 	cfg.LogFormat = "invalid"
 	assert.Error(t, cfg.ValidateBasic())
+
+	cfg = TestBaseConfig()
+	cfg.VerifierTrustedHeight = -1
+	assert.Error(t, cfg.ValidateBasic())
 }
 
 func TestRPCConfigValidateBasic(t *testing.T) {
@@ -186,3 +192,211 @@ func TestInstrumentationConfigValidateBasic(t *testing.T) {
 	cfg.MaxOpenConnections = -1
 	assert.Error(t, cfg.ValidateBasic())
 }
+
+func TestPrivValidatorConfigReadKeyPassphrase(t *testing.T) {
+	dir := t.TempDir()
+	cfg := DefaultPrivValidatorConfig()
+	cfg.RootDir = dir
+	t.Setenv(PrivValidatorKeyPassphraseEnv, "")
+
+	passphrase, err := cfg.ReadKeyPassphrase()
+	require.NoError(t, err)
+	assert.Nil(t, passphrase)
+
+	t.Setenv(PrivValidatorKeyPassphraseEnv, "from env")
+	passphrase, err = cfg.ReadKeyPassphrase()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("from env"), passphrase)
+
+	// the passphrase file takes precedence, without its trailing newline
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "passphrase"), []byte(" from file \n"), 0600))
+	cfg.KeyPassphrase = "passphrase"
+	passphrase, err = cfg.ReadKeyPassphrase()
+	require.NoError(t, err)
+	assert.Equal(t, []byte(" from file "), passphrase)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "passphrase"), []byte("\n"), 0600))
+	_, err = cfg.ReadKeyPassphrase()
+	assert.Error(t, err)
+
+	cfg.KeyPassphrase = "missing"
+	_, err = cfg.ReadKeyPassphrase()
+	assert.Error(t, err)
+}
+
+func TestSettlementConfigMaxFeeWei(t *testing.T) {
+	cfg := DefaultSettlementConfig()
+	fee, err := cfg.MaxFeeWei()
+	require.NoError(t, err)
+	assert.Nil(t, fee)
+
+	cfg.MaxFee = "0x2386f26fc10000"
+	fee, err = cfg.MaxFeeWei()
+	require.NoError(t, err)
+	assert.EqualValues(t, 10000000000000000, fee.Int64())
+}
+
+func TestSettlementConfigValidateBasic(t *testing.T) {
+	cfg := DefaultSettlementConfig()
+	assert.NoError(t, cfg.ValidateBasic())
+
+	// the file backend needs somewhere to write to
+	cfg.Backend = SettlementBackendFile
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.FilePath = ""
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg.Backend = "carrier-pigeon"
+	assert.Error(t, cfg.ValidateBasic())
+
+	// test verification and backend parameters
+	invalidValues := []func(*SettlementConfig){
+		func(cfg *SettlementConfig) { cfg.FailoverTimeout = 0 },
+		func(cfg *SettlementConfig) { cfg.StartHeight = -1 },
+		func(cfg *SettlementConfig) { cfg.TrustingPeriod = 0 },
+		func(cfg *SettlementConfig) { cfg.MaxClockDrift = -time.Second },
+		func(cfg *SettlementConfig) { cfg.BatchSize = 0 },
+		func(cfg *SettlementConfig) { cfg.AdjacentFunction = "" },
+		func(cfg *SettlementConfig) { cfg.NonAdjacentFunction = "" },
+		func(cfg *SettlementConfig) { cfg.MaxFee = "lots" },
+		func(cfg *SettlementConfig) { cfg.MaxFee = "-1" },
+		func(cfg *SettlementConfig) { cfg.FeeMultiplier = 0.9 },
+		func(cfg *SettlementConfig) { cfg.MaxAttempts = 0 },
+		func(cfg *SettlementConfig) { cfg.RetryBackoff = 0 },
+		func(cfg *SettlementConfig) { cfg.RetryMaxBackoff = time.Second },
+	}
+	for i, invalidate := range invalidValues {
+		cfg := DefaultSettlementConfig()
+		invalidate(cfg)
+		assert.Error(t, cfg.ValidateBasic(), "testCase%d failed", i)
+	}
+
+	// test mode and trust level
+	fieldsToTest := []struct {
+		mode       string
+		interval   int64
+		trustLevel string
+		wantErr    bool
+	}{
+		{SettlementModeEveryHeight, 0, "1/3", false},
+		{SettlementModeInterval, 10, "2/3", false},
+		{SettlementModeInterval, 0, "1/3", true},
+		{SettlementModeValidatorSetChange, 0, "1/1", false},
+		{"sometimes", 10, "1/3", true},
+		{SettlementModeInterval, 10, "1/4", true},
+		{SettlementModeInterval, 10, "4/3", true},
+		{SettlementModeInterval, 10, "one third", true},
+	}
+
+	for i, tc := range fieldsToTest {
+		cfg := DefaultSettlementConfig()
+		cfg.Mode = tc.mode
+		cfg.Interval = tc.interval
+		cfg.TrustLevel = tc.trustLevel
+
+		if tc.wantErr {
+			assert.Error(t, cfg.ValidateBasic(), "testCase%d failed", i)
+		} else {
+			assert.NoError(t, cfg.ValidateBasic(), "testCase%d failed", i)
+		}
+	}
+}
+
+func TestSettlementTargetsValidateBasic(t *testing.T) {
+	testCases := []struct {
+		targets []*SettlementTargetConfig
+		wantErr bool
+	}{
+		{nil, false},
+		{[]*SettlementTargetConfig{{Name: "testnet"}, {Name: "devnet-2", Backend: SettlementBackendStarknet}}, false},
+		{[]*SettlementTargetConfig{{Name: "testnet"}, {Name: "testnet"}}, true},
+		{[]*SettlementTargetConfig{{Name: ""}}, true},
+		{[]*SettlementTargetConfig{{Name: "../testnet"}}, true},
+		{[]*SettlementTargetConfig{{Name: "Testnet"}}, true},
+		{[]*SettlementTargetConfig{{Name: "testnet", Backend: "carrier-pigeon"}}, true},
+		{[]*SettlementTargetConfig{{Name: "testnet", Mode: "sometimes"}}, true},
+		{[]*SettlementTargetConfig{{Name: "testnet", Mode: SettlementModeInterval, Interval: 10}}, false},
+		{[]*SettlementTargetConfig{{Name: "testnet", Interval: -1}}, true},
+		{[]*SettlementTargetConfig{{Name: "testnet", BatchSize: -1}}, true},
+		{[]*SettlementTargetConfig{{Name: "testnet", StartHeight: -1}}, true},
+	}
+	for i, tc := range testCases {
+		cfg := DefaultSettlementConfig()
+		cfg.Targets = tc.targets
+		if tc.wantErr {
+			assert.Error(t, cfg.ValidateBasic(), "testCase%d failed", i)
+		} else {
+			assert.NoError(t, cfg.ValidateBasic(), "testCase%d failed", i)
+		}
+	}
+
+	// the interval mode needs an interval, inherited or not
+	cfg := DefaultSettlementConfig()
+	cfg.Interval = 0
+	cfg.Targets = []*SettlementTargetConfig{{Name: "testnet", Mode: SettlementModeInterval}}
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.Targets[0].Interval = 5
+	assert.NoError(t, cfg.ValidateBasic())
+}
+
+func TestConfigSettlementTargets(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.SetRoot("/home")
+	cfg.VerifierAddress = "0x1"
+
+	// the sections of the config make up the default target
+	targets := cfg.SettlementTargets()
+	require.Len(t, targets, 1)
+	assert.Equal(t, DefaultSettlementTarget, targets[0].Name)
+	assert.Equal(t, "0x1", targets[0].VerifierAddress)
+	assert.Equal(t, cfg.Settlement.Backend, targets[0].Backend)
+	assert.Equal(t, cfg.Settlement.File(), targets[0].FilePath)
+	assert.Equal(t, cfg.Protostar, targets[0].Protostar())
+
+	cfg.Settlement.Targets = []*SettlementTargetConfig{
+		{
+			Name:            "testnet",
+			Backend:         SettlementBackendStarknet,
+			VerifierAddress: "0x2",
+			RPCURL:          "https://testnet.example/rpc",
+			Mode:            SettlementModeInterval,
+			Interval:        50,
+			StartHeight:     7,
+		},
+		{
+			Name:            "devnet",
+			VerifierAddress: "0x3",
+			AccountAddress:  "0x4",
+			BatchSize:       1,
+			Signer:          StarknetSignerPrivValidator,
+		},
+	}
+	targets = cfg.SettlementTargets()
+	require.Len(t, targets, 2)
+
+	testnet := targets[0]
+	assert.Equal(t, "0x2", testnet.VerifierAddress)
+	assert.Equal(t, &StarknetConfig{
+		RPCURL:         "https://testnet.example/rpc",
+		AccountAddress: cfg.Starknet.AccountAddress,
+		PrivateKeyPath: cfg.Starknet.PrivateKeyPath,
+		Signer:         StarknetSignerKeyFile,
+	}, testnet.Starknet())
+	assert.Equal(t, SettlementModeInterval, testnet.Mode)
+	assert.EqualValues(t, 50, testnet.Interval)
+	assert.Equal(t, cfg.Settlement.BatchSize, testnet.BatchSize)
+	assert.Equal(t, "/home/data/settlement-testnet.jsonl", testnet.FilePath)
+	assert.EqualValues(t, 7, testnet.StartHeight)
+
+	devnet := targets[1]
+	assert.Equal(t, cfg.Settlement.Backend, devnet.Backend)
+	assert.Equal(t, "0x4", devnet.Protostar().AccountAddress)
+	assert.Equal(t, cfg.Protostar.GatewayUrl, devnet.Protostar().GatewayUrl)
+	assert.Equal(t, cfg.Settlement.Mode, devnet.Mode)
+	assert.Equal(t, 1, devnet.BatchSize)
+	assert.Equal(t, cfg.Settlement.StartHeight, devnet.StartHeight)
+	assert.Equal(t, StarknetSignerPrivValidator, devnet.Starknet().Signer)
+
+	// the configured targets are left as they are
+	assert.Empty(t, cfg.Settlement.Targets[1].Backend)
+}
@@ -145,6 +145,13 @@ node-key-file = "{{ js .BaseConfig.NodeKey }}"
 # Deployed verifier's contract address
 verifier-address = "{{ .BaseConfig.VerifierAddress }}"
 
+# Class hash of the deployed verifier contract
+verifier-class-hash = "{{ .BaseConfig.VerifierClassHash }}"
+
+# Height of the header the verifier was initialized with, which it trusts
+# without verifying it. Set by "slush verifier init" and "slush verifier upgrade".
+verifier-trusted-height = {{ .BaseConfig.VerifierTrustedHeight }}
+
 # The directory containing the cairo files
 cairo-dir = "{{ .BaseConfig.CairoDir }}"
 
@@ -167,6 +174,12 @@ key-file = "{{ js .PrivValidator.Key }}"
 # Path to the JSON file containing the last sign state of a validator
 state-file = "{{ js .PrivValidator.State }}"
 
+# Path to the file containing the passphrase of the key file, when it is
+# encrypted (see "slush keys encrypt"). If empty, the passphrase is read from
+# the TM_PRIV_VALIDATOR_KEY_PASSPHRASE environment variable, and a key file
+# generated without a passphrase is stored in the clear.
+key-passphrase-file = "{{ js .PrivValidator.KeyPassphrase }}"
+
 # TCP or UNIX socket address for Tendermint to listen on for
 # connections from an external PrivValidator process
 # when the listenAddr is prefixed with grpc instead of tcp it will use the gRPC Client
@@ -182,28 +195,170 @@ client-key-file = "{{ js .PrivValidator.ClientKey }}"
 # Path to the Root Certificate Authority used to sign both client and server certificates
 root-ca-file = "{{ js .PrivValidator.RootCA }}"
 
+# Path to the file containing the hex encoded private key of the StarkNet
+# account settling commits, for the starknet settlement backend to sign its
+# transactions through the validator (signer = "priv-validator"). Only read
+# by the local file validator, remote signers are configured on their own.
+starknet-key-file = "{{ js .PrivValidator.StarknetKey }}"
+
+# Calls the transactions signed with the starknet key may make, as
+# "<contract address>:<function>" entries, or "<contract address>:*" to allow
+# all the functions of a contract. Transactions making any other call are
+# refused.
+starknet-allowed-calls = [{{ range .PrivValidator.StarknetAllowedCalls }}{{ printf "%q, " . }}{{end}}]
+
+# Address of the account contract the starknet key signs for, and chain id of
+# the StarkNet network, as hex or decimal felts. Both are required with a
+# starknet key: transactions of other accounts or chains are refused.
+starknet-account-address = "{{ .PrivValidator.StarknetAccountAddress }}"
+starknet-chain-id = "{{ .PrivValidator.StarknetChainID }}"
+
+# Maximum fee of the transactions signed with the starknet key, in wei.
+# Transactions which may cost more are refused.
+starknet-max-fee = "{{ .PrivValidator.StarknetMaxFee }}"
+
+#######################################################
+###            Settlement Configuration             ###
+#######################################################
+[settlement]
+
+# Backend used to submit commits for settlement:
+#   1) "protostar" - invoke the verifier through the protostar CLI, see [protostar]
+#   2) "starknet"  - invoke the verifier through the native JSON-RPC client, see [starknet]
+#   3) "file"      - do not send anything, write the calldata to file-path (dry run)
+#   4) "mock"      - keep submissions in memory, for tests
+backend = "{{ .Settlement.Backend }}"
+
+# Path of the file the "file" backend writes to
+file-path = "{{ js .Settlement.FilePath }}"
+
+# Heights that are settled:
+#   1) "every-height"         - settle every height against the previous one
+#   2) "interval"             - settle every "interval" heights, and every height
+#                               at which the validator set changes
+#   3) "validator-set-change" - only settle heights at which the validator set changes
+# The last two skip heights, so fewer transactions are sent, and rely on
+# trust-level of the last settled validators signing the skipped-to height.
+mode = "{{ .Settlement.Mode }}"
+
+# Number of heights between two settled heights in the "interval" mode
+interval = {{ .Settlement.Interval }}
+
+# Fraction of the last settled validator set that must have signed a skipped-to
+# height. Must be within [1/3, 1].
+trust-level = "{{ .Settlement.TrustLevel }}"
+
+# How long a validator waits for the validator before it to settle a height.
+# Each height is settled by the proposer of its block first. When it is still
+# not settled on-chain failover-timeout after the block time, the next
+# validator in proposer priority order takes over, then the one after it, and so on.
+failover-timeout = "{{ .Settlement.FailoverTimeout }}"
+
+# First height that is settled, e.g. the first one after the verifier was
+# deployed. 0 settles from the first height with a predecessor.
+start-height = {{ .Settlement.StartHeight }}
+
+# Trusting period the verifier checks the trusted header against. Should be
+# significantly shorter than the unbonding period of the chain.
+trusting-period = "{{ .Settlement.TrustingPeriod }}"
+
+# Maximum clock drift the verifier allows between the untrusted header and
+# the time of settlement
+max-clock-drift = "{{ .Settlement.MaxClockDrift }}"
+
+# Number of commits batched into one multicall by the "protostar" backend
+batch-size = {{ .Settlement.BatchSize }}
+
+# Verifier entry points verifying a block against its predecessor, and
+# against an earlier block in the skipping modes
+adjacent-function = "{{ .Settlement.AdjacentFunction }}"
+non-adjacent-function = "{{ .Settlement.NonAdjacentFunction }}"
+
+# Maximum fee of a settlement transaction, in wei, as a decimal or hex number.
+# Transactions estimated to cost more are not sent. Empty means no cap.
+max-fee = "{{ .Settlement.MaxFee }}"
+
+# Margin applied to the estimated fee of a settlement transaction. Every retry
+# of a transaction that ran out of fee applies it once more, up to max-fee.
+# The "protostar" backend lets protostar estimate the fee and only uses max-fee.
+fee-multiplier = {{ .Settlement.FeeMultiplier }}
+
+# Number of times a settlement transaction is sent before giving up. Errors
+# that can't be fixed by retrying, like a rejected transaction, are not
+# retried.
+max-attempts = {{ .Settlement.MaxAttempts }}
+
+# Wait before the first retry, doubled after each retry up to
+# retry-max-backoff
+retry-backoff = "{{ .Settlement.RetryBackoff }}"
+retry-max-backoff = "{{ .Settlement.RetryMaxBackoff }}"
+
+# Verifier contracts commits are settled on, each with its own queue, so that
+# one target failing does not hold up the others. Without targets, commits
+# are settled on verifier-address with the backend of this section. The values
+# a target leaves empty are taken from this section, and from the [starknet]
+# or [protostar] section of its backend. For example:
+#
+# [[settlement.targets]]
+# name = "testnet"
+# backend = "starknet"
+# verifier-address = "0x..."
+# rpc-url = "https://starknet-testnet.example/rpc"
+# account-address = "0x..."
+# private-key-path = "testnet-pkey"
+# mode = "interval"
+# interval = 100
+#
+# [[settlement.targets]]
+# name = "devnet"
+# backend = "protostar"
+# verifier-address = "0x..."
+# gateway-url = "http://127.0.0.1:5050/"
+# batch-size = 1
+{{- range .Settlement.Targets }}
+
+[[settlement.targets]]
+name = "{{ .Name }}"
+backend = "{{ .Backend }}"
+file-path = "{{ js .FilePath }}"
+verifier-address = "{{ .VerifierAddress }}"
+rpc-url = "{{ .RPCURL }}"
+gateway-url = "{{ .GatewayURL }}"
+network = "{{ .Network }}"
+chain-id = "{{ .ChainID }}"
+account-address = "{{ .AccountAddress }}"
+private-key-path = "{{ js .PrivateKeyPath }}"
+signer = "{{ .Signer }}"
+mode = "{{ .Mode }}"
+interval = {{ .Interval }}
+batch-size = {{ .BatchSize }}
+start-height = {{ .StartHeight }}
+{{- end }}
+
 #######################################################
 ###             Starknet Configuration              ###
 #######################################################
 [starknet]
 
-#
-account = "{{ .Starknet.Account }}"
+# URL of the StarkNet JSON-RPC endpoint used by the native settlement client
+rpc-url = "{{ .Starknet.RPCURL }}"
 
-#
-account-dir = "{{ .Starknet.AccountDir }}"
+# Address of the account contract sending settlement transactions
+account-address = "{{ .Starknet.AccountAddress }}"
 
-#
-feeder-gateway-url = "{{ .Starknet.FeederGatewayURL }}"
+# Path to the file containing the hex encoded private key of the account
+private-key-path = "{{ js .Starknet.PrivateKeyPath }}"
 
-#
-gateway-url = "{{ .Starknet.GatewayURL }}"
-
-#
-network = "{{ .Starknet.Network }}"
+# How settlement transactions are signed:
+#   1) "key-file"       - with the key read from private-key-path
+#   2) "priv-validator" - through the priv validator of the node, local or
+#                         remote, which holds the key and checks the calls
+#                         against its allow-list, see [priv-validator]
+signer = "{{ .Starknet.Signer }}"
 
-#
-wallet = "{{ .Starknet.Wallet }}"
+# Chain id of the StarkNet network, as a hex or decimal felt.
+# If empty, it is queried from the RPC endpoint.
+chain-id = "{{ .Starknet.ChainID }}"
 
 #######################################################
 ###             Protostar Configuration              ###
@@ -4,10 +4,11 @@ import (
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/crypto/sr25519"
+	"github.com/tendermint/tendermint/crypto/stark"
 )
 
 // CreateBatchVerifier checks if a key type implements the batch verifier interface.
-// Currently only ed25519 & sr25519 supports batch verification.
+// Currently only ed25519, sr25519 & stark support batch verification.
 func CreateBatchVerifier(pk crypto.PubKey) (crypto.BatchVerifier, bool) {
 
 	switch pk.Type() {
@@ -15,6 +16,8 @@ func CreateBatchVerifier(pk crypto.PubKey) (crypto.BatchVerifier, bool) {
 		return ed25519.NewBatchVerifier(), true
 	case sr25519.KeyType:
 		return sr25519.NewBatchVerifier(), true
+	case stark.KeyType:
+		return stark.NewBatchVerifier(), true
 	}
 
 	// case where the key does not support batch verification
@@ -25,7 +28,7 @@ func CreateBatchVerifier(pk crypto.PubKey) (crypto.BatchVerifier, bool) {
 // interface.
 func SupportsBatchVerifier(pk crypto.PubKey) bool {
 	switch pk.Type() {
-	case ed25519.KeyType, sr25519.KeyType:
+	case ed25519.KeyType, sr25519.KeyType, stark.KeyType:
 		return true
 	}
 
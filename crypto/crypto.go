@@ -1,9 +1,12 @@
 package crypto
 
 import (
+	"fmt"
 	"hash"
+	"sync/atomic"
 
 	"github.com/tendermint/tendermint/crypto/pedersen"
+	"github.com/tendermint/tendermint/crypto/poseidon"
 
 	"github.com/tendermint/tendermint/libs/bytes"
 )
@@ -31,32 +34,113 @@ func AddressHash(bz []byte) Address {
 	return Address(h[:32])
 }
 
-// NewFelt returns a new pedersen hasher that expects a byte chunk
+// The commitment hashes, which a chain selects in its genesis document.
+const (
+	CommitmentHashPedersen = "pedersen"
+	CommitmentHashPoseidon = "poseidon"
+)
+
+// commitmentHasher holds the functions of a commitment hash.
+type commitmentHasher struct {
+	name    string
+	newFelt func() hash.Hash
+	new128  func() hash.Hash
+	sumFelt func([]byte) [HashSize]byte
+	sum128  func([]byte) [HashSize]byte
+}
+
+var commitmentHashers = map[string]*commitmentHasher{
+	CommitmentHashPedersen: {
+		name:    CommitmentHashPedersen,
+		newFelt: pedersen.New,
+		new128:  pedersen.New128,
+		sumFelt: pedersen.Sum,
+		sum128:  pedersen.Sum128,
+	},
+	CommitmentHashPoseidon: {
+		name:    CommitmentHashPoseidon,
+		newFelt: poseidon.New,
+		new128:  poseidon.New128,
+		sumFelt: poseidon.Sum,
+		sum128:  poseidon.Sum128,
+	},
+}
+
+// commitment holds the *commitmentHasher in use.
+var commitment atomic.Value
+
+func init() {
+	commitment.Store(commitmentHashers[CommitmentHashPedersen])
+}
+
+func commitmentHash() *commitmentHasher {
+	return commitment.Load().(*commitmentHasher)
+}
+
+// ValidateCommitmentHash returns an error if name is neither a commitment
+// hash nor empty, which stands for pedersen.
+func ValidateCommitmentHash(name string) error {
+	if _, ok := commitmentHashers[name]; !ok && name != "" {
+		return fmt.Errorf("unknown commitment hash %q, expected %q or %q",
+			name, CommitmentHashPedersen, CommitmentHashPoseidon)
+	}
+	return nil
+}
+
+// SetCommitmentHash selects the hash behind NewFelt, New128, Checksum128,
+// ChecksumFelt and Sum128, which commit to the headers, votes, transactions
+// and merkle trees of the chain and hash the messages signed by stark keys.
+// It defaults to pedersen, which an empty name also selects, and is set from
+// the genesis document before the node computes any hash.
+//
+// The selection is process-wide, not carried with the values it hashes: a
+// process follows a single chain, and must not switch hashes once it has
+// computed any. Only pedersen chains can settle on StarkNet, as the verifier
+// contract hashes with the pedersen builtin.
+func SetCommitmentHash(name string) error {
+	if err := ValidateCommitmentHash(name); err != nil {
+		return err
+	}
+	if name == "" {
+		name = CommitmentHashPedersen
+	}
+	commitment.Store(commitmentHashers[name])
+	return nil
+}
+
+// CommitmentHash returns the name of the commitment hash in use.
+func CommitmentHash() string {
+	return commitmentHash().name
+}
+
+// NewFelt returns a new commitment hasher that expects a byte chunk
 // containing 32 length felt byte slices
 func NewFelt() hash.Hash {
-	return pedersen.New()
+	return commitmentHash().newFelt()
 }
 
-// New128 returns a new pedersen hasher that expects a byte chunk
+// New128 returns a new commitment hasher that expects a byte chunk
 // containing 16 length felt byte slices
 func New128() hash.Hash {
-	return pedersen.New128()
+	return commitmentHash().new128()
 }
 
-// Checksum returns the pedersen of the bz.
+// Checksum128 returns the commitment hash of the bz, split into 16 byte
+// felts.
 func Checksum128(in []byte) []byte {
-	hash := pedersen.Sum128(in)
+	hash := commitmentHash().sum128(in)
 	return hash[:]
 }
 
-// Checksum returns the SHA256 of the bz.
+// ChecksumFelt returns the commitment hash of the bz, split into 32 byte
+// felts.
 func ChecksumFelt(in []byte) []byte {
-	hash := pedersen.Sum(in)
+	hash := commitmentHash().sumFelt(in)
 	return hash[:]
 }
 
 func Sum128(in []byte) [HashSize]byte {
-	return pedersen.Sum128(in)
+	return commitmentHash().sum128(in)
 }
 
 type PubKey interface {
@@ -0,0 +1,49 @@
+package crypto_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/pedersen"
+	"github.com/tendermint/tendermint/crypto/poseidon"
+)
+
+func TestSetCommitmentHash(t *testing.T) {
+	t.Cleanup(func() { require.NoError(t, crypto.SetCommitmentHash(crypto.CommitmentHashPedersen)) })
+
+	input := []byte("hello world")
+	testCases := []struct {
+		name    string
+		sum128  [crypto.HashSize]byte
+		sumFelt [crypto.HashSize]byte
+	}{
+		0: {crypto.CommitmentHashPedersen, pedersen.Sum128(input), pedersen.Sum(input)},
+		1: {crypto.CommitmentHashPoseidon, poseidon.Sum128(input), poseidon.Sum(input)},
+		2: {"", pedersen.Sum128(input), pedersen.Sum(input)},
+	}
+
+	for i, tc := range testCases {
+		require.NoError(t, crypto.SetCommitmentHash(tc.name), "testCase%d failed", i)
+		require.Equal(t, tc.sum128[:], crypto.Checksum128(input), "testCase%d failed", i)
+		require.Equal(t, tc.sum128, crypto.Sum128(input), "testCase%d failed", i)
+		require.Equal(t, tc.sumFelt[:], crypto.ChecksumFelt(input), "testCase%d failed", i)
+
+		h := crypto.New128()
+		h.Write(input)
+		require.Equal(t, tc.sum128[:], h.Sum(nil), "testCase%d failed", i)
+		h = crypto.NewFelt()
+		h.Write(input)
+		require.Equal(t, tc.sumFelt[:], h.Sum(nil), "testCase%d failed", i)
+	}
+	require.Equal(t, crypto.CommitmentHashPedersen, crypto.CommitmentHash())
+
+	require.NoError(t, crypto.SetCommitmentHash(crypto.CommitmentHashPoseidon))
+	require.Error(t, crypto.SetCommitmentHash("sha256"))
+	require.Equal(t, crypto.CommitmentHashPoseidon, crypto.CommitmentHash(), "an invalid name should not change the hash")
+
+	// addresses do not depend on the commitment hash
+	addr := pedersen.Sum(input)
+	require.Equal(t, crypto.Address(addr[:]), crypto.AddressHash(input))
+}
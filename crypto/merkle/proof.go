@@ -32,17 +32,19 @@ type Proof struct {
 }
 
 func ProofsFromByteSlicesInt128(items [][]byte) (rootHash []byte, proofs []*Proof) {
-	return proofsFromByteSlices(crypto.New128(), items)
+	return proofsFromByteSlices(crypto.New128(), leafHash, items)
 }
 
+// ProofsFromByteSlicesFelt computes inclusion proofs in the tree of
+// HashFromByteSlicesFelt, whose leaves are hashed as felts.
 func ProofsFromByteSlicesFelt(items [][]byte) (rootHash []byte, proofs []*Proof) {
-	return proofsFromByteSlices(crypto.NewFelt(), items)
+	return proofsFromByteSlices(crypto.NewFelt(), leafHashFelt, items)
 }
 
 // ProofsFromByteSlices computes inclusion proof for given items.
 // proofs[0] is the proof for items[0].
-func proofsFromByteSlices(hasher hash.Hash, items [][]byte) (rootHash []byte, proofs []*Proof) {
-	trails, rootSPN := trailsFromByteSlices(hasher, items)
+func proofsFromByteSlices(hasher hash.Hash, leafHasher func([]byte) []byte, items [][]byte) (rootHash []byte, proofs []*Proof) {
+	trails, rootSPN := trailsFromByteSlices(hasher, leafHasher, items)
 	rootHash = rootSPN.Hash
 	proofs = make([]*Proof, len(items))
 	for i, trail := range trails {
@@ -57,23 +59,23 @@ func proofsFromByteSlices(hasher hash.Hash, items [][]byte) (rootHash []byte, pr
 }
 
 func (sp *Proof) VerifyInt128(rootHash []byte, leaf []byte) error {
-	return sp.verify(crypto.New128(), rootHash, leaf)
+	return sp.verify(crypto.New128(), leafHash, rootHash, leaf)
 }
 
 func (sp *Proof) VerifyFelt(rootHash []byte, leaf []byte) error {
-	return sp.verify(crypto.NewFelt(), rootHash, leaf)
+	return sp.verify(crypto.NewFelt(), leafHashFelt, rootHash, leaf)
 }
 
 // Verify that the Proof proves the root hash.
 // Check sp.Index/sp.Total manually if needed
-func (sp *Proof) verify(hasher hash.Hash, rootHash []byte, leaf []byte) error {
+func (sp *Proof) verify(hasher hash.Hash, leafHasher func([]byte) []byte, rootHash []byte, leaf []byte) error {
 	if sp.Total < 0 {
 		return errors.New("proof total must be positive")
 	}
 	if sp.Index < 0 {
 		return errors.New("proof index cannot be negative")
 	}
-	leafHash := leafHash(leaf)
+	leafHash := leafHasher(leaf)
 	if !bytes.Equal(sp.LeafHash, leafHash) {
 		return fmt.Errorf("invalid leaf hash: wanted %X got %X", leafHash, sp.LeafHash)
 	}
@@ -249,27 +251,27 @@ func (spn *ProofNode) FlattenAunts() [][]byte {
 }
 
 func TrailsFromByteSlicesInt128(items [][]byte) (trails []*ProofNode, root *ProofNode) {
-	return trailsFromByteSlices(crypto.New128(), items)
+	return trailsFromByteSlices(crypto.New128(), leafHash, items)
 }
 
 func TrailsFromByteSlicesFelt(items [][]byte) (trails []*ProofNode, root *ProofNode) {
-	return trailsFromByteSlices(crypto.NewFelt(), items)
+	return trailsFromByteSlices(crypto.NewFelt(), leafHashFelt, items)
 }
 
 // trails[0].Hash is the leaf hash for items[0].
 // trails[i].Parent.Parent....Parent == root for all i.
-func trailsFromByteSlices(hasher hash.Hash, items [][]byte) (trails []*ProofNode, root *ProofNode) {
+func trailsFromByteSlices(hasher hash.Hash, leafHasher func([]byte) []byte, items [][]byte) (trails []*ProofNode, root *ProofNode) {
 	// Recursive impl.
 	switch len(items) {
 	case 0:
 		return []*ProofNode{}, &ProofNode{emptyHash(), nil, nil, nil}
 	case 1:
-		trail := &ProofNode{leafHash(items[0]), nil, nil, nil}
+		trail := &ProofNode{leafHasher(items[0]), nil, nil, nil}
 		return []*ProofNode{trail}, trail
 	default:
 		k := getSplitPoint(int64(len(items)))
-		lefts, leftRoot := trailsFromByteSlices(hasher, items[:k])
-		rights, rightRoot := trailsFromByteSlices(hasher, items[k:])
+		lefts, leftRoot := trailsFromByteSlices(hasher, leafHasher, items[:k])
+		rights, rightRoot := trailsFromByteSlices(hasher, leafHasher, items[k:])
 		rootHash := innerHashOpt(hasher, leftRoot.Hash, rightRoot.Hash)
 		root := &ProofNode{rootHash, nil, nil, nil}
 		leftRoot.Parent = root
@@ -78,15 +78,7 @@ func (op ValueOp) Run(args [][]byte) ([][]byte, error) {
 	if len(args) != 1 {
 		return nil, fmt.Errorf("expected 1 arg, got %v", len(args))
 	}
-	value := args[0]
-
-	vhash := crypto.Checksum128(value)
-
-	bz := new(bytes.Buffer)
-	// Wrap <op.Key, vhash> to hash the KVPair.
-	encodeByteSlice(bz, op.key) //nolint: errcheck // does not error
-	encodeByteSlice(bz, vhash)  //nolint: errcheck // does not error
-	kvhash := leafHash(bz.Bytes())
+	kvhash := leafHash(op.Leaf(args[0]))
 
 	if !bytes.Equal(kvhash, op.Proof.LeafHash) {
 		return nil, fmt.Errorf("leaf hash mismatch: want %X got %X", op.Proof.LeafHash, kvhash)
@@ -97,6 +89,18 @@ func (op ValueOp) Run(args [][]byte) ([][]byte, error) {
 	}, nil
 }
 
+// Leaf returns the leaf of the proof for value: the key of the op and the
+// hash of value, which is the KVPair the tree holds.
+func (op ValueOp) Leaf(value []byte) []byte {
+	vhash := crypto.Checksum128(value)
+
+	bz := new(bytes.Buffer)
+	// Wrap <op.Key, vhash> to hash the KVPair.
+	encodeByteSlice(bz, op.key) //nolint: errcheck // does not error
+	encodeByteSlice(bz, vhash)  //nolint: errcheck // does not error
+	return bz.Bytes()
+}
+
 func (op ValueOp) GetKey() []byte {
 	return op.key
 }
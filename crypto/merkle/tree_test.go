@@ -156,6 +156,28 @@ func TestProof(t *testing.T) {
 	}
 }
 
+func TestProofFelt(t *testing.T) {
+	for total := 1; total <= 14; total++ {
+		items := make([][]byte, total)
+		for i := range items {
+			items[i] = pedersen.RandFeltBytes(32)
+		}
+
+		rootHash, proofs := ProofsFromByteSlicesFelt(items)
+		require.Equal(t, HashFromByteSlicesFelt(items), rootHash, "total %d", total)
+		for i, item := range items {
+			proof := proofs[i]
+			require.NoError(t, proof.VerifyFelt(rootHash, item), "total %d, index %d", total, i)
+			require.Equal(t, rootHash, ComputeHashFromAuntsFelt(proof.Index, proof.Total, proof.LeafHash, proof.Aunts))
+
+			// the leaves of an Int128 tree are hashed differently
+			require.Error(t, proof.VerifyInt128(rootHash, item))
+			err := proof.VerifyFelt(ctest.MutateByteSlice(rootHash), item)
+			require.Error(t, err, "Expected verification to fail for mutated root hash")
+		}
+	}
+}
+
 func TestHashAlternatives(t *testing.T) {
 
 	total := 104
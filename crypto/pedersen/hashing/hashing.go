@@ -4,37 +4,75 @@ import (
 	_ "embed"
 	"fmt"
 	"math/big"
+	"sync"
 
 	"github.com/tendermint/tendermint/crypto/pedersen/felt"
+	"github.com/tendermint/tendermint/crypto/weierstrass"
 )
 
+// windowBits is the width of the windows the inputs of hash2 are split
+// into.
+const windowBits = 4
+
+// windows is the number of windows in the 252 bits of an input.
+const windows = 252 / windowBits
+
+var (
+	tablesOnce sync.Once
+	// shift is the shift point of the hash.
+	shift weierstrass.StarkPoint
+	// tables[i][w][d] is the sum of the constant points selected by the
+	// bits of d in the window w of the input i.
+	tables [2][windows][1 << windowBits]weierstrass.StarkPoint
+)
+
+func initTables() {
+	shift.SetAffine(points[0].x, points[0].y)
+	for i := range tables {
+		for w := range tables[i] {
+			row := &tables[i][w]
+			row[0].SetInfinity()
+			for b := 0; b < windowBits; b++ {
+				var pt weierstrass.StarkPoint
+				constant := points[2+i*252+w*windowBits+b]
+				pt.SetAffine(constant.x, constant.y)
+				// the entries with bit b as their highest bit
+				for d := 1 << b; d < 2<<b; d++ {
+					row[d].Add(&row[d-(1<<b)], &pt)
+				}
+			}
+		}
+	}
+}
+
 // hash2 returns a field element that is the result of hashing two inputs
 // ((a, b) ∈ 𝔽²ₚ where p = 2²⁵¹ + 17·2¹⁹² + 1). This function will panic if
 // an input is not in the felt range (0 <= x < 2²⁵¹ + 17·2¹⁹² + 1).
+//
+// The windows of the inputs are looked up in precomputed tables. The
+// inputs of the hash being public, the computation does not run in
+// constant time.
 func hash2(felt1, felt2 *felt.Felt) *felt.Felt {
-	// Make a defensive copy of the input data.
-	elements := make([]*big.Int, 2)
-	elements[0] = new(big.Int).Set((*big.Int)(felt1))
-	elements[1] = new(big.Int).Set((*big.Int)(felt2))
-
-	zero := new(big.Int)
+	tablesOnce.Do(initTables)
 
-	// Shift point.
-	pt1 := points[0]
-	for i, x := range elements {
-		if x.Cmp(zero) == -1 || x.Cmp(primeMinusOne) == 1 {
+	pt := shift
+	for i, f := range [2]*felt.Felt{felt1, felt2} {
+		x := (*big.Int)(f)
+		if x.Sign() < 0 || x.Cmp(primeMinusOne) == 1 {
 			panic(fmt.Sprintf("%x is not in the range 0 <= x < 2²⁵¹ + 17·2¹⁹² + 1", x))
 		}
-		for j := 0; j < 252; j++ {
-			// Create a copy because *big.Int.And mutates.
-			copyX := new(big.Int).Set(x)
-			if copyX.And(copyX, big.NewInt(1)).Cmp(zero) != 0 {
-				pt1.add(&points[2+i*252+j])
+
+		var b [32]byte
+		x.FillBytes(b[:])
+		for w := 0; w < windows; w++ {
+			d := b[len(b)-1-w/2] >> (w % 2 * windowBits) & 0x0f
+			if d != 0 {
+				pt.Add(&pt, &tables[i][w][d])
 			}
-			x.Rsh(x, 1)
 		}
 	}
-	return (*felt.Felt)(pt1.x)
+	x, _ := pt.AffineVarTime()
+	return (*felt.Felt)(x)
 }
 
 // hashFelt returns a Felt that is the result of hashing an
@@ -74,3 +112,15 @@ func Hash(data ...*felt.Felt) *felt.Felt {
 		return hashFeltArray(data...)
 	}
 }
+
+// Hash2 returns the pedersen hash of two field elements, the same value
+// as the StarkNet pedersen(a, b) builtin.
+func Hash2(felt1, felt2 *felt.Felt) *felt.Felt {
+	return hash2(felt1, felt2)
+}
+
+// HashArray returns the StarkNet array hash (compute_hash_on_elements) of
+// the input. Unlike Hash, it does not special-case inputs of length 0 or 1.
+func HashArray(data ...*felt.Felt) *felt.Felt {
+	return hashFeltArray(data...)
+}
@@ -1,6 +1,7 @@
 package hashing
 
 import (
+	"fmt"
 	"math/big"
 	"testing"
 
@@ -51,6 +52,56 @@ func TestHash2(t *testing.T) {
 		require.Equal(t, tc.expected, result.String(), "TestCAse%d %s failed: hashes don't match: %s != %s", i, tc.name, tc.expected, result.String())
 	}
 }
+
+// the expected values are generated with the bit by bit implementation
+// of hash2, which sums a constant point per set bit of the inputs
+func TestHash2LargeInputs(t *testing.T) {
+	type Hash2TestCase struct {
+		name     string
+		num1     string
+		num2     string
+		expected string
+	}
+	testCases := []Hash2TestCase{
+		0: {
+			name:     "largest felts",
+			num1:     "800000000000011000000000000000000000000000000000000000000000000",
+			num2:     "800000000000011000000000000000000000000000000000000000000000000",
+			expected: "7258fccaf3371fad51b117471d9d888a1786c5694c3e6099160477b593a576e",
+		},
+		1: {
+			name:     "highest bit",
+			num1:     "800000000000000000000000000000000000000000000000000000000000000",
+			num2:     "1",
+			expected: "15f8c86a90d6a4f08900ef5d7d47e26ab57363989c98f3a13521e68067eeb0e",
+		},
+		2: {
+			name:     "dense inputs",
+			num1:     "3d937c035c878245caf64531a5756109c53068da139362728feb561405371cb",
+			num2:     "208a0a10250e382e1e4bbe2880906c2791bf6275695e02fbbc6aeff9cd8b31a",
+			expected: "30e480bed5fe53fa909cc0f8c4d99b8f9f2c016be4c41e13a4848797979c662",
+		},
+		3: {
+			name:     "dense inputs",
+			num1:     "58f580910a6ca59b28927c08fe6c43e2e303ca384badc365795fc645d479d45",
+			num2:     "78734f65a067be9bdb39de18434d71e79f7b6466a4b66bbd979ab9e7515fe0b",
+			expected: "68cc0b76cddd1dd4ed2301ada9b7c872b23875d5ff837b3a87993e0d9996b87",
+		},
+	}
+
+	for i, tc := range testCases {
+		x1, _ := new(big.Int).SetString(tc.num1, 16)
+		x2, _ := new(big.Int).SetString(tc.num2, 16)
+		result := hash2(felt.New().SetBigInt(x1), felt.New().SetBigInt(x2))
+		got := fmt.Sprintf("%x", (*big.Int)(result))
+		require.Equal(t, tc.expected, got, "TestCase%d %s failed: hashes don't match: %s != %s", i, tc.name, tc.expected, got)
+	}
+
+	prime := felt.New().SetBigInt(new(big.Int).Add(primeMinusOne, big.NewInt(1)))
+	require.Panics(t, func() { hash2(prime, felt.New()) })
+	require.Panics(t, func() { hash2(felt.New(), felt.New().SetBigInt(big.NewInt(-1))) })
+}
+
 func TestHashFelt(t *testing.T) {
 	type HashFeltTestCase struct {
 		name     string
@@ -144,3 +195,14 @@ func TestHash(t *testing.T) {
 		require.Equal(t, tc.expected, result.String(), "TestCase%d %s failed: hashes don't match: %s != %s", i, tc.name, tc.expected, result.String())
 	}
 }
+
+func BenchmarkHash2(b *testing.B) {
+	x1, _ := new(big.Int).SetString("3d937c035c878245caf64531a5756109c53068da139362728feb561405371cb", 16)
+	x2, _ := new(big.Int).SetString("208a0a10250e382e1e4bbe2880906c2791bf6275695e02fbbc6aeff9cd8b31a", 16)
+	felt1 := felt.New().SetBigInt(x1)
+	felt2 := felt.New().SetBigInt(x2)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		hash2(felt1, felt2)
+	}
+}
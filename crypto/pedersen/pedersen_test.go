@@ -1,6 +1,7 @@
 package pedersen
 
 import (
+	"encoding/hex"
 	"math/big"
 	"reflect"
 	"testing"
@@ -121,6 +122,16 @@ func TestSum128(t *testing.T) {
 	require.Equal(t, expectedHash, hash, "Sum128() should return the same hash as hashing.Hash()")
 }
 
+func TestSumKnownAnswers(t *testing.T) {
+	// two felts of 32 bytes
+	input := append([]byte("\x00hello world, this is thirty-one"), []byte("\x07and a second felt of input data")...)
+	hash := Sum(input)
+	require.Equal(t, "06a47f20cc33614bf3b10e7d0f7e106a63919aa1d4df2e81314008f7b33c5901", hex.EncodeToString(hash[:]))
+
+	hash = Sum128([]byte("hello world, this is more than one felt of input data"))
+	require.Equal(t, "01343049da9ee1aef4b9e92f26221459e3702a26a2f5537af730a93ecf8e6a83", hex.EncodeToString(hash[:]))
+}
+
 func TestReset(t *testing.T) {
 	input := []byte("hello world")
 	ph := New()
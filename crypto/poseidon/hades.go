@@ -0,0 +1,85 @@
+package poseidon
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"strconv"
+
+	"github.com/tendermint/tendermint/crypto/weierstrass/fp"
+)
+
+// The parameters of the Starknet instance of the Hades permutation, over a
+// state of three field elements.
+const (
+	width         = 3
+	fullRounds    = 8
+	partialRounds = 83
+	rounds        = fullRounds + partialRounds
+)
+
+// roundConstants[r] is added to the state at the beginning of round r.
+var roundConstants [rounds][width]fp.Element
+
+func init() {
+	// the constants are generated as in cairo-lang, the i-th constant being
+	// the big-endian integer sha256("Hades" || i) reduced modulo p
+	p := fp.Modulus()
+	for r := range roundConstants {
+		for i := range roundConstants[r] {
+			sum := sha256.Sum256([]byte("Hades" + strconv.Itoa(width*r+i)))
+			c := new(big.Int).SetBytes(sum[:])
+			roundConstants[r][i].SetBigInt(c.Mod(c, p))
+		}
+	}
+}
+
+// permute applies the Hades permutation to state: four full rounds, the
+// partial rounds and four more full rounds. A round adds the round
+// constants, cubes all the elements of the state in a full round and only
+// the last one in a partial round, then multiplies the state by the MDS
+// matrix.
+func permute(state *[width]fp.Element) {
+	for r := 0; r < rounds; r++ {
+		for i := range state {
+			state[i].Add(&state[i], &roundConstants[r][i])
+		}
+
+		if r < fullRounds/2 || r >= fullRounds/2+partialRounds {
+			for i := range state {
+				cube(&state[i])
+			}
+		} else {
+			cube(&state[width-1])
+		}
+
+		mix(state)
+	}
+}
+
+func cube(x *fp.Element) {
+	var x2 fp.Element
+	x2.Square(x)
+	x.Mul(x, &x2)
+}
+
+// mix multiplies state by the MDS matrix
+//
+//	[ 3  1  1 ]
+//	[ 1 -1  1 ]
+//	[ 1  1 -2 ]
+func mix(state *[width]fp.Element) {
+	var t, d fp.Element
+	t.Add(&state[0], &state[1])
+	t.Add(&t, &state[2])
+
+	// t + 2·s0
+	d.Double(&state[0])
+	state[0].Add(&t, &d)
+	// t - 2·s1
+	d.Double(&state[1])
+	state[1].Sub(&t, &d)
+	// t - 3·s2
+	d.Double(&state[2])
+	d.Add(&d, &state[2])
+	state[2].Sub(&t, &d)
+}
@@ -0,0 +1,173 @@
+// Package poseidon implements the Starknet Poseidon hash, the sponge over
+// the Hades permutation exposed by the poseidon builtin of Cairo.
+//
+// Like the pedersen package, it provides a hash.Hash which splits its input
+// into 32 or 16 byte felts, so that the two can be used interchangeably as
+// the commitment hash of a chain.
+package poseidon
+
+import (
+	"fmt"
+	"hash"
+	"math/big"
+
+	"github.com/tendermint/tendermint/crypto/pedersen/felt"
+	"github.com/tendermint/tendermint/crypto/utils"
+	"github.com/tendermint/tendermint/crypto/weierstrass/fp"
+)
+
+// The size of a poseidon checksum
+const Size = 32
+
+// The blocksize of poseidon
+const BlockSize = 32
+
+// The blocksize of poseidon128
+const BlockSize128 = 16
+
+var prime = fp.Modulus()
+
+// Hash2 returns the poseidon hash of two field elements, the same value as
+// the Starknet poseidon_hash(x, y). It panics if an input is not in the felt
+// range.
+func Hash2(x, y *felt.Felt) *felt.Felt {
+	var state [width]fp.Element
+	setFelt(&state[0], x)
+	setFelt(&state[1], y)
+	state[2].SetUint64(2)
+	permute(&state)
+	return toFelt(&state[0])
+}
+
+// HashMany returns the poseidon hash of a sequence of field elements, the
+// same value as the Starknet poseidon_hash_many(data). It panics if an
+// input is not in the felt range.
+func HashMany(data ...*felt.Felt) *felt.Felt {
+	var state [width]fp.Element
+	var v fp.Element
+	// the input is padded with a 1 and, if the length is then odd, a 0, and
+	// absorbed two elements at a time
+	for i := 0; i <= len(data); i += 2 {
+		for j := 0; j < 2; j++ {
+			switch {
+			case i+j < len(data):
+				setFelt(&v, data[i+j])
+			case i+j == len(data):
+				v.SetOne()
+			default:
+				v.SetZero()
+			}
+			state[j].Add(&state[j], &v)
+		}
+		permute(&state)
+	}
+	return toFelt(&state[0])
+}
+
+func setFelt(z *fp.Element, f *felt.Felt) {
+	x := (*big.Int)(f)
+	if x.Sign() < 0 || x.Cmp(prime) >= 0 {
+		panic(fmt.Sprintf("%x is not in the range 0 <= x < 2²⁵¹ + 17·2¹⁹² + 1", x))
+	}
+	z.SetBigInt(x)
+}
+
+func toFelt(z *fp.Element) *felt.Felt {
+	return (*felt.Felt)(z.BigInt(new(big.Int)))
+}
+
+// implementation of hash.Hash interface
+
+type poseidonHash struct {
+	input []*felt.Felt
+	is128 bool
+}
+
+// New creates a poseidonHash (with the BlockSize of 32)
+// which implements the hash.Hash interface
+func New() hash.Hash {
+	ph := new(poseidonHash)
+	ph.Reset()
+	return ph
+}
+
+// New128 creates a poseidonHash (with the BlockSize of 16)
+// which implements the hash.Hash interface
+func New128() hash.Hash {
+	ph := new(poseidonHash)
+	ph.Reset()
+	ph.is128 = true
+	return ph
+}
+
+// Size returns the size of the poseidonHash's checksum
+func (ph *poseidonHash) Size() int {
+	return Size
+}
+
+// BlockSize returns the poseidonHash's BlockSize
+func (ph *poseidonHash) BlockSize() int {
+	if ph.is128 {
+		return BlockSize128
+	}
+	return BlockSize
+}
+
+// Reset resets the poseidonHash's input to an empty Felt slice
+func (ph *poseidonHash) Reset() {
+	ph.input = nil
+}
+
+// getFeltsFromBytes returns a function that splits the input
+// into chunks of the given length and converts these chunks
+// to Felts
+func getFeltsFromBytes(blockSize int) func(bytes []byte) []*felt.Felt {
+	return func(bytes []byte) []*felt.Felt {
+		rounded := utils.ByteRounder(blockSize)(bytes)
+
+		chunks := utils.Split(rounded, blockSize)
+
+		feltSlice := make([]*felt.Felt, len(chunks))
+		for i, chunk := range chunks {
+			feltSlice[i] = felt.New().SetBytes(chunk)
+		}
+		return feltSlice
+	}
+}
+
+// Write splits the input either into 32 or 16 length byte chunks
+// (depending on the BlockSize), converts them to Felts and
+// appends these Felts to the poseidonHash's input
+func (ph *poseidonHash) Write(input []byte) (int, error) {
+	ph.input = append(ph.input, getFeltsFromBytes(ph.BlockSize())(input)...)
+	return len(input), nil
+}
+
+// checkSum returns the fixed length (32 bytes) hash of the
+// poseidonHash's input
+func (ph *poseidonHash) checkSum() [Size]byte {
+	return HashMany(ph.input...).Bytes32()
+}
+
+// Sum appends the checksum of the poseidonHash's input to the
+// bytes slice that was passed in and returns the resulting slice
+func (ph *poseidonHash) Sum(in []byte) []byte {
+	hash := ph.checkSum()
+	return append(in, hash[:]...)
+}
+
+// Sum splits the input into 32 length byte chunks and returns
+// the fixed length (32 bytes) checksum of these chunks
+func Sum(data []byte) [Size]byte {
+	ph := poseidonHash{}
+	ph.Write(data)
+	return ph.checkSum()
+}
+
+// Sum128 splits the input into 16 length byte chunks and returns
+// the fixed length (32 bytes) checksum of these chunks
+func Sum128(data []byte) [Size]byte {
+	ph := poseidonHash{is128: true}
+	ph.Write(data)
+	return ph.checkSum()
+}
@@ -0,0 +1,165 @@
+package poseidon
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/pedersen/felt"
+	"github.com/tendermint/tendermint/crypto/weierstrass/fp"
+)
+
+func newFelt(v int64) *felt.Felt {
+	return felt.New().SetBigInt(big.NewInt(v))
+}
+
+func hexFelt(s string) *felt.Felt {
+	v, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic("invalid hex " + s)
+	}
+	return felt.New().SetBigInt(v)
+}
+
+// permuted returns the first element of the permutation of (s0, s1, s2)
+func permuted(s0, s1, s2 *felt.Felt) *felt.Felt {
+	var state [width]fp.Element
+	setFelt(&state[0], s0)
+	setFelt(&state[1], s1)
+	setFelt(&state[2], s2)
+	permute(&state)
+	return toFelt(&state[0])
+}
+
+func TestHash2(t *testing.T) {
+	// poseidon_hash(1, 2) in cairo-lang
+	require.Equal(t,
+		hexFelt("5d44a3decb2b2e0cc71071f7b802f45dd792d064f0fc7316c46514f70f9891a").String(),
+		Hash2(newFelt(1), newFelt(2)).String())
+
+	// the capacity element holds the length of the input
+	require.Equal(t, permuted(newFelt(3), newFelt(4), newFelt(2)).String(), Hash2(newFelt(3), newFelt(4)).String())
+	require.NotEqual(t, Hash2(newFelt(3), newFelt(4)).String(), Hash2(newFelt(4), newFelt(3)).String())
+}
+
+func TestHashMany(t *testing.T) {
+	type HashManyTestCase struct {
+		name     string
+		numArray []int64
+		expected func() *felt.Felt
+	}
+	testCases := []HashManyTestCase{
+		0: {
+			name:     "empty input is padded to (1, 0)",
+			numArray: []int64{},
+			expected: func() *felt.Felt { return permuted(newFelt(1), newFelt(0), newFelt(0)) },
+		},
+		1: {
+			name:     "odd length input is padded with (1)",
+			numArray: []int64{7},
+			expected: func() *felt.Felt { return permuted(newFelt(7), newFelt(1), newFelt(0)) },
+		},
+		2: {
+			name:     "even length input is padded with (1, 0)",
+			numArray: []int64{7, 8},
+			expected: func() *felt.Felt {
+				var state [width]fp.Element
+				state[0].SetUint64(7)
+				state[1].SetUint64(8)
+				permute(&state)
+				var one fp.Element
+				state[0].Add(&state[0], one.SetOne())
+				permute(&state)
+				return toFelt(&state[0])
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		feltArray := make([]*felt.Felt, len(tc.numArray))
+		for j, n := range tc.numArray {
+			feltArray[j] = newFelt(n)
+		}
+		result := HashMany(feltArray...)
+		require.Equal(t, tc.expected().String(), result.String(), "TestCase%d %s failed", i, tc.name)
+	}
+
+	// padding keeps inputs of different lengths apart
+	require.NotEqual(t, HashMany(newFelt(7)).String(), HashMany(newFelt(7), newFelt(1)).String())
+	require.NotEqual(t, HashMany().String(), HashMany(newFelt(0)).String())
+}
+
+func TestKnownAnswers(t *testing.T) {
+	// poseidon_hash and poseidon_hash_many in cairo-lang
+	testCases := []struct {
+		input    []int64
+		many     bool
+		expected string
+	}{
+		0: {[]int64{0, 0}, false, "293d3e8a80f400daaaffdd5932e2bcc8814bab8f414a75dcacf87318f8b14c5"},
+		1: {[]int64{1, 2}, false, "5d44a3decb2b2e0cc71071f7b802f45dd792d064f0fc7316c46514f70f9891a"},
+		2: {[]int64{}, true, "2272be0f580fd156823304800919530eaa97430e972d7213ee13f4fbf7a5dbc"},
+		3: {[]int64{1}, true, "579e8877c7755365d5ec1ec7d3a94a457eff5d1f40482bbe9729c064cdead2"},
+		4: {[]int64{1, 2}, true, "371cb6995ea5e7effcd2e174de264b5b407027a75a231a70c2c8d196107f0e7"},
+		5: {[]int64{1, 2, 3}, true, "2f0d8840bcf3bc629598d8a6cc80cb7c0d9e52d93dab244bbf9cd0dca0ad082"},
+		6: {[]int64{1, 2, 3, 4, 5}, true, "159f4ab3b9bdc95a6a4a9ffb36456ad33290ea1fa809e0445bc449b0ad62da4"},
+	}
+
+	for i, tc := range testCases {
+		input := make([]*felt.Felt, len(tc.input))
+		for j, n := range tc.input {
+			input[j] = newFelt(n)
+		}
+		var result *felt.Felt
+		if tc.many {
+			result = HashMany(input...)
+		} else {
+			result = Hash2(input[0], input[1])
+		}
+		require.Equal(t, hexFelt(tc.expected).String(), result.String(), "testCase%d failed", i)
+	}
+}
+
+func TestOutOfRange(t *testing.T) {
+	p := felt.New()
+	(*big.Int)(p).Set(prime)
+	require.Panics(t, func() { Hash2(p, newFelt(0)) })
+	require.Panics(t, func() { HashMany(newFelt(0), p) })
+	require.Panics(t, func() { HashMany(newFelt(-1)) })
+}
+
+func TestPoseidonHash(t *testing.T) {
+	// two felts of 32 bytes, which must be smaller than p
+	input := append([]byte("\x00hello world, this is thirty-one"), []byte("\x07and a second felt of input data")...)
+
+	ph := New()
+	require.Equal(t, Size, ph.Size())
+	require.Equal(t, BlockSize, ph.BlockSize())
+	ph.Write(input[:32])
+	ph.Write(input[32:])
+	expected := HashMany(getFeltsFromBytes(BlockSize)(input)...).Bytes32()
+	require.Equal(t, expected[:], ph.Sum(nil), "Sum() should return the same hash as HashMany()")
+	require.Equal(t, expected, Sum(input))
+
+	input = []byte("hello world, this is more than one felt of input data")
+	ph128 := New128()
+	require.Equal(t, BlockSize128, ph128.BlockSize())
+	ph128.Write(input)
+	expected128 := HashMany(getFeltsFromBytes(BlockSize128)(input)...).Bytes32()
+	require.Equal(t, expected128[:], ph128.Sum(nil), "Sum() should return the same hash as HashMany()")
+	require.Equal(t, expected128, Sum128(input))
+
+	ph128.Reset()
+	empty := HashMany().Bytes32()
+	require.Equal(t, empty[:], ph128.Sum(nil), "Sum() should return the hash of no input after Reset()")
+}
+
+func BenchmarkHash2(b *testing.B) {
+	x := hexFelt("3d937c035c878245caf64531a5756109c53068da139362728feb561405371cb")
+	y := hexFelt("208a0a10250e382e1e4bbe2880906c2791bf6275695e02fbbc6aeff9cd8b31a")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Hash2(x, y)
+	}
+}
@@ -0,0 +1,156 @@
+package stark
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/weierstrass"
+)
+
+var _ crypto.BatchVerifier = &BatchVerifier{}
+
+// BatchVerifier implements batch verification for stark.
+//
+// A signature is accepted when the x coordinate of u1*G + u2*P or of
+// u1*G - u2*P is r modulo N, as in VerifySignature. A signature carries
+// neither the sign of its point R nor the one of the u2*P term, so the
+// entries cannot be folded into one randomized linear combination: the
+// combination would only hold for one of the 4^n assignments of signs.
+// Each entry is thus still verified on its own, and a batch is not
+// asymptotically faster than as many calls to VerifySignature. Only the
+// inversions of s are shared, in a single modular inversion. Each sum is
+// computed with one variable-time Straus-Shamir pass over the width-w
+// NAFs of u1 and u2, all inputs being public, u1*G + u2*P first as it
+// is the sum of the signatures made by Sign, and x coordinates are
+// compared in projective form so that no field inversion is needed.
+type BatchVerifier struct {
+	entries []batchEntry
+}
+
+type batchEntry struct {
+	x, y *big.Int
+	hash *big.Int
+	r, s *big.Int
+}
+
+func NewBatchVerifier() crypto.BatchVerifier {
+	return &BatchVerifier{}
+}
+
+func (b *BatchVerifier) Add(key crypto.PubKey, msg, signature []byte) error {
+	pk, ok := key.(PubKey)
+	if !ok {
+		return fmt.Errorf("pubkey is not stark")
+	}
+
+	if l := len(pk); l != PubKeySize {
+		return fmt.Errorf("pubkey size is incorrect; expected: %d, got %d", PubKeySize, l)
+	}
+
+	pub := UnmarshalCompressedStark(curve, pk)
+	if pub.X == nil {
+		return errors.New("pubkey is not on the curve")
+	}
+
+	r, s, err := deserializeSig(signature)
+	if err != nil {
+		return err
+	}
+
+	b.entries = append(b.entries, batchEntry{
+		x:    pub.X,
+		y:    pub.Y,
+		hash: new(big.Int).SetBytes(crypto.Checksum128(msg)),
+		r:    r,
+		s:    s,
+	})
+
+	return nil
+}
+
+func (b *BatchVerifier) Verify() (bool, []bool) {
+	if len(b.entries) == 0 {
+		return false, nil
+	}
+
+	params := curve.Params()
+
+	// signatures with r or s out of range are invalid, the others are
+	// inverted together
+	ss := make([]*big.Int, len(b.entries))
+	for i, entry := range b.entries {
+		if inRange(entry.r, params.N) && inRange(entry.s, params.N) {
+			ss[i] = entry.s
+		}
+	}
+	ws := invertAll(ss, params.N)
+
+	valid := make([]bool, len(b.entries))
+	allValid := true
+	for i, entry := range b.entries {
+		valid[i] = ws[i] != nil && entry.verify(params, ws[i])
+		allValid = allValid && valid[i]
+	}
+
+	return allValid, valid
+}
+
+// verify checks the entry given w, the inverse of s modulo N.
+func (entry batchEntry) verify(params *weierstrass.CurveParams, w *big.Int) bool {
+	u1 := new(big.Int).Mul(entry.hash, w)
+	u1.Mod(u1, params.N)
+	u2 := new(big.Int).Mul(entry.r, w)
+	u2.Mod(u2, params.N)
+
+	var pub, sum weierstrass.StarkPoint
+	pub.SetAffine(entry.x, entry.y)
+	if hasX(params, sum.DoubleScalarBaseMultVarTime(u1.Bytes(), &pub, u2.Bytes()), entry.r) {
+		return true
+	}
+	return hasX(params, sum.DoubleScalarBaseMultVarTime(u1.Bytes(), pub.Neg(&pub), u2.Bytes()), entry.r)
+}
+
+// hasX reports whether p is not the point at infinity and its affine x
+// coordinate is r modulo N, that is r or r + N as N < P < 2N.
+func hasX(params *weierstrass.CurveParams, p *weierstrass.StarkPoint, r *big.Int) bool {
+	for c := new(big.Int).Set(r); c.Cmp(params.P) < 0; c.Add(c, params.N) {
+		if p.HasAffineX(c) {
+			return true
+		}
+	}
+	return false
+}
+
+func inRange(v, n *big.Int) bool {
+	return v.Sign() > 0 && v.Cmp(n) < 0
+}
+
+// invertAll returns the inverses modulo the prime n of values, leaving
+// nil the ones of nil values, with a single modular inversion.
+func invertAll(values []*big.Int, n *big.Int) []*big.Int {
+	prefixes := make([]*big.Int, len(values))
+	acc := big.NewInt(1)
+	for i, v := range values {
+		if v == nil {
+			continue
+		}
+		prefixes[i] = new(big.Int).Set(acc)
+		acc.Mul(acc, v)
+		acc.Mod(acc, n)
+	}
+
+	inverses := make([]*big.Int, len(values))
+	inv := new(big.Int).ModInverse(acc, n)
+	for i := len(values) - 1; i >= 0; i-- {
+		if values[i] == nil {
+			continue
+		}
+		inverses[i] = new(big.Int).Mul(inv, prefixes[i])
+		inverses[i].Mod(inverses[i], n)
+		inv.Mul(inv, values[i])
+		inv.Mod(inv, n)
+	}
+	return inverses
+}
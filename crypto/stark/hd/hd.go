@@ -0,0 +1,158 @@
+// Package hd derives stark keys from BIP-39 mnemonics, following EIP-2645:
+// a BIP-32 secp256k1 key is derived along a hardened path, then ground into
+// the order of the stark curve, as Starknet wallets do.
+package hd
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil/hdkeychain"
+	bip39 "github.com/cosmos/go-bip39"
+
+	"github.com/tendermint/tendermint/crypto/stark"
+	"github.com/tendermint/tendermint/crypto/weierstrass"
+)
+
+const (
+	// MnemonicEntropySize is the entropy, in bits, of the mnemonics of
+	// NewMnemonic, which have 24 words.
+	MnemonicEntropySize = 256
+
+	// StarknetCoinType is the SLIP-44 coin type of Starknet.
+	StarknetCoinType = 9004
+
+	// EIP-2645 layer and application of the validator keys
+	validatorLayer       = "starknet"
+	validatorApplication = "slush"
+
+	// the number of sha256 digests GrindKey tries before giving up, which
+	// only happens with a probability below 2^-100000
+	maxGrindIterations = 100000
+)
+
+// NewMnemonic returns a new random 24-word BIP-39 mnemonic.
+func NewMnemonic() (string, error) {
+	entropy, err := bip39.NewEntropy(MnemonicEntropySize)
+	if err != nil {
+		return "", err
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// ValidatorPath returns the EIP-2645 path of the validator key of the given
+// index: m/2645'/<starknet>'/<slush>'/0'/0'/index, of which the layer and
+// application are the 31 lowest bits of the sha256 of their names.
+func ValidatorPath(index uint32) string {
+	return fmt.Sprintf("m/2645'/%d'/%d'/0'/0'/%d",
+		eip2645Component(validatorLayer), eip2645Component(validatorApplication), index)
+}
+
+// AccountPath returns the path of the key of the Starknet account of the given
+// index, m/44'/9004'/0'/0/index, the one of the Braavos wallet.
+func AccountPath(index uint32) string {
+	return fmt.Sprintf("m/44'/%d'/0'/0/%d", StarknetCoinType, index)
+}
+
+// eip2645Component returns the 31 lowest bits of the sha256 of name.
+func eip2645Component(name string) uint32 {
+	digest := sha256.Sum256([]byte(name))
+	return binary.BigEndian.Uint32(digest[28:]) & (hdkeychain.HardenedKeyStart - 1)
+}
+
+// ParsePath parses a BIP-32 path such as m/44'/9004'/0'/0/0 into the indexes
+// of its children, hardened ones included.
+func ParsePath(path string) ([]uint32, error) {
+	parts := strings.Split(path, "/")
+	if parts[0] != "m" {
+		return nil, fmt.Errorf("invalid path %q: does not start with m", path)
+	}
+
+	indexes := make([]uint32, len(parts)-1)
+	for i, part := range parts[1:] {
+		hardened := strings.HasSuffix(part, "'")
+		index, err := strconv.ParseUint(strings.TrimSuffix(part, "'"), 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path %q: invalid index %q", path, part)
+		}
+		indexes[i] = uint32(index)
+		if hardened {
+			indexes[i] += hdkeychain.HardenedKeyStart
+		}
+	}
+	return indexes, nil
+}
+
+// DerivePrivKey derives the stark key of path from mnemonic: the BIP-32 key
+// of path derived from the BIP-39 seed of mnemonic, without passphrase, is
+// ground into a stark key.
+func DerivePrivKey(mnemonic, path string) (stark.PrivKey, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(NormalizeMnemonic(mnemonic), "")
+	if err != nil {
+		return nil, fmt.Errorf("invalid mnemonic: %w", err)
+	}
+	return DerivePrivKeyFromSeed(seed, path)
+}
+
+// DerivePrivKeyFromSeed derives the stark key of path from a BIP-39 seed.
+func DerivePrivKeyFromSeed(seed []byte, path string) (stark.PrivKey, error) {
+	indexes, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := hdkeychain.NewMaster(seed, &chaincfg.MainNetParams)
+	if err != nil {
+		return nil, err
+	}
+	for _, index := range indexes {
+		if key, err = key.Derive(index); err != nil {
+			return nil, err
+		}
+	}
+
+	ecKey, err := key.ECPrivKey()
+	if err != nil {
+		return nil, err
+	}
+	k, err := GrindKey(ecKey.D.FillBytes(make([]byte, 32)))
+	if err != nil {
+		return nil, err
+	}
+	return stark.PrivKey(k.Bytes()), nil
+}
+
+// NormalizeMnemonic returns mnemonic in lower case with its words separated
+// by single spaces.
+func NormalizeMnemonic(mnemonic string) string {
+	return strings.Join(strings.Fields(strings.ToLower(mnemonic)), " ")
+}
+
+// GrindKey returns a stark key derived from seed without modulo bias: the
+// first sha256(seed || i), i = 0, 1... big-endian on as few bytes as
+// possible, below the largest multiple of the curve order under 2^256, reduced
+// modulo the order.
+func GrindKey(seed []byte) (*big.Int, error) {
+	order := weierstrass.Stark().Params().N
+	limit := new(big.Int).Lsh(big.NewInt(1), 256)
+	limit.Sub(limit, new(big.Int).Mod(limit, order))
+
+	key := new(big.Int)
+	for i := int64(0); i < maxGrindIterations; i++ {
+		index := big.NewInt(i).Bytes()
+		if len(index) == 0 {
+			index = []byte{0}
+		}
+		digest := sha256.Sum256(append(append([]byte{}, seed...), index...))
+		key.SetBytes(digest[:])
+		if key.Cmp(limit) < 0 {
+			return key.Mod(key, order), nil
+		}
+	}
+	return nil, errors.New("could not grind the key")
+}
@@ -0,0 +1,98 @@
+package hd
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGrindKey(t *testing.T) {
+	// test vector of the StarkWare key derivation
+	seed, err := hex.DecodeString("86F3E7293141F20A8BAFF320E8EE4ACCB9D4A4BF2B4D295E8CEE784DB46E0519")
+	require.NoError(t, err)
+	key, err := GrindKey(seed)
+	require.NoError(t, err)
+	assert.Equal(t, "5c8c8683596c732541a59e03007b2d30dbbbb873556fe65b5fb63c16688f941", key.Text(16))
+}
+
+func TestParsePath(t *testing.T) {
+	testCases := []struct {
+		path    string
+		indexes []uint32
+		expErr  bool
+	}{
+		0: {"m", []uint32{}, false},
+		1: {"m/44'/9004'/0'/0/1", []uint32{0x8000002c, 0x8000232c, 0x80000000, 0, 1}, false},
+		2: {"m/2147483647'", []uint32{0xffffffff}, false},
+		3: {"m/2147483648", nil, true},
+		4: {"44'/0", nil, true},
+		5: {"m/a", nil, true},
+		6: {"m/-1", nil, true},
+		7: {"m/0''", nil, true},
+		8: {"m//0", nil, true},
+	}
+
+	for i, tc := range testCases {
+		indexes, err := ParsePath(tc.path)
+		if tc.expErr {
+			assert.Error(t, err, "testCase%d failed", i)
+			continue
+		}
+		require.NoError(t, err, "testCase%d failed", i)
+		assert.Equal(t, tc.indexes, indexes, "testCase%d failed", i)
+	}
+}
+
+func TestPaths(t *testing.T) {
+	// the layer of StarkEx keys in EIP-2645
+	assert.EqualValues(t, 579218131, eip2645Component("starkex"))
+	assert.Equal(t, "m/2645'/1195502025'/1450157734'/0'/0'/3", ValidatorPath(3))
+	assert.Equal(t, "m/44'/9004'/0'/0/3", AccountPath(3))
+}
+
+func TestDerivePrivKeyFromSeed(t *testing.T) {
+	// test vector 1 of BIP-32
+	seed, err := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	require.NoError(t, err)
+	bip32Key, err := hex.DecodeString("471b76e389e528d6de6d816857e012c5455051cad6660850e58372a6c3e6e7c8")
+	require.NoError(t, err)
+
+	key, err := DerivePrivKeyFromSeed(seed, "m/0'/1/2'/2/1000000000")
+	require.NoError(t, err)
+	expected, err := GrindKey(bip32Key)
+	require.NoError(t, err)
+	assert.Equal(t, expected, new(big.Int).SetBytes(key))
+}
+
+func TestDerivePrivKey(t *testing.T) {
+	mnemonic, err := NewMnemonic()
+	require.NoError(t, err)
+	assert.Len(t, strings.Fields(mnemonic), 24)
+
+	validatorKey, err := DerivePrivKey(mnemonic, ValidatorPath(0))
+	require.NoError(t, err)
+	accountKey, err := DerivePrivKey(mnemonic, AccountPath(0))
+	require.NoError(t, err)
+	assert.NotEqual(t, validatorKey, accountKey)
+
+	// the key is a valid stark key, recovered from the same mnemonic however
+	// it is spaced
+	msg := []byte("message")
+	sig, err := validatorKey.Sign(msg)
+	require.NoError(t, err)
+	assert.True(t, validatorKey.PubKey().VerifySignature(msg, sig))
+	recovered, err := DerivePrivKey("  "+strings.ToUpper(strings.ReplaceAll(mnemonic, " ", "\n  ")), ValidatorPath(0))
+	require.NoError(t, err)
+	assert.Equal(t, validatorKey, recovered)
+
+	_, err = DerivePrivKey(strings.Repeat("abandon ", 11)+"about", ValidatorPath(0))
+	assert.NoError(t, err)
+	_, err = DerivePrivKey(strings.Repeat("abandon ", 12), ValidatorPath(0))
+	assert.Error(t, err, "the checksum of the mnemonic should be checked")
+	_, err = DerivePrivKey(mnemonic, "0/1")
+	assert.Error(t, err)
+}
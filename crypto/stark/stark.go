@@ -55,6 +55,8 @@ func (pv PrivKey) PubKey() crypto.PubKey {
 
 }
 
+// Sign signs the commitment hash of msg, see crypto.SetCommitmentHash, so
+// that signatures follow the hash of the chain like the sign bytes of votes.
 func (privKey PrivKey) Sign(msg []byte) ([]byte, error) {
 
 	hash := crypto.Checksum128(msg)
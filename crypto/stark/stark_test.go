@@ -1,10 +1,13 @@
 package stark_test
 
 import (
+	"encoding/hex"
 	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/crypto/pedersen/felt"
 	"github.com/tendermint/tendermint/crypto/pedersen/hashing"
 	"github.com/tendermint/tendermint/crypto/stark"
@@ -58,6 +61,26 @@ func TestSignAndVerify(t *testing.T) {
 	pb.VerifySignature(msg, sig)
 }
 
+func TestSignFollowsCommitmentHash(t *testing.T) {
+	t.Cleanup(func() { require.NoError(t, crypto.SetCommitmentHash(crypto.CommitmentHashPedersen)) })
+
+	pv := stark.GenPrivKey()
+	pb := pv.PubKey()
+	msg := []byte("hello world")
+
+	require.NoError(t, crypto.SetCommitmentHash(crypto.CommitmentHashPoseidon))
+	sig, err := pv.Sign(msg)
+	require.NoError(t, err)
+	require.True(t, pb.VerifySignature(msg, sig))
+	v := stark.NewBatchVerifier()
+	require.NoError(t, v.Add(pb, msg, sig))
+	ok, _ := v.Verify()
+	require.True(t, ok)
+
+	require.NoError(t, crypto.SetCommitmentHash(crypto.CommitmentHashPedersen))
+	require.False(t, pb.VerifySignature(msg, sig))
+}
+
 func TestMarshalling(t *testing.T) {
 	pv := stark.GenPrivKey()
 	pb := pv.PubKey()
@@ -68,6 +91,22 @@ func TestMarshalling(t *testing.T) {
 
 }
 
+func TestSignKnownAnswer(t *testing.T) {
+	privBytes, _ := hex.DecodeString("02dccce1da22003777062ee0870e9881b460a8b7eca276870f57c601f182136c")
+	priv := stark.PrivKey(privBytes)
+	require.Equal(t,
+		"00499f65ae2f71d5298d2d88823b2e5e19596a71aac1984710479e406a00243904745865467631492cf6ecc433a3cf4ecc580d698097d6b738ad8f3da7c4d66c",
+		hex.EncodeToString(priv.PubKey().Bytes()))
+
+	// signatures are deterministic
+	sig, err := priv.Sign([]byte("known answer"))
+	require.NoError(t, err)
+	require.Equal(t,
+		"002a5d23a204884bb3fb4c24188bc34f7d843290382742f7f2e3576623d513b307de4cdd08e4ea0b2c606b117de3f5ce090cd3aa9c5b17a3a1ba5ab66a644380",
+		hex.EncodeToString(sig))
+	require.True(t, priv.PubKey().VerifySignature([]byte("known answer"), sig))
+}
+
 // imported signature from https://www.cairo-lang.org/docs/hello_starknet/signature_verification.html?highlight=signature#interacting-with-the-contract
 // to make sure of compatibility
 func TestImportedSig(t *testing.T) {
@@ -97,3 +136,95 @@ func TestImportedSig(t *testing.T) {
 	require.True(t, res)
 
 }
+
+func TestBatchSafe(t *testing.T) {
+	v := stark.NewBatchVerifier()
+
+	for i := 0; i <= 38; i++ {
+		priv := stark.GenPrivKey()
+		pub := priv.PubKey()
+
+		var msg []byte
+		if i%2 == 0 {
+			msg = []byte("easter")
+		} else {
+			msg = []byte("egg")
+		}
+
+		sig, err := priv.Sign(msg)
+		require.NoError(t, err)
+
+		err = v.Add(pub, msg, sig)
+		require.NoError(t, err)
+	}
+
+	ok, valid := v.Verify()
+	require.True(t, ok)
+	require.Len(t, valid, 39)
+}
+
+func TestBatchVerifyMatchesVerifySignature(t *testing.T) {
+	msg := []byte("hello world")
+	n := weierstrass.Stark().Params().N
+
+	priv := stark.GenPrivKey()
+	pub := priv.PubKey()
+	sig, err := priv.Sign(msg)
+	require.NoError(t, err)
+
+	// signing with -d gives a signature of pub verified through u1*G - u2*P
+	negPriv := stark.PrivKey(new(big.Int).Sub(n, new(big.Int).SetBytes(priv)).Bytes())
+	negSig, err := negPriv.Sign(msg)
+	require.NoError(t, err)
+
+	tampered := append([]byte{}, sig...)
+	tampered[63] ^= 0x01
+
+	zeroR := append(make([]byte, 32), sig[32:]...)
+
+	highS := append([]byte{}, sig[:32]...)
+	highS = append(highS, n.FillBytes(make([]byte, 32))...)
+
+	testCases := []struct {
+		msg   []byte
+		sig   []byte
+		valid bool
+	}{
+		{msg, sig, true},
+		{msg, negSig, true},
+		{[]byte("hello"), sig, false},
+		{msg, tampered, false},
+		{msg, zeroR, false},
+		{msg, highS, false},
+	}
+
+	v := stark.NewBatchVerifier()
+	for i, tc := range testCases {
+		require.Equal(t, tc.valid, pub.VerifySignature(tc.msg, tc.sig), "testCase%d failed", i)
+		require.NoError(t, v.Add(pub, tc.msg, tc.sig), "testCase%d failed", i)
+	}
+
+	ok, valid := v.Verify()
+	require.False(t, ok)
+	for i, tc := range testCases {
+		require.Equal(t, tc.valid, valid[i], "testCase%d failed", i)
+	}
+}
+
+func TestBatchVerifierAdd(t *testing.T) {
+	priv := stark.GenPrivKey()
+	pub := priv.PubKey()
+	msg := []byte("hello world")
+	sig, err := priv.Sign(msg)
+	require.NoError(t, err)
+
+	v := stark.NewBatchVerifier()
+	require.Error(t, v.Add(ed25519.GenPrivKey().PubKey(), msg, sig))
+	require.Error(t, v.Add(stark.PubKey(pub.Bytes()[:32]), msg, sig))
+	require.Error(t, v.Add(stark.PubKey(make([]byte, stark.PubKeySize)), msg, sig))
+	require.Error(t, v.Add(pub, msg, sig[:32]))
+
+	ok, valid := v.Verify()
+	require.False(t, ok)
+	require.Empty(t, valid)
+}
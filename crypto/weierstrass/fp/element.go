@@ -0,0 +1,272 @@
+// Package fp implements arithmetic in the field of integers modulo the
+// STARK prime p = 2²⁵¹ + 17·2¹⁹² + 1, the base field of the STARK curve
+// and the field of StarkNet felts.
+//
+// Elements are held in four 64-bit limbs in Montgomery form. All the
+// operations run in constant time, except for the conversions from and
+// to big.Int.
+package fp
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// Element is an element of 𝔽ₚ, stored in Montgomery form (x·2²⁵⁶ mod p)
+// with the least significant limb first. The zero value is 0.
+type Element [4]uint64
+
+// modulus is p, with the least significant limb first.
+var modulus = Element{1, 0, 0, 0x0800000000000011}
+
+// qInvNeg is -p⁻¹ mod 2⁶⁴.
+const qInvNeg = 0xffffffffffffffff
+
+var (
+	// pBig is p as a big.Int.
+	pBig = new(big.Int).SetBytes([]byte{
+		0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x11, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+	})
+	// one is 1 in Montgomery form, 2²⁵⁶ mod p.
+	one = limbs(new(big.Int).Mod(new(big.Int).Lsh(big.NewInt(1), 256), pBig))
+	// rSquare is 2⁵¹² mod p, multiplying by it converts to Montgomery form.
+	rSquare = limbs(new(big.Int).Mod(new(big.Int).Lsh(big.NewInt(1), 512), pBig))
+	// pMinus2 is the exponent of the inversion.
+	pMinus2 = limbs(new(big.Int).Sub(pBig, big.NewInt(2)))
+)
+
+// limbs returns the limbs of v, which must be smaller than 2²⁵⁶.
+func limbs(v *big.Int) Element {
+	var b [32]byte
+	v.FillBytes(b[:])
+	return fromBytes(&b)
+}
+
+func fromBytes(b *[32]byte) Element {
+	var z Element
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 8; j++ {
+			z[i] |= uint64(b[31-8*i-j]) << (8 * j)
+		}
+	}
+	return z
+}
+
+// Modulus returns p.
+func Modulus() *big.Int {
+	return new(big.Int).Set(pBig)
+}
+
+// SetZero sets z to 0 and returns z.
+func (z *Element) SetZero() *Element {
+	*z = Element{}
+	return z
+}
+
+// SetOne sets z to 1 and returns z.
+func (z *Element) SetOne() *Element {
+	*z = one
+	return z
+}
+
+// Set sets z to x and returns z.
+func (z *Element) Set(x *Element) *Element {
+	*z = *x
+	return z
+}
+
+// SetUint64 sets z to v and returns z.
+func (z *Element) SetUint64(v uint64) *Element {
+	*z = Element{v}
+	return z.Mul(z, &rSquare)
+}
+
+// SetBigInt sets z to v mod p and returns z.
+func (z *Element) SetBigInt(v *big.Int) *Element {
+	var b [32]byte
+	new(big.Int).Mod(v, pBig).FillBytes(b[:])
+	*z = fromBytes(&b)
+	return z.Mul(z, &rSquare)
+}
+
+// SetBytes sets z to the big-endian integer b, reduced modulo p, and
+// returns z.
+func (z *Element) SetBytes(b []byte) *Element {
+	return z.SetBigInt(new(big.Int).SetBytes(b))
+}
+
+// BigInt sets res to z and returns res.
+func (z *Element) BigInt(res *big.Int) *big.Int {
+	b := z.Bytes()
+	return res.SetBytes(b[:])
+}
+
+// Bytes returns z in big-endian form.
+func (z *Element) Bytes() [32]byte {
+	var t Element
+	t.Mul(z, &Element{1})
+
+	var b [32]byte
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 8; j++ {
+			b[31-8*i-j] = byte(t[i] >> (8 * j))
+		}
+	}
+	return b
+}
+
+// String returns z in base 10.
+func (z *Element) String() string {
+	return z.BigInt(new(big.Int)).String()
+}
+
+// Equal reports whether z and x are equal.
+func (z *Element) Equal(x *Element) bool {
+	return (z[0]^x[0])|(z[1]^x[1])|(z[2]^x[2])|(z[3]^x[3]) == 0
+}
+
+// IsZero reports whether z is 0.
+func (z *Element) IsZero() bool {
+	return z[0]|z[1]|z[2]|z[3] == 0
+}
+
+// Select sets z to x0 if c is 0 and to x1 if c is 1, and returns z.
+func (z *Element) Select(c uint64, x0, x1 *Element) *Element {
+	mask := -c
+	z[0] = x0[0] ^ (mask & (x0[0] ^ x1[0]))
+	z[1] = x0[1] ^ (mask & (x0[1] ^ x1[1]))
+	z[2] = x0[2] ^ (mask & (x0[2] ^ x1[2]))
+	z[3] = x0[3] ^ (mask & (x0[3] ^ x1[3]))
+	return z
+}
+
+// Add sets z to x + y and returns z.
+func (z *Element) Add(x, y *Element) *Element {
+	// x + y < 2p < 2²⁵⁶, so there is no carry out
+	var t Element
+	var c uint64
+	t[0], c = bits.Add64(x[0], y[0], 0)
+	t[1], c = bits.Add64(x[1], y[1], c)
+	t[2], c = bits.Add64(x[2], y[2], c)
+	t[3], _ = bits.Add64(x[3], y[3], c)
+	return z.reduce(&t)
+}
+
+// Double sets z to 2x and returns z.
+func (z *Element) Double(x *Element) *Element {
+	return z.Add(x, x)
+}
+
+// Sub sets z to x - y and returns z.
+func (z *Element) Sub(x, y *Element) *Element {
+	var t Element
+	var b uint64
+	t[0], b = bits.Sub64(x[0], y[0], 0)
+	t[1], b = bits.Sub64(x[1], y[1], b)
+	t[2], b = bits.Sub64(x[2], y[2], b)
+	t[3], b = bits.Sub64(x[3], y[3], b)
+
+	// add p back if the subtraction borrowed
+	mask := -b
+	var c uint64
+	z[0], c = bits.Add64(t[0], modulus[0]&mask, 0)
+	z[1], c = bits.Add64(t[1], modulus[1]&mask, c)
+	z[2], c = bits.Add64(t[2], modulus[2]&mask, c)
+	z[3], _ = bits.Add64(t[3], modulus[3]&mask, c)
+	return z
+}
+
+// Neg sets z to -x and returns z.
+func (z *Element) Neg(x *Element) *Element {
+	return z.Sub(&Element{}, x)
+}
+
+// Mul sets z to x·y and returns z.
+func (z *Element) Mul(x, y *Element) *Element {
+	// Montgomery multiplication, with the coarsely integrated operand
+	// scanning (CIOS) method.
+	var t0, t1, t2, t3, t4 uint64
+	for i := 0; i < 4; i++ {
+		// t += x·y[i]
+		var c, t5 uint64
+		t0, c = madd(x[0], y[i], t0, c)
+		t1, c = madd(x[1], y[i], t1, c)
+		t2, c = madd(x[2], y[i], t2, c)
+		t3, c = madd(x[3], y[i], t3, c)
+		t4, t5 = bits.Add64(t4, c, 0)
+
+		// t = (t + m·p) / 2⁶⁴, with m chosen for t + m·p to be a
+		// multiple of 2⁶⁴. Only the first and last limbs of p are not
+		// zero.
+		m := t0 * qInvNeg
+		_, c = bits.Add64(t0, m, 0)
+		t0, c = bits.Add64(t1, 0, c)
+		t1, c = bits.Add64(t2, 0, c)
+		hi, lo := bits.Mul64(m, modulus[3])
+		t2, c = bits.Add64(t3, lo, c)
+		t3, c = bits.Add64(t4, hi, c)
+		t4 = t5 + c
+	}
+
+	// t < 2p < 2²⁵⁶, so t4 is 0
+	return z.reduce(&Element{t0, t1, t2, t3})
+}
+
+// Square sets z to x² and returns z.
+func (z *Element) Square(x *Element) *Element {
+	return z.Mul(x, x)
+}
+
+// Inverse sets z to x⁻¹, or 0 if x is 0, and returns z.
+func (z *Element) Inverse(x *Element) *Element {
+	// x^(p-2), by square and multiply over the public exponent
+	var r Element
+	r.SetOne()
+	base := *x
+	for i := 3; i >= 0; i-- {
+		for j := 63; j >= 0; j-- {
+			r.Square(&r)
+			if (pMinus2[i]>>uint(j))&1 == 1 {
+				r.Mul(&r, &base)
+			}
+		}
+	}
+	*z = r
+	return z
+}
+
+// InverseVarTime sets z to x⁻¹, or 0 if x is 0, and returns z. It is
+// faster than Inverse but does not run in constant time, and must only
+// be used on public values.
+func (z *Element) InverseVarTime(x *Element) *Element {
+	v := x.BigInt(new(big.Int))
+	if v.ModInverse(v, pBig) == nil {
+		return z.SetZero()
+	}
+	return z.SetBigInt(v)
+}
+
+// reduce sets z to t mod p, for t < 2p, and returns z.
+func (z *Element) reduce(t *Element) *Element {
+	var s Element
+	var b uint64
+	s[0], b = bits.Sub64(t[0], modulus[0], 0)
+	s[1], b = bits.Sub64(t[1], modulus[1], b)
+	s[2], b = bits.Sub64(t[2], modulus[2], b)
+	s[3], b = bits.Sub64(t[3], modulus[3], b)
+
+	// keep t if the subtraction borrowed, that is if t < p
+	return z.Select(b, &s, t)
+}
+
+// madd returns the high and low words of a·b + c + d.
+func madd(a, b, c, d uint64) (lo, hi uint64) {
+	hi, lo = bits.Mul64(a, b)
+	var carry uint64
+	lo, carry = bits.Add64(lo, c, 0)
+	hi += carry
+	lo, carry = bits.Add64(lo, d, 0)
+	hi += carry
+	return lo, hi
+}
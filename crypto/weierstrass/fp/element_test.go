@@ -0,0 +1,140 @@
+package fp
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func randBig(t *testing.T) *big.Int {
+	v, err := rand.Int(rand.Reader, pBig)
+	require.NoError(t, err)
+	return v
+}
+
+func edgeValues(t *testing.T) []*big.Int {
+	values := []*big.Int{
+		big.NewInt(0),
+		big.NewInt(1),
+		big.NewInt(2),
+		new(big.Int).Sub(pBig, big.NewInt(1)),
+		new(big.Int).Sub(pBig, big.NewInt(2)),
+		new(big.Int).Lsh(big.NewInt(1), 251),
+		new(big.Int).Lsh(big.NewInt(1), 192),
+	}
+	for i := 0; i < 32; i++ {
+		values = append(values, randBig(t))
+	}
+	return values
+}
+
+func TestConversions(t *testing.T) {
+	for i, v := range edgeValues(t) {
+		var x Element
+		x.SetBigInt(v)
+		require.Equal(t, v.String(), x.String(), "testCase%d failed", i)
+
+		b := x.Bytes()
+		require.Equal(t, v.FillBytes(make([]byte, 32)), b[:], "testCase%d failed", i)
+
+		var y Element
+		require.True(t, y.SetBytes(b[:]).Equal(&x), "testCase%d failed", i)
+	}
+
+	var x Element
+	require.Equal(t, big.NewInt(0).String(), x.String())
+	require.Equal(t, big.NewInt(1).String(), x.SetOne().String())
+	require.Equal(t, big.NewInt(42).String(), x.SetUint64(42).String())
+
+	// values are reduced modulo p
+	require.Equal(t, big.NewInt(3).String(), x.SetBigInt(new(big.Int).Add(pBig, big.NewInt(3))).String())
+	require.Equal(t, new(big.Int).Sub(pBig, big.NewInt(3)).String(), x.SetBigInt(big.NewInt(-3)).String())
+}
+
+func TestArithmetic(t *testing.T) {
+	values := edgeValues(t)
+	for i, a := range values {
+		for j, b := range values {
+			var x, y, z Element
+			x.SetBigInt(a)
+			y.SetBigInt(b)
+
+			sum := new(big.Int).Add(a, b)
+			require.Equal(t, sum.Mod(sum, pBig).String(), z.Add(&x, &y).String(), "testCase%d/%d failed", i, j)
+
+			diff := new(big.Int).Sub(a, b)
+			require.Equal(t, diff.Mod(diff, pBig).String(), z.Sub(&x, &y).String(), "testCase%d/%d failed", i, j)
+
+			prod := new(big.Int).Mul(a, b)
+			require.Equal(t, prod.Mod(prod, pBig).String(), z.Mul(&x, &y).String(), "testCase%d/%d failed", i, j)
+		}
+	}
+}
+
+func TestUnaryOperations(t *testing.T) {
+	for i, a := range edgeValues(t) {
+		var x, z Element
+		x.SetBigInt(a)
+
+		neg := new(big.Int).Neg(a)
+		require.Equal(t, neg.Mod(neg, pBig).String(), z.Neg(&x).String(), "testCase%d failed", i)
+
+		double := new(big.Int).Lsh(a, 1)
+		require.Equal(t, double.Mod(double, pBig).String(), z.Double(&x).String(), "testCase%d failed", i)
+
+		square := new(big.Int).Mul(a, a)
+		require.Equal(t, square.Mod(square, pBig).String(), z.Square(&x).String(), "testCase%d failed", i)
+
+		inv := new(big.Int).ModInverse(a, pBig)
+		if inv == nil {
+			inv = new(big.Int)
+		}
+		require.Equal(t, inv.String(), z.Inverse(&x).String(), "testCase%d failed", i)
+		require.Equal(t, inv.String(), z.InverseVarTime(&x).String(), "testCase%d failed", i)
+	}
+}
+
+func TestAliasing(t *testing.T) {
+	a, b := randBig(t), randBig(t)
+	prod := new(big.Int).Mul(a, b)
+	prod.Mod(prod, pBig)
+
+	var x, y Element
+	x.SetBigInt(a)
+	y.SetBigInt(b)
+	x.Mul(&x, &y)
+	require.Equal(t, prod.String(), x.String())
+
+	y.Sub(&y, &y)
+	require.True(t, y.IsZero())
+}
+
+func TestSelect(t *testing.T) {
+	var x0, x1, z Element
+	x0.SetUint64(1)
+	x1.SetUint64(2)
+	require.True(t, z.Select(0, &x0, &x1).Equal(&x0))
+	require.True(t, z.Select(1, &x0, &x1).Equal(&x1))
+	require.False(t, x0.Equal(&x1))
+}
+
+func BenchmarkMul(b *testing.B) {
+	var x, y Element
+	x.SetUint64(12345)
+	y.SetBigInt(new(big.Int).Sub(pBig, big.NewInt(12345)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.Mul(&x, &y)
+	}
+}
+
+func BenchmarkInverse(b *testing.B) {
+	var x Element
+	x.SetUint64(12345)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.Inverse(&x)
+	}
+}
@@ -2,9 +2,10 @@ package weierstrass
 
 import "math/big"
 
-// Stark returns a Curve which implements the STARK curve as described
-// in https://docs.starkware.co/starkex-v4/crypto/stark-curve.
-func Stark() Curve {
+// starkParams are the parameters of the STARK curve.
+var starkParams = newStarkParams()
+
+func newStarkParams() *CurveParams {
 	stark := CurveParams{Name: "STARK", BitSize: 252, A: big.NewInt(1)}
 	stark.B, _ = new(big.Int).SetString("6f21413efbe40de150e596d72f7a8c5609ad26c15c915c1f4cdfcb99cee9e89", 16)
 	stark.Gx, _ = new(big.Int).SetString("1ef15c18599971b7beced415a40f0c7deacfd9b0d1819e03d723d8bc943cfca", 16)
@@ -13,3 +14,55 @@ func Stark() Curve {
 	stark.P, _ = new(big.Int).SetString("800000000000011000000000000000000000000000000000000000000000001", 16)
 	return &stark
 }
+
+// Stark returns a Curve which implements the STARK curve as described
+// in https://docs.starkware.co/starkex-v4/crypto/stark-curve.
+//
+// Unlike the generic CurveParams, its arithmetic is done on StarkPoint,
+// over a fixed-width field, and its scalar multiplications run in
+// constant time.
+func Stark() Curve {
+	return &starkCurve{newStarkParams()}
+}
+
+type starkCurve struct {
+	*CurveParams
+}
+
+// Add returns the sum of (x1, y1) and (x2, y2).
+func (curve *starkCurve) Add(x1, y1, x2, y2 *big.Int) (*big.Int, *big.Int) {
+	var p, q StarkPoint
+	p.SetAffine(x1, y1)
+	q.SetAffine(x2, y2)
+	return p.Add(&p, &q).Affine()
+}
+
+// Double returns 2 * (x, y).
+func (curve *starkCurve) Double(x1, y1 *big.Int) (*big.Int, *big.Int) {
+	var p StarkPoint
+	p.SetAffine(x1, y1)
+	return p.Double(&p).Affine()
+}
+
+// ScalarMult returns k * (Bx, By) where k is a number in big-endian.
+func (curve *starkCurve) ScalarMult(Bx, By *big.Int, k []byte) (*big.Int, *big.Int) {
+	var p StarkPoint
+	p.SetAffine(Bx, By)
+	return p.ScalarMult(&p, k).Affine()
+}
+
+// ScalarBaseMult returns k * G, where G is the base point of the group
+// and k is an integer in big-endian.
+func (curve *starkCurve) ScalarBaseMult(k []byte) (*big.Int, *big.Int) {
+	var p StarkPoint
+	return p.ScalarBaseMult(k).Affine()
+}
+
+// CombinedMult returns [s1]G + [s2]P where G is the generator. It is
+// meant for signature verification, where all the inputs are public.
+func (curve *starkCurve) CombinedMult(Px, Py *big.Int, s1, s2 []byte) (x, y *big.Int) {
+	var p, q StarkPoint
+	q.SetAffine(Px, Py)
+	q.ScalarMult(&q, s2)
+	return p.ScalarBaseMult(s1).Add(&p, &q).AffineVarTime()
+}
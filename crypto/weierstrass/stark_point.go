@@ -0,0 +1,408 @@
+package weierstrass
+
+import (
+	"crypto/subtle"
+	"math/big"
+	"sync"
+
+	"github.com/tendermint/tendermint/crypto/weierstrass/fp"
+)
+
+// StarkPoint is a point of the STARK curve in homogeneous projective
+// coordinates (X : Y : Z), standing for the affine point (X/Z, Y/Z). The
+// point at infinity is (0 : 1 : 0).
+//
+// Additions and doublings use the complete formulas of Renes, Costello
+// and Batina (https://eprint.iacr.org/2015/1060, algorithms 1 and 3),
+// which have no exceptional cases and run in constant time. The zero
+// value is not a valid point, use SetInfinity.
+type StarkPoint struct {
+	x, y, z fp.Element
+}
+
+// starkB3 is 3·b, where y² = x³ + x + b is the STARK curve.
+var starkB3 fp.Element
+
+func init() {
+	starkB3.SetBigInt(new(big.Int).Mul(big.NewInt(3), starkParams.B))
+}
+
+// SetInfinity sets p to the point at infinity and returns p.
+func (p *StarkPoint) SetInfinity() *StarkPoint {
+	p.x.SetZero()
+	p.y.SetOne()
+	p.z.SetZero()
+	return p
+}
+
+// SetAffine sets p to (x, y) and returns p, (0, 0) standing for the
+// point at infinity. The point must be on the curve.
+func (p *StarkPoint) SetAffine(x, y *big.Int) *StarkPoint {
+	if x.Sign() == 0 && y.Sign() == 0 {
+		return p.SetInfinity()
+	}
+	p.x.SetBigInt(x)
+	p.y.SetBigInt(y)
+	p.z.SetOne()
+	return p
+}
+
+// Affine returns the affine coordinates of p, or (0, 0) for the point
+// at infinity.
+func (p *StarkPoint) Affine() (x, y *big.Int) {
+	var zInv, ax, ay fp.Element
+	// the inverse of 0 is 0, which maps the point at infinity to (0, 0)
+	zInv.Inverse(&p.z)
+	ax.Mul(&p.x, &zInv)
+	ay.Mul(&p.y, &zInv)
+	return ax.BigInt(new(big.Int)), ay.BigInt(new(big.Int))
+}
+
+// AffineVarTime is like Affine, but it is faster and does not run in
+// constant time. It must only be used on public points.
+func (p *StarkPoint) AffineVarTime() (x, y *big.Int) {
+	var zInv, ax, ay fp.Element
+	zInv.InverseVarTime(&p.z)
+	ax.Mul(&p.x, &zInv)
+	ay.Mul(&p.y, &zInv)
+	return ax.BigInt(new(big.Int)), ay.BigInt(new(big.Int))
+}
+
+// HasAffineX reports whether p is not the point at infinity and has the
+// affine x coordinate x, without computing the affine coordinates.
+func (p *StarkPoint) HasAffineX(x *big.Int) bool {
+	var xz fp.Element
+	xz.SetBigInt(x)
+	xz.Mul(&xz, &p.z)
+	return !p.z.IsZero() && xz.Equal(&p.x)
+}
+
+// Set sets p to q and returns p.
+func (p *StarkPoint) Set(q *StarkPoint) *StarkPoint {
+	*p = *q
+	return p
+}
+
+// IsInfinity reports whether p is the point at infinity.
+func (p *StarkPoint) IsInfinity() bool {
+	return p.z.IsZero()
+}
+
+// Neg sets p to -q and returns p.
+func (p *StarkPoint) Neg(q *StarkPoint) *StarkPoint {
+	p.x.Set(&q.x)
+	p.y.Neg(&q.y)
+	p.z.Set(&q.z)
+	return p
+}
+
+// Select sets p to q0 if c is 0 and to q1 if c is 1, and returns p.
+func (p *StarkPoint) Select(c uint64, q0, q1 *StarkPoint) *StarkPoint {
+	p.x.Select(c, &q0.x, &q1.x)
+	p.y.Select(c, &q0.y, &q1.y)
+	p.z.Select(c, &q0.z, &q1.z)
+	return p
+}
+
+// Add sets p to q + r and returns p.
+func (p *StarkPoint) Add(q, r *StarkPoint) *StarkPoint {
+	// Algorithm 1, with a = 1.
+	var t0, t1, t2, t3, t4, t5, x3, y3, z3 fp.Element
+	t0.Mul(&q.x, &r.x)
+	t1.Mul(&q.y, &r.y)
+	t2.Mul(&q.z, &r.z)
+	t3.Add(&q.x, &q.y)
+	t4.Add(&r.x, &r.y)
+	t3.Mul(&t3, &t4)
+	t4.Add(&t0, &t1)
+	t3.Sub(&t3, &t4)
+	t4.Add(&q.x, &q.z)
+	t5.Add(&r.x, &r.z)
+	t4.Mul(&t4, &t5)
+	t5.Add(&t0, &t2)
+	t4.Sub(&t4, &t5)
+	t5.Add(&q.y, &q.z)
+	x3.Add(&r.y, &r.z)
+	t5.Mul(&t5, &x3)
+	x3.Add(&t1, &t2)
+	t5.Sub(&t5, &x3)
+	x3.Mul(&starkB3, &t2)
+	z3.Add(&x3, &t4)
+	x3.Sub(&t1, &z3)
+	z3.Add(&t1, &z3)
+	y3.Mul(&x3, &z3)
+	t1.Double(&t0)
+	t1.Add(&t1, &t0)
+	t4.Mul(&starkB3, &t4)
+	t1.Add(&t1, &t2)
+	t2.Sub(&t0, &t2)
+	t4.Add(&t4, &t2)
+	t0.Mul(&t1, &t4)
+	y3.Add(&y3, &t0)
+	t0.Mul(&t5, &t4)
+	x3.Mul(&t3, &x3)
+	x3.Sub(&x3, &t0)
+	t0.Mul(&t3, &t1)
+	z3.Mul(&t5, &z3)
+	z3.Add(&z3, &t0)
+
+	p.x, p.y, p.z = x3, y3, z3
+	return p
+}
+
+// Double sets p to 2·q and returns p.
+func (p *StarkPoint) Double(q *StarkPoint) *StarkPoint {
+	// Algorithm 3, with a = 1.
+	var t0, t1, t2, t3, x3, y3, z3 fp.Element
+	t0.Square(&q.x)
+	t1.Square(&q.y)
+	t2.Square(&q.z)
+	t3.Mul(&q.x, &q.y)
+	t3.Double(&t3)
+	z3.Mul(&q.x, &q.z)
+	z3.Double(&z3)
+	y3.Mul(&starkB3, &t2)
+	y3.Add(&z3, &y3)
+	x3.Sub(&t1, &y3)
+	y3.Add(&t1, &y3)
+	y3.Mul(&x3, &y3)
+	x3.Mul(&t3, &x3)
+	z3.Mul(&starkB3, &z3)
+	t3.Sub(&t0, &t2)
+	t3.Add(&t3, &z3)
+	z3.Double(&t0)
+	t0.Add(&z3, &t0)
+	t0.Add(&t0, &t2)
+	t0.Mul(&t0, &t3)
+	y3.Add(&y3, &t0)
+	t2.Mul(&q.y, &q.z)
+	t2.Double(&t2)
+	t0.Mul(&t2, &t3)
+	x3.Sub(&x3, &t0)
+	z3.Mul(&t2, &t1)
+	z3.Double(&z3)
+	z3.Double(&z3)
+
+	p.x, p.y, p.z = x3, y3, z3
+	return p
+}
+
+// starkTable holds the multiples 0·q to 15·q of a point q.
+type starkTable [16]StarkPoint
+
+func (t *starkTable) init(q *StarkPoint) {
+	t[0].SetInfinity()
+	t[1].Set(q)
+	for i := 2; i < len(t); i++ {
+		t[i].Add(&t[i-1], q)
+	}
+}
+
+// lookup sets p to t[i], reading every entry of the table so that the
+// memory accesses do not depend on i, and returns p.
+func (t *starkTable) lookup(p *StarkPoint, i byte) *StarkPoint {
+	p.SetInfinity()
+	for j := range t {
+		c := uint64(subtle.ConstantTimeByteEq(byte(j), i))
+		p.Select(c, p, &t[j])
+	}
+	return p
+}
+
+// ScalarMult sets p to k·q, where k is a big-endian integer, and returns
+// p. It runs in constant time for a given length of k.
+func (p *StarkPoint) ScalarMult(q *StarkPoint, k []byte) *StarkPoint {
+	var table starkTable
+	table.init(q)
+
+	var r, t StarkPoint
+	r.SetInfinity()
+	for _, b := range k {
+		for _, w := range [2]byte{b >> 4, b & 0x0f} {
+			r.Double(&r)
+			r.Double(&r)
+			r.Double(&r)
+			r.Double(&r)
+			r.Add(&r, table.lookup(&t, w))
+		}
+	}
+	return p.Set(&r)
+}
+
+var (
+	starkBaseTableOnce sync.Once
+	// starkBaseTable[i] holds the multiples of 16ⁱ·G.
+	starkBaseTable [64]starkTable
+)
+
+// ScalarBaseMult sets p to k·G, where G is the generator and k is a
+// big-endian integer, and returns p. It runs in constant time for scalars
+// of up to 32 bytes.
+func (p *StarkPoint) ScalarBaseMult(k []byte) *StarkPoint {
+	var r, t StarkPoint
+	r.SetInfinity()
+	for i, w := range baseWindows(k) {
+		r.Add(&r, starkBaseTable[i].lookup(&t, w))
+	}
+	return p.Set(&r)
+}
+
+func initStarkBaseTable() {
+	var g StarkPoint
+	g.SetAffine(starkParams.Gx, starkParams.Gy)
+	for i := range starkBaseTable {
+		starkBaseTable[i].init(&g)
+		for j := 0; j < 4; j++ {
+			g.Double(&g)
+		}
+	}
+}
+
+// ScalarBaseMultVarTime is like ScalarBaseMult, but it is faster and does
+// not run in constant time. It must only be used with public scalars.
+func (p *StarkPoint) ScalarBaseMultVarTime(k []byte) *StarkPoint {
+	var r StarkPoint
+	r.SetInfinity()
+	for i, w := range baseWindows(k) {
+		if w != 0 {
+			r.Add(&r, &starkBaseTable[i][w])
+		}
+	}
+	return p.Set(&r)
+}
+
+// baseWindows returns the 4-bit windows of the scalar k of ScalarBaseMult,
+// least significant first, initializing the base table on first use.
+func baseWindows(k []byte) [64]byte {
+	starkBaseTableOnce.Do(initStarkBaseTable)
+
+	var scalar [32]byte
+	if len(k) > len(scalar) {
+		// G has order N
+		new(big.Int).Mod(new(big.Int).SetBytes(k), starkParams.N).FillBytes(scalar[:])
+	} else {
+		copy(scalar[len(scalar)-len(k):], k)
+	}
+
+	var windows [64]byte
+	for i := range windows {
+		b := scalar[len(scalar)-1-i/2]
+		windows[i] = b & 0x0f
+		if i%2 == 1 {
+			windows[i] = b >> 4
+		}
+	}
+	return windows
+}
+
+// wnafWidth is the width of the non-adjacent forms of ScalarMultVarTime,
+// whose tables hold the odd multiples q to 15·q.
+const wnafWidth = 5
+
+// ScalarMultVarTime is like ScalarMult, but it is faster and does not run in
+// constant time. It must only be used with public points and scalars.
+func (p *StarkPoint) ScalarMultVarTime(q *StarkPoint, k []byte) *StarkPoint {
+	var table [1 << (wnafWidth - 2)]StarkPoint
+	oddMultiples(table[:], q)
+
+	digits := wnaf(new(big.Int).SetBytes(k), wnafWidth)
+	var r, t StarkPoint
+	r.SetInfinity()
+	for i := len(digits) - 1; i >= 0; i-- {
+		r.Double(&r)
+		r.addDigit(&t, table[:], digits[i])
+	}
+	return p.Set(&r)
+}
+
+// wnafBaseWidth is the width of the non-adjacent forms of the scalars of G
+// in DoubleScalarBaseMultVarTime, whose precomputed table holds the odd
+// multiples G to 63·G.
+const wnafBaseWidth = 7
+
+var (
+	starkBaseOddTableOnce sync.Once
+	starkBaseOddTable     [1 << (wnafBaseWidth - 2)]StarkPoint
+)
+
+// DoubleScalarBaseMultVarTime sets p to k1·G + k2·q, where G is the
+// generator and k1 and k2 are big-endian integers, and returns p. Both
+// products are computed in a single pass over the non-adjacent forms of
+// k1 and k2 (Straus-Shamir), sharing the doublings. It does not run in
+// constant time and must only be used with public points and scalars.
+func (p *StarkPoint) DoubleScalarBaseMultVarTime(k1 []byte, q *StarkPoint, k2 []byte) *StarkPoint {
+	starkBaseOddTableOnce.Do(func() {
+		var g StarkPoint
+		g.SetAffine(starkParams.Gx, starkParams.Gy)
+		oddMultiples(starkBaseOddTable[:], &g)
+	})
+
+	var table [1 << (wnafWidth - 2)]StarkPoint
+	oddMultiples(table[:], q)
+
+	digits1 := wnaf(new(big.Int).SetBytes(k1), wnafBaseWidth)
+	digits2 := wnaf(new(big.Int).SetBytes(k2), wnafWidth)
+	n := len(digits1)
+	if len(digits2) > n {
+		n = len(digits2)
+	}
+
+	var r, t StarkPoint
+	r.SetInfinity()
+	for i := n - 1; i >= 0; i-- {
+		r.Double(&r)
+		if i < len(digits1) {
+			r.addDigit(&t, starkBaseOddTable[:], digits1[i])
+		}
+		if i < len(digits2) {
+			r.addDigit(&t, table[:], digits2[i])
+		}
+	}
+	return p.Set(&r)
+}
+
+// oddMultiples sets table to the odd multiples q, 3·q, 5·q, ... of q.
+func oddMultiples(table []StarkPoint, q *StarkPoint) {
+	var q2 StarkPoint
+	q2.Double(q)
+	table[0].Set(q)
+	for i := 1; i < len(table); i++ {
+		table[i].Add(&table[i-1], &q2)
+	}
+}
+
+// addDigit adds d·q to p, where d is a digit of a non-adjacent form and
+// table holds the odd multiples of q, using t as scratch space.
+func (p *StarkPoint) addDigit(t *StarkPoint, table []StarkPoint, d int8) {
+	switch {
+	case d > 0:
+		p.Add(p, &table[d/2])
+	case d < 0:
+		p.Add(p, t.Neg(&table[-d/2]))
+	}
+}
+
+// wnaf returns the width-w non-adjacent form of k, least significant digit
+// first: odd digits of absolute value below 2^(w-1), separated by at least
+// w-1 zeros.
+func wnaf(k *big.Int, w uint) []int8 {
+	k = new(big.Int).Set(k)
+	mask := big.Word(1)<<w - 1
+	digits := make([]int8, 0, k.BitLen()+1)
+	for k.Sign() > 0 {
+		var d int8
+		if k.Bit(0) == 1 {
+			m := k.Bits()[0] & mask
+			if m >= 1<<(w-1) {
+				d = int8(int(m) - 1<<w)
+				k.Add(k, big.NewInt(int64(-d)))
+			} else {
+				d = int8(m)
+				k.Sub(k, big.NewInt(int64(d)))
+			}
+		}
+		digits = append(digits, d)
+		k.Rsh(k, 1)
+	}
+	return digits
+}
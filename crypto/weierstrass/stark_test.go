@@ -0,0 +1,313 @@
+package weierstrass
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func hexInt(s string) *big.Int {
+	v, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic("invalid hex " + s)
+	}
+	return v
+}
+
+// the expected values are computed with the generic CurveParams arithmetic
+func TestStarkKnownAnswers(t *testing.T) {
+	curve := Stark()
+	qx := hexInt("7ff1f26d08680b0b44be20f6ee69c7a5a0d9be58def063ec5602cbe2ccee066")
+	qy := hexInt("14327171bf2bca8e85d243eb8a25d08f94e84ca75dac451080c3ea858046533")
+
+	type testCase struct {
+		k    string
+		base [2]string
+		mult [2]string
+	}
+	testCases := []testCase{
+		0: {
+			k: "1",
+			base: [2]string{
+				"1ef15c18599971b7beced415a40f0c7deacfd9b0d1819e03d723d8bc943cfca",
+				"5668060aa49730b7be4801df46ec62de53ecd11abe43a32873000c36e8dc1f",
+			},
+			mult: [2]string{
+				"7ff1f26d08680b0b44be20f6ee69c7a5a0d9be58def063ec5602cbe2ccee066",
+				"14327171bf2bca8e85d243eb8a25d08f94e84ca75dac451080c3ea858046533",
+			},
+		},
+		1: {
+			k: "2",
+			base: [2]string{
+				"759ca09377679ecd535a81e83039658bf40959283187c654c5416f439403cf5",
+				"6f524a3400e7708d5c01a28598ad272e7455aa88778b19f93b562d7a9646c41",
+			},
+			mult: [2]string{
+				"721e5448fec8131a766935745d4e1d95d1243bef68c934c7c63c626b199cc41",
+				"4d42cf25e153272e75e572c8e3e05f96fc072cf26d2d63dd51de160fc15b08f",
+			},
+		},
+		2: {
+			k: "f",
+			base: [2]string{
+				"64b098ab256881bb3916f719b8c1e362b9c681446d454773d513e647f0b148d",
+				"67861383870079fa6f3f3af8083d0a0d84d6f38368e0a220b521d47359ff8ce",
+			},
+			mult: [2]string{
+				"4c5c1c3d6f9118d256da5c03cd0563774bf9c2e9098fcbcb2ed80e24cb130b2",
+				"228bd99a4af85060363ef71b4fd1bae069ef7b6b529cbad9a188a59fd78656a",
+			},
+		},
+		3: {
+			k: "800000000000010ffffffffffffffffb781126dcae7b2321e66a241adc64d2e",
+			base: [2]string{
+				"1ef15c18599971b7beced415a40f0c7deacfd9b0d1819e03d723d8bc943cfca",
+				"7a997f9f55b68e04841b7fe20b9139d21ac132ee541bc5cd78cfff3c91723e2",
+			},
+			mult: [2]string{
+				"7ff1f26d08680b0b44be20f6ee69c7a5a0d9be58def063ec5602cbe2ccee066",
+				"6bcd8e8e40d436817a2dbc1475da2f706b17b358a253baef7f3c157a7fb9ace",
+			},
+		},
+		4: {
+			k: "5b9d6f6c2e7cb4e9ce4c7d5a0a31aa8f0ffe3b2a5ea1b4adf0d4e21d1e6dd21",
+			base: [2]string{
+				"7ff1f26d08680b0b44be20f6ee69c7a5a0d9be58def063ec5602cbe2ccee066",
+				"14327171bf2bca8e85d243eb8a25d08f94e84ca75dac451080c3ea858046533",
+			},
+			mult: [2]string{
+				"1bdd34d8b6b5c71dd27f31f3e747a5e0ec02f3dc0ea3696c835ea025254e65",
+				"49c75fe09a391ddc1c8dfbf0db05f78e37419c8abe0e111885ad21f6f5f6e4d",
+			},
+		},
+		5: {
+			k: "1c2a44c2a9e5f7c8c7a1a8d0f6b3e2d4c5b6a7988796a5b4c3d2e1f00112233",
+			base: [2]string{
+				"5bb1595c39f73fa7d151d4350ee8cecad7750be5bc23868eb25b11b98be4b31",
+				"615baf0e8c198bcbe67021e622f181f378bd867575f3687d6c3e0c49d310e1",
+			},
+			mult: [2]string{
+				"374b1c42ee32d175281369a203e10a5d163ff112267adb868ae79bbcdf9fae0",
+				"5a9d77983f2d298742e986419827f073f9e486edcda33c3239de8b3afd1c69f",
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		k := hexInt(tc.k).Bytes()
+
+		x, y := curve.ScalarBaseMult(k)
+		require.Equal(t, hexInt(tc.base[0]), x, "testCase%d failed: ScalarBaseMult x", i)
+		require.Equal(t, hexInt(tc.base[1]), y, "testCase%d failed: ScalarBaseMult y", i)
+
+		x, y = curve.ScalarMult(qx, qy, k)
+		require.Equal(t, hexInt(tc.mult[0]), x, "testCase%d failed: ScalarMult x", i)
+		require.Equal(t, hexInt(tc.mult[1]), y, "testCase%d failed: ScalarMult y", i)
+	}
+
+	params := curve.Params()
+	x, y := curve.Add(params.Gx, params.Gy, qx, qy)
+	require.Equal(t, hexInt("5cfd637a4064be342444820fdf1127837e80f21d1360246e14ef113259ab540"), x)
+	require.Equal(t, hexInt("2f4cb4f17f85352d8d9ee5f30a34409c338ff99978e720cfa989cab93b75940"), y)
+
+	x, y = curve.Double(qx, qy)
+	require.Equal(t, hexInt("721e5448fec8131a766935745d4e1d95d1243bef68c934c7c63c626b199cc41"), x)
+	require.Equal(t, hexInt("4d42cf25e153272e75e572c8e3e05f96fc072cf26d2d63dd51de160fc15b08f"), y)
+}
+
+func TestStarkMatchesCurveParams(t *testing.T) {
+	curve := Stark()
+	generic := newStarkParams()
+
+	for i := 0; i < 20; i++ {
+		k1, err := rand.Int(rand.Reader, generic.N)
+		require.NoError(t, err)
+		k2, err := rand.Int(rand.Reader, generic.N)
+		require.NoError(t, err)
+
+		x1, y1 := curve.ScalarBaseMult(k1.Bytes())
+		gx1, gy1 := generic.ScalarBaseMult(k1.Bytes())
+		require.Equal(t, gx1, x1, "testCase%d failed: ScalarBaseMult", i)
+		require.Equal(t, gy1, y1, "testCase%d failed: ScalarBaseMult", i)
+		require.True(t, curve.IsOnCurve(x1, y1))
+
+		x2, y2 := curve.ScalarMult(x1, y1, k2.Bytes())
+		gx2, gy2 := generic.ScalarMult(x1, y1, k2.Bytes())
+		require.Equal(t, gx2, x2, "testCase%d failed: ScalarMult", i)
+		require.Equal(t, gy2, y2, "testCase%d failed: ScalarMult", i)
+
+		x, y := curve.Add(x1, y1, x2, y2)
+		gx, gy := generic.Add(x1, y1, x2, y2)
+		require.Equal(t, gx, x, "testCase%d failed: Add", i)
+		require.Equal(t, gy, y, "testCase%d failed: Add", i)
+
+		x, y = curve.Double(x1, y1)
+		gx, gy = generic.Double(x1, y1)
+		require.Equal(t, gx, x, "testCase%d failed: Double", i)
+		require.Equal(t, gy, y, "testCase%d failed: Double", i)
+
+		// the formulas are complete, adding a point to itself doubles it
+		x, y = curve.Add(x1, y1, x1, y1)
+		require.Equal(t, gx, x, "testCase%d failed: Add to itself", i)
+		require.Equal(t, gy, y, "testCase%d failed: Add to itself", i)
+
+		x, y = curve.(*starkCurve).CombinedMult(x1, y1, k1.Bytes(), k2.Bytes())
+		gx, gy = generic.Add(gx1, gy1, gx2, gy2)
+		require.Equal(t, gx, x, "testCase%d failed: CombinedMult", i)
+		require.Equal(t, gy, y, "testCase%d failed: CombinedMult", i)
+	}
+}
+
+func TestStarkInfinity(t *testing.T) {
+	curve := Stark()
+	params := curve.Params()
+	zero := new(big.Int)
+
+	// scalars which are multiples of N, including ones longer than 32 bytes
+	long := new(big.Int).Lsh(params.N, 8*8)
+	for i, k := range []*big.Int{zero, params.N, long} {
+		x, y := curve.ScalarBaseMult(k.Bytes())
+		require.Zero(t, x.Sign(), "testCase%d failed: ScalarBaseMult", i)
+		require.Zero(t, y.Sign(), "testCase%d failed: ScalarBaseMult", i)
+
+		x, y = curve.ScalarMult(params.Gx, params.Gy, k.Bytes())
+		require.Zero(t, x.Sign(), "testCase%d failed: ScalarMult", i)
+		require.Zero(t, y.Sign(), "testCase%d failed: ScalarMult", i)
+	}
+
+	// scalars longer than 32 bytes are reduced
+	k := new(big.Int).Add(long, big.NewInt(2))
+	x, y := curve.ScalarBaseMult(k.Bytes())
+	dx, dy := curve.Double(params.Gx, params.Gy)
+	require.Equal(t, dx, x)
+	require.Equal(t, dy, y)
+	x, y = curve.ScalarMult(params.Gx, params.Gy, k.Bytes())
+	require.Equal(t, dx, x)
+	require.Equal(t, dy, y)
+
+	// P + (-P) and the additions of the point at infinity
+	negY := new(big.Int).Sub(params.P, params.Gy)
+	x, y = curve.Add(params.Gx, params.Gy, params.Gx, negY)
+	require.Zero(t, x.Sign())
+	require.Zero(t, y.Sign())
+
+	x, y = curve.Add(zero, zero, params.Gx, params.Gy)
+	require.Equal(t, params.Gx, x)
+	require.Equal(t, params.Gy, y)
+
+	x, y = curve.Double(zero, zero)
+	require.Zero(t, x.Sign())
+	require.Zero(t, y.Sign())
+}
+
+func TestStarkVarTimeMatchesConstantTime(t *testing.T) {
+	params := Stark().Params()
+	var g StarkPoint
+	g.SetAffine(params.Gx, params.Gy)
+
+	scalars := []*big.Int{
+		big.NewInt(0), big.NewInt(1), big.NewInt(15), big.NewInt(16), big.NewInt(31),
+		new(big.Int).Sub(params.N, big.NewInt(1)), params.N,
+		// longer than 32 bytes
+		new(big.Int).Add(new(big.Int).Lsh(params.N, 8*8), big.NewInt(2)),
+	}
+	for i := 0; i < 20; i++ {
+		k, err := rand.Int(rand.Reader, params.N)
+		require.NoError(t, err)
+		scalars = append(scalars, k)
+	}
+
+	var q StarkPoint
+	q.ScalarBaseMult(big.NewInt(7).Bytes())
+	for i, k := range scalars {
+		var want, got StarkPoint
+		wx, wy := want.ScalarBaseMult(k.Bytes()).Affine()
+		x, y := got.ScalarBaseMultVarTime(k.Bytes()).Affine()
+		require.Equal(t, wx, x, "testCase%d failed: ScalarBaseMultVarTime", i)
+		require.Equal(t, wy, y, "testCase%d failed: ScalarBaseMultVarTime", i)
+
+		wx, wy = want.ScalarMult(&q, k.Bytes()).Affine()
+		x, y = got.ScalarMultVarTime(&q, k.Bytes()).Affine()
+		require.Equal(t, wx, x, "testCase%d failed: ScalarMultVarTime", i)
+		require.Equal(t, wy, y, "testCase%d failed: ScalarMultVarTime", i)
+	}
+}
+
+func TestStarkDoubleScalarBaseMultVarTime(t *testing.T) {
+	params := Stark().Params()
+
+	scalars := []*big.Int{
+		big.NewInt(0), big.NewInt(1), big.NewInt(63), big.NewInt(64),
+		new(big.Int).Sub(params.N, big.NewInt(1)),
+	}
+	for i := 0; i < 10; i++ {
+		k, err := rand.Int(rand.Reader, params.N)
+		require.NoError(t, err)
+		scalars = append(scalars, k)
+	}
+
+	var q StarkPoint
+	q.ScalarBaseMult(big.NewInt(7).Bytes())
+	for i, k1 := range scalars {
+		k2 := scalars[(i+3)%len(scalars)]
+
+		var a, b, want, got StarkPoint
+		a.ScalarBaseMult(k1.Bytes())
+		b.ScalarMult(&q, k2.Bytes())
+		wx, wy := want.Add(&a, &b).Affine()
+		x, y := got.DoubleScalarBaseMultVarTime(k1.Bytes(), &q, k2.Bytes()).Affine()
+		require.Equal(t, wx, x, "testCase%d failed", i)
+		require.Equal(t, wy, y, "testCase%d failed", i)
+	}
+
+	// k1·G + k2·(-G) is the point at infinity when k1 = k2
+	var g, r StarkPoint
+	g.SetAffine(params.Gx, params.Gy)
+	k := scalars[len(scalars)-1].Bytes()
+	require.True(t, r.DoubleScalarBaseMultVarTime(k, g.Neg(&g), k).IsInfinity())
+}
+
+func BenchmarkStarkScalarBaseMult(b *testing.B) {
+	curve := Stark()
+	k, _ := rand.Int(rand.Reader, curve.Params().N)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		curve.ScalarBaseMult(k.Bytes())
+	}
+}
+
+func BenchmarkStarkScalarMult(b *testing.B) {
+	curve := Stark()
+	params := curve.Params()
+	k, _ := rand.Int(rand.Reader, params.N)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		curve.ScalarMult(params.Gx, params.Gy, k.Bytes())
+	}
+}
+
+func BenchmarkStarkScalarMultVarTime(b *testing.B) {
+	params := Stark().Params()
+	k, _ := rand.Int(rand.Reader, params.N)
+	var p, g StarkPoint
+	g.SetAffine(params.Gx, params.Gy)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.ScalarMultVarTime(&g, k.Bytes())
+	}
+}
+
+func BenchmarkStarkDoubleScalarBaseMultVarTime(b *testing.B) {
+	params := Stark().Params()
+	k1, _ := rand.Int(rand.Reader, params.N)
+	k2, _ := rand.Int(rand.Reader, params.N)
+	var p, q StarkPoint
+	q.ScalarBaseMult(big.NewInt(7).Bytes())
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.DoubleScalarBaseMultVarTime(k1.Bytes(), &q, k2.Bytes())
+	}
+}
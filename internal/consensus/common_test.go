@@ -21,7 +21,6 @@ import (
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/config"
 	cstypes "github.com/tendermint/tendermint/internal/consensus/types"
-	"github.com/tendermint/tendermint/internal/settlement/parser"
 
 	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
 	mempoolv0 "github.com/tendermint/tendermint/internal/mempool/v0"
@@ -396,13 +395,12 @@ func subscribeToVoter(cs *State, addr []byte) <-chan tmpubsub.Message {
 //-------------------------------------------------------------------------------
 // consensus states
 
-func newState(state sm.State, pv types.PrivValidator, app abci.Application) (*State, *DummySettlementReactor, error) {
+func newState(state sm.State, pv types.PrivValidator, app abci.Application) (*State, error) {
 	cfg, err := config.ResetTestRoot("consensus_state_test")
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
-	stateRes, Dummy := newStateWithConfig(cfg, state, pv, app)
-	return stateRes, &Dummy, nil
+	return newStateWithConfig(cfg, state, pv, app), nil
 }
 
 func newStateWithConfig(
@@ -410,7 +408,7 @@ func newStateWithConfig(
 	state sm.State,
 	pv types.PrivValidator,
 	app abci.Application,
-) (*State, DummySettlementReactor) {
+) *State {
 	blockStore := store.NewBlockStore(dbm.NewMemDB())
 	return newStateWithConfigAndBlockStore(thisConfig, state, pv, app, blockStore)
 }
@@ -421,7 +419,7 @@ func newStateWithConfigAndBlockStore(
 	pv types.PrivValidator,
 	app abci.Application,
 	blockStore *store.BlockStore,
-) (*State, DummySettlementReactor) {
+) *State {
 
 	// one for mempool, one for consensus
 	mtx := new(tmsync.Mutex)
@@ -446,12 +444,8 @@ func newStateWithConfigAndBlockStore(
 		panic(err)
 	}
 
-	settlementChan := make(chan parser.SettlementData, 100)
-	settlementReactor := DummySettlementReactor{logger: log.TestingLogger(), SettlementCh: settlementChan, stopChan: make(chan bool)}
-	settlementReactor.OnStart()
-
 	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyAppConnCon, mempool, evpool, blockStore)
-	cs := NewState(thisConfig.Consensus, state, blockExec, blockStore, mempool, evpool, settlementChan)
+	cs := NewState(thisConfig.Consensus, state, blockExec, blockStore, mempool, evpool)
 	cs.SetLogger(log.TestingLogger().With("module", "consensus"))
 	cs.SetPrivValidator(pv)
 
@@ -462,7 +456,7 @@ func newStateWithConfigAndBlockStore(
 		panic(err)
 	}
 	cs.SetEventBus(eventBus)
-	return cs, settlementReactor
+	return cs
 }
 
 func loadPrivValidator(cfg *config.Config) *privval.FilePV {
@@ -477,15 +471,15 @@ func loadPrivValidator(cfg *config.Config) *privval.FilePV {
 	return privValidator
 }
 
-func randState(cfg *config.Config, nValidators int) (*State, []*validatorStub, *DummySettlementReactor, error) {
+func randState(cfg *config.Config, nValidators int) (*State, []*validatorStub, error) {
 	// Get State
 	state, privVals := randGenesisState(cfg, nValidators, false, 10)
 
 	vss := make([]*validatorStub, nValidators)
 
-	cs, setReactor, err := newState(state, privVals[0], kvstore.NewApplication())
+	cs, err := newState(state, privVals[0], kvstore.NewApplication())
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, err
 	}
 
 	for i := 0; i < nValidators; i++ {
@@ -494,7 +488,7 @@ func randState(cfg *config.Config, nValidators int) (*State, []*validatorStub, *
 	// since cs1 starts at 1
 	incrementHeight(vss[1:]...)
 
-	return cs, vss, setReactor, nil
+	return cs, vss, nil
 }
 
 //-------------------------------------------------------------------------------
@@ -771,7 +765,6 @@ func randConsensusState(
 
 	genDoc, privVals := factory.RandGenesisDoc(cfg, nValidators, false, 30)
 	css := make([]*State, nValidators)
-	setReactors := make([]DummySettlementReactor, nValidators)
 	logger := consensusLogger()
 
 	closeFuncs := make([]func() error, 0, nValidators)
@@ -802,7 +795,7 @@ func randConsensusState(
 		vals := types.TM2PB.ValidatorUpdates(state.Validators)
 		app.InitChain(abci.RequestInitChain{Validators: vals})
 
-		css[i], setReactors[i] = newStateWithConfigAndBlockStore(thisConfig, state, privVals[i], app, blockStore)
+		css[i] = newStateWithConfigAndBlockStore(thisConfig, state, privVals[i], app, blockStore)
 		css[i].SetTimeoutTicker(tickerFunc())
 		css[i].SetLogger(logger.With("validator", i, "module", "consensus"))
 	}
@@ -814,9 +807,6 @@ func randConsensusState(
 		for _, dir := range configRootDirs {
 			os.RemoveAll(dir)
 		}
-		for _, reactor := range setReactors {
-			reactor.OnStop()
-		}
 	}
 }
 
@@ -831,7 +821,6 @@ func randConsensusNetWithPeers(
 ) ([]*State, *types.GenesisDoc, *config.Config, cleanupFunc) {
 	genDoc, privVals := factory.RandGenesisDoc(cfg, nValidators, false, testMinPower)
 	css := make([]*State, nPeers)
-	setReactors := make([]DummySettlementReactor, nPeers)
 	logger := consensusLogger()
 
 	var peer0Config *config.Config
@@ -876,7 +865,7 @@ func randConsensusNetWithPeers(
 		app.InitChain(abci.RequestInitChain{Validators: vals})
 		// sm.SaveState(stateDB,state)	//height 1's validatorsInfo already saved in LoadStateFromDBOrGenesisDoc above
 
-		css[i], setReactors[i] = newStateWithConfig(thisConfig, state, privVal, app)
+		css[i] = newStateWithConfig(thisConfig, state, privVal, app)
 		css[i].SetTimeoutTicker(tickerFunc())
 		css[i].SetLogger(logger.With("validator", i, "module", "consensus"))
 	}
@@ -884,9 +873,6 @@ func randConsensusNetWithPeers(
 		for _, dir := range configRootDirs {
 			os.RemoveAll(dir)
 		}
-		for _, setReactor := range setReactors {
-			setReactor.OnStop()
-		}
 	}
 }
 
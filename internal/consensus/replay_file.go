@@ -14,7 +14,6 @@ import (
 
 	"github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/internal/proxy"
-	"github.com/tendermint/tendermint/internal/settlement/parser"
 	sm "github.com/tendermint/tendermint/internal/state"
 	"github.com/tendermint/tendermint/internal/store"
 	"github.com/tendermint/tendermint/libs/log"
@@ -33,12 +32,11 @@ const (
 
 // replay the wal file
 func RunReplayFile(cfg config.BaseConfig, csConfig *config.ConsensusConfig, console bool) {
-	consensusState, setReactor := newConsensusStateForReplay(cfg, csConfig)
+	consensusState := newConsensusStateForReplay(cfg, csConfig)
 
 	if err := consensusState.ReplayFile(csConfig.WalFile(), console); err != nil {
 		tmos.Exit(fmt.Sprintf("Error during consensus replay: %v", err))
 	}
-	setReactor.OnStop()
 }
 
 // Replay msgs in file or start the console
@@ -133,13 +131,8 @@ func (pb *playback) replayReset(count int, newStepSub types.Subscription) error
 	}
 	pb.cs.Wait()
 
-	settlementChan := make(chan parser.SettlementData, 100)
-	logger, _ := log.NewDefaultLogger("plain", "info", false)
-	settlementReactor := DummySettlementReactor{logger: logger, SettlementCh: settlementChan, stopChan: make(chan bool)}
-	settlementReactor.OnStart()
-
 	newCS := NewState(pb.cs.config, pb.genesisState.Copy(), pb.cs.blockExec,
-		pb.cs.blockStore, pb.cs.txNotifier, pb.cs.evpool, settlementChan)
+		pb.cs.blockStore, pb.cs.txNotifier, pb.cs.evpool)
 	newCS.SetEventBus(pb.cs.eventBus)
 	newCS.startForReplay()
 
@@ -293,7 +286,7 @@ func (pb *playback) replayConsoleLoop() int {
 //--------------------------------------------------------------------------------
 
 // convenience for replay mode
-func newConsensusStateForReplay(cfg config.BaseConfig, csConfig *config.ConsensusConfig) (*State, DummySettlementReactor) {
+func newConsensusStateForReplay(cfg config.BaseConfig, csConfig *config.ConsensusConfig) *State {
 	dbType := dbm.BackendType(cfg.DBBackend)
 	// Get BlockStore
 	blockStoreDB, err := dbm.NewDB("blockstore", dbType, cfg.DBDir())
@@ -340,41 +333,9 @@ func newConsensusStateForReplay(cfg config.BaseConfig, csConfig *config.Consensu
 	mempool, evpool := emptyMempool{}, sm.EmptyEvidencePool{}
 	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(), mempool, evpool, blockStore)
 
-	settlementChan := make(chan parser.SettlementData, 100)
-
 	consensusState := NewState(csConfig, state.Copy(), blockExec,
-		blockStore, mempool, evpool, settlementChan)
-
-	settlementReactor := DummySettlementReactor{logger: consensusState.Logger, SettlementCh: settlementChan, stopChan: make(chan bool)}
-	settlementReactor.OnStart()
+		blockStore, mempool, evpool)
 
 	consensusState.SetEventBus(eventBus)
-	return consensusState, settlementReactor
-}
-
-type DummySettlementReactor struct {
-	logger       log.Logger
-	SettlementCh <-chan parser.SettlementData
-	stopChan     chan bool
-}
-
-func (dsr DummySettlementReactor) OnStart() {
-	go func() {
-		dsr.logger.Info("started settlement reactor")
-		for {
-			select {
-			case <-dsr.SettlementCh:
-				//dsr.FormatAndSendCommit(newBlock)
-
-			case <-dsr.stopChan:
-				dsr.logger.Info("Stopping settlement reactor via stopChan")
-				return
-			}
-		}
-
-	}()
-}
-
-func (dsr DummySettlementReactor) OnStop() {
-	dsr.stopChan <- true
+	return consensusState
 }
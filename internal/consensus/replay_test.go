@@ -61,7 +61,7 @@ func startNewStateAndWaitForBlock(t *testing.T, consensusReplayConfig *config.Co
 	require.NoError(t, err)
 	privValidator := loadPrivValidator(consensusReplayConfig)
 	blockStore := store.NewBlockStore(dbm.NewMemDB())
-	cs, setReactor := newStateWithConfigAndBlockStore(
+	cs := newStateWithConfigAndBlockStore(
 		consensusReplayConfig,
 		state,
 		privValidator,
@@ -69,9 +69,6 @@ func startNewStateAndWaitForBlock(t *testing.T, consensusReplayConfig *config.Co
 		blockStore,
 	)
 	cs.SetLogger(logger)
-	defer func() {
-		setReactor.OnStop()
-	}()
 
 	bytes, _ := ioutil.ReadFile(cs.config.WalFile())
 	t.Logf("====== WAL: \n\r%X\n", bytes)
@@ -162,7 +159,7 @@ LOOP:
 		state, err := sm.MakeGenesisStateFromFile(consensusReplayConfig.GenesisFile())
 		require.NoError(t, err)
 		privValidator := loadPrivValidator(consensusReplayConfig)
-		cs, setReactor := newStateWithConfigAndBlockStore(
+		cs := newStateWithConfigAndBlockStore(
 			consensusReplayConfig,
 			state,
 			privValidator,
@@ -170,9 +167,6 @@ LOOP:
 			blockStore,
 		)
 		cs.SetLogger(logger)
-		defer func() {
-			setReactor.OnStop()
-		}()
 
 		// start sending transactions
 		ctx, cancel := context.WithCancel(context.Background())
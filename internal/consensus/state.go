@@ -6,7 +6,6 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
-	"math/big"
 	"os"
 	"runtime/debug"
 	"sort"
@@ -16,11 +15,9 @@ import (
 	"github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/crypto"
 	cstypes "github.com/tendermint/tendermint/internal/consensus/types"
-	"github.com/tendermint/tendermint/internal/evidence"
 	"github.com/tendermint/tendermint/internal/libs/fail"
 	tmstrings "github.com/tendermint/tendermint/internal/libs/strings"
 	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
-	"github.com/tendermint/tendermint/internal/settlement/parser"
 	sm "github.com/tendermint/tendermint/internal/state"
 	tmevents "github.com/tendermint/tendermint/libs/events"
 	tmjson "github.com/tendermint/tendermint/libs/json"
@@ -101,10 +98,6 @@ type State struct {
 	// when it's detected
 	evpool evidencePool
 
-	// send lightblocks to settlement
-	// when block is finalised
-	SettlementCh chan<- parser.SettlementData
-
 	// internal state
 	mtx tmsync.RWMutex
 	cstypes.RoundState
@@ -166,7 +159,6 @@ func NewState(
 	blockStore sm.BlockStore,
 	txNotifier txNotifier,
 	evpool evidencePool,
-	settlementCh chan parser.SettlementData,
 	options ...StateOption,
 ) *State {
 	cs := &State{
@@ -185,7 +177,6 @@ func NewState(
 		evsw:             tmevents.NewEventSwitch(),
 		metrics:          NopMetrics(),
 		onStopCh:         make(chan *cstypes.RoundState),
-		SettlementCh:     settlementCh,
 	}
 
 	// set function defaults (may be overwritten before calling Start)
@@ -1782,71 +1773,6 @@ func (cs *State) finalizeCommit(height int64) {
 	// * cs.Height has been increment to height+1
 	// * cs.Step is now cstypes.RoundStepNewHeight
 	// * cs.StartTime is set to when we will start round0.
-
-	// Finally, we want to send the commit to starknet (exact location TBD)
-	err = cs.PushCommitToSettlement()
-	if err != nil {
-		logger.Error("Failed to construct tx to Cairo: ", err)
-	}
-}
-
-func (cs *State) getLightBlock(height int64) (types.LightBlock, error) {
-	signedHeader, err := evidence.GetSignedHeader(cs.blockStore, height)
-
-	if err != nil {
-		return types.LightBlock{}, err
-	}
-
-	validators := cs.Votes.GetValSet() // .LoadValidators(height)
-	if err != nil {
-		return types.LightBlock{}, err
-	}
-
-	return types.LightBlock{SignedHeader: signedHeader, ValidatorSet: validators}, nil
-}
-
-// we use this to push new block to settlment channel
-func (cs *State) PushCommitToSettlement() (err error) {
-	// First blocks are not sent to starknet, they are required for the consensus initialization
-	if cs.Height <= 3 {
-		logger := cs.Logger
-		logger.Info(fmt.Sprintf("Initialization block %d of 3", cs.Height))
-		return
-	}
-
-	trustedLightBlock, err := cs.getLightBlock(cs.Height - 3)
-	if err != nil {
-		return
-	}
-
-	untrustedLightBlock, err := cs.getLightBlock(cs.Height - 2)
-	if err != nil {
-		return
-	}
-
-	vc := parser.VerificationConfig{
-		CurrentTime:    big.NewInt((time.Now().UnixNano())),
-		MaxClockDrift:  big.NewInt(10),
-		TrustingPeriod: big.NewInt(999999999999999999),
-	}
-
-	proposer := untrustedLightBlock.ProposerAddress
-	var validatorAddress string
-
-	if cs.privValidatorPubKey != nil {
-		validatorAddress = (cs.privValidatorPubKey.Address()).String()
-	} else {
-		validatorAddress = ""
-	}
-
-	inputs, err := parser.ParseInput(trustedLightBlock, untrustedLightBlock, vc)
-	if err != nil {
-		err = fmt.Errorf("failed to format for settlement: %w", err)
-		return
-	}
-	toSend := parser.SettlementData{CommitmentProposer: proposer.String(), ValidatorAddress: validatorAddress, Data: inputs}
-	cs.SettlementCh <- toSend
-	return
 }
 
 func (cs *State) RecordMetrics(height int64, block *types.Block) {
@@ -4,16 +4,24 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"math/big"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
 
 	"github.com/tendermint/tendermint/abci/example/kvstore"
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto/encoding"
 	"github.com/tendermint/tendermint/crypto/pedersen"
 	cstypes "github.com/tendermint/tendermint/internal/consensus/types"
+	"github.com/tendermint/tendermint/internal/mempool"
 	p2pmock "github.com/tendermint/tendermint/internal/p2p/mock"
+	"github.com/tendermint/tendermint/internal/settlement"
+	"github.com/tendermint/tendermint/internal/settlement/parser"
+	"github.com/tendermint/tendermint/internal/settlement/verifier"
 	"github.com/tendermint/tendermint/libs/log"
 	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
@@ -58,10 +66,7 @@ x * TestHalt1 - if we see +2/3 precommits after timing out into new round, we sh
 func TestStateProposerSelection0(t *testing.T) {
 	config := configSetup(t)
 
-	cs1, vss, setReactor, err := randState(config, 4)
-	defer func() {
-		setReactor.OnStop()
-	}()
+	cs1, vss, err := randState(config, 4)
 	require.NoError(t, err)
 
 	height, round := cs1.Height, cs1.Round
@@ -105,10 +110,7 @@ func TestStateProposerSelection0(t *testing.T) {
 func TestStateProposerSelection2(t *testing.T) {
 	config := configSetup(t)
 
-	cs1, vss, setReactor, err := randState(config, 4) // test needs more work for more than 3 validators
-	defer func() {
-		setReactor.OnStop()
-	}()
+	cs1, vss, err := randState(config, 4) // test needs more work for more than 3 validators
 	require.NoError(t, err)
 
 	height := cs1.Height
@@ -149,10 +151,7 @@ func TestStateProposerSelection2(t *testing.T) {
 func TestStateEnterProposeNoPrivValidator(t *testing.T) {
 	config := configSetup(t)
 
-	cs, _, setReactor, err := randState(config, 1)
-	defer func() {
-		setReactor.OnStop()
-	}()
+	cs, _, err := randState(config, 1)
 	require.NoError(t, err)
 	cs.SetPrivValidator(nil)
 	height, round := cs.Height, cs.Round
@@ -174,10 +173,7 @@ func TestStateEnterProposeNoPrivValidator(t *testing.T) {
 func TestStateEnterProposeYesPrivValidator(t *testing.T) {
 	config := configSetup(t)
 
-	cs, _, setReactor, err := randState(config, 1)
-	defer func() {
-		setReactor.OnStop()
-	}()
+	cs, _, err := randState(config, 1)
 	require.NoError(t, err)
 	height, round := cs.Height, cs.Round
 
@@ -210,11 +206,8 @@ func TestStateEnterProposeYesPrivValidator(t *testing.T) {
 func TestStateBadProposal(t *testing.T) {
 	config := configSetup(t)
 
-	cs1, vss, setReactor, err := randState(config, 2)
+	cs1, vss, err := randState(config, 2)
 	require.NoError(t, err)
-	defer func() {
-		setReactor.OnStop()
-	}()
 	height, round := cs1.Height, cs1.Round
 	vs2 := vss[1]
 
@@ -273,11 +266,8 @@ func TestStateBadProposal(t *testing.T) {
 func TestStateOversizedBlock(t *testing.T) {
 	config := configSetup(t)
 
-	cs1, vss, setReactor, err := randState(config, 2)
+	cs1, vss, err := randState(config, 2)
 	require.NoError(t, err)
-	defer func() {
-		setReactor.OnStop()
-	}()
 	cs1.state.ConsensusParams.Block.MaxBytes = 2000
 	height, round := cs1.Height, cs1.Round
 	vs2 := vss[1]
@@ -340,11 +330,8 @@ func TestStateOversizedBlock(t *testing.T) {
 func TestStateFullRound1(t *testing.T) {
 	config := configSetup(t)
 
-	cs, vss, setReactor, err := randState(config, 1)
+	cs, vss, err := randState(config, 1)
 	require.NoError(t, err)
-	defer func() {
-		setReactor.OnStop()
-	}()
 	height, round := cs.Height, cs.Round
 
 	// NOTE: buffer capacity of 0 ensures we can validate prevote and last commit
@@ -385,11 +372,8 @@ func TestStateFullRound1(t *testing.T) {
 func TestStateFullRoundNil(t *testing.T) {
 	config := configSetup(t)
 
-	cs, vss, setReactor, err := randState(config, 1)
+	cs, vss, err := randState(config, 1)
 	require.NoError(t, err)
-	defer func() {
-		setReactor.OnStop()
-	}()
 	height, round := cs.Height, cs.Round
 
 	voteCh := subscribe(cs.eventBus, types.EventQueryVote)
@@ -409,11 +393,8 @@ func TestStateFullRoundNil(t *testing.T) {
 func TestStateFullRound2(t *testing.T) {
 	config := configSetup(t)
 
-	cs1, vss, setReactor, err := randState(config, 2)
+	cs1, vss, err := randState(config, 2)
 	require.NoError(t, err)
-	defer func() {
-		setReactor.OnStop()
-	}()
 	vs2 := vss[1]
 	height, round := cs1.Height, cs1.Round
 
@@ -455,11 +436,8 @@ func TestStateFullRound2(t *testing.T) {
 func TestStateLockNoPOL(t *testing.T) {
 	config := configSetup(t)
 
-	cs1, vss, setReactor, err := randState(config, 2)
+	cs1, vss, err := randState(config, 2)
 	require.NoError(t, err)
-	defer func() {
-		setReactor.OnStop()
-	}()
 	vs2 := vss[1]
 	height, round := cs1.Height, cs1.Round
 
@@ -594,11 +572,8 @@ func TestStateLockNoPOL(t *testing.T) {
 
 	ensureNewTimeout(timeoutWaitCh, height, round, cs1.config.Precommit(round).Nanoseconds())
 
-	cs2, _, setReactor2, err := randState(config, 2) // needed so generated block is different than locked block
+	cs2, _, err := randState(config, 2) // needed so generated block is different than locked block
 	require.NoError(t, err)
-	defer func() {
-		setReactor2.OnStop()
-	}()
 	// before we time out into new round, set next proposal block
 	prop, propBlock := decideProposal(cs2, vs2, vs2.Height, vs2.Round+1)
 	if prop == nil || propBlock == nil {
@@ -649,11 +624,8 @@ func TestStateLockNoPOL(t *testing.T) {
 func TestStateLockPOLRelock(t *testing.T) {
 	config := configSetup(t)
 
-	cs1, vss, setReactor, err := randState(config, 4)
+	cs1, vss, err := randState(config, 4)
 	require.NoError(t, err)
-	defer func() {
-		setReactor.OnStop()
-	}()
 	vs2, vs3, vs4 := vss[1], vss[2], vss[3]
 	height, round := cs1.Height, cs1.Round
 
@@ -697,10 +669,7 @@ func TestStateLockPOLRelock(t *testing.T) {
 	signAddVotes(config, cs1, tmproto.PrecommitType, nil, types.PartSetHeader{}, vs2, vs3, vs4)
 
 	// before we timeout to the new round set the new proposal
-	cs2, setReactor, err := newState(cs1.state, vs2, kvstore.NewApplication())
-	defer func() {
-		setReactor.OnStop()
-	}()
+	cs2, err := newState(cs1.state, vs2, kvstore.NewApplication())
 	require.NoError(t, err)
 
 	prop, propBlock := decideProposal(cs2, vs2, vs2.Height, vs2.Round+1)
@@ -756,11 +725,8 @@ func TestStateLockPOLRelock(t *testing.T) {
 func TestStateLockPOLUnlock(t *testing.T) {
 	config := configSetup(t)
 
-	cs1, vss, setReactor, err := randState(config, 4)
+	cs1, vss, err := randState(config, 4)
 	require.NoError(t, err)
-	defer func() {
-		setReactor.OnStop()
-	}()
 	vs2, vs3, vs4 := vss[1], vss[2], vss[3]
 	height, round := cs1.Height, cs1.Round
 
@@ -852,10 +818,7 @@ func TestStateLockPOLUnlock(t *testing.T) {
 func TestStateLockPOLUnlockOnUnknownBlock(t *testing.T) {
 	config := configSetup(t)
 
-	cs1, vss, setReactor, err := randState(config, 4)
-	defer func() {
-		setReactor.OnStop()
-	}()
+	cs1, vss, err := randState(config, 4)
 	require.NoError(t, err)
 	vs2, vs3, vs4 := vss[1], vss[2], vss[3]
 	height, round := cs1.Height, cs1.Round
@@ -896,10 +859,7 @@ func TestStateLockPOLUnlockOnUnknownBlock(t *testing.T) {
 	signAddVotes(config, cs1, tmproto.PrecommitType, nil, types.PartSetHeader{}, vs2, vs3, vs4)
 
 	// before we timeout to the new round set the new proposal
-	cs2, setReactor, err := newState(cs1.state, vs2, kvstore.NewApplication())
-	defer func() {
-		setReactor.OnStop()
-	}()
+	cs2, err := newState(cs1.state, vs2, kvstore.NewApplication())
 	require.NoError(t, err)
 	prop, propBlock := decideProposal(cs2, vs2, vs2.Height, vs2.Round+1)
 	if prop == nil || propBlock == nil {
@@ -943,11 +903,8 @@ func TestStateLockPOLUnlockOnUnknownBlock(t *testing.T) {
 	signAddVotes(config, cs1, tmproto.PrecommitType, nil, types.PartSetHeader{}, vs2, vs3, vs4)
 
 	// before we timeout to the new round set the new proposal
-	cs3, setReactor, err := newState(cs1.state, vs3, kvstore.NewApplication())
+	cs3, err := newState(cs1.state, vs3, kvstore.NewApplication())
 	require.NoError(t, err)
-	defer func() {
-		setReactor.OnStop()
-	}()
 	prop, propBlock = decideProposal(cs3, vs3, vs3.Height, vs3.Round+1)
 	if prop == nil || propBlock == nil {
 		t.Fatal("Failed to create proposal block with vs2")
@@ -989,11 +946,8 @@ func TestStateLockPOLUnlockOnUnknownBlock(t *testing.T) {
 func TestStateLockPOLSafety1(t *testing.T) {
 	config := configSetup(t)
 
-	cs1, vss, setReactor, err := randState(config, 4)
+	cs1, vss, err := randState(config, 4)
 	require.NoError(t, err)
-	defer func() {
-		setReactor.OnStop()
-	}()
 	vs2, vs3, vs4 := vss[1], vss[2], vss[3]
 	height, round := cs1.Height, cs1.Round
 
@@ -1112,11 +1066,8 @@ func TestStateLockPOLSafety1(t *testing.T) {
 func TestStateLockPOLSafety2(t *testing.T) {
 	config := configSetup(t)
 
-	cs1, vss, setReactor, err := randState(config, 4)
+	cs1, vss, err := randState(config, 4)
 	require.NoError(t, err)
-	defer func() {
-		setReactor.OnStop()
-	}()
 	vs2, vs3, vs4 := vss[1], vss[2], vss[3]
 	height, round := cs1.Height, cs1.Round
 
@@ -1212,11 +1163,8 @@ func TestStateLockPOLSafety2(t *testing.T) {
 func TestProposeValidBlock(t *testing.T) {
 	config := configSetup(t)
 
-	cs1, vss, setReactor, err := randState(config, 4)
+	cs1, vss, err := randState(config, 4)
 	require.NoError(t, err)
-	defer func() {
-		setReactor.OnStop()
-	}()
 	vs2, vs3, vs4 := vss[1], vss[2], vss[3]
 	height, round := cs1.Height, cs1.Round
 
@@ -1306,11 +1254,8 @@ func TestProposeValidBlock(t *testing.T) {
 func TestSetValidBlockOnDelayedPrevote(t *testing.T) {
 	config := configSetup(t)
 
-	cs1, vss, setReactor, err := randState(config, 4)
+	cs1, vss, err := randState(config, 4)
 	require.NoError(t, err)
-	defer func() {
-		setReactor.OnStop()
-	}()
 	vs2, vs3, vs4 := vss[1], vss[2], vss[3]
 	height, round := cs1.Height, cs1.Round
 
@@ -1373,11 +1318,8 @@ func TestSetValidBlockOnDelayedPrevote(t *testing.T) {
 func TestSetValidBlockOnDelayedProposal(t *testing.T) {
 	config := configSetup(t)
 
-	cs1, vss, setReactor, err := randState(config, 4)
+	cs1, vss, err := randState(config, 4)
 	require.NoError(t, err)
-	defer func() {
-		setReactor.OnStop()
-	}()
 	vs2, vs3, vs4 := vss[1], vss[2], vss[3]
 	height, round := cs1.Height, cs1.Round
 
@@ -1434,11 +1376,8 @@ func TestSetValidBlockOnDelayedProposal(t *testing.T) {
 func TestWaitingTimeoutOnNilPolka(t *testing.T) {
 	config := configSetup(t)
 
-	cs1, vss, setReactor, err := randState(config, 4)
+	cs1, vss, err := randState(config, 4)
 	require.NoError(t, err)
-	defer func() {
-		setReactor.OnStop()
-	}()
 	vs2, vs3, vs4 := vss[1], vss[2], vss[3]
 	height, round := cs1.Height, cs1.Round
 
@@ -1461,11 +1400,8 @@ func TestWaitingTimeoutOnNilPolka(t *testing.T) {
 func TestWaitingTimeoutProposeOnNewRound(t *testing.T) {
 	config := configSetup(t)
 
-	cs1, vss, setReactor, err := randState(config, 4)
+	cs1, vss, err := randState(config, 4)
 	require.NoError(t, err)
-	defer func() {
-		setReactor.OnStop()
-	}()
 	vs2, vs3, vs4 := vss[1], vss[2], vss[3]
 	height, round := cs1.Height, cs1.Round
 
@@ -1502,11 +1438,8 @@ func TestWaitingTimeoutProposeOnNewRound(t *testing.T) {
 func TestRoundSkipOnNilPolkaFromHigherRound(t *testing.T) {
 	config := configSetup(t)
 
-	cs1, vss, setReactor, err := randState(config, 4)
+	cs1, vss, err := randState(config, 4)
 	require.NoError(t, err)
-	defer func() {
-		setReactor.OnStop()
-	}()
 
 	vs2, vs3, vs4 := vss[1], vss[2], vss[3]
 	height, round := cs1.Height, cs1.Round
@@ -1545,11 +1478,8 @@ func TestRoundSkipOnNilPolkaFromHigherRound(t *testing.T) {
 func TestWaitTimeoutProposeOnNilPolkaForTheCurrentRound(t *testing.T) {
 	config := configSetup(t)
 
-	cs1, vss, setReactor, err := randState(config, 4)
+	cs1, vss, err := randState(config, 4)
 	require.NoError(t, err)
-	defer func() {
-		setReactor.OnStop()
-	}()
 	vs2, vs3, vs4 := vss[1], vss[2], vss[3]
 	height, round := cs1.Height, int32(1)
 
@@ -1577,11 +1507,8 @@ func TestWaitTimeoutProposeOnNilPolkaForTheCurrentRound(t *testing.T) {
 func TestEmitNewValidBlockEventOnCommitWithoutBlock(t *testing.T) {
 	config := configSetup(t)
 
-	cs1, vss, setReactor, err := randState(config, 4)
+	cs1, vss, err := randState(config, 4)
 	require.NoError(t, err)
-	defer func() {
-		setReactor.OnStop()
-	}()
 	vs2, vs3, vs4 := vss[1], vss[2], vss[3]
 	height, round := cs1.Height, int32(1)
 
@@ -1617,11 +1544,8 @@ func TestEmitNewValidBlockEventOnCommitWithoutBlock(t *testing.T) {
 func TestCommitFromPreviousRound(t *testing.T) {
 	config := configSetup(t)
 
-	cs1, vss, setReactor, err := randState(config, 4)
+	cs1, vss, err := randState(config, 4)
 	require.NoError(t, err)
-	defer func() {
-		setReactor.OnStop()
-	}()
 	vs2, vs3, vs4 := vss[1], vss[2], vss[3]
 	height, round := cs1.Height, int32(1)
 
@@ -1677,11 +1601,8 @@ func TestStartNextHeightCorrectlyAfterTimeout(t *testing.T) {
 	config := configSetup(t)
 
 	config.Consensus.SkipTimeoutCommit = false
-	cs1, vss, setReactor, err := randState(config, 4)
+	cs1, vss, err := randState(config, 4)
 	require.NoError(t, err)
-	defer func() {
-		setReactor.OnStop()
-	}()
 	cs1.txNotifier = &fakeTxNotifier{ch: make(chan struct{})}
 
 	vs2, vs3, vs4 := vss[1], vss[2], vss[3]
@@ -1743,11 +1664,8 @@ func TestResetTimeoutPrecommitUponNewHeight(t *testing.T) {
 	config := configSetup(t)
 
 	config.Consensus.SkipTimeoutCommit = false
-	cs1, vss, setReactor, err := randState(config, 4)
+	cs1, vss, err := randState(config, 4)
 	require.NoError(t, err)
-	defer func() {
-		setReactor.OnStop()
-	}()
 
 	vs2, vs3, vs4 := vss[1], vss[2], vss[3]
 	height, round := cs1.Height, cs1.Round
@@ -1889,11 +1807,8 @@ func TestStateSlashingPrecommits(t *testing.T) {
 func TestStateHalt1(t *testing.T) {
 	config := configSetup(t)
 
-	cs1, vss, setReactor, err := randState(config, 4)
+	cs1, vss, err := randState(config, 4)
 	require.NoError(t, err)
-	defer func() {
-		setReactor.OnStop()
-	}()
 	vs2, vs3, vs4 := vss[1], vss[2], vss[3]
 	height, round := cs1.Height, cs1.Round
 	partSize := types.BlockPartSizeBytes
@@ -1962,11 +1877,8 @@ func TestStateOutputsBlockPartsStats(t *testing.T) {
 	config := configSetup(t)
 
 	// create dummy peer
-	cs, _, setReactor, err := randState(config, 1)
+	cs, _, err := randState(config, 1)
 
-	defer func() {
-		setReactor.OnStop()
-	}()
 	require.NoError(t, err)
 	peer := p2pmock.NewPeer(nil)
 
@@ -2011,10 +1923,7 @@ func TestStateOutputsBlockPartsStats(t *testing.T) {
 func TestStateOutputVoteStats(t *testing.T) {
 	config := configSetup(t)
 
-	cs, vss, setReactor, err := randState(config, 2)
-	defer func() {
-		setReactor.OnStop()
-	}()
+	cs, vss, err := randState(config, 2)
 
 	require.NoError(t, err)
 	// create dummy peer
@@ -2051,11 +1960,8 @@ func TestStateOutputVoteStats(t *testing.T) {
 func TestSignSameVoteTwice(t *testing.T) {
 	config := configSetup(t)
 
-	_, vss, setReactor, err := randState(config, 2)
+	_, vss, err := randState(config, 2)
 	require.NoError(t, err)
-	defer func() {
-		setReactor.OnStop()
-	}()
 
 	randBytes := pedersen.RandFeltBytes(32)
 
@@ -2076,6 +1982,241 @@ func TestSignSameVoteTwice(t *testing.T) {
 	require.Equal(t, vote, vote2)
 }
 
+// startSettlementReactor starts a settlement reactor following the stores of
+// cs, which keeps its submissions in memory. It polls the block store for new
+// blocks unless eventBus is set.
+func startSettlementReactor(
+	t *testing.T,
+	cs *State,
+	policy settlement.Policy,
+	backend settlement.SettlementBackend,
+	eventBus *types.EventBus,
+) {
+	t.Helper()
+
+	r := settlement.NewReactor(
+		log.TestingLogger(),
+		backend,
+		settlement.NewStore(dbm.NewMemDB()),
+		cs.blockStore,
+		cs.blockExec.Store(),
+		policy,
+		cs.privValidatorPubKey.Address().String(),
+	)
+	if eventBus != nil {
+		r.SetEventBus(eventBus)
+	}
+	require.NoError(t, r.Start())
+	t.Cleanup(func() { _ = r.Stop() })
+}
+
+// testSettlementPolicy returns the policy of the test settlement
+// configuration, whose trusting period covers the blocks of a test.
+func testSettlementPolicy(t *testing.T) settlement.Policy {
+	t.Helper()
+
+	policy, err := settlement.NewPolicy(config.TestSettlementConfig())
+	require.NoError(t, err)
+	return policy
+}
+
+// a single validator proposes every block, so it settles every commit
+func TestStateSettlesCommits(t *testing.T) {
+	config := configSetup(t)
+
+	cs, _, err := randState(config, 1)
+	require.NoError(t, err)
+	backend := settlement.NewMockBackend()
+	startSettlementReactor(t, cs, testSettlementPolicy(t), backend, cs.eventBus)
+
+	newBlockCh := subscribe(cs.eventBus, types.EventQueryNewBlock)
+	startTestRound(cs, cs.Height, cs.Round)
+	for i := 0; i < 5; i++ {
+		ensureNewEventOnChannel(newBlockCh)
+	}
+
+	// the commit of height h is stored with block h+1
+	require.Eventually(t, func() bool {
+		return len(backend.Submissions()) >= 3
+	}, ensureTimeout, 10*time.Millisecond)
+	for i, data := range backend.Submissions()[:3] {
+		assert.EqualValues(t, i+2, data.Height)
+		assert.Equal(t, data.CommitmentProposer, data.ValidatorAddress)
+		assert.NotEmpty(t, data.Data)
+	}
+
+	height, err := backend.LatestSettledHeight(context.Background())
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, height, int64(4))
+}
+
+func TestStateSettlesEveryNthCommit(t *testing.T) {
+	cfg := configSetup(t)
+
+	cs, _, err := randState(cfg, 1)
+	require.NoError(t, err)
+	backend := settlement.NewMockBackend()
+	policy := testSettlementPolicy(t)
+	policy.Mode = config.SettlementModeInterval
+	policy.Interval = 2
+	// no event bus, the block store is polled
+	startSettlementReactor(t, cs, policy, backend, nil)
+
+	newBlockCh := subscribe(cs.eventBus, types.EventQueryNewBlock)
+	startTestRound(cs, cs.Height, cs.Round)
+	for i := 0; i < 5; i++ {
+		ensureNewEventOnChannel(newBlockCh)
+	}
+
+	// height 2 is verified against its predecessor, height 4 against 2
+	require.Eventually(t, func() bool {
+		return len(backend.Submissions()) >= 2
+	}, ensureTimeout, 10*time.Millisecond)
+	submissions := backend.Submissions()
+	assert.EqualValues(t, 2, submissions[0].Height)
+	assert.Equal(t, parser.AdjacentFunction, submissions[0].Function)
+	assert.EqualValues(t, 4, submissions[1].Height)
+	assert.Equal(t, parser.NonAdjacentFunction, submissions[1].Function)
+}
+
+// the validator set changes while commits are settled: the power of the
+// validator changes, a validator joins and leaves again, and each commit must
+// be settled with the validators of its own block
+func TestStateSettlesValidatorSetChanges(t *testing.T) {
+	cfg := configSetup(t)
+
+	state, privVals := randGenesisState(cfg, 1, false, 10)
+	cs, err := newState(state, privVals[0], newPersistentKVStore())
+	require.NoError(t, err)
+	backend := settlement.NewMockBackend()
+	startSettlementReactor(t, cs, testSettlementPolicy(t), backend, cs.eventBus)
+
+	pubKey, err := privVals[0].GetPubKey(context.Background())
+	require.NoError(t, err)
+	pubKeyProto, err := encoding.PubKeyToProto(pubKey)
+	require.NoError(t, err)
+	// the new validator does not sign, its power stays below a third
+	newPubKey, err := types.NewMockPV().GetPubKey(context.Background())
+	require.NoError(t, err)
+	newPubKeyProto, err := encoding.PubKeyToProto(newPubKey)
+	require.NoError(t, err)
+
+	newBlockCh := subscribe(cs.eventBus, types.EventQueryNewBlock)
+	startTestRound(cs, cs.Height, cs.Round)
+	// the changes are a few blocks apart, so that each one takes effect at
+	// its own height
+	for _, tx := range [][]byte{
+		kvstore.MakeValSetChangeTx(pubKeyProto, 25),
+		kvstore.MakeValSetChangeTx(newPubKeyProto, 1),
+		kvstore.MakeValSetChangeTx(newPubKeyProto, 0),
+	} {
+		require.NoError(t, assertMempool(cs.txNotifier).CheckTx(context.Background(), tx, nil, mempool.TxInfo{}))
+		for i := 0; i < 3; i++ {
+			ensureNewEventOnChannel(newBlockCh)
+		}
+	}
+	for i := 0; i < 2; i++ {
+		ensureNewEventOnChannel(newBlockCh)
+	}
+
+	stateStore := cs.blockExec.Store()
+	lastHeight := cs.blockStore.Height()
+	vals := make(map[int64]*types.ValidatorSet, lastHeight)
+	var changes []int64
+	for height := int64(1); height <= lastHeight; height++ {
+		vals[height], err = stateStore.LoadValidators(height)
+		require.NoError(t, err)
+		if height > 1 && !bytes.Equal(vals[height].Hash(), vals[height-1].Hash()) {
+			changes = append(changes, height)
+		}
+	}
+	require.Len(t, changes, 3)
+	assert.EqualValues(t, 25, vals[changes[0]].TotalVotingPower())
+	assert.Equal(t, 2, vals[changes[1]].Size())
+	assert.Equal(t, 1, vals[changes[2]].Size())
+
+	require.Eventually(t, func() bool {
+		submissions := backend.Submissions()
+		return len(submissions) > 0 && submissions[len(submissions)-1].Height >= changes[2]
+	}, ensureTimeout, 10*time.Millisecond)
+	settled := make(map[int64]bool)
+	for i, data := range backend.Submissions() {
+		require.NoError(t, verifier.Verify(data.Function, data.Data), "submission %d of height %d", i, data.Height)
+		requireSettledValidators(t, vals[data.Height], settledUntrustedVals(t, data), "submission %d of height %d", i, data.Height)
+		settled[data.Height] = true
+	}
+	for _, height := range changes {
+		assert.True(t, settled[height], "validator set change at height %d was not settled", height)
+	}
+}
+
+// settledUntrustedVals decodes the validators of the untrusted block of a
+// verification submission.
+func settledUntrustedVals(t *testing.T, data parser.SettlementData) verifier.ValidatorSet {
+	t.Helper()
+
+	in, err := verifier.Decode(data.Function, data.Data)
+	require.NoError(t, err)
+	switch in := in.(type) {
+	case *verifier.AdjacentInput:
+		return in.UntrustedVals
+	case *verifier.NonAdjacentInput:
+		return in.UntrustedVals
+	default:
+		require.Failf(t, "unexpected submission", "function %s", data.Function)
+		return verifier.ValidatorSet{}
+	}
+}
+
+// requireSettledValidators requires the settled validators to be the ones of
+// want, in the same order.
+func requireSettledValidators(t *testing.T, want *types.ValidatorSet, got verifier.ValidatorSet, msgAndArgs ...interface{}) {
+	t.Helper()
+
+	require.Len(t, got.Validators, want.Size(), msgAndArgs...)
+	for i, val := range want.Validators {
+		require.Zero(t, new(big.Int).SetBytes(val.Address).Cmp(got.Validators[i].Address), msgAndArgs...)
+		require.Zero(t, big.NewInt(val.VotingPower).Cmp(got.Validators[i].VotingPower), msgAndArgs...)
+	}
+	require.Zero(t, big.NewInt(want.TotalVotingPower()).Cmp(got.TotalVotingPower), msgAndArgs...)
+}
+
+// stuckBackend never returns from Submit before it is canceled.
+type stuckBackend struct {
+	*settlement.MockBackend
+	submitting chan struct{}
+}
+
+func (b *stuckBackend) Submit(ctx context.Context, data parser.SettlementData) (string, error) {
+	select {
+	case b.submitting <- struct{}{}:
+	default:
+	}
+	<-ctx.Done()
+	return "", ctx.Err()
+}
+
+func TestStateNotBlockedBySettlement(t *testing.T) {
+	config := configSetup(t)
+
+	cs, _, err := randState(config, 1)
+	require.NoError(t, err)
+	backend := &stuckBackend{settlement.NewMockBackend(), make(chan struct{}, 1)}
+	startSettlementReactor(t, cs, testSettlementPolicy(t), backend, cs.eventBus)
+
+	newBlockCh := subscribe(cs.eventBus, types.EventQueryNewBlock)
+	startTestRound(cs, cs.Height, cs.Round)
+	for i := 0; i < 3; i++ {
+		ensureNewEventOnChannel(newBlockCh)
+	}
+	<-backend.submitting
+
+	// blocks keep coming while the first submission hangs
+	for i := 0; i < 3; i++ {
+		ensureNewEventOnChannel(newBlockCh)
+	}
+}
+
 // subscribe subscribes test client to the given query and returns a channel with cap = 1.
 func subscribe(eventBus *types.EventBus, q tmpubsub.Query) <-chan tmpubsub.Message {
 	sub, err := eventBus.Subscribe(context.Background(), testSubscriber, q)
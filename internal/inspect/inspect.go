@@ -7,8 +7,10 @@ import (
 	"net"
 
 	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/internal/inspect/rpc"
 	rpccore "github.com/tendermint/tendermint/internal/rpc/core"
+	"github.com/tendermint/tendermint/internal/settlement"
 	"github.com/tendermint/tendermint/internal/state"
 	"github.com/tendermint/tendermint/internal/state/indexer"
 	"github.com/tendermint/tendermint/internal/state/indexer/sink"
@@ -34,6 +36,10 @@ type Inspector struct {
 	indexerService *indexer.Service
 	eventBus       *types.EventBus
 	logger         log.Logger
+
+	// settlement, when set, submits the persisted heights that have not been
+	// settled yet. It polls the block store as no new blocks are produced.
+	settlement *settlement.Group
 }
 
 // New returns an Inspector that serves RPC on the specified BlockStore and StateStore.
@@ -73,12 +79,24 @@ func NewFromConfig(logger log.Logger, cfg *config.Config) (*Inspector, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := crypto.SetCommitmentHash(genDoc.CommitmentHash); err != nil {
+		return nil, err
+	}
 	sinks, err := sink.EventSinksFromConfig(cfg, config.DefaultDBProvider, genDoc.ChainID)
 	if err != nil {
 		return nil, err
 	}
 	ss := state.NewStore(sDB, state.StoreOptions{DiscardABCIResponses: false})
-	return New(cfg.RPC, bs, ss, sinks, logger), nil
+	ins := New(cfg.RPC, bs, ss, sinks, logger)
+
+	// without a validator, the reactors only follow settlement and never
+	// submit, see settlement.Reactor
+	ins.settlement, err = settlement.NewGroupFromConfig(logger.With("module", "settlement"), cfg,
+		config.DefaultDBProvider, bs, ss, "", nil, settlement.NopMetrics())
+	if err != nil {
+		return nil, err
+	}
+	return ins, nil
 }
 
 // Run starts the Inspector servers and blocks until the servers shut down. The passed
@@ -104,6 +122,19 @@ func (ins *Inspector) Run(ctx context.Context) error {
 			ins.logger.Error("indexer service stopped with error", "err", err)
 		}
 	}()
+	if ins.settlement != nil {
+		defer ins.settlement.Close()
+		err = ins.settlement.Start()
+		if err != nil {
+			return fmt.Errorf("error starting settlement reactors: %s", err)
+		}
+		defer func() {
+			err := ins.settlement.Stop()
+			if err != nil {
+				ins.logger.Error("settlement reactors stopped with error", "err", err)
+			}
+		}()
+	}
 	return startRPCServers(ctx, ins.config, ins.logger, ins.routes)
 }
 
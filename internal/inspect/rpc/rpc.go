@@ -44,7 +44,7 @@ func Routes(cfg config.RPCConfig, s state.Store, bs state.BlockStore, es []index
 		"block_results":    server.NewRPCFunc(env.BlockResults, "height", true),
 		"commit":           server.NewRPCFunc(env.Commit, "height", true),
 		"validators":       server.NewRPCFunc(env.Validators, "height,page,per_page", true),
-		"tx":               server.NewRPCFunc(env.Tx, "hash,prove", true),
+		"tx":               server.NewRPCFunc(env.Tx, "hash,prove,format", true),
 		"tx_search":        server.NewRPCFunc(env.TxSearch, "query,prove,page,per_page,order_by", false),
 		"block_search":     server.NewRPCFunc(env.BlockSearch, "query,page,per_page,order_by", false),
 	}
@@ -3,6 +3,7 @@ package confix_test
 import (
 	"bytes"
 	"context"
+	"os"
 	"strings"
 	"testing"
 
@@ -97,3 +98,81 @@ func TestApplyFixes(t *testing.T) {
 		})
 	})
 }
+
+func TestApplyFixesSettlement(t *testing.T) {
+	doc := mustParseConfig(t, "testdata/v35-config.toml")
+	if doc.First("settlement") != nil {
+		t.Fatal("v0.35 config should not have a [settlement] section")
+	}
+	if err := confix.ApplyFixes(context.Background(), doc); err != nil {
+		t.Fatalf("ApplyFixes: unexpected error: %v", err)
+	}
+	for _, key := range []string{"backend", "trusting-period", "max-clock-drift", "batch-size", "start-height", "max-fee"} {
+		if doc.First("settlement", key) == nil {
+			t.Errorf("settlement.%s was not added", key)
+		}
+	}
+
+	// existing settings are kept
+	bz, err := os.ReadFile("testdata/v35-config.toml")
+	if err != nil {
+		t.Fatalf("Reading config: %v", err)
+	}
+	bz = append(bz, "\n[settlement]\nbatch-size = 5\n"...)
+	doc, err = tomledit.Parse(bytes.NewReader(bz))
+	if err != nil {
+		t.Fatalf("Parsing config: %v", err)
+	}
+	if err := confix.ApplyFixes(context.Background(), doc); err != nil {
+		t.Fatalf("ApplyFixes: unexpected error: %v", err)
+	}
+	if got := doc.First("settlement", "batch-size").Value.String(); got != "5" {
+		t.Errorf("settlement.batch-size: got %q, want 5", got)
+	}
+}
+
+func TestApplyFixesStarknet(t *testing.T) {
+	bz, err := os.ReadFile("testdata/v35-config.toml")
+	if err != nil {
+		t.Fatalf("Reading config: %v", err)
+	}
+	bz = append(bz, `
+[starknet]
+account = "devnet"
+wallets-dir = ".starknet_accounts"
+feeder-gateway-url = "http://127.0.0.1:5050/"
+gateway-url = "http://127.0.0.1:5050/"
+network = "alpha-goerli"
+wallet = "starkware.starknet.wallets.open_zeppelin.OpenZeppelinAccount"
+`...)
+	doc, err := tomledit.Parse(bytes.NewReader(bz))
+	if err != nil {
+		t.Fatalf("Parsing config: %v", err)
+	}
+	if err := confix.ApplyFixes(context.Background(), doc); err != nil {
+		t.Fatalf("ApplyFixes: unexpected error: %v", err)
+	}
+
+	for _, key := range []string{"account", "wallets-dir", "feeder-gateway-url", "gateway-url", "network", "wallet"} {
+		if doc.First("starknet", key) != nil {
+			t.Errorf("starknet.%s was not removed", key)
+		}
+	}
+	for _, key := range []string{"account-address", "private-key-path", "signer", "chain-id"} {
+		if doc.First("starknet", key) == nil {
+			t.Errorf("starknet.%s was not added", key)
+		}
+	}
+	if got := doc.First("starknet", "rpc-url").Value.String(); got != `"http://127.0.0.1:5050/rpc"` {
+		t.Errorf("starknet.rpc-url: got %s, want the JSON-RPC endpoint of the gateway", got)
+	}
+
+	// a v0.35 config without [starknet] is left alone
+	doc = mustParseConfig(t, "testdata/v35-config.toml")
+	if err := confix.ApplyFixes(context.Background(), doc); err != nil {
+		t.Fatalf("ApplyFixes: unexpected error: %v", err)
+	}
+	if doc.First("starknet") != nil {
+		t.Error("a [starknet] section was added")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/creachadair/tomledit"
@@ -224,4 +225,178 @@ var plan = transform.Plan{
 			return fmt.Errorf("unrecognized value: %v", idx.KeyValue)
 		}),
 	},
+	{
+		// Slush: settlement verification parameters used to be hard-coded.
+		Desc: "Add [settlement] settings",
+		T: transform.Func(func(_ context.Context, doc *tomledit.Document) error {
+			var sec *tomledit.Section
+			if dst := transform.FindTable(doc, "settlement"); dst == nil {
+				sec = &tomledit.Section{
+					Heading: &parser.Heading{
+						Block: parser.Comments{
+							"#######################################################",
+							"###         Settlement Configuration Options        ###",
+							"#######################################################",
+						},
+						Name: parser.Key{"settlement"},
+					},
+				}
+				doc.Sections = append(doc.Sections, sec)
+			} else {
+				sec = dst.Section
+			}
+
+			for _, kv := range []*parser.KeyValue{
+				{
+					Block: parser.Comments{"Backend used to submit commits for settlement: protostar | starknet | file | mock"},
+					Name:  parser.Key{"backend"},
+					Value: parser.MustValue(`"protostar"`),
+				},
+				{
+					Block: parser.Comments{`Path of the file the "file" backend writes to`},
+					Name:  parser.Key{"file-path"},
+					Value: parser.MustValue(`"data/settlement.jsonl"`),
+				},
+				{
+					Block: parser.Comments{"Heights that are settled: every-height | interval | validator-set-change"},
+					Name:  parser.Key{"mode"},
+					Value: parser.MustValue(`"every-height"`),
+				},
+				{
+					Block: parser.Comments{`Number of heights between two settled heights in the "interval" mode`},
+					Name:  parser.Key{"interval"},
+					Value: parser.MustValue("100"),
+				},
+				{
+					Block: parser.Comments{"Fraction of the last settled validator set that must have signed a skipped-to height."},
+					Name:  parser.Key{"trust-level"},
+					Value: parser.MustValue(`"1/3"`),
+				},
+				{
+					Block: parser.Comments{"How long a validator waits for the validator before it to settle a height."},
+					Name:  parser.Key{"failover-timeout"},
+					Value: parser.MustValue(`"5m0s"`),
+				},
+				{
+					Block: parser.Comments{"First height that is settled. 0 settles from the first height with a predecessor."},
+					Name:  parser.Key{"start-height"},
+					Value: parser.MustValue("0"),
+				},
+				{
+					Block: parser.Comments{"Trusting period the verifier checks the trusted header against."},
+					Name:  parser.Key{"trusting-period"},
+					Value: parser.MustValue(`"168h0m0s"`),
+				},
+				{
+					Block: parser.Comments{"Maximum clock drift the verifier allows."},
+					Name:  parser.Key{"max-clock-drift"},
+					Value: parser.MustValue(`"10s"`),
+				},
+				{
+					Block: parser.Comments{`Number of commits batched into one multicall by the "protostar" backend`},
+					Name:  parser.Key{"batch-size"},
+					Value: parser.MustValue("10"),
+				},
+				{
+					Block: parser.Comments{"Verifier entry points"},
+					Name:  parser.Key{"adjacent-function"},
+					Value: parser.MustValue(`"externalVerifyAdjacent"`),
+				},
+				{
+					Name:  parser.Key{"non-adjacent-function"},
+					Value: parser.MustValue(`"externalVerifyNonAdjacent"`),
+				},
+				{
+					Block: parser.Comments{"Maximum fee of a settlement transaction, in wei. Empty means no cap."},
+					Name:  parser.Key{"max-fee"},
+					Value: parser.MustValue(`""`),
+				},
+				{
+					Block: parser.Comments{"Margin applied to the estimated fee of a settlement transaction, once more on every retry."},
+					Name:  parser.Key{"fee-multiplier"},
+					Value: parser.MustValue("1.5"),
+				},
+				{
+					Block: parser.Comments{"Number of times a settlement transaction is sent before giving up."},
+					Name:  parser.Key{"max-attempts"},
+					Value: parser.MustValue("5"),
+				},
+				{
+					Block: parser.Comments{"Wait before the first retry, doubled after each retry up to retry-max-backoff."},
+					Name:  parser.Key{"retry-backoff"},
+					Value: parser.MustValue(`"5s"`),
+				},
+				{
+					Name:  parser.Key{"retry-max-backoff"},
+					Value: parser.MustValue(`"2m0s"`),
+				},
+			} {
+				transform.InsertMapping(sec, kv, false)
+			}
+			return nil
+		}),
+	},
+	{
+		// Slush: [starknet] used to configure the starknet CLI, with an account
+		// name, a wallets directory and gateways, before the native JSON-RPC
+		// client.
+		Desc: "Rename the [starknet] CLI settings to the JSON-RPC client settings",
+		T: transform.Func(func(_ context.Context, doc *tomledit.Document) error {
+			tab := transform.FindTable(doc, "starknet")
+			if tab == nil {
+				return nil
+			}
+
+			// starknet-devnet serves its JSON-RPC endpoint under the gateway
+			if gw := doc.First("starknet", "gateway-url"); gw != nil && doc.First("starknet", "rpc-url") == nil {
+				url, err := strconv.Unquote(gw.Value.String())
+				if err != nil {
+					return fmt.Errorf("starknet.gateway-url: %w", err)
+				}
+				gw.Name = parser.Key{"rpc-url"}
+				gw.Value = parser.MustValue(strconv.Quote(strings.TrimSuffix(url, "/") + "/rpc"))
+				gw.Block = parser.Comments{"URL of the StarkNet JSON-RPC endpoint used by the native settlement client"}
+			}
+			// the account is now given by its address and key rather than by
+			// its name in the wallets directory, and the chain id is queried
+			// from the endpoint unless set
+			for _, key := range []string{"account", "account-dir", "wallets-dir", "wallet", "feeder-gateway-url", "gateway-url", "network"} {
+				doc.First("starknet", key).Remove()
+			}
+
+			for _, kv := range []*parser.KeyValue{
+				{
+					Block: parser.Comments{"URL of the StarkNet JSON-RPC endpoint used by the native settlement client"},
+					Name:  parser.Key{"rpc-url"},
+					Value: parser.MustValue(`"http://127.0.0.1:5050/rpc"`),
+				},
+				{
+					Block: parser.Comments{"Address of the account contract sending settlement transactions"},
+					Name:  parser.Key{"account-address"},
+					Value: parser.MustValue(`"0x347be35996a21f6bf0623e75dbce52baba918ad5ae8d83b6f416045ab22961a"`),
+				},
+				{
+					Block: parser.Comments{"Path to the file containing the hex encoded private key of the account"},
+					Name:  parser.Key{"private-key-path"},
+					Value: parser.MustValue(`"seed42pkey"`),
+				},
+				{
+					Block: parser.Comments{`How settlement transactions are signed: "key-file" | "priv-validator"`},
+					Name:  parser.Key{"signer"},
+					Value: parser.MustValue(`"key-file"`),
+				},
+				{
+					Block: parser.Comments{
+						"Chain id of the StarkNet network, as a hex or decimal felt.",
+						"If empty, it is queried from the RPC endpoint.",
+					},
+					Name:  parser.Key{"chain-id"},
+					Value: parser.MustValue(`""`),
+				},
+			} {
+				transform.InsertMapping(tab.Section, kv, false)
+			}
+			return nil
+		}),
+	},
 }
@@ -48,6 +48,7 @@ type evilConn struct {
 	locEphPriv *[32]byte
 	remEphPub  *[32]byte
 	privKey    crypto.PrivKey
+	remKeyType string
 
 	readStep   int
 	writeStep  int
@@ -68,6 +69,8 @@ func newEvilConn(shareEphKey, badEphKey, shareAuthSignature, badAuthSignature bo
 		locEphPriv: locEphPriv,
 		remEphPub:  &rep,
 		privKey:    privKey,
+		// the type of the key of the connection under test
+		remKeyType: ed25519.KeyType,
 
 		shareEphKey:        shareEphKey,
 		badEphKey:          badEphKey,
@@ -113,39 +116,14 @@ func (c *evilConn) Read(data []byte) (n int, err error) {
 
 		return n, nil
 	case 1:
-		signature := c.signChallenge()
-		if !c.badAuthSignature {
-			pkpb, err := encoding.PubKeyToProto(c.privKey.PubKey())
-			if err != nil {
-				panic(err)
-			}
-			bz, err := protoio.MarshalDelimited(&tmp2p.AuthSigMessage{PubKey: pkpb, Sig: signature})
-			if err != nil {
-				panic(err)
-			}
-			n, err = c.secretConn.Write(bz)
-			if err != nil {
-				panic(err)
-			}
-			if c.readOffset > len(c.buffer.Bytes()) {
-				return len(data), nil
-			}
-			copy(data, c.buffer.Bytes()[c.readOffset:])
-		} else {
-			bz, err := protoio.MarshalDelimited(&gogotypes.BytesValue{Value: []byte("select * from users;")})
-			if err != nil {
-				panic(err)
-			}
-			n, err = c.secretConn.Write(bz)
-			if err != nil {
-				panic(err)
-			}
-			if c.readOffset > len(c.buffer.Bytes()) {
-				return len(data), nil
-			}
-			copy(data, c.buffer.Bytes())
+		if c.buffer == nil {
+			c.writeHandshake()
+		}
+		if c.readOffset >= len(c.buffer.Bytes()) {
+			return 0, io.EOF
 		}
-		c.readOffset += len(data)
+		n = copy(data, c.buffer.Bytes()[c.readOffset:])
+		c.readOffset += n
 		return n, nil
 	default:
 		return 0, io.EOF
@@ -182,6 +160,40 @@ func (c *evilConn) Close() error {
 	return nil
 }
 
+// writeHandshake writes the encrypted key type and auth signature messages to
+// the buffer of the secret connection.
+func (c *evilConn) writeHandshake() {
+	signature := c.signChallenge()
+	bz, err := protoio.MarshalDelimited(&gogotypes.StringValue{Value: c.privKey.Type()})
+	if err != nil {
+		panic(err)
+	}
+	if _, err := c.secretConn.Write(bz); err != nil {
+		panic(err)
+	}
+
+	if !c.badAuthSignature {
+		pkpb, err := encoding.PubKeyToProto(c.privKey.PubKey())
+		if err != nil {
+			panic(err)
+		}
+		bz, err = protoio.MarshalDelimited(&tmp2p.AuthSigMessage{PubKey: pkpb, Sig: signature})
+		if err != nil {
+			panic(err)
+		}
+	} else {
+		bz, err = protoio.MarshalDelimited(&gogotypes.BytesValue{Value: []byte("select * from users;")})
+		if err != nil {
+			panic(err)
+		}
+		// sealed again with the first nonce, which the connection refuses
+		c.secretConn.sendNonce = new([aeadNonceSize]byte)
+	}
+	if _, err := c.secretConn.Write(bz); err != nil {
+		panic(err)
+	}
+}
+
 func (c *evilConn) signChallenge() []byte {
 	// Sort by lexical order.
 	loEphPub, hiEphPub := sort32(c.locEphPub, c.remEphPub)
@@ -208,6 +220,14 @@ func (c *evilConn) signChallenge() []byte {
 	// from the dhSecret).
 	recvSecret, sendSecret := deriveSecrets(dhSecret, locIsLeast)
 
+	if locIsLeast {
+		transcript.AppendMessage(labelLowerKeyType, []byte(c.privKey.Type()))
+		transcript.AppendMessage(labelUpperKeyType, []byte(c.remKeyType))
+	} else {
+		transcript.AppendMessage(labelLowerKeyType, []byte(c.remKeyType))
+		transcript.AppendMessage(labelUpperKeyType, []byte(c.privKey.Type()))
+	}
+
 	const challengeSize = 32
 	var challenge [challengeSize]byte
 	transcript.ExtractBytes(challenge[:], labelSecretConnectionMac)
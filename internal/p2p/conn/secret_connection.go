@@ -24,6 +24,7 @@ import (
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/crypto/encoding"
+	"github.com/tendermint/tendermint/crypto/stark"
 	"github.com/tendermint/tendermint/internal/libs/protoio"
 	tmsync "github.com/tendermint/tendermint/internal/libs/sync"
 	"github.com/tendermint/tendermint/libs/async"
@@ -42,6 +43,8 @@ const (
 	labelEphemeralLowerPublicKey = "EPHEMERAL_LOWER_PUBLIC_KEY"
 	labelEphemeralUpperPublicKey = "EPHEMERAL_UPPER_PUBLIC_KEY"
 	labelDHSecret                = "DH_SECRET"
+	labelLowerKeyType            = "LOWER_KEY_TYPE"
+	labelUpperKeyType            = "UPPER_KEY_TYPE"
 	labelSecretConnectionMac     = "SECRET_CONNECTION_MAC"
 )
 
@@ -49,6 +52,12 @@ var (
 	ErrSmallOrderRemotePubKey = errors.New("detected low order point from remote peer")
 
 	secretConnKeyAndChallengeGen = []byte("TENDERMINT_SECRET_CONNECTION_KEY_AND_CHALLENGE_GEN")
+
+	// the types of the node keys a SecretConnection authenticates
+	supportedKeyTypes = map[string]bool{
+		ed25519.KeyType: true,
+		stark.KeyType:   true,
+	}
 )
 
 // SecretConnection implements net.Conn.
@@ -130,10 +139,6 @@ func MakeSecretConnection(conn io.ReadWriteCloser, locPrivKey crypto.PrivKey) (*
 	// from the dhSecret).
 	recvSecret, sendSecret := deriveSecrets(dhSecret, locIsLeast)
 
-	const challengeSize = 32
-	var challenge [challengeSize]byte
-	transcript.ExtractBytes(challenge[:], labelSecretConnectionMac)
-
 	sendAead, err := chacha20poly1305.New(sendSecret[:])
 	if err != nil {
 		return nil, errors.New("invalid send SecretConnection Key")
@@ -152,6 +157,30 @@ func MakeSecretConnection(conn io.ReadWriteCloser, locPrivKey crypto.PrivKey) (*
 		sendAead:   sendAead,
 	}
 
+	// Negotiate the types of the node keys: each side announces the type of
+	// its key, which the other must support. The types are bound to the
+	// challenge, so that they cannot be tampered with. Every connection
+	// exchanges them, ed25519 ones included, which is a break of p2p protocol
+	// 8, see version.P2PProtocol.
+	remKeyType, err := shareKeyType(sc, locPrivKey.Type())
+	if err != nil {
+		return nil, err
+	}
+	if !supportedKeyTypes[remKeyType] {
+		return nil, fmt.Errorf("unsupported remote key type %q", remKeyType)
+	}
+	if locIsLeast {
+		transcript.AppendMessage(labelLowerKeyType, []byte(locPrivKey.Type()))
+		transcript.AppendMessage(labelUpperKeyType, []byte(remKeyType))
+	} else {
+		transcript.AppendMessage(labelLowerKeyType, []byte(remKeyType))
+		transcript.AppendMessage(labelUpperKeyType, []byte(locPrivKey.Type()))
+	}
+
+	const challengeSize = 32
+	var challenge [challengeSize]byte
+	transcript.ExtractBytes(challenge[:], labelSecretConnectionMac)
+
 	// Sign the challenge bytes for authentication.
 	locSignature, err := signChallenge(&challenge, locPrivKey)
 	if err != nil {
@@ -166,8 +195,14 @@ func MakeSecretConnection(conn io.ReadWriteCloser, locPrivKey crypto.PrivKey) (*
 
 	remPubKey, remSignature := authSigMsg.Key, authSigMsg.Sig
 
-	if _, ok := remPubKey.(ed25519.PubKey); !ok {
-		return nil, fmt.Errorf("expected ed25519.pubkey, got %T", remPubKey)
+	if remPubKey.Type() != remKeyType {
+		return nil, fmt.Errorf("expected %s pubkey, got %s", remKeyType, remPubKey.Type())
+	}
+	// a stark key off the curve cannot verify signatures
+	if pk, ok := remPubKey.(stark.PubKey); ok {
+		if pub := pk.MakeFull(); pub.X == nil {
+			return nil, errors.New("remote stark pubkey is not on the curve")
+		}
 	}
 
 	if !remPubKey.VerifySignature(challenge[:], remSignature) {
@@ -334,6 +369,37 @@ func shareEphPubKey(conn io.ReadWriter, locEphPub *[32]byte) (remEphPub *[32]byt
 	return &_remEphPub, nil
 }
 
+func shareKeyType(conn io.ReadWriter, locKeyType string) (remKeyType string, err error) {
+
+	// Send our key type and receive theirs in tandem.
+	var trs, _ = async.Parallel(
+		func(_ int) (val interface{}, abort bool, err error) {
+			_, err = protoio.NewDelimitedWriter(conn).WriteMsg(&gogotypes.StringValue{Value: locKeyType})
+			if err != nil {
+				return nil, true, err // abort
+			}
+			return nil, false, nil
+		},
+		func(_ int) (val interface{}, abort bool, err error) {
+			var keyType gogotypes.StringValue
+			_, err = protoio.NewDelimitedReader(conn, 1024).ReadMsg(&keyType)
+			if err != nil {
+				return nil, true, err // abort
+			}
+			return keyType.Value, false, nil
+		},
+	)
+
+	// If error:
+	if trs.FirstError() != nil {
+		err = trs.FirstError()
+		return
+	}
+
+	// Otherwise:
+	return trs.FirstValue().(string), nil
+}
+
 func deriveSecrets(
 	dhSecret *[32]byte,
 	locIsLeast bool,
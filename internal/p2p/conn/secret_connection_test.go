@@ -56,6 +56,14 @@ func (pk privKeyWithNilPubKey) PubKey() crypto.PubKey           { return nil }
 func (pk privKeyWithNilPubKey) Equals(pk2 crypto.PrivKey) bool  { return pk.orig.Equals(pk2) }
 func (pk privKeyWithNilPubKey) Type() string                    { return "privKeyWithNilPubKey" }
 
+// privKeyWithPubKey is a stark key which claims pubKey as its public key.
+type privKeyWithPubKey struct {
+	stark.PrivKey
+	pubKey crypto.PubKey
+}
+
+func (pk privKeyWithPubKey) PubKey() crypto.PubKey { return pk.pubKey }
+
 func TestSecretConnectionHandshake(t *testing.T) {
 	fooSecConn, barSecConn := makeSecretConnPair(t)
 	if err := fooSecConn.Close(); err != nil {
@@ -286,6 +294,54 @@ func TestNonEd25519Pubkey(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestInvalidStarkPubkey(t *testing.T) {
+	offCurve := make(stark.PubKey, stark.PubKeySize)
+	offCurve[stark.PubKeySize-1] = 1
+	testCases := []struct {
+		pubKey crypto.PubKey
+		errMsg string
+	}{
+		0: {offCurve, "not on the curve"},
+		// the key must be of the announced type
+		1: {ed25519.GenPrivKey().PubKey(), "expected stark pubkey, got ed25519"},
+	}
+
+	for i, tc := range testCases {
+		var fooConn, barConn = makeKVStoreConnPair()
+		var fooPrvKey = ed25519.GenPrivKey()
+		var barPrvKey = privKeyWithPubKey{stark.GenPrivKey(), tc.pubKey}
+
+		go MakeSecretConnection(barConn, barPrvKey) //nolint:errcheck // ignore for tests
+
+		_, err := MakeSecretConnection(fooConn, fooPrvKey)
+		require.Error(t, err, "testCase%d failed", i)
+		assert.Contains(t, err.Error(), tc.errMsg, "testCase%d failed", i)
+		closeAll(t, fooConn, barConn)()
+	}
+}
+
+func TestMixedKeyTypes(t *testing.T) {
+	var fooConn, barConn = makeKVStoreConnPair()
+	t.Cleanup(closeAll(t, fooConn, barConn))
+
+	var fooPrvKey = ed25519.GenPrivKey()
+	var barPrvKey = stark.GenPrivKey()
+
+	errc := make(chan error, 1)
+	go func() {
+		barSecConn, err := MakeSecretConnection(barConn, barPrvKey)
+		if err == nil && !barSecConn.RemotePubKey().Equals(fooPrvKey.PubKey()) {
+			err = fmt.Errorf("unexpected remote pubkey %v", barSecConn.RemotePubKey())
+		}
+		errc <- err
+	}()
+
+	fooSecConn, err := MakeSecretConnection(fooConn, fooPrvKey)
+	require.NoError(t, err)
+	assert.True(t, fooSecConn.RemotePubKey().Equals(barPrvKey.PubKey()))
+	require.NoError(t, <-errc)
+}
+
 func writeLots(t *testing.T, wg *sync.WaitGroup, conn io.Writer, txt string, n int) {
 	defer wg.Done()
 	for i := 0; i < n; i++ {
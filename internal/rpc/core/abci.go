@@ -8,7 +8,10 @@ import (
 	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
 )
 
-// ABCIQuery queries the application for some information.
+// ABCIQuery queries the application for some information. With prove and the
+// felt format, the proof of the value is also returned in the layout of the
+// verifier contract, which requires the application to prove it with a single
+// simple:v operation.
 // More: https://docs.tendermint.com/master/rpc/#/ABCI/abci_query
 func (env *Environment) ABCIQuery(
 	ctx *rpctypes.Context,
@@ -16,7 +19,13 @@ func (env *Environment) ABCIQuery(
 	data bytes.HexBytes,
 	height int64,
 	prove bool,
+	format string,
 ) (*coretypes.ResultABCIQuery, error) {
+	felt, err := feltFormat(format, prove)
+	if err != nil {
+		return nil, err
+	}
+
 	resQuery, err := env.ProxyAppQuery.QuerySync(ctx.Context(), abci.RequestQuery{
 		Path:   path,
 		Data:   data,
@@ -27,7 +36,13 @@ func (env *Environment) ABCIQuery(
 		return nil, err
 	}
 
-	return &coretypes.ResultABCIQuery{Response: *resQuery}, nil
+	result := &coretypes.ResultABCIQuery{Response: *resQuery}
+	if felt && resQuery.IsOK() {
+		if result.FeltProof, err = env.queryFeltProof(resQuery); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
 }
 
 // ABCIInfo gets some info about the application.
@@ -11,6 +11,7 @@ import (
 	"github.com/tendermint/tendermint/internal/mempool"
 	"github.com/tendermint/tendermint/internal/p2p"
 	"github.com/tendermint/tendermint/internal/proxy"
+	"github.com/tendermint/tendermint/internal/settlement"
 	sm "github.com/tendermint/tendermint/internal/state"
 	"github.com/tendermint/tendermint/internal/state/indexer"
 	"github.com/tendermint/tendermint/internal/statesync"
@@ -69,6 +70,11 @@ type peerManager interface {
 	Addresses(types.NodeID) []p2p.NodeAddress
 }
 
+type settlementTargets interface {
+	Reactor(target string) *settlement.Reactor
+	Targets() []string
+}
+
 //----------------------------------------------
 // Environment contains objects and interfaces used by the RPC. It is expected
 // to be setup once during startup.
@@ -78,12 +84,13 @@ type Environment struct {
 	ProxyAppMempool proxy.AppConnMempool
 
 	// interfaces defined in types and above
-	StateStore       sm.Store
-	BlockStore       sm.BlockStore
-	EvidencePool     sm.EvidencePool
-	ConsensusState   consensusState
-	ConsensusReactor consensusReactor
-	P2PPeers         peers
+	StateStore        sm.Store
+	BlockStore        sm.BlockStore
+	EvidencePool      sm.EvidencePool
+	ConsensusState    consensusState
+	ConsensusReactor  consensusReactor
+	SettlementTargets settlementTargets
+	P2PPeers          peers
 
 	// Legacy p2p stack
 	P2PTransport transport
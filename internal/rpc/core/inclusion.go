@@ -0,0 +1,95 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/internal/settlement/parser"
+	"github.com/tendermint/tendermint/rpc/coretypes"
+	"github.com/tendermint/tendermint/types"
+)
+
+// ProofFormatFelt is the proof format of the tx and abci_query routes that
+// returns inclusion proofs in the felt layout of the verifyInclusion view of
+// the verifier contract, along with their calldata.
+const ProofFormatFelt = "felt"
+
+// feltFormat reports whether format requests felt proofs, which requires
+// prove.
+func feltFormat(format string, prove bool) (bool, error) {
+	switch format {
+	case "":
+		return false, nil
+	case ProofFormatFelt:
+		if !prove {
+			return false, fmt.Errorf("proof format %q requires prove", format)
+		}
+		return true, nil
+	default:
+		return false, fmt.Errorf("unknown proof format %q", format)
+	}
+}
+
+// txFeltProof returns the felt proof of the transaction of proof, included in
+// the block at height.
+func (env *Environment) txFeltProof(height int64, proof types.TxProof) (*coretypes.FeltProof, error) {
+	meta := env.BlockStore.LoadBlockMeta(height)
+	if meta == nil {
+		return nil, fmt.Errorf("%w: block at height %d", coretypes.ErrHeightNotAvailable, height)
+	}
+	return feltProof(proof.FeltProof(&meta.Header))
+}
+
+// resultFeltProof returns the felt proof of the result of the transaction at
+// index in the block at height. It is nil while the next block, which commits
+// to the results, is not stored.
+func (env *Environment) resultFeltProof(height int64, index int) (*coretypes.FeltProof, error) {
+	meta := env.BlockStore.LoadBlockMeta(height + 1)
+	if meta == nil {
+		return nil, nil
+	}
+	responses, err := env.StateStore.LoadABCIResponses(height)
+	if err != nil {
+		return nil, err
+	}
+	return feltProof(types.NewResults(responses.DeliverTxs).FeltProof(&meta.Header, index))
+}
+
+// queryFeltProof returns the felt proof of the value of a query. The proof
+// must be a single simple:v operation whose root is the application hash,
+// which the block following the height of the query commits to.
+func (env *Environment) queryFeltProof(res *abci.ResponseQuery) (*coretypes.FeltProof, error) {
+	if res.ProofOps == nil || len(res.ProofOps.Ops) != 1 || res.ProofOps.Ops[0].Type != merkle.ProofOpValue {
+		return nil, errors.New("felt proofs require a single simple:v proof operation")
+	}
+	op, err := merkle.ValueOpDecoder(res.ProofOps.Ops[0])
+	if err != nil {
+		return nil, err
+	}
+	valueOp := op.(merkle.ValueOp)
+	if !bytes.Equal(valueOp.GetKey(), res.Key) {
+		return nil, fmt.Errorf("proof is for key %X, not %X", valueOp.GetKey(), res.Key)
+	}
+
+	meta := env.BlockStore.LoadBlockMeta(res.Height + 1)
+	if meta == nil {
+		return nil, fmt.Errorf("%w: the app hash of height %d is committed to by the next block",
+			coretypes.ErrHeightNotAvailable, res.Height)
+	}
+	return feltProof(types.NewFeltProof(&meta.Header, types.HeaderFieldAppHash,
+		valueOp.Leaf(res.Value), *valueOp.Proof))
+}
+
+func feltProof(proof types.FeltProof, err error) (*coretypes.FeltProof, error) {
+	if err != nil {
+		return nil, fmt.Errorf("failed to build felt proof: %w", err)
+	}
+	calldata, err := parser.ParseInclusionInput(proof)
+	if err != nil {
+		return nil, err
+	}
+	return &coretypes.FeltProof{Proof: proof, Calldata: calldata}, nil
+}
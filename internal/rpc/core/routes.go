@@ -31,7 +31,7 @@ func (env *Environment) GetRoutes() RoutesMap {
 		"commit":               rpc.NewRPCFunc(env.Commit, "height", true),
 		"check_tx":             rpc.NewRPCFunc(env.CheckTx, "tx", true),
 		"remove_tx":            rpc.NewRPCFunc(env.RemoveTx, "txkey", false),
-		"tx":                   rpc.NewRPCFunc(env.Tx, "hash,prove", true),
+		"tx":                   rpc.NewRPCFunc(env.Tx, "hash,prove,format", true),
 		"tx_search":            rpc.NewRPCFunc(env.TxSearch, "query,prove,page,per_page,order_by", false),
 		"block_search":         rpc.NewRPCFunc(env.BlockSearch, "query,page,per_page,order_by", false),
 		"validators":           rpc.NewRPCFunc(env.Validators, "height,page,per_page", true),
@@ -47,11 +47,15 @@ func (env *Environment) GetRoutes() RoutesMap {
 		"broadcast_tx_async":  rpc.NewRPCFunc(env.BroadcastTxAsync, "tx", false),
 
 		// abci API
-		"abci_query": rpc.NewRPCFunc(env.ABCIQuery, "path,data,height,prove", false),
+		"abci_query": rpc.NewRPCFunc(env.ABCIQuery, "path,data,height,prove,format", false),
 		"abci_info":  rpc.NewRPCFunc(env.ABCIInfo, "", true),
 
 		// evidence API
 		"broadcast_evidence": rpc.NewRPCFunc(env.BroadcastEvidence, "evidence", false),
+
+		// settlement API
+		"settlement_status": rpc.NewRPCFunc(env.SettlementStatus, "target", false),
+		"settlement_tx":     rpc.NewRPCFunc(env.SettlementTx, "height,target", false),
 	}
 }
 
@@ -0,0 +1,103 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tendermint/tendermint/internal/settlement"
+	"github.com/tendermint/tendermint/rpc/coretypes"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+// SettlementStatus returns the progress of the settlement of the chain's
+// commits on the given target. If no target is provided, it returns the one
+// of the first target.
+func (env *Environment) SettlementStatus(ctx *rpctypes.Context, target string) (*coretypes.ResultSettlementStatus, error) {
+	r, err := env.settlementReactor(target)
+	if err != nil {
+		return nil, err
+	}
+	status, err := r.Status()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load settlement status: %w", err)
+	}
+
+	result := &coretypes.ResultSettlementStatus{
+		Target:              status.Target,
+		Targets:             env.SettlementTargets.Targets(),
+		LastEnqueuedHeight:  status.LastEnqueuedHeight,
+		LastSubmittedHeight: status.LastSubmittedHeight,
+		LastAcceptedHeight:  status.LastAcceptedHeight,
+		PendingTxs:          make([]coretypes.ResultSettlementTx, 0, len(status.Pending)),
+	}
+	for _, rec := range status.Pending {
+		tx := settlementTx(rec)
+		tx.Calldata = nil
+		result.PendingTxs = append(result.PendingTxs, tx)
+	}
+	if status.LastError != nil {
+		result.LastError = status.LastError.Error()
+	}
+	return result, nil
+}
+
+// SettlementTx returns the settlement record of the given height on the given
+// target. If no height is provided, it returns the one of the last height
+// handed to settlement. If no target is provided, it returns the one of the
+// first target.
+func (env *Environment) SettlementTx(ctx *rpctypes.Context, heightPtr *int64, target string) (*coretypes.ResultSettlementTx, error) {
+	r, err := env.settlementReactor(target)
+	if err != nil {
+		return nil, err
+	}
+	store := r.Store()
+
+	var height int64
+	if heightPtr != nil {
+		height = *heightPtr
+		if height <= 0 {
+			return nil, fmt.Errorf("%w (requested height: %d)", coretypes.ErrZeroOrNegativeHeight, height)
+		}
+	} else {
+		last, err := store.LastHeight()
+		if err != nil {
+			return nil, err
+		}
+		height = last
+	}
+
+	rec, err := store.Load(height)
+	if err != nil {
+		return nil, err
+	}
+	if rec == nil {
+		return nil, fmt.Errorf("%w: no settlement record at height %d", coretypes.ErrHeightNotAvailable, height)
+	}
+	tx := settlementTx(rec)
+	return &tx, nil
+}
+
+// settlementReactor returns the reactor settling on target, the first one if
+// target is empty.
+func (env *Environment) settlementReactor(target string) (*settlement.Reactor, error) {
+	if env.SettlementTargets == nil {
+		return nil, errors.New("settlement is not enabled")
+	}
+	r := env.SettlementTargets.Reactor(target)
+	if r == nil {
+		return nil, fmt.Errorf("unknown settlement target %q", target)
+	}
+	return r, nil
+}
+
+func settlementTx(rec *settlement.Record) coretypes.ResultSettlementTx {
+	return coretypes.ResultSettlementTx{
+		Height:    rec.Height,
+		Status:    string(rec.Status),
+		TxHash:    rec.TxHash,
+		Proposer:  rec.Data.CommitmentProposer,
+		Submitter: rec.Data.Submitter,
+		Function:  rec.Data.Function,
+		Calldata:  rec.Data.Data,
+	}
+}
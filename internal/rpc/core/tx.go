@@ -16,9 +16,15 @@ import (
 
 // Tx allows you to query the transaction results. `nil` could mean the
 // transaction is in the mempool, invalidated, or was not sent in the first
-// place.
+// place. With prove and the felt format, the proofs of the transaction and
+// of its result are also returned in the layout of the verifier contract.
 // More: https://docs.tendermint.com/master/rpc/#/Info/tx
-func (env *Environment) Tx(ctx *rpctypes.Context, hash bytes.HexBytes, prove bool) (*coretypes.ResultTx, error) {
+func (env *Environment) Tx(ctx *rpctypes.Context, hash bytes.HexBytes, prove bool, format string) (*coretypes.ResultTx, error) {
+	felt, err := feltFormat(format, prove)
+	if err != nil {
+		return nil, err
+	}
+
 	// if index is disabled, return error
 
 	// N.B. The hash parameter is HexBytes so that the reflective parameter
@@ -45,14 +51,23 @@ func (env *Environment) Tx(ctx *rpctypes.Context, hash bytes.HexBytes, prove boo
 				proof = block.Data.Txs.Proof(int(index)) // XXX: overflow on 32-bit machines
 			}
 
-			return &coretypes.ResultTx{
+			result := &coretypes.ResultTx{
 				Hash:     hash,
 				Height:   height,
 				Index:    index,
 				TxResult: r.Result,
 				Tx:       r.Tx,
 				Proof:    proof,
-			}, nil
+			}
+			if felt {
+				if result.FeltProof, err = env.txFeltProof(height, proof); err != nil {
+					return nil, err
+				}
+				if result.FeltResultProof, err = env.resultFeltProof(height, int(index)); err != nil {
+					return nil, err
+				}
+			}
+			return result, nil
 		}
 	}
 
@@ -0,0 +1,165 @@
+package settlement
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"path/filepath"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/internal/settlement/parser"
+	"github.com/tendermint/tendermint/internal/settlement/retry"
+	"github.com/tendermint/tendermint/internal/settlement/starknet"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// verifyFunction returns the verifier entry point data is settled with.
+// Data recorded before non adjacent settlement existed names none.
+func verifyFunction(data parser.SettlementData) string {
+	if data.Function == "" {
+		return parser.AdjacentFunction
+	}
+	return data.Function
+}
+
+// SubmissionStatus is the status of a commit submitted for settlement.
+type SubmissionStatus string
+
+const (
+	// StatusUnknown is returned for submissions the backend does not know of.
+	StatusUnknown SubmissionStatus = "unknown"
+	// StatusPending is returned for submissions that are not final yet.
+	StatusPending SubmissionStatus = "pending"
+	// StatusAccepted is returned for submissions the verifier accepted.
+	StatusAccepted SubmissionStatus = "accepted"
+	// StatusRejected is returned for submissions the verifier rejected.
+	StatusRejected SubmissionStatus = "rejected"
+)
+
+// SettlementBackend submits commits to the verifier contract and reports on
+// their progress. Implementations must be safe for concurrent use.
+type SettlementBackend interface {
+	// Submit submits data for settlement and returns an identifier of the
+	// submission, usually a transaction hash. Backends that batch commits, or
+	// leave the submission to another validator, return an empty identifier.
+	Submit(ctx context.Context, data parser.SettlementData) (string, error)
+
+	// Status returns the status of a submission identified by the value
+	// returned from Submit.
+	Status(ctx context.Context, txHash string) (SubmissionStatus, error)
+
+	// LatestSettledHeight returns the height of the latest block the verifier
+	// settled, whichever node sent it, or 0 if there is none. It does not
+	// account for the submissions of this node that are not settled yet.
+	LatestSettledHeight(ctx context.Context) (int64, error)
+}
+
+// FeeBackend is implemented by backends that can report the fee paid by their
+// transactions.
+type FeeBackend interface {
+	// ActualFee returns the fee paid by the transaction txHash, in wei.
+	ActualFee(ctx context.Context, txHash string) (*big.Int, error)
+}
+
+// HeaderBackend is implemented by backends that can read the headers the
+// verifier settled.
+type HeaderBackend interface {
+	// SettledHeader returns the hash of the header the verifier settled at
+	// height, zero if it settled none.
+	SettledHeader(ctx context.Context, height int64) (*big.Int, error)
+}
+
+// VerifierAdmin is implemented by backends that can deploy and initialize the
+// verifier contract of their target.
+type VerifierAdmin interface {
+	SettlementBackend
+
+	// DeployVerifier declares the verifier class compiled at contractPath and
+	// deploys a contract of it, owned by the account of the backend, the only
+	// one allowed to initialize it. It waits for both transactions to be
+	// accepted.
+	DeployVerifier(ctx context.Context, contractPath string) (classHash, contractAddress string, err error)
+
+	// InitVerifier initializes the verifier with inputs, the calldata of
+	// parser.InitFunction, and waits for the transaction to be accepted.
+	InitVerifier(ctx context.Context, inputs []string) (txHash string, err error)
+
+	// TrustedHeader returns the hash and height of the header the verifier
+	// was initialized with.
+	TrustedHeader(ctx context.Context) (hash *big.Int, height int64, err error)
+}
+
+// NewVerifierAdmin returns the backend of target as a VerifierAdmin, see
+// NewBackend. Targets without a verifier yet can only deploy one.
+func NewVerifierAdmin(
+	logger log.Logger,
+	cfg *config.Config,
+	target *config.SettlementTargetConfig,
+	signer starknet.Signer,
+) (VerifierAdmin, error) {
+	t := *target
+	if t.VerifierAddress == "" {
+		t.VerifierAddress = "0x0"
+	}
+	backend, err := NewBackend(logger, cfg, &t, signer)
+	if err != nil {
+		return nil, err
+	}
+	admin, ok := backend.(VerifierAdmin)
+	if !ok {
+		return nil, fmt.Errorf("settlement backend %q cannot deploy or initialize a verifier", target.Backend)
+	}
+	return admin, nil
+}
+
+// ErrMaxFeeExceeded is returned by Submit when the transaction would cost more
+// than the maximum fee. The commit can be submitted again later, when fees
+// are lower.
+var ErrMaxFeeExceeded = retry.ErrMaxFeeExceeded
+
+// NewBackend returns the backend of target, one of the settlement targets of
+// cfg. The starknet backend signs transactions with signer if the target
+// selects the priv validator signer, signer may be nil otherwise.
+func NewBackend(
+	logger log.Logger,
+	cfg *config.Config,
+	target *config.SettlementTargetConfig,
+	signer starknet.Signer,
+) (SettlementBackend, error) {
+	policy, err := retry.NewPolicy(cfg.Settlement)
+	if err != nil {
+		return nil, err
+	}
+
+	// the verifier contract hashes headers, votes and validator sets with
+	// pedersen
+	if target.Backend != config.SettlementBackendMock && crypto.CommitmentHash() != crypto.CommitmentHashPedersen {
+		return nil, fmt.Errorf("settlement backend %q requires the %s commitment hash, the chain uses %s",
+			target.Backend, crypto.CommitmentHashPedersen, crypto.CommitmentHash())
+	}
+
+	switch target.Backend {
+	case config.SettlementBackendProtostar:
+		return NewProtostarBackend(logger, target.Protostar(), target.VerifierAddress,
+			filepath.Join(cfg.DBDir(), targetName("multicalls", target.Name)), target.BatchSize, policy), nil
+	case config.SettlementBackendStarknet:
+		return NewStarknetBackend(logger, target.Starknet(), target.VerifierAddress, signer, policy)
+	case config.SettlementBackendFile:
+		return NewFileBackend(target.FilePath)
+	case config.SettlementBackendMock:
+		return NewMockBackend(), nil
+	default:
+		return nil, fmt.Errorf("unknown settlement backend %q", target.Backend)
+	}
+}
+
+// targetName returns the name of the database or file called base of the
+// target named name. The default target keeps the names of single target
+// nodes.
+func targetName(base, name string) string {
+	if name == config.DefaultSettlementTarget {
+		return base
+	}
+	return base + "-" + name
+}
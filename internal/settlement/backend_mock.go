@@ -0,0 +1,96 @@
+package settlement
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/tendermint/tendermint/internal/settlement/parser"
+)
+
+// MockBackend is an in-memory backend for tests. Every submission is
+// accepted immediately, unless an error is set with SetSubmitError.
+type MockBackend struct {
+	mtx         sync.Mutex
+	submissions []parser.SettlementData
+	ids         map[string]int
+	submitErr   error
+}
+
+var (
+	_ SettlementBackend = (*MockBackend)(nil)
+	_ FeeBackend        = (*MockBackend)(nil)
+)
+
+// NewMockBackend returns an empty MockBackend.
+func NewMockBackend() *MockBackend {
+	return &MockBackend{ids: make(map[string]int)}
+}
+
+// Submit records data and returns a sequential identifier.
+func (b *MockBackend) Submit(ctx context.Context, data parser.SettlementData) (string, error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if b.submitErr != nil {
+		return "", b.submitErr
+	}
+	id := fmt.Sprintf("0x%x", len(b.submissions)+1)
+	b.ids[id] = len(b.submissions)
+	b.submissions = append(b.submissions, data)
+	return id, nil
+}
+
+// Status implements SettlementBackend.
+func (b *MockBackend) Status(ctx context.Context, txHash string) (SubmissionStatus, error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if _, ok := b.ids[txHash]; ok {
+		return StatusAccepted, nil
+	}
+	return StatusUnknown, nil
+}
+
+// ActualFee returns a fee of 1 wei for every known submission.
+func (b *MockBackend) ActualFee(ctx context.Context, txHash string) (*big.Int, error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if _, ok := b.ids[txHash]; !ok {
+		return nil, fmt.Errorf("unknown submission %s", txHash)
+	}
+	return big.NewInt(1), nil
+}
+
+// LatestSettledHeight returns the highest height submitted so far.
+func (b *MockBackend) LatestSettledHeight(ctx context.Context) (int64, error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	var height int64
+	for _, data := range b.submissions {
+		if data.Height > height {
+			height = data.Height
+		}
+	}
+	return height, nil
+}
+
+// Submissions returns a copy of everything submitted so far, in order.
+func (b *MockBackend) Submissions() []parser.SettlementData {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	return append([]parser.SettlementData(nil), b.submissions...)
+}
+
+// SetSubmitError makes subsequent submissions fail with err, or succeed again
+// if err is nil.
+func (b *MockBackend) SetSubmitError(err error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	b.submitErr = err
+}
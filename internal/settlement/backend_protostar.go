@@ -0,0 +1,128 @@
+package settlement
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/settlement/parser"
+	"github.com/tendermint/tendermint/internal/settlement/protostar"
+	"github.com/tendermint/tendermint/internal/settlement/retry"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// ProtostarBackend settles commits through the protostar CLI, batching them
+// into multicalls. protostar waits for a multicall to be accepted before
+// returning, so the backend only ever reports accepted submissions.
+type ProtostarBackend struct {
+	logger          log.Logger
+	cfg             *config.ProtostarConfig
+	verifierAddress string
+	policy          retry.Policy
+
+	mtx      sync.Mutex
+	batcher  *protostar.Batcher
+	accepted map[string]struct{}
+}
+
+var (
+	_ SettlementBackend = (*ProtostarBackend)(nil)
+	_ HeaderBackend     = (*ProtostarBackend)(nil)
+	_ VerifierAdmin     = (*ProtostarBackend)(nil)
+)
+
+// NewProtostarBackend returns a backend invoking the verifier at
+// verifierAddress with protostar, keeping its multicall files in dir. Calls
+// are sent in multicalls of batchSize calls, within the fee and retry bounds
+// of policy.
+func NewProtostarBackend(
+	logger log.Logger,
+	cfg *config.ProtostarConfig,
+	verifierAddress, dir string,
+	batchSize int,
+	policy retry.Policy,
+) *ProtostarBackend {
+	return &ProtostarBackend{
+		logger:          logger,
+		cfg:             cfg,
+		verifierAddress: verifierAddress,
+		policy:          policy,
+		batcher:         protostar.NewBatcher(dir, batchSize, policy),
+		accepted:        make(map[string]struct{}),
+	}
+}
+
+// Submit records data in the current multicall.
+func (b *ProtostarBackend) Submit(ctx context.Context, data parser.SettlementData) (string, error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	txHash, err := b.batcher.Invoke(ctx, b.logger, b.cfg, b.verifierAddress, verifyFunction(data), data)
+	if err != nil {
+		return "", fmt.Errorf("failed to invoke starknet contract: %w", err)
+	}
+	if txHash != "" {
+		b.accepted[txHash] = struct{}{}
+	}
+	return txHash, nil
+}
+
+// Status implements SettlementBackend.
+func (b *ProtostarBackend) Status(ctx context.Context, txHash string) (SubmissionStatus, error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if _, ok := b.accepted[txHash]; ok {
+		return StatusAccepted, nil
+	}
+	return StatusUnknown, nil
+}
+
+// LatestSettledHeight returns the latest height recorded by the verifier,
+// whichever validator settled it.
+func (b *ProtostarBackend) LatestSettledHeight(ctx context.Context) (int64, error) {
+	height, err := protostar.LatestSettledHeight(b.cfg, b.verifierAddress)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query latest settled height: %w", err)
+	}
+	return height, nil
+}
+
+// SettledHeader returns the hash of the header the verifier settled at height,
+// zero if it settled none.
+func (b *ProtostarBackend) SettledHeader(ctx context.Context, height int64) (*big.Int, error) {
+	hash, err := protostar.SettledHeader(b.cfg, b.verifierAddress, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query settled header at height %d: %w", height, err)
+	}
+	return hash, nil
+}
+
+// DeployVerifier declares and deploys a verifier with protostar, owned by the
+// account of the protostar configuration.
+func (b *ProtostarBackend) DeployVerifier(ctx context.Context, contractPath string) (string, string, error) {
+	classHash, txHash, err := protostar.Declare(ctx, b.logger, b.cfg, b.policy, contractPath)
+	if err != nil {
+		return "", "", err
+	}
+	b.logger.Info("declared verifier", "class_hash", classHash, "tx_hash", txHash)
+
+	address, txHash, err := protostar.Deploy(ctx, b.logger, b.cfg, b.policy, classHash, []string{b.cfg.AccountAddress})
+	if err != nil {
+		return "", "", err
+	}
+	b.logger.Info("deployed verifier", "address", address, "tx_hash", txHash)
+	return classHash, address, nil
+}
+
+// InitVerifier invokes parser.InitFunction with protostar.
+func (b *ProtostarBackend) InitVerifier(ctx context.Context, inputs []string) (string, error) {
+	return protostar.InvokeAndWait(ctx, b.logger, b.cfg, b.policy, b.verifierAddress, parser.InitFunction, inputs)
+}
+
+// TrustedHeader calls the trusted header view with protostar.
+func (b *ProtostarBackend) TrustedHeader(ctx context.Context) (*big.Int, int64, error) {
+	return protostar.TrustedHeader(b.cfg, b.verifierAddress)
+}
@@ -0,0 +1,346 @@
+package settlement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/settlement/parser"
+	"github.com/tendermint/tendermint/internal/settlement/retry"
+	"github.com/tendermint/tendermint/internal/settlement/starknet"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// StarknetBackend settles commits through the native StarkNet JSON-RPC
+// client, sending one invoke transaction per commit.
+type StarknetBackend struct {
+	logger   log.Logger
+	cfg      *config.StarknetConfig
+	verifier *big.Int
+	client   *starknet.Client
+	signer   starknet.Signer
+	policy   retry.Policy
+
+	// mtx guards the fields below, and serializes the reads of the account
+	// nonce with the transactions sent with it
+	mtx       sync.Mutex
+	account   *starknet.Account
+	submitted map[string]int64 // transaction hash -> settled height
+}
+
+var (
+	_ SettlementBackend = (*StarknetBackend)(nil)
+	_ FeeBackend        = (*StarknetBackend)(nil)
+	_ HeaderBackend     = (*StarknetBackend)(nil)
+	_ VerifierAdmin     = (*StarknetBackend)(nil)
+)
+
+// NewStarknetBackend returns a backend invoking the verifier at
+// verifierAddress from the account configured in cfg, paying fees and
+// retrying transactions as set by policy. Transactions are signed with the
+// key file of cfg, or with signer if cfg selects the priv validator signer.
+// The node is not contacted until the first submission.
+func NewStarknetBackend(
+	logger log.Logger,
+	cfg *config.StarknetConfig,
+	verifierAddress string,
+	signer starknet.Signer,
+	policy retry.Policy,
+) (*StarknetBackend, error) {
+	verifier, err := starknet.ParseFelt(verifierAddress)
+	if err != nil {
+		return nil, fmt.Errorf("invalid verifier address: %w", err)
+	}
+	return &StarknetBackend{
+		logger:    logger,
+		cfg:       cfg,
+		verifier:  verifier,
+		client:    starknet.NewClient(cfg.RPCURL),
+		signer:    signer,
+		policy:    policy,
+		submitted: make(map[string]int64),
+	}, nil
+}
+
+// getAccount returns the account sending transactions, creating it on first
+// use. The caller must hold b.mtx.
+func (b *StarknetBackend) getAccount(ctx context.Context) (*starknet.Account, error) {
+	if b.account != nil {
+		return b.account, nil
+	}
+
+	address, err := starknet.ParseFelt(b.cfg.AccountAddress)
+	if err != nil {
+		return nil, fmt.Errorf("invalid account address: %w", err)
+	}
+	signer := b.signer
+	if b.cfg.Signer != config.StarknetSignerPrivValidator {
+		key, err := starknet.LoadPrivateKey(b.cfg.PrivateKeyPath)
+		if err != nil {
+			return nil, err
+		}
+		signer = starknet.NewKeySigner(key)
+	} else if signer == nil {
+		return nil, errors.New("the priv-validator signer requires a node with a priv validator")
+	}
+	var chainID *big.Int
+	if b.cfg.ChainID != "" {
+		if chainID, err = starknet.ParseFelt(b.cfg.ChainID); err != nil {
+			return nil, fmt.Errorf("invalid chain id: %w", err)
+		}
+	}
+
+	account, err := starknet.NewAccount(ctx, b.client, address, signer, chainID)
+	if err != nil {
+		return nil, err
+	}
+	b.account = account
+	return account, nil
+}
+
+// Submit sends an invoke transaction settling data if this node is its
+// submitter, and returns its hash without waiting for it to be accepted. The
+// fee is estimated before every attempt, and transient errors are retried as
+// set by the policy of the backend. Only the attempts are serialized with the
+// other transactions of the account, not the backoff between them.
+func (b *StarknetBackend) Submit(ctx context.Context, data parser.SettlementData) (string, error) {
+	if !data.IsSubmitter() {
+		return "", nil
+	}
+
+	calldata, err := starknet.ParseFelts(data.Data)
+	if err != nil {
+		return "", fmt.Errorf("invalid calldata: %w", err)
+	}
+
+	b.mtx.Lock()
+	account, err := b.getAccount(ctx)
+	b.mtx.Unlock()
+	if err != nil {
+		return "", err
+	}
+	calls := []starknet.FunctionCall{{
+		ContractAddress:    b.verifier,
+		EntryPointSelector: starknet.Selector(verifyFunction(data)),
+		Calldata:           calldata,
+	}}
+
+	var txHash *big.Int
+	err = b.policy.Do(ctx, b.logger.With("height", data.Height), "settlement transaction",
+		func(ctx context.Context, attempt int) error {
+			// the nonce is read when the transaction is built
+			b.mtx.Lock()
+			defer b.mtx.Unlock()
+
+			estimate, err := account.EstimateFee(ctx, calls)
+			if err != nil {
+				return classifyStarknetError(fmt.Errorf("failed to estimate fee: %w", err))
+			}
+			maxFee, err := b.policy.Fee(estimate.OverallFee, attempt)
+			if err != nil {
+				return err
+			}
+			txHash, err = account.Execute(ctx, calls, maxFee)
+			if err != nil {
+				return classifyStarknetError(fmt.Errorf("failed to invoke starknet contract: %w", err))
+			}
+			b.submitted[starknet.FeltHex(txHash)] = data.Height
+			return nil
+		})
+	if err != nil {
+		return "", err
+	}
+
+	hash := starknet.FeltHex(txHash)
+	b.logger.Info("sent settlement transaction", "height", data.Height, "tx_hash", hash)
+	return hash, nil
+}
+
+// classifyStarknetError marks the errors of the starknet node that may go
+// away on their own as retryable: the node being unreachable or overloaded, a
+// nonce taken by a concurrent transaction, or a fee that went up since it was
+// estimated. Errors returned with the reason of a failure, like a transaction
+// failing validation or an account without enough funds, are fatal.
+func classifyStarknetError(err error) error {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	var rpcErr *starknet.RPCError
+	if !errors.As(err, &rpcErr) {
+		return retry.Retryable(err)
+	}
+	switch rpcErr.Code {
+	case starknet.ErrCodeInvalidTransactionNonce, starknet.ErrCodeInsufficientMaxFee:
+		return retry.Retryable(err)
+	default:
+		return err
+	}
+}
+
+// Status queries the receipt of the transaction txHash.
+func (b *StarknetBackend) Status(ctx context.Context, txHash string) (SubmissionStatus, error) {
+	hash, err := starknet.ParseFelt(txHash)
+	if err != nil {
+		return StatusUnknown, fmt.Errorf("invalid transaction hash: %w", err)
+	}
+
+	b.mtx.Lock()
+	_, known := b.submitted[starknet.FeltHex(hash)]
+	b.mtx.Unlock()
+
+	receipt, err := b.client.TransactionReceipt(ctx, hash)
+	switch {
+	case starknet.IsTxnHashNotFound(err) && known:
+		// freshly sent transactions are not always visible to the node yet
+		return StatusPending, nil
+	case starknet.IsTxnHashNotFound(err):
+		return StatusUnknown, nil
+	case err != nil:
+		return StatusUnknown, err
+	}
+
+	switch {
+	case receipt.Status.Accepted():
+		return StatusAccepted, nil
+	case receipt.Status == starknet.StatusRejected:
+		return StatusRejected, nil
+	default:
+		return StatusPending, nil
+	}
+}
+
+// ActualFee returns the fee paid by the transaction txHash, from its receipt.
+func (b *StarknetBackend) ActualFee(ctx context.Context, txHash string) (*big.Int, error) {
+	hash, err := starknet.ParseFelt(txHash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction hash: %w", err)
+	}
+	receipt, err := b.client.TransactionReceipt(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	return receipt.ActualFee, nil
+}
+
+// LatestSettledHeight returns the latest height recorded by the verifier,
+// whichever validator settled it.
+func (b *StarknetBackend) LatestSettledHeight(ctx context.Context) (int64, error) {
+	result, err := b.client.Call(ctx, starknet.FunctionCall{
+		ContractAddress:    b.verifier,
+		EntryPointSelector: starknet.Selector(parser.LatestHeightFunction),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to query latest settled height: %w", err)
+	}
+	if len(result) != 1 || !result[0].IsInt64() {
+		return 0, fmt.Errorf("invalid latest settled height %v", result)
+	}
+	return result[0].Int64(), nil
+}
+
+// SettledHeader returns the hash of the header the verifier settled at height,
+// zero if it settled none.
+func (b *StarknetBackend) SettledHeader(ctx context.Context, height int64) (*big.Int, error) {
+	result, err := b.client.Call(ctx, starknet.FunctionCall{
+		ContractAddress:    b.verifier,
+		EntryPointSelector: starknet.Selector(parser.SettledHeaderFunction),
+		Calldata:           []*big.Int{big.NewInt(height)},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query settled header at height %d: %w", height, err)
+	}
+	if len(result) != 2 {
+		return nil, fmt.Errorf("invalid settled header %v", result)
+	}
+	return result[0], nil
+}
+
+// DeployVerifier declares the verifier class and deploys a contract of it
+// through the universal deployer, from the account of the backend.
+func (b *StarknetBackend) DeployVerifier(ctx context.Context, contractPath string) (string, string, error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	account, err := b.getAccount(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	classHash, txHash, err := account.Declare(ctx, contractPath)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to declare verifier: %w", err)
+	}
+	if err := b.waitAccepted(ctx, txHash); err != nil {
+		return "", "", fmt.Errorf("failed to declare verifier: %w", err)
+	}
+	b.logger.Info("declared verifier", "class_hash", starknet.FeltHex(classHash), "tx_hash", starknet.FeltHex(txHash))
+
+	address, txHash, err := account.Deploy(ctx, classHash, []*big.Int{account.Address()})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to deploy verifier: %w", err)
+	}
+	if err := b.waitAccepted(ctx, txHash); err != nil {
+		return "", "", fmt.Errorf("failed to deploy verifier: %w", err)
+	}
+	b.logger.Info("deployed verifier", "address", starknet.FeltHex(address), "tx_hash", starknet.FeltHex(txHash))
+	return starknet.FeltHex(classHash), starknet.FeltHex(address), nil
+}
+
+// InitVerifier invokes parser.InitFunction from the account of the backend.
+func (b *StarknetBackend) InitVerifier(ctx context.Context, inputs []string) (string, error) {
+	calldata, err := starknet.ParseFelts(inputs)
+	if err != nil {
+		return "", fmt.Errorf("invalid calldata: %w", err)
+	}
+
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	account, err := b.getAccount(ctx)
+	if err != nil {
+		return "", err
+	}
+	txHash, err := account.Invoke(ctx, b.verifier, parser.InitFunction, calldata)
+	if err != nil {
+		return "", fmt.Errorf("failed to invoke starknet contract: %w", err)
+	}
+	if err := b.waitAccepted(ctx, txHash); err != nil {
+		return "", err
+	}
+	return starknet.FeltHex(txHash), nil
+}
+
+// TrustedHeader calls the trusted header view of the verifier.
+func (b *StarknetBackend) TrustedHeader(ctx context.Context) (*big.Int, int64, error) {
+	result, err := b.client.Call(ctx, starknet.FunctionCall{
+		ContractAddress:    b.verifier,
+		EntryPointSelector: starknet.Selector(parser.TrustedHeaderFunction),
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query trusted header: %w", err)
+	}
+	if len(result) != 2 || !result[1].IsInt64() {
+		return nil, 0, fmt.Errorf("invalid trusted header %v", result)
+	}
+	return result[0], result[1].Int64(), nil
+}
+
+// adminPollInterval is how often the transactions of VerifierAdmin are
+// looked up while waiting for them to be accepted.
+const adminPollInterval = 2 * time.Second
+
+// waitAccepted waits for the transaction txHash to be final, and fails if it
+// was rejected.
+func (b *StarknetBackend) waitAccepted(ctx context.Context, txHash *big.Int) error {
+	receipt, err := b.client.WaitForTransaction(ctx, txHash, adminPollInterval)
+	if err != nil {
+		return err
+	}
+	if !receipt.Status.Accepted() {
+		return fmt.Errorf("transaction %s was rejected: %s", starknet.FeltHex(txHash), receipt.StatusData)
+	}
+	return nil
+}
@@ -0,0 +1,366 @@
+package settlement
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/internal/settlement/parser"
+	sm "github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/internal/state/mocks"
+	tmstore "github.com/tendermint/tendermint/internal/store"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestNewBackend(t *testing.T) {
+	testCases := []struct {
+		backend  string
+		expected SettlementBackend
+		err      bool
+	}{
+		0: {backend: config.SettlementBackendProtostar, expected: &ProtostarBackend{}},
+		1: {backend: config.SettlementBackendStarknet, expected: &StarknetBackend{}},
+		2: {backend: config.SettlementBackendFile, expected: &FileBackend{}},
+		3: {backend: config.SettlementBackendMock, expected: &MockBackend{}},
+		4: {backend: "unknown", err: true},
+	}
+	for i, tc := range testCases {
+		cfg := config.TestConfig().SetRoot(t.TempDir())
+		cfg.VerifierAddress = "0x1234"
+		cfg.Settlement.Backend = tc.backend
+
+		backend, err := NewBackend(log.TestingLogger(), cfg, cfg.SettlementTargets()[0], nil)
+		if tc.err {
+			require.Error(t, err, "testCase%d failed", i)
+			continue
+		}
+		require.NoError(t, err, "testCase%d failed", i)
+		require.IsType(t, tc.expected, backend, "testCase%d failed", i)
+	}
+}
+
+func TestNewBackendRequiresPedersen(t *testing.T) {
+	require.NoError(t, crypto.SetCommitmentHash(crypto.CommitmentHashPoseidon))
+	t.Cleanup(func() { require.NoError(t, crypto.SetCommitmentHash(crypto.CommitmentHashPedersen)) })
+
+	cfg := config.TestConfig().SetRoot(t.TempDir())
+	cfg.VerifierAddress = "0x1234"
+	cfg.Settlement.Backend = config.SettlementBackendStarknet
+	_, err := NewBackend(log.TestingLogger(), cfg, cfg.SettlementTargets()[0], nil)
+	require.Error(t, err)
+
+	cfg.Settlement.Backend = config.SettlementBackendMock
+	_, err = NewBackend(log.TestingLogger(), cfg, cfg.SettlementTargets()[0], nil)
+	require.NoError(t, err)
+}
+
+func TestNewTargetBackend(t *testing.T) {
+	cfg := config.TestConfig().SetRoot(t.TempDir())
+	cfg.VerifierAddress = "0x1234"
+	cfg.Settlement.Backend = config.SettlementBackendProtostar
+	cfg.Settlement.Targets = []*config.SettlementTargetConfig{
+		{Name: "testnet", VerifierAddress: "0x5678", GatewayURL: "http://testnet.example/"},
+		{Name: "dry-run", Backend: config.SettlementBackendFile},
+	}
+	targets := cfg.SettlementTargets()
+
+	backend, err := NewBackend(log.TestingLogger(), cfg, targets[0], nil)
+	require.NoError(t, err)
+	require.IsType(t, &ProtostarBackend{}, backend)
+	protostarBackend := backend.(*ProtostarBackend)
+	require.Equal(t, "0x5678", protostarBackend.verifierAddress)
+	require.Equal(t, "http://testnet.example/", protostarBackend.cfg.GatewayUrl)
+	require.Equal(t, cfg.Protostar.AccountAddress, protostarBackend.cfg.AccountAddress)
+
+	backend, err = NewBackend(log.TestingLogger(), cfg, targets[1], nil)
+	require.NoError(t, err)
+	require.IsType(t, &FileBackend{}, backend)
+	require.Equal(t, filepath.Join(cfg.RootDir, "data", "settlement-dry-run.jsonl"), backend.(*FileBackend).path)
+}
+
+func TestFileBackend(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "data", "settlement.jsonl")
+
+	backend, err := NewFileBackend(path)
+	require.NoError(t, err)
+	height, err := backend.LatestSettledHeight(ctx)
+	require.NoError(t, err)
+	require.Zero(t, height)
+
+	var ids []string
+	for _, h := range []int64{5, 7, 6} {
+		id, err := backend.Submit(ctx, parser.SettlementData{Height: h, Data: []string{"1", "2", "3"}})
+		require.NoError(t, err)
+		require.NotEmpty(t, id)
+		ids = append(ids, id)
+	}
+
+	// submissions are recovered from the file
+	backend, err = NewFileBackend(path)
+	require.NoError(t, err)
+	height, err = backend.LatestSettledHeight(ctx)
+	require.NoError(t, err)
+	require.EqualValues(t, 7, height)
+	status, err := backend.Status(ctx, ids[0])
+	require.NoError(t, err)
+	require.Equal(t, StatusAccepted, status)
+	status, err = backend.Status(ctx, "deadbeef")
+	require.NoError(t, err)
+	require.Equal(t, StatusUnknown, status)
+}
+
+func TestReactorSubmitsToBackend(t *testing.T) {
+	ctx := context.Background()
+	backend := NewMockBackend()
+	r := newTestReactor(backend, NewStore(dbm.NewMemDB()))
+
+	// a failed submission stays enqueued
+	backend.SetSubmitError(errors.New("sequencer unavailable"))
+	require.Error(t, r.SendCommit(ctx, parser.SettlementData{Height: 1}))
+	rec, err := r.Store().Load(1)
+	require.NoError(t, err)
+	require.Equal(t, RecordEnqueued, rec.Status)
+
+	backend.SetSubmitError(nil)
+	require.NoError(t, r.SendCommit(ctx, parser.SettlementData{Height: 2}))
+	require.NoError(t, r.SendCommit(ctx, parser.SettlementData{Height: 3}))
+
+	require.Len(t, backend.Submissions(), 2)
+	require.EqualValues(t, 2, backend.Submissions()[0].Height)
+
+	status, err := backend.Status(ctx, "0x2")
+	require.NoError(t, err)
+	require.Equal(t, StatusAccepted, status)
+	height, err := backend.LatestSettledHeight(ctx)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, height)
+}
+
+func TestReactorFollower(t *testing.T) {
+	ctx := context.Background()
+	backend := NewMockBackend()
+	store := NewStore(dbm.NewMemDB())
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{})
+	blockStore := tmstore.NewBlockStore(dbm.NewMemDB())
+	r := NewReactor(log.TestingLogger(), backend, store, blockStore, stateStore, Policy{}, "")
+
+	// nodes without a validator never submit
+	require.NoError(t, r.SendCommit(ctx, parser.SettlementData{Height: 2, CommitmentProposer: "A"}))
+	require.NoError(t, r.resume(ctx))
+	require.Empty(t, backend.Submissions())
+	rec, err := store.Load(2)
+	require.NoError(t, err)
+	require.Equal(t, RecordEnqueued, rec.Status)
+
+	// but follow the heights the validators settle
+	_, err = backend.Submit(ctx, parser.SettlementData{Height: 2})
+	require.NoError(t, err)
+	require.NoError(t, r.checkSubmitted(ctx))
+	rec, err = store.Load(2)
+	require.NoError(t, err)
+	require.Equal(t, RecordAccepted, rec.Status)
+}
+
+func TestReactorResubmit(t *testing.T) {
+	ctx := context.Background()
+	backend := NewMockBackend()
+	store := NewStore(dbm.NewMemDB())
+	for _, height := range []int64{2, 3} {
+		_, err := store.Enqueue(parser.SettlementData{Height: height})
+		require.NoError(t, err)
+	}
+	r := newTestReactor(backend, store)
+
+	// heights that fail to be sent are kept for the status ticker, with the
+	// heights after them
+	backend.SetSubmitError(fmt.Errorf("%w: 2 > 1", ErrMaxFeeExceeded))
+	require.ErrorIs(t, r.resume(ctx), ErrMaxFeeExceeded)
+	require.True(t, r.hasUnsent())
+	require.ErrorIs(t, r.resubmit(ctx), ErrMaxFeeExceeded)
+	require.Empty(t, backend.Submissions())
+
+	// and resubmitted in order once fees are lower
+	backend.SetSubmitError(nil)
+	require.NoError(t, r.resubmit(ctx))
+	require.False(t, r.hasUnsent())
+	submissions := backend.Submissions()
+	require.Len(t, submissions, 2)
+	require.EqualValues(t, 2, submissions[0].Height)
+	require.EqualValues(t, 3, submissions[1].Height)
+	last, err := store.LastSettledHeight()
+	require.NoError(t, err)
+	require.EqualValues(t, 3, last)
+}
+
+// slowBackend returns from Submit some time after it is canceled.
+type slowBackend struct {
+	*MockBackend
+	submitting chan struct{}
+	returned   chan struct{}
+}
+
+func (b *slowBackend) Submit(ctx context.Context, data parser.SettlementData) (string, error) {
+	close(b.submitting)
+	<-ctx.Done()
+	time.Sleep(50 * time.Millisecond)
+	close(b.returned)
+	return "", ctx.Err()
+}
+
+func TestReactorStopWaitsForSubmission(t *testing.T) {
+	store := NewStore(dbm.NewMemDB())
+	_, err := store.Enqueue(parser.SettlementData{Height: 2})
+	require.NoError(t, err)
+	backend := &slowBackend{NewMockBackend(), make(chan struct{}), make(chan struct{})}
+	r := newTestReactor(backend, store)
+
+	require.NoError(t, r.Start())
+	<-backend.submitting
+	require.NoError(t, r.Stop())
+	select {
+	case <-backend.returned:
+	default:
+		t.Fatal("reactor stopped before its submission returned")
+	}
+}
+
+func TestReactorTrustedHeight(t *testing.T) {
+	testCases := []struct {
+		startHeight, last, height int64
+		expected                  int64
+	}{
+		0: {0, 0, 5, 4},
+		1: {0, 3, 5, 3},
+		2: {0, 4, 5, 4},
+		// the first height is verified against the initial trusted header
+		3: {3, 0, 5, 2},
+		4: {3, 0, 3, 2},
+		5: {3, 4, 8, 4},
+	}
+	for i, tc := range testCases {
+		r := newTestReactor(NewMockBackend(), NewStore(dbm.NewMemDB()))
+		r.policy.StartHeight = tc.startHeight
+		require.Equal(t, tc.expected, r.trustedHeight(tc.last, tc.height), "testCase%d failed", i)
+	}
+}
+
+// headerBackend is a mock backend reading the settled headers from headers.
+type headerBackend struct {
+	*MockBackend
+	headers map[int64]*big.Int
+}
+
+func (b headerBackend) SettledHeader(ctx context.Context, height int64) (*big.Int, error) {
+	if hash, ok := b.headers[height]; ok {
+		return hash, nil
+	}
+	return new(big.Int), nil
+}
+
+func TestReactorCheckSettledHeader(t *testing.T) {
+	ctx := context.Background()
+	blockHash := bytes.Repeat([]byte{1}, 32)
+	otherHash := bytes.Repeat([]byte{2}, 32)
+
+	testCases := []struct {
+		settledHash []byte
+		hasBlock    bool
+		err         error
+	}{
+		0: {blockHash, true, nil},
+		1: {otherHash, true, ErrSettledHeaderMismatch},
+		2: {nil, true, ErrSettledHeaderMismatch},
+		// the block store is behind the verifier
+		3: {otherHash, false, nil},
+	}
+
+	for i, tc := range testCases {
+		backend := headerBackend{NewMockBackend(), map[int64]*big.Int{}}
+		_, err := backend.Submit(ctx, parser.SettlementData{Height: 5})
+		require.NoError(t, err)
+		if tc.settledHash != nil {
+			backend.headers[5] = new(big.Int).SetBytes(tc.settledHash)
+		}
+		blockStore := &mocks.BlockStore{}
+		if tc.hasBlock {
+			blockStore.On("LoadBlockMeta", int64(5)).Return(&types.BlockMeta{BlockID: types.BlockID{Hash: blockHash}})
+		} else {
+			blockStore.On("LoadBlockMeta", int64(5)).Return(nil)
+		}
+		store := NewStore(dbm.NewMemDB())
+		_, err = store.Enqueue(parser.SettlementData{Height: 5})
+		require.NoError(t, err)
+
+		stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{})
+		r := NewReactor(log.TestingLogger(), backend, store, blockStore, stateStore, Policy{}, "")
+		_, err = r.syncSettledHeight(ctx)
+		rec, loadErr := store.Load(5)
+		require.NoError(t, loadErr)
+		if tc.err != nil {
+			require.ErrorIs(t, err, tc.err, "testCase%d failed", i)
+			require.Equal(t, RecordEnqueued, rec.Status, "testCase%d failed", i)
+			continue
+		}
+		require.NoError(t, err, "testCase%d failed", i)
+		require.Equal(t, RecordAccepted, rec.Status, "testCase%d failed", i)
+	}
+}
+
+func TestReactorSyncSettledHeight(t *testing.T) {
+	ctx := context.Background()
+	backend := headerBackend{NewMockBackend(), map[int64]*big.Int{}}
+	store := NewStore(dbm.NewMemDB())
+	r := newTestReactor(backend, store)
+
+	// another validator settled heights 2 and 4, height 3 was never sent and
+	// height 5 was sent in a transaction the backend does not know of
+	for _, height := range []int64{2, 4} {
+		_, err := backend.Submit(ctx, parser.SettlementData{Height: height})
+		require.NoError(t, err)
+		backend.headers[height] = big.NewInt(height)
+	}
+	for _, rec := range []*Record{
+		{Height: 2, Status: RecordEnqueued},
+		{Height: 3, Status: RecordEnqueued},
+		{Height: 4, Status: RecordEnqueued},
+		{Height: 5, Status: RecordSubmitted, TxHash: "0xdead"},
+	} {
+		require.NoError(t, store.Save(rec))
+	}
+	_, err := backend.Submit(ctx, parser.SettlementData{Height: 5})
+	require.NoError(t, err)
+	backend.headers[5] = big.NewInt(5)
+
+	last, err := r.syncSettledHeight(ctx)
+	require.NoError(t, err)
+	require.EqualValues(t, 4, last)
+	for height, status := range map[int64]RecordStatus{
+		2: RecordAccepted,
+		3: RecordEnqueued,
+		4: RecordAccepted,
+		5: RecordSubmitted,
+	} {
+		rec, err := store.Load(height)
+		require.NoError(t, err)
+		require.Equal(t, status, rec.Status, "height %d", height)
+	}
+}
+
+// newTestReactor returns a reactor of validator V following an empty chain.
+func newTestReactor(backend SettlementBackend, store *Store) *Reactor {
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{})
+	blockStore := tmstore.NewBlockStore(dbm.NewMemDB())
+	return NewReactor(log.TestingLogger(), backend, store, blockStore, stateStore, Policy{}, "V")
+}
@@ -0,0 +1,299 @@
+package settlement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tendermint/tendermint/internal/settlement/parser"
+	"github.com/tendermint/tendermint/internal/settlement/verifier"
+	"github.com/tendermint/tendermint/types"
+)
+
+// Types of evidence, the values of the type label of the Evidence metric.
+const (
+	EvidenceDuplicateVote     = "duplicate_vote"
+	EvidenceLightClientAttack = "light_client_attack"
+)
+
+// settleEvidence submits the evidence committed in the block at height to the
+// verifier, once the commit of the block was handed to the backend. data is
+// the settlement data of that commit: the evidence is sent by the submitter of
+// the block, and taken over with the block by failover.
+//
+// Evidence that cannot be proven against the headers settled on the verifier
+// is skipped. Duplicate votes are proven against the validator set settled at
+// their height, and light client attacks against the header settled at their
+// common height.
+func (r *Reactor) settleEvidence(ctx context.Context, height int64, data parser.SettlementData) error {
+	block := r.blockStore.LoadBlock(height)
+	if block == nil {
+		return fmt.Errorf("missing block at height %d", height)
+	}
+
+	for _, ev := range block.Evidence.Evidence {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		evData, err := r.evidenceData(block.ChainID, ev, data)
+		if errors.Is(err, ErrPreflightFailed) {
+			r.logError("skipping evidence", err, "height", height, "evidence", ev)
+			r.metrics.Submissions.With("outcome", OutcomeInvalid).Add(1)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err := r.submitEvidence(ctx, ev.Hash(), evData); err != nil {
+			// the record is kept and resent on the status ticker
+			r.logError("failed to send evidence", err, "height", height, "evidence", ev)
+		}
+	}
+	return nil
+}
+
+// evidenceData returns the settlement data submitting ev, committed in the
+// block settled with data.
+func (r *Reactor) evidenceData(chainID string, ev types.Evidence, data parser.SettlementData) (parser.SettlementData, error) {
+	var (
+		function string
+		inputs   []string
+		err      error
+	)
+	vc := r.policy.VerificationConfig(time.Now())
+	switch ev := ev.(type) {
+	case *types.DuplicateVoteEvidence:
+		function = parser.DuplicateVoteFunction
+		inputs, err = r.duplicateVoteInput(chainID, ev)
+	case *types.LightClientAttackEvidence:
+		function = parser.LightClientAttackFunction
+		inputs, err = r.lightClientAttackInput(ev, vc)
+	default:
+		return parser.SettlementData{}, fmt.Errorf("%w: unknown evidence type %T", ErrPreflightFailed, ev)
+	}
+	if err != nil {
+		return parser.SettlementData{}, err
+	}
+	if err := verifier.VerifyEvidence(function, inputs, verifier.NewAnchor(chainID, vc)); err != nil {
+		return parser.SettlementData{}, fmt.Errorf("%w: %s: %v", ErrPreflightFailed, function, err)
+	}
+
+	data.Function = function
+	data.Data = inputs
+	return data, nil
+}
+
+// duplicateVoteInput returns the calldata proving ev against the validator set
+// of the height of its votes, which must be settled.
+func (r *Reactor) duplicateVoteInput(chainID string, ev *types.DuplicateVoteEvidence) ([]string, error) {
+	height := ev.Height()
+	settled, err := r.handedOver(height)
+	if err != nil {
+		return nil, err
+	}
+	if !settled {
+		return nil, fmt.Errorf("%w: height %d is not settled", ErrPreflightFailed, height)
+	}
+	vals, err := r.stateStore.LoadValidators(height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load validators at height %d: %w", height, err)
+	}
+	inputs, err := parser.ParseDuplicateVoteInput(chainID, ev, vals)
+	if err != nil {
+		return nil, fmt.Errorf("failed to format for settlement: %w", err)
+	}
+	return inputs, nil
+}
+
+// lightClientAttackInput returns the calldata proving ev against the header
+// at its common height. Both that header and the one at the height of the
+// conflicting block must be settled.
+func (r *Reactor) lightClientAttackInput(ev *types.LightClientAttackEvidence, vc parser.VerificationConfig) ([]string, error) {
+	for _, height := range []int64{ev.CommonHeight, ev.ConflictingBlock.Height} {
+		settled, err := r.handedOver(height)
+		if err != nil {
+			return nil, err
+		}
+		if !settled {
+			return nil, fmt.Errorf("%w: height %d is not settled", ErrPreflightFailed, height)
+		}
+	}
+
+	trusted, err := r.lightBlock(ev.CommonHeight)
+	if err != nil {
+		return nil, err
+	}
+	inputs, err := parser.ParseLightClientAttackInput(trusted, ev, vc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to format for settlement: %w", err)
+	}
+	return inputs, nil
+}
+
+// handedOver reports whether height was handed to the backend and not
+// rejected, so that it is settled on the verifier by the time evidence sent
+// after it is.
+func (r *Reactor) handedOver(height int64) (bool, error) {
+	rec, err := r.store.Load(height)
+	if err != nil {
+		return false, err
+	}
+	return rec != nil && rec.Status != RecordRejected, nil
+}
+
+// submitEvidence records the evidence of hash in the store and submits data
+// to the backend. Evidence that is already known is skipped.
+func (r *Reactor) submitEvidence(ctx context.Context, hash []byte, data parser.SettlementData) error {
+	rec, err := r.store.EnqueueEvidence(hash, data)
+	if err != nil {
+		return fmt.Errorf("failed to record evidence: %w", err)
+	}
+	if rec.Status != RecordEnqueued {
+		r.logger.Debug("evidence already submitted", "hash", fmt.Sprintf("%X", hash))
+		return nil
+	}
+	return r.sendEvidence(ctx, rec)
+}
+
+// sendEvidence sends rec to the backend and records the outcome. Evidence of
+// blocks another validator submits is left enqueued, until failover takes it
+// over or the block is settled. Evidence the backend batches is recorded as
+// submitted without a transaction hash and not followed up.
+func (r *Reactor) sendEvidence(ctx context.Context, rec *EvidenceRecord) error {
+	if !rec.Data.IsSubmitter() {
+		r.logger.Debug("evidence left to the submitter of its block", "height", rec.Height,
+			"hash", fmt.Sprintf("%X", rec.Hash))
+		return nil
+	}
+
+	r.logger.Info("submitting evidence", "height", rec.Height, "function", rec.Data.Function,
+		"hash", fmt.Sprintf("%X", rec.Hash))
+
+	txHash, err := r.backend.Submit(ctx, rec.Data)
+	if err != nil {
+		if errors.Is(err, ErrMaxFeeExceeded) {
+			r.metrics.Submissions.With("outcome", OutcomeFeeRetry).Add(1)
+		}
+		return fmt.Errorf("failed to submit evidence: %w", err)
+	}
+	if txHash != "" {
+		r.logger.Info("submitted evidence", "height", rec.Height, "tx_hash", txHash)
+		r.metrics.Evidence.With("type", evidenceType(rec.Data.Function)).Add(1)
+	}
+	rec.Status = RecordSubmitted
+	rec.TxHash = txHash
+	return r.store.SaveEvidence(rec)
+}
+
+// resumeEvidence resubmits the evidence left enqueued by a previous run, and
+// the submitted evidence whose transaction was lost.
+func (r *Reactor) resumeEvidence(ctx context.Context) error {
+	records, err := r.store.PendingEvidence()
+	if err != nil {
+		return err
+	}
+	for _, rec := range records {
+		if rec.Status == RecordSubmitted {
+			if rec.TxHash == "" {
+				continue
+			}
+			status, err := r.updateEvidenceStatus(ctx, rec)
+			if err != nil {
+				return err
+			}
+			if status != StatusUnknown {
+				continue
+			}
+		}
+		if err := r.sendEvidence(ctx, rec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkEvidence queries the status of every evidence transaction that is not
+// final, and resends the enqueued evidence this node submits. Evidence left to
+// another validator is accepted with the block it was committed in, which that
+// validator settled and sent the evidence of.
+func (r *Reactor) checkEvidence(ctx context.Context) error {
+	records, err := r.store.PendingEvidence()
+	if err != nil {
+		return err
+	}
+	settled, err := r.store.LastSettledHeight()
+	if err != nil {
+		return err
+	}
+	for _, rec := range records {
+		switch {
+		case rec.Status == RecordEnqueued && rec.Data.IsSubmitter():
+			if err := r.sendEvidence(ctx, rec); err != nil {
+				r.logError("failed to send evidence", err, "height", rec.Height)
+			}
+		case rec.Status == RecordEnqueued && rec.Height <= settled:
+			rec.Status = RecordAccepted
+			if err := r.store.SaveEvidence(rec); err != nil {
+				return err
+			}
+		case rec.Status == RecordSubmitted && rec.TxHash != "":
+			if _, err := r.updateEvidenceStatus(ctx, rec); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// takeOverEvidence takes over the enqueued evidence committed in the block at
+// height, whose settlement this node took over.
+func (r *Reactor) takeOverEvidence(ctx context.Context, height int64) error {
+	records, err := r.store.PendingEvidence()
+	if err != nil {
+		return err
+	}
+	for _, rec := range records {
+		if rec.Height != height || rec.Status != RecordEnqueued || rec.Data.IsSubmitter() {
+			continue
+		}
+		rec.Data.Submitter = r.validatorAddress
+		if err := r.store.SaveEvidence(rec); err != nil {
+			return err
+		}
+		if err := r.sendEvidence(ctx, rec); err != nil {
+			r.logError("failed to send evidence", err, "height", height)
+		}
+	}
+	return nil
+}
+
+// updateEvidenceStatus queries the status of the transaction of a submitted
+// evidence record, and records it if it is final.
+func (r *Reactor) updateEvidenceStatus(ctx context.Context, rec *EvidenceRecord) (SubmissionStatus, error) {
+	status, err := r.backend.Status(ctx, rec.TxHash)
+	if err != nil {
+		return status, fmt.Errorf("failed to query status of %s: %w", rec.TxHash, err)
+	}
+	switch status {
+	case StatusAccepted:
+		r.logger.Info("evidence accepted", "height", rec.Height, "tx_hash", rec.TxHash)
+		rec.Status = RecordAccepted
+	case StatusRejected:
+		r.logger.Error("evidence transaction rejected", "height", rec.Height, "tx_hash", rec.TxHash)
+		r.metrics.Submissions.With("outcome", OutcomeRejected).Add(1)
+		rec.Status = RecordRejected
+	default:
+		return status, nil
+	}
+	return status, r.store.SaveEvidence(rec)
+}
+
+// evidenceType returns the type of the evidence submitted to function.
+func evidenceType(function string) string {
+	if function == parser.LightClientAttackFunction {
+		return EvidenceLightClientAttack
+	}
+	return EvidenceDuplicateVote
+}
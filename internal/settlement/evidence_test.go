@@ -0,0 +1,175 @@
+package settlement
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/internal/settlement/parser"
+	"github.com/tendermint/tendermint/internal/settlement/verifier"
+	"github.com/tendermint/tendermint/internal/state/mocks"
+	"github.com/tendermint/tendermint/internal/test/factory"
+	"github.com/tendermint/tendermint/libs/log"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestReactorSettleEvidence(t *testing.T) {
+	ctx := context.Background()
+	chainID := factory.DefaultTestChainID
+	vals, privVals := factory.RandValidatorSet(4, 10)
+	_, otherPrivVals := factory.RandValidatorSet(1, 10)
+	now := time.Now().Truncate(time.Millisecond)
+
+	duplicateVote := func(pv types.PrivValidator, height int64) *types.DuplicateVoteEvidence {
+		var votes [2]*types.Vote
+		for i := range votes {
+			vote, err := factory.MakeVote(pv, chainID, 0, height, 0, int(tmproto.PrevoteType), factory.MakeBlockID(), now)
+			require.NoError(t, err)
+			votes[i] = vote
+		}
+		return &types.DuplicateVoteEvidence{VoteA: votes[0], VoteB: votes[1]}
+	}
+	valid := duplicateVote(privVals[0], 2)
+	// the validator is in no settled set
+	unknown := duplicateVote(otherPrivVals[0], 2)
+
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("LoadBlock", int64(3)).Return(&types.Block{
+		Header:   types.Header{ChainID: chainID, Height: 3},
+		Evidence: types.EvidenceData{Evidence: types.EvidenceList{unknown, valid}},
+	})
+	stateStore := &mocks.Store{}
+	stateStore.On("LoadValidators", int64(2)).Return(vals, nil)
+	stateStore.On("LoadValidators", int64(3)).Return(vals, nil)
+
+	backend := NewMockBackend()
+	store := NewStore(dbm.NewMemDB())
+	r := NewReactor(log.TestingLogger(), backend, store, blockStore, stateStore, Policy{}, "A")
+
+	// height 2 was settled, height 3 is settled with the evidence
+	require.NoError(t, store.Save(&Record{Height: 2, Status: RecordAccepted}))
+	require.NoError(t, store.Save(&Record{Height: 3, Status: RecordSubmitted}))
+	data := parser.SettlementData{Height: 3, CommitmentProposer: "A", ValidatorAddress: "A"}
+	require.NoError(t, r.settleEvidence(ctx, 3, data))
+
+	submissions := backend.Submissions()
+	require.Len(t, submissions, 1)
+	sub := submissions[0]
+	assert.EqualValues(t, 3, sub.Height)
+	assert.Equal(t, "A", sub.CommitmentProposer)
+	assert.Equal(t, parser.DuplicateVoteFunction, sub.Function)
+	in, err := verifier.DecodeDuplicateVote(sub.Data)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, in.VoteA.Height.Int64())
+
+	rec, err := store.LoadEvidence(valid.Hash())
+	require.NoError(t, err)
+	require.NotNil(t, rec)
+	assert.Equal(t, RecordSubmitted, rec.Status)
+	rec, err = store.LoadEvidence(unknown.Hash())
+	require.NoError(t, err)
+	assert.Nil(t, rec)
+
+	// known evidence is not submitted again
+	require.NoError(t, r.settleEvidence(ctx, 3, data))
+	assert.Len(t, backend.Submissions(), 1)
+
+	require.NoError(t, r.checkEvidence(ctx))
+	rec, err = store.LoadEvidence(valid.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, RecordAccepted, rec.Status)
+	pending, err := store.PendingEvidence()
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func TestReactorResumeEvidence(t *testing.T) {
+	ctx := context.Background()
+	backend := NewMockBackend()
+	store := NewStore(dbm.NewMemDB())
+
+	records := []*EvidenceRecord{
+		{Hash: []byte{1}, Height: 3, Status: RecordEnqueued},
+		{Hash: []byte{2}, Height: 3, Status: RecordSubmitted, TxHash: "0xdead"}, // dropped by the sequencer
+		{Hash: []byte{3}, Height: 4, Status: RecordSubmitted},                   // batched
+		{Hash: []byte{4}, Height: 4, Status: RecordRejected, TxHash: "0xbeef"},
+	}
+	for _, rec := range records {
+		rec.Data = parser.SettlementData{
+			Height:             rec.Height,
+			Function:           parser.DuplicateVoteFunction,
+			CommitmentProposer: "A",
+			ValidatorAddress:   "A",
+		}
+		require.NoError(t, store.SaveEvidence(rec))
+	}
+
+	r := newTestReactor(backend, store)
+	require.NoError(t, r.resumeEvidence(ctx))
+	assert.Len(t, backend.Submissions(), 2)
+
+	pending, err := store.PendingEvidence()
+	require.NoError(t, err)
+	require.Len(t, pending, 3)
+	for _, rec := range pending {
+		assert.Equal(t, RecordSubmitted, rec.Status)
+	}
+	assert.Equal(t, "0x1", pending[0].TxHash)
+	assert.Equal(t, "0x2", pending[1].TxHash)
+	assert.Empty(t, pending[2].TxHash)
+}
+
+func TestReactorEvidenceLeftToSubmitter(t *testing.T) {
+	ctx := context.Background()
+	backend := NewMockBackend()
+	store := NewStore(dbm.NewMemDB())
+	r := newTestReactor(backend, store)
+
+	// the evidence of blocks another validator submits is not sent
+	data := parser.SettlementData{Height: 3, Function: parser.DuplicateVoteFunction, CommitmentProposer: "A", ValidatorAddress: "B"}
+	require.NoError(t, r.submitEvidence(ctx, []byte{1}, data))
+	assert.Empty(t, backend.Submissions())
+	rec, err := store.LoadEvidence([]byte{1})
+	require.NoError(t, err)
+	assert.Equal(t, RecordEnqueued, rec.Status)
+
+	// nor resent while its block is not settled
+	require.NoError(t, r.checkEvidence(ctx))
+	assert.Empty(t, backend.Submissions())
+
+	// and is accepted with its block
+	require.NoError(t, store.Save(&Record{Height: 3, Status: RecordAccepted}))
+	require.NoError(t, r.checkEvidence(ctx))
+	assert.Empty(t, backend.Submissions())
+	pending, err := store.PendingEvidence()
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func TestReactorCheckEvidenceResends(t *testing.T) {
+	ctx := context.Background()
+	backend := NewMockBackend()
+	store := NewStore(dbm.NewMemDB())
+	r := newTestReactor(backend, store)
+
+	data := parser.SettlementData{Height: 3, Function: parser.DuplicateVoteFunction, CommitmentProposer: "A", ValidatorAddress: "A"}
+	backend.SetSubmitError(ErrMaxFeeExceeded)
+	require.Error(t, r.submitEvidence(ctx, []byte{1}, data))
+	rec, err := store.LoadEvidence([]byte{1})
+	require.NoError(t, err)
+	assert.Equal(t, RecordEnqueued, rec.Status)
+
+	// the evidence is resent on the next check, once fees are lower
+	backend.SetSubmitError(nil)
+	require.NoError(t, r.checkEvidence(ctx))
+	require.Len(t, backend.Submissions(), 1)
+	rec, err = store.LoadEvidence([]byte{1})
+	require.NoError(t, err)
+	assert.Equal(t, RecordSubmitted, rec.Status)
+	assert.Equal(t, "0x1", rec.TxHash)
+}
@@ -0,0 +1,110 @@
+package settlement
+
+import (
+	"context"
+	"time"
+
+	"github.com/tendermint/tendermint/internal/settlement/parser"
+	"github.com/tendermint/tendermint/types"
+)
+
+// failoverRounds bounds the proposer rounds simulated to order the
+// validators, in multiples of the size of the set.
+const failoverRounds = 3
+
+// failoverOrder returns the addresses of vals in the order in which they
+// settle a block proposed by proposer: the proposer first, then the
+// validators in the order they would propose after it, as given by their
+// proposer priority. Validators with too little voting power to propose
+// within failoverRounds rounds per validator follow, in the order of the set.
+// vals is not modified.
+func failoverOrder(proposer types.Address, vals *types.ValidatorSet) []string {
+	order := make([]string, 0, vals.Size())
+	seen := make(map[string]bool, vals.Size())
+	add := func(address types.Address) {
+		if s := address.String(); !seen[s] {
+			seen[s] = true
+			order = append(order, s)
+		}
+	}
+
+	add(proposer)
+	vals = vals.Copy()
+	for i := 0; i < failoverRounds*vals.Size() && len(order) < vals.Size(); i++ {
+		add(vals.GetProposer().Address)
+		vals.IncrementProposerPriority(1)
+	}
+	for _, val := range vals.Validators {
+		add(val.Address)
+	}
+	return order
+}
+
+// failoverRank returns the position of address in the failover order of
+// data, or -1 if it is not in it.
+func failoverRank(data parser.SettlementData, address string) int {
+	for i, submitter := range data.Submitters {
+		if submitter == address {
+			return i
+		}
+	}
+	return -1
+}
+
+// takeoverTime returns when the validator at rank in the failover order of a
+// block committed at blockTime takes over its settlement.
+func (p Policy) takeoverTime(blockTime time.Time, rank int) time.Time {
+	return blockTime.Add(time.Duration(rank) * p.FailoverTimeout)
+}
+
+// failover takes over the settlement of the heights that are still not
+// settled on-chain at now, once every validator before this one in their
+// failover order had its time to settle them. The evidence committed in these
+// heights is taken over with them.
+func (r *Reactor) failover(ctx context.Context, now time.Time) error {
+	if r.validatorAddress == "" || r.policy.FailoverTimeout <= 0 {
+		return nil
+	}
+
+	last, err := r.syncSettledHeight(ctx)
+	if err != nil {
+		return err
+	}
+	records, err := r.store.Unsettled(last)
+	if err != nil {
+		return err
+	}
+
+	for _, rec := range records {
+		// submitted and rejected heights are followed up by their submitter,
+		// and enqueued ones this node submits are resubmitted on the status ticker
+		if rec.Status != RecordEnqueued || rec.Data.IsSubmitter() {
+			continue
+		}
+		rank := failoverRank(rec.Data, r.validatorAddress)
+		if rank <= 0 {
+			continue
+		}
+		meta := r.blockStore.LoadBlockMeta(rec.Height)
+		if meta == nil {
+			continue
+		}
+		if now.Before(r.policy.takeoverTime(meta.Header.Time, rank)) {
+			continue
+		}
+
+		r.logger.Info("taking over settlement", "height", rec.Height, "rank", rank,
+			"proposer", rec.Data.CommitmentProposer)
+		rec.Data.Submitter = r.validatorAddress
+		if err := r.store.Save(rec); err != nil {
+			return err
+		}
+		if err := r.submit(ctx, rec); err != nil {
+			return err
+		}
+		if err := r.takeOverEvidence(ctx, rec.Height); err != nil {
+			return err
+		}
+	}
+	return nil
+}
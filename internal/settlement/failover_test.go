@@ -0,0 +1,142 @@
+package settlement
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/internal/settlement/parser"
+	sm "github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/internal/state/mocks"
+	"github.com/tendermint/tendermint/internal/test/factory"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestFailoverOrder(t *testing.T) {
+	vals, _ := factory.RandValidatorSet(4, 10)
+
+	// the proposers of the following heights back up the proposer
+	expected := make([]string, 0, vals.Size())
+	next := vals.Copy()
+	for i := 0; i < vals.Size(); i++ {
+		expected = append(expected, next.GetProposer().Address.String())
+		next.IncrementProposerPriority(1)
+	}
+	assert.Equal(t, expected, failoverOrder(vals.GetProposer().Address, vals))
+
+	// the proposer of a later round goes first
+	proposer := vals.Validators[0].Address
+	if bytes.Equal(vals.GetProposer().Address, proposer) {
+		proposer = vals.Validators[1].Address
+	}
+	order := failoverOrder(proposer, vals)
+	assert.Equal(t, proposer.String(), order[0])
+	assert.ElementsMatch(t, expected, order)
+}
+
+func TestFailoverOrderLowPower(t *testing.T) {
+	// the weak validator is not reached in the rounds simulated
+	strong, _ := factory.RandValidator(false, 1000000)
+	weak, _ := factory.RandValidator(false, 1)
+	vals := types.NewValidatorSet([]*types.Validator{strong, weak})
+	order := failoverOrder(vals.GetProposer().Address, vals)
+	require.Len(t, order, 2)
+	assert.Equal(t, vals.Validators[1].Address.String(), order[1])
+}
+
+func TestReactorFailover(t *testing.T) {
+	ctx := context.Background()
+	blockTime := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("LoadBlockMeta", int64(5)).Return(&types.BlockMeta{Header: types.Header{Height: 5, Time: blockTime}})
+
+	data := parser.SettlementData{
+		Height:             5,
+		CommitmentProposer: "A",
+		ValidatorAddress:   "C",
+		Submitters:         []string{"A", "B", "C"},
+	}
+
+	testCases := []struct {
+		now          time.Time
+		settled      bool // by another validator
+		expectSubmit bool
+	}{
+		0: {blockTime.Add(time.Minute), false, false},
+		1: {blockTime.Add(119 * time.Second), false, false},
+		2: {blockTime.Add(2 * time.Minute), false, true},
+		3: {blockTime.Add(time.Hour), true, false},
+	}
+
+	for i, tc := range testCases {
+		backend := NewMockBackend()
+		if tc.settled {
+			_, err := backend.Submit(ctx, parser.SettlementData{Height: 5})
+			require.NoError(t, err)
+		}
+		store := NewStore(dbm.NewMemDB())
+		_, err := store.Enqueue(data)
+		require.NoError(t, err)
+
+		stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{})
+		r := NewReactor(log.TestingLogger(), backend, store, blockStore, stateStore, Policy{FailoverTimeout: time.Minute}, "C")
+		require.NoError(t, r.failover(ctx, tc.now), "testCase%d failed", i)
+
+		submissions := backend.Submissions()
+		if tc.settled {
+			submissions = submissions[1:]
+		}
+		if !tc.expectSubmit {
+			assert.Empty(t, submissions, "testCase%d failed", i)
+			continue
+		}
+		require.Len(t, submissions, 1, "testCase%d failed", i)
+		assert.Equal(t, "C", submissions[0].Submitter, "testCase%d failed", i)
+		assert.True(t, submissions[0].IsSubmitter(), "testCase%d failed", i)
+		rec, err := store.Load(5)
+		require.NoError(t, err)
+		assert.Equal(t, RecordAccepted, rec.Status, "testCase%d failed", i)
+	}
+}
+
+func TestReactorFailoverEvidence(t *testing.T) {
+	ctx := context.Background()
+	blockTime := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	blockStore := &mocks.BlockStore{}
+	blockStore.On("LoadBlockMeta", int64(5)).Return(&types.BlockMeta{Header: types.Header{Height: 5, Time: blockTime}})
+
+	data := parser.SettlementData{
+		Height:             5,
+		CommitmentProposer: "A",
+		ValidatorAddress:   "C",
+		Submitters:         []string{"A", "B", "C"},
+	}
+	backend := NewMockBackend()
+	store := NewStore(dbm.NewMemDB())
+	_, err := store.Enqueue(data)
+	require.NoError(t, err)
+	evData := data
+	evData.Function = parser.DuplicateVoteFunction
+	_, err = store.EnqueueEvidence([]byte{1}, evData)
+	require.NoError(t, err)
+
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{})
+	r := NewReactor(log.TestingLogger(), backend, store, blockStore, stateStore, Policy{FailoverTimeout: time.Minute}, "C")
+	require.NoError(t, r.failover(ctx, blockTime.Add(2*time.Minute)))
+
+	// the evidence is submitted with the block it was committed in
+	submissions := backend.Submissions()
+	require.Len(t, submissions, 2)
+	assert.Equal(t, parser.DuplicateVoteFunction, submissions[1].Function)
+	assert.Equal(t, "C", submissions[1].Submitter)
+	rec, err := store.LoadEvidence([]byte{1})
+	require.NoError(t, err)
+	assert.Equal(t, RecordSubmitted, rec.Status)
+	assert.Equal(t, "0x2", rec.TxHash)
+}
@@ -0,0 +1,170 @@
+package settlement
+
+import (
+	"fmt"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/settlement/starknet"
+	sm "github.com/tendermint/tendermint/internal/state"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/libs/service"
+	"github.com/tendermint/tendermint/types"
+)
+
+// Group settles commits on several targets, running a Reactor for each. The
+// reactors share nothing but the block and state stores: each has its own
+// backend, store and policy, so that a target that fails or lags behind does
+// not hold up the others. Slush addition.
+type Group struct {
+	service.BaseService
+	logger   log.Logger
+	reactors []*Reactor
+}
+
+// NewGroup returns a group running reactors, whose targets must be distinct.
+func NewGroup(logger log.Logger, reactors ...*Reactor) *Group {
+	g := &Group{
+		logger:   logger,
+		reactors: reactors,
+	}
+	g.BaseService = *service.NewBaseService(logger, "SettlementGroup", g)
+	return g
+}
+
+// NewGroupFromConfig returns a group settling on every settlement target of
+// cfg. The store of each target is opened with dbProvider, and closed by
+// Close. validatorAddress is the address of the node's validator, if any:
+// without one the reactors only follow settlement. signer signs the
+// transactions of the targets which select the priv validator signer, see
+// NewBackend.
+func NewGroupFromConfig(
+	logger log.Logger,
+	cfg *config.Config,
+	dbProvider config.DBProvider,
+	blockStore sm.BlockStore,
+	stateStore sm.Store,
+	validatorAddress string,
+	signer starknet.Signer,
+	metrics *Metrics,
+) (*Group, error) {
+	g := NewGroup(logger)
+	for _, target := range cfg.SettlementTargets() {
+		r, err := newTargetReactor(logger, cfg, target, dbProvider, blockStore, stateStore,
+			validatorAddress, signer, metrics)
+		if err != nil {
+			if cerr := g.Close(); cerr != nil {
+				logger.Error("failed to close settlement stores", "err", cerr)
+			}
+			return nil, fmt.Errorf("settlement target %q: %w", target.Name, err)
+		}
+		g.reactors = append(g.reactors, r)
+	}
+	return g, nil
+}
+
+// newTargetReactor returns the reactor settling on target, one of the
+// settlement targets of cfg.
+func newTargetReactor(
+	logger log.Logger,
+	cfg *config.Config,
+	target *config.SettlementTargetConfig,
+	dbProvider config.DBProvider,
+	blockStore sm.BlockStore,
+	stateStore sm.Store,
+	validatorAddress string,
+	signer starknet.Signer,
+	metrics *Metrics,
+) (*Reactor, error) {
+	policy, err := NewTargetPolicy(cfg.Settlement, target)
+	if err != nil {
+		return nil, err
+	}
+	backend, err := NewBackend(logger.With("target", target.Name), cfg, target, signer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create settlement backend: %w", err)
+	}
+	db, err := dbProvider(&config.DBContext{ID: targetName("settlement", target.Name), Config: cfg})
+	if err != nil {
+		return nil, fmt.Errorf("unable to initialize settlement db: %w", err)
+	}
+
+	return NewReactor(
+		logger,
+		backend,
+		NewStore(db),
+		blockStore,
+		stateStore,
+		policy,
+		validatorAddress,
+		ReactorMetrics(metrics),
+		ReactorTarget(target.Name),
+	), nil
+}
+
+// SetEventBus sets the event bus of every reactor of the group, see
+// Reactor.SetEventBus. Must be called before the group is started.
+func (g *Group) SetEventBus(b *types.EventBus) {
+	for _, r := range g.reactors {
+		r.SetEventBus(b)
+	}
+}
+
+// Reactors returns the reactors of the group, in the order of their targets.
+func (g *Group) Reactors() []*Reactor {
+	return g.reactors
+}
+
+// Reactor returns the reactor of target, or nil if there is none. An empty
+// target is the first one of the group.
+func (g *Group) Reactor(target string) *Reactor {
+	for _, r := range g.reactors {
+		if target == "" || r.target == target {
+			return r
+		}
+	}
+	return nil
+}
+
+// Targets returns the names of the targets of the group.
+func (g *Group) Targets() []string {
+	targets := make([]string, len(g.reactors))
+	for i, r := range g.reactors {
+		targets[i] = r.target
+	}
+	return targets
+}
+
+// OnStart starts every reactor of the group.
+func (g *Group) OnStart() error {
+	for i, r := range g.reactors {
+		if err := r.Start(); err != nil {
+			g.stop(g.reactors[:i])
+			return fmt.Errorf("failed to start settlement on target %q: %w", r.target, err)
+		}
+	}
+	return nil
+}
+
+// OnStop stops every reactor of the group.
+func (g *Group) OnStop() {
+	g.stop(g.reactors)
+}
+
+func (g *Group) stop(reactors []*Reactor) {
+	for _, r := range reactors {
+		if err := r.Stop(); err != nil {
+			g.logger.Error("failed to stop settlement reactor", "target", r.target, "err", err)
+		}
+	}
+}
+
+// Close closes the stores of every reactor of the group.
+func (g *Group) Close() error {
+	var firstErr error
+	for _, r := range g.reactors {
+		if err := r.store.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,117 @@
+package settlement
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/settlement/parser"
+	sm "github.com/tendermint/tendermint/internal/state"
+	tmstore "github.com/tendermint/tendermint/internal/store"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func TestNewGroupFromConfig(t *testing.T) {
+	cfg := config.TestConfig().SetRoot(t.TempDir())
+
+	var dbIDs []string
+	dbProvider := func(ctx *config.DBContext) (dbm.DB, error) {
+		dbIDs = append(dbIDs, ctx.ID)
+		return dbm.NewMemDB(), nil
+	}
+	newGroup := func() *Group {
+		stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{})
+		blockStore := tmstore.NewBlockStore(dbm.NewMemDB())
+		g, err := NewGroupFromConfig(log.TestingLogger(), cfg, dbProvider, blockStore, stateStore, "", nil, NopMetrics())
+		require.NoError(t, err)
+		t.Cleanup(func() { require.NoError(t, g.Close()) })
+		return g
+	}
+
+	// single target nodes keep their database
+	g := newGroup()
+	assert.Equal(t, []string{config.DefaultSettlementTarget}, g.Targets())
+	assert.Equal(t, []string{"settlement"}, dbIDs)
+
+	dbIDs = nil
+	cfg.Settlement.Targets = []*config.SettlementTargetConfig{
+		{Name: "testnet"},
+		{Name: "devnet", Mode: config.SettlementModeInterval, Interval: 10},
+	}
+	g = newGroup()
+	assert.Equal(t, []string{"testnet", "devnet"}, g.Targets())
+	assert.Equal(t, []string{"settlement-testnet", "settlement-devnet"}, dbIDs)
+
+	assert.Equal(t, "testnet", g.Reactor("").Target())
+	devnet := g.Reactor("devnet")
+	require.NotNil(t, devnet)
+	assert.Equal(t, config.SettlementModeInterval, devnet.policy.Mode)
+	assert.EqualValues(t, 10, devnet.policy.Interval)
+	assert.Equal(t, cfg.Settlement.Mode, g.Reactor("testnet").policy.Mode)
+	assert.Nil(t, g.Reactor("mainnet"))
+
+	// an invalid target fails the group
+	cfg.Settlement.Targets[1].Backend = "carrier-pigeon"
+	_, err := NewGroupFromConfig(log.TestingLogger(), cfg, dbProvider,
+		tmstore.NewBlockStore(dbm.NewMemDB()), sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{}), "", nil, NopMetrics())
+	assert.Error(t, err)
+}
+
+func TestGroupTargetsAreIndependent(t *testing.T) {
+	ctx := context.Background()
+	testnetBackend, devnetBackend := NewMockBackend(), NewMockBackend()
+	testnet := newTestReactor(testnetBackend, NewStore(dbm.NewMemDB()))
+	testnet.target = "testnet"
+	devnet := newTestReactor(devnetBackend, NewStore(dbm.NewMemDB()))
+	devnet.target = "devnet"
+
+	g := NewGroup(log.TestingLogger(), testnet, devnet)
+
+	// the devnet is down while the testnet settles
+	devnetBackend.SetSubmitError(errors.New("devnet unavailable"))
+	for _, height := range []int64{2, 3} {
+		data := parser.SettlementData{Height: height}
+		require.NoError(t, g.Reactor("testnet").SendCommit(ctx, data))
+		require.Error(t, g.Reactor("devnet").SendCommit(ctx, data))
+	}
+
+	testnetStatus, err := testnet.Status()
+	require.NoError(t, err)
+	assert.Equal(t, "testnet", testnetStatus.Target)
+	assert.EqualValues(t, 3, testnetStatus.LastAcceptedHeight)
+
+	devnetStatus, err := devnet.Status()
+	require.NoError(t, err)
+	assert.Equal(t, "devnet", devnetStatus.Target)
+	assert.EqualValues(t, 3, devnetStatus.LastEnqueuedHeight)
+	assert.Zero(t, devnetStatus.LastAcceptedHeight)
+
+	// the devnet catches up on its own queue
+	devnetBackend.SetSubmitError(nil)
+	require.NoError(t, devnet.resume(ctx))
+	assert.Len(t, devnetBackend.Submissions(), 2)
+	assert.Len(t, testnetBackend.Submissions(), 2)
+}
+
+func TestGroupStartStop(t *testing.T) {
+	reactors := []*Reactor{
+		newTestReactor(NewMockBackend(), NewStore(dbm.NewMemDB())),
+		newTestReactor(NewMockBackend(), NewStore(dbm.NewMemDB())),
+	}
+	g := NewGroup(log.TestingLogger(), reactors...)
+
+	require.NoError(t, g.Start())
+	for _, r := range reactors {
+		assert.True(t, r.IsRunning())
+	}
+	require.NoError(t, g.Stop())
+	for _, r := range reactors {
+		assert.False(t, r.IsRunning())
+	}
+	require.NoError(t, g.Close())
+}
@@ -0,0 +1,159 @@
+package settlement
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "settlement"
+)
+
+// Outcomes of a submission, the values of the outcome label of Submissions.
+const (
+	OutcomeAccepted = "accepted"
+	OutcomeRejected = "rejected"
+	// OutcomeFeeRetry submissions were held back for costing more than the
+	// maximum fee, to be sent again later.
+	OutcomeFeeRetry = "fee_retry"
+	// OutcomeInvalid heights were skipped for calldata failing verification
+	// before submission.
+	OutcomeInvalid = "invalid"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Number of heights handed to settlement that are not settled yet.
+	QueueDepth metrics.Gauge
+	// Number of blocks between the latest committed height and the latest
+	// settled height.
+	Lag metrics.Gauge
+	// Latest settled height.
+	SettledHeight metrics.Gauge
+
+	// Number of submissions, by outcome.
+	Submissions metrics.Counter
+	// Fees paid by the transactions of this node, in wei.
+	FeesPaid metrics.Counter
+	// Number of pieces of evidence submitted to the verifier, by type.
+	Evidence metrics.Counter
+	// Time between the commit of a block and its acceptance on L2.
+	AcceptanceLatency metrics.Histogram
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue"). The metrics are labelled with the target of the reactor that
+// uses them too.
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	return prometheusMetrics(stdprometheus.DefaultRegisterer, namespace, labelsAndValues...)
+}
+
+// prometheusMetrics returns the Metrics of PrometheusMetrics, registered with
+// reg.
+func prometheusMetrics(reg stdprometheus.Registerer, namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	labels = append(labels, "target")
+	return &Metrics{
+		QueueDepth: newGauge(reg, stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "queue_depth",
+			Help:      "Number of heights handed to settlement that are not settled yet.",
+		}, labels).With(labelsAndValues...),
+		Lag: newGauge(reg, stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "lag",
+			Help:      "Number of blocks between the latest committed height and the latest settled height.",
+		}, labels).With(labelsAndValues...),
+		SettledHeight: newGauge(reg, stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "settled_height",
+			Help:      "Latest settled height.",
+		}, labels).With(labelsAndValues...),
+		Submissions: newCounter(reg, stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "submissions",
+			Help:      "Number of submissions, by outcome (accepted, rejected, fee_retry, invalid).",
+		}, append(labels, "outcome")).With(labelsAndValues...),
+		FeesPaid: newCounter(reg, stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "fees_paid",
+			Help:      "Fees paid by the settlement transactions of this node, in wei.",
+		}, labels).With(labelsAndValues...),
+		Evidence: newCounter(reg, stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "evidence",
+			Help:      "Number of pieces of evidence submitted to the verifier, by type.",
+		}, append(labels, "type")).With(labelsAndValues...),
+		AcceptanceLatency: newHistogram(reg, stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "acceptance_latency",
+			Help:      "Time between the commit of a block and its acceptance on L2, in seconds.",
+			Buckets:   stdprometheus.ExponentialBucketsRange(10, 6*60*60, 10),
+		}, labels).With(labelsAndValues...),
+	}
+}
+
+// newGauge returns a gauge of opts with the label names labels, registered
+// with reg.
+func newGauge(reg stdprometheus.Registerer, opts stdprometheus.GaugeOpts, labels []string) metrics.Gauge {
+	gv := stdprometheus.NewGaugeVec(opts, labels)
+	reg.MustRegister(gv)
+	return prometheus.NewGauge(gv)
+}
+
+// newCounter returns a counter of opts with the label names labels,
+// registered with reg.
+func newCounter(reg stdprometheus.Registerer, opts stdprometheus.CounterOpts, labels []string) metrics.Counter {
+	cv := stdprometheus.NewCounterVec(opts, labels)
+	reg.MustRegister(cv)
+	return prometheus.NewCounter(cv)
+}
+
+// newHistogram returns a histogram of opts with the label names labels,
+// registered with reg.
+func newHistogram(reg stdprometheus.Registerer, opts stdprometheus.HistogramOpts, labels []string) metrics.Histogram {
+	hv := stdprometheus.NewHistogramVec(opts, labels)
+	reg.MustRegister(hv)
+	return prometheus.NewHistogram(hv)
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		QueueDepth:        discard.NewGauge(),
+		Lag:               discard.NewGauge(),
+		SettledHeight:     discard.NewGauge(),
+		Submissions:       discard.NewCounter(),
+		FeesPaid:          discard.NewCounter(),
+		Evidence:          discard.NewCounter(),
+		AcceptanceLatency: discard.NewHistogram(),
+	}
+}
+
+// target returns the metrics of the settlement target named name.
+func (m *Metrics) target(name string) *Metrics {
+	return &Metrics{
+		QueueDepth:        m.QueueDepth.With("target", name),
+		Lag:               m.Lag.With("target", name),
+		SettledHeight:     m.SettledHeight.With("target", name),
+		Submissions:       m.Submissions.With("target", name),
+		FeesPaid:          m.FeesPaid.With("target", name),
+		Evidence:          m.Evidence.With("target", name),
+		AcceptanceLatency: m.AcceptanceLatency.With("target", name),
+	}
+}
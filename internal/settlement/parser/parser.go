@@ -10,14 +10,69 @@ import (
 
 	"github.com/tendermint/tendermint/crypto/utils"
 	"github.com/tendermint/tendermint/crypto/weierstrass"
+	tmmath "github.com/tendermint/tendermint/libs/math"
 	"github.com/tendermint/tendermint/types"
 	"github.com/tendermint/tendermint/version"
 )
 
+// Verifier entry points, see cairo/src/main.cairo
+const (
+	// AdjacentFunction verifies a block against its predecessor.
+	AdjacentFunction = "externalVerifyAdjacent"
+	// NonAdjacentFunction verifies a block against an earlier, non adjacent
+	// one (skipping verification).
+	NonAdjacentFunction = "externalVerifyNonAdjacent"
+	// LatestHeightFunction returns the height of the latest verified block.
+	LatestHeightFunction = "latestSettledHeight"
+	// InitFunction saves the hash of a trusted header, which the saved entry
+	// points verify the next block against.
+	InitFunction = "initBlockData"
+	// TrustedHeaderFunction returns the hash and height of the saved trusted
+	// header.
+	TrustedHeaderFunction = "trustedHeader"
+	// SettledHeaderFunction returns the header hash and validators hash of
+	// a settled height.
+	SettledHeaderFunction = "settledHeader"
+	// DuplicateVoteFunction proves that a validator of a settled validator
+	// set signed two votes for different blocks, and slashes it.
+	DuplicateVoteFunction = "submitDuplicateVote"
+	// LightClientAttackFunction proves that a header other than the settled
+	// one verifies against a settled header, and freezes the verifier.
+	LightClientAttackFunction = "submitLightClientAttack"
+	// FrozenHeightFunction returns the height the verifier was frozen at, 0
+	// if it is not.
+	FrozenHeightFunction = "frozenHeight"
+	// SlashedHeightFunction returns the height a validator was slashed at, 0
+	// if it was not.
+	SlashedHeightFunction = "slashedHeight"
+	// InclusionFunction proves that a leaf is committed to by a field of a
+	// settled header, see types.FeltProof.
+	InclusionFunction = "verifyInclusion"
+)
+
 type SettlementData struct {
+	// Height of the untrusted block whose commit is settled
+	Height             int64
 	CommitmentProposer string
 	ValidatorAddress   string
-	Data               []string
+	// Validators that may send Data, in failover order: the proposer of the
+	// block, then the validators following it in proposer priority.
+	Submitters []string `json:",omitempty"`
+	// Validator expected to send Data. Empty means CommitmentProposer.
+	Submitter string `json:",omitempty"`
+	// Verifier entry point Data is passed to. Empty means AdjacentFunction.
+	Function string `json:",omitempty"`
+	Data     []string
+}
+
+// IsSubmitter reports whether the node of ValidatorAddress is the one
+// expected to send Data.
+func (sd SettlementData) IsSubmitter() bool {
+	submitter := sd.Submitter
+	if submitter == "" {
+		submitter = sd.CommitmentProposer
+	}
+	return sd.ValidatorAddress != "" && sd.ValidatorAddress == submitter
 }
 
 type blockIdFlagData struct {
@@ -108,6 +163,11 @@ type verificationArgs struct {
 	TrustingPeriod durationData `json:"trusting_period"`
 }
 
+type fractionData struct {
+	Numerator   *big.Int `json:"numerator"`
+	Denominator *big.Int `json:"denominator"`
+}
+
 type callData struct {
 	ChainIdArray            []*big.Int       `json:"chain_id_array"`
 	TrustedCommitSigArray   []commitSigData  `json:"trusted_commit_sig_array"`
@@ -119,6 +179,74 @@ type callData struct {
 	VerificationArgs        verificationArgs `json:"verification_args"`
 }
 
+type nonAdjacentCallData struct {
+	ChainIdArray              []*big.Int       `json:"chain_id_array"`
+	TrustedCommitSigArray     []commitSigData  `json:"trusted_commit_sig_array"`
+	UntrustedCommitSigArray   []commitSigData  `json:"untrusted_commit_sig_array"`
+	TrustedValidatorArray     []validatorData  `json:"trusted_validator_array"`
+	UntrustedValidatorArray   []validatorData  `json:"untrusted_validator_array"`
+	Trusted                   signedHeaderArgs `json:"trusted"`
+	Untrusted                 signedHeaderArgs `json:"untrusted"`
+	TrustedValidatorSetArgs   validatorSetArgs `json:"trusted_validator_set_args"`
+	UntrustedValidatorSetArgs validatorSetArgs `json:"untrusted_validator_set_args"`
+	VerificationArgs          verificationArgs `json:"verification_args"`
+	TrustLevel                fractionData     `json:"trust_level"`
+}
+
+type initCallData struct {
+	ChainIdArray          []*big.Int       `json:"chain_id_array"`
+	TrustedCommitSigArray []commitSigData  `json:"trusted_commit_sig_array"`
+	ValidatorArray        []validatorData  `json:"validator_array"`
+	Trusted               signedHeaderArgs `json:"trusted"`
+	ValidatorSetArgs      validatorSetArgs `json:"validator_set_args"`
+	TrustingPeriod        durationData     `json:"trusting_period"`
+}
+
+type voteArgs struct {
+	Type             *big.Int      `json:"type"`
+	Height           *big.Int      `json:"height"`
+	Round            *big.Int      `json:"round"`
+	BlockId          blockIdData   `json:"block_id"`
+	Timestamp        timestampData `json:"timestamp"`
+	ValidatorAddress *big.Int      `json:"validator_address"`
+	Signature        signatureData `json:"signature"`
+}
+
+type duplicateVoteCallData struct {
+	ChainIdArray     []*big.Int       `json:"chain_id_array"`
+	ValidatorArray   []validatorData  `json:"validator_array"`
+	ValidatorSetArgs validatorSetArgs `json:"validator_set_args"`
+	VoteA            voteArgs         `json:"vote_a"`
+	VoteB            voteArgs         `json:"vote_b"`
+}
+
+// lightClientAttackCallData holds the arguments of nonAdjacentCallData, but
+// the current time and trusting period, which the verifier does not take from
+// the caller.
+type lightClientAttackCallData struct {
+	ChainIdArray              []*big.Int       `json:"chain_id_array"`
+	TrustedCommitSigArray     []commitSigData  `json:"trusted_commit_sig_array"`
+	UntrustedCommitSigArray   []commitSigData  `json:"untrusted_commit_sig_array"`
+	TrustedValidatorArray     []validatorData  `json:"trusted_validator_array"`
+	UntrustedValidatorArray   []validatorData  `json:"untrusted_validator_array"`
+	Trusted                   signedHeaderArgs `json:"trusted"`
+	Untrusted                 signedHeaderArgs `json:"untrusted"`
+	TrustedValidatorSetArgs   validatorSetArgs `json:"trusted_validator_set_args"`
+	UntrustedValidatorSetArgs validatorSetArgs `json:"untrusted_validator_set_args"`
+	MaxClockDrift             durationData     `json:"max_clock_drift"`
+	TrustLevel                fractionData     `json:"trust_level"`
+}
+
+type inclusionCallData struct {
+	Height      *big.Int   `json:"height"`
+	Field       *big.Int   `json:"field"`
+	Leaf        []*big.Int `json:"leaf"`
+	Index       *big.Int   `json:"index"`
+	Total       *big.Int   `json:"total"`
+	Aunts       []*big.Int `json:"aunts"`
+	HeaderAunts []*big.Int `json:"header_aunts"`
+}
+
 func formatPartSetHeader(partSetHeader types.PartSetHeader) partSetHeaderData {
 	return partSetHeaderData{
 		Total: big.NewInt(int64(partSetHeader.Total)),
@@ -247,6 +375,18 @@ func formatCommitSigArray(commitSigArray []types.CommitSig) []commitSigData {
 	return commitSigDataArray
 }
 
+func formatVote(vote *types.Vote) voteArgs {
+	return voteArgs{
+		Type:             big.NewInt(int64(vote.Type)),
+		Height:           big.NewInt(vote.Height),
+		Round:            big.NewInt(int64(vote.Round)),
+		BlockId:          formatBlockId(vote.BlockID),
+		Timestamp:        formatTimeStampData(vote.Timestamp),
+		ValidatorAddress: big.NewInt(0).SetBytes(vote.ValidatorAddress),
+		Signature:        formatSignatureData(vote.Signature),
+	}
+}
+
 func formatValidatorArray(validators []*types.Validator) []validatorData {
 	validatorArray := make([]validatorData, len(validators))
 	for i, validator := range validators {
@@ -255,6 +395,12 @@ func formatValidatorArray(validators []*types.Validator) []validatorData {
 	return validatorArray
 }
 
+// ChainIDFelts returns chainID as the verifier takes it, split into 128 bit
+// integers.
+func ChainIDFelts(chainID string) []*big.Int {
+	return formatChainId(chainID)
+}
+
 func formatChainId(chainId string) []*big.Int {
 	chainIDchunks := utils.Split(utils.ByteRounder(16)([]byte(chainId)), 16)
 
@@ -270,21 +416,83 @@ type VerificationConfig struct {
 	CurrentTime    *big.Int
 	MaxClockDrift  *big.Int
 	TrustingPeriod *big.Int
+	// TrustLevel is only used when verifying non adjacent blocks
+	TrustLevel tmmath.Fraction
+	// Names of the verifier entry points, AdjacentFunction and
+	// NonAdjacentFunction if empty
+	AdjacentFunction    string
+	NonAdjacentFunction string
+}
+
+// Function returns the name of the verifier entry point that verifies
+// untrustedLB against trustedLB.
+func (vc VerificationConfig) Function(trustedLB types.LightBlock, untrustedLB types.LightBlock) string {
+	switch VerifyFunction(trustedLB, untrustedLB) {
+	case AdjacentFunction:
+		if vc.AdjacentFunction != "" {
+			return vc.AdjacentFunction
+		}
+		return AdjacentFunction
+	default:
+		if vc.NonAdjacentFunction != "" {
+			return vc.NonAdjacentFunction
+		}
+		return NonAdjacentFunction
+	}
+}
+
+// EntryPoint returns AdjacentFunction or NonAdjacentFunction for function, an
+// entry point name returned by Function. Unknown names are returned as is.
+func (vc VerificationConfig) EntryPoint(function string) string {
+	switch function {
+	case AdjacentFunction, vc.AdjacentFunction:
+		return AdjacentFunction
+	case NonAdjacentFunction, vc.NonAdjacentFunction:
+		return NonAdjacentFunction
+	default:
+		return function
+	}
+}
+
+func formatFraction(fraction tmmath.Fraction) fractionData {
+	return fractionData{
+		Numerator:   new(big.Int).SetUint64(fraction.Numerator),
+		Denominator: new(big.Int).SetUint64(fraction.Denominator),
+	}
 }
 
+// formatCallData returns the arguments of AdjacentFunction. The validators
+// are the ones of untrustedLB, which signed its commit, and differ from the
+// ones of trustedLB when the validator set changed between the two heights.
 func formatCallData(trustedLB types.LightBlock, untrustedLB types.LightBlock, vc VerificationConfig) callData {
 	return callData{
 		ChainIdArray:            formatChainId(trustedLB.ChainID),
 		TrustedCommitSigArray:   formatCommitSigArray(trustedLB.Commit.Signatures),
 		UntrustedCommitSigArray: formatCommitSigArray(untrustedLB.Commit.Signatures),
-		ValidatorArray:          formatValidatorArray(trustedLB.ValidatorSet.Validators),
+		ValidatorArray:          formatValidatorArray(untrustedLB.ValidatorSet.Validators),
 		Trusted:                 formatSignedHeader(*trustedLB.SignedHeader),
 		Untrusted:               formatSignedHeader(*untrustedLB.SignedHeader),
-		ValidatorSetArgs:        formatValidatorSet(trustedLB.ValidatorSet),
+		ValidatorSetArgs:        formatValidatorSet(untrustedLB.ValidatorSet),
 		VerificationArgs:        formatVerificationArgs(vc),
 	}
 }
 
+func formatNonAdjacentCallData(trustedLB types.LightBlock, untrustedLB types.LightBlock, vc VerificationConfig) nonAdjacentCallData {
+	return nonAdjacentCallData{
+		ChainIdArray:              formatChainId(trustedLB.ChainID),
+		TrustedCommitSigArray:     formatCommitSigArray(trustedLB.Commit.Signatures),
+		UntrustedCommitSigArray:   formatCommitSigArray(untrustedLB.Commit.Signatures),
+		TrustedValidatorArray:     formatValidatorArray(trustedLB.ValidatorSet.Validators),
+		UntrustedValidatorArray:   formatValidatorArray(untrustedLB.ValidatorSet.Validators),
+		Trusted:                   formatSignedHeader(*trustedLB.SignedHeader),
+		Untrusted:                 formatSignedHeader(*untrustedLB.SignedHeader),
+		TrustedValidatorSetArgs:   formatValidatorSet(trustedLB.ValidatorSet),
+		UntrustedValidatorSetArgs: formatValidatorSet(untrustedLB.ValidatorSet),
+		VerificationArgs:          formatVerificationArgs(vc),
+		TrustLevel:                formatFraction(vc.TrustLevel),
+	}
+}
+
 func serialize(input interface{}) (res []*big.Int, err error) {
 	v := reflect.ValueOf(input)
 	switch v.Kind() {
@@ -317,8 +525,112 @@ func serialize(input interface{}) (res []*big.Int, err error) {
 	return
 }
 
+// VerifyFunction returns the verifier entry point that verifies untrustedLB
+// against trustedLB.
+func VerifyFunction(trustedLB types.LightBlock, untrustedLB types.LightBlock) string {
+	if untrustedLB.Height == trustedLB.Height+1 {
+		return AdjacentFunction
+	}
+	return NonAdjacentFunction
+}
+
+// ParseInput returns the calldata of the entry point returned by
+// VerifyFunction for the same blocks.
 func ParseInput(trustedLB types.LightBlock, untrustedLB types.LightBlock, vc VerificationConfig) (inputs []string, err error) {
-	callData := formatCallData(trustedLB, untrustedLB, vc)
+	var callData interface{}
+	if VerifyFunction(trustedLB, untrustedLB) == AdjacentFunction {
+		callData = formatCallData(trustedLB, untrustedLB, vc)
+	} else {
+		callData = formatNonAdjacentCallData(trustedLB, untrustedLB, vc)
+	}
+	return toFelts(callData)
+}
+
+// ParseInitInput returns the calldata of InitFunction, trusting trustedLB.
+// The verifier proves light client attacks with trustingPeriod.
+func ParseInitInput(trustedLB types.LightBlock, trustingPeriod time.Duration) (inputs []string, err error) {
+	return toFelts(initCallData{
+		ChainIdArray:          formatChainId(trustedLB.ChainID),
+		TrustedCommitSigArray: formatCommitSigArray(trustedLB.Commit.Signatures),
+		ValidatorArray:        formatValidatorArray(trustedLB.ValidatorSet.Validators),
+		Trusted:               formatSignedHeader(*trustedLB.SignedHeader),
+		ValidatorSetArgs:      formatValidatorSet(trustedLB.ValidatorSet),
+		TrustingPeriod:        formatDurationData(big.NewInt(trustingPeriod.Nanoseconds())),
+	})
+}
+
+// ParseDuplicateVoteInput returns the calldata of DuplicateVoteFunction
+// proving ev. vals is the validator set of the height of the votes, which the
+// verifier must have settled.
+func ParseDuplicateVoteInput(chainID string, ev *types.DuplicateVoteEvidence, vals *types.ValidatorSet) (inputs []string, err error) {
+	return toFelts(duplicateVoteCallData{
+		ChainIdArray:     formatChainId(chainID),
+		ValidatorArray:   formatValidatorArray(vals.Validators),
+		ValidatorSetArgs: formatValidatorSet(vals),
+		VoteA:            formatVote(ev.VoteA),
+		VoteB:            formatVote(ev.VoteB),
+	})
+}
+
+// ParseLightClientAttackInput returns the calldata of
+// LightClientAttackFunction proving ev, its conflicting block verified against
+// trustedLB, a settled block. The arguments are the ones of
+// NonAdjacentFunction, whether the blocks are adjacent or not, but the current
+// time and trusting period of vc: the verifier uses the block timestamp and
+// its own trusting period.
+func ParseLightClientAttackInput(trustedLB types.LightBlock, ev *types.LightClientAttackEvidence, vc VerificationConfig) (inputs []string, err error) {
+	untrustedLB := *ev.ConflictingBlock
+	return toFelts(lightClientAttackCallData{
+		ChainIdArray:              formatChainId(trustedLB.ChainID),
+		TrustedCommitSigArray:     formatCommitSigArray(trustedLB.Commit.Signatures),
+		UntrustedCommitSigArray:   formatCommitSigArray(untrustedLB.Commit.Signatures),
+		TrustedValidatorArray:     formatValidatorArray(trustedLB.ValidatorSet.Validators),
+		UntrustedValidatorArray:   formatValidatorArray(untrustedLB.ValidatorSet.Validators),
+		Trusted:                   formatSignedHeader(*trustedLB.SignedHeader),
+		Untrusted:                 formatSignedHeader(*untrustedLB.SignedHeader),
+		TrustedValidatorSetArgs:   formatValidatorSet(trustedLB.ValidatorSet),
+		UntrustedValidatorSetArgs: formatValidatorSet(untrustedLB.ValidatorSet),
+		MaxClockDrift:             formatDurationData(vc.MaxClockDrift),
+		TrustLevel:                formatFraction(vc.TrustLevel),
+	})
+}
+
+// ParseInclusionInput returns the calldata of InclusionFunction proving
+// proof. The leaf is split into 128 bit integers, the hashes are felts.
+func ParseInclusionInput(proof types.FeltProof) (inputs []string, err error) {
+	return toFelts(inclusionCallData{
+		Height:      big.NewInt(proof.Height),
+		Field:       big.NewInt(int64(proof.Field)),
+		Leaf:        formatInt128Array(proof.Leaf),
+		Index:       big.NewInt(proof.Proof.Index),
+		Total:       big.NewInt(proof.Proof.Total),
+		Aunts:       formatFeltArray(proof.Proof.Aunts),
+		HeaderAunts: formatFeltArray(proof.HeaderProof.Aunts),
+	})
+}
+
+// formatInt128Array splits bz into 16 byte chunks, the first one left padded
+// with zeros, the way the Int128 Merkle tree hashes its leaves.
+func formatInt128Array(bz []byte) []*big.Int {
+	chunks := utils.Split(utils.ByteRounder(16)(bz), 16)
+	res := make([]*big.Int, len(chunks))
+	for i, chunk := range chunks {
+		res[i] = new(big.Int).SetBytes(chunk)
+	}
+	return res
+}
+
+func formatFeltArray(hashes [][]byte) []*big.Int {
+	res := make([]*big.Int, len(hashes))
+	for i, hash := range hashes {
+		res[i] = new(big.Int).SetBytes(hash)
+	}
+	return res
+}
+
+// toFelts serializes callData into felts, negative values taken modulo the
+// field prime.
+func toFelts(callData interface{}) (inputs []string, err error) {
 	bigInts, err := serialize(callData)
 	if err != nil {
 		return
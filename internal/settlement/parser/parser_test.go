@@ -4,12 +4,14 @@ import (
 	"encoding/json"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/tendermint/types"
 
 	tmjson "github.com/tendermint/tendermint/libs/json"
+	tmmath "github.com/tendermint/tendermint/libs/math"
 )
 
 func TestFormatSignedHeader(t *testing.T) {
@@ -59,6 +61,10 @@ func loadFromStings(trustedLightBlockString, untrustedLightBlockString, validato
 	if err := tmjson.Unmarshal([]byte(untrustedLightBlockString), &untrustedLightBlock); err != nil {
 		panic(err)
 	}
+	if err := tmjson.Unmarshal([]byte(validatorSetString), &untrustedLightBlock.ValidatorSet); err != nil {
+		panic(err)
+	}
+	untrustedLightBlock.ValidatorSet.Proposer = untrustedLightBlock.ValidatorSet.Validators[0]
 	return
 }
 
@@ -249,3 +255,109 @@ func TestSerializeE2E(t *testing.T) {
 	}
 	require.Equal(t, expected, res)
 }
+
+func TestParseInputNonAdjacent(t *testing.T) {
+	// setup
+	trustedLightBlockString := `{"signed_header":{"header":{"version":{"block":"11","app":"1"},"chain_id":"test-chain-IrF74Y","height":"2","time":"2022-11-04T17:43:45.220479Z","last_block_id":{"hash":"038E1EFB6F2C0B4AA1051C0A9B4494B0A7CF34D81C76E6C161B164249A660ABF","parts":{"total":1,"hash":"06A9F404CEC26739C0E7FBDC46DAC64B9151D3A14FA4BB8B4DFD1785D3B14C15"}},"last_commit_hash":"06BE053E669912201CFE99C43884AB9AC38713AC888873B375588A4C401428F6","data_hash":"049EE3EBA8C1600700EE1B87EB599F16716B0B1022947733551FDE4050CA6804","validators_hash":"0241C0593DCFA3154B864E19E3AB6C03D2B79181BE7DC6565C9B6C68EA4D47F6","next_validators_hash":"0241C0593DCFA3154B864E19E3AB6C03D2B79181BE7DC6565C9B6C68EA4D47F6","consensus_hash":"00848270D575B49884653D7B3ED720EB84CE99D064D3BD3210FE23BFB811CB66","app_hash":"0000000000000000000000000000000000000000000000000000000000000000","last_results_hash":"049EE3EBA8C1600700EE1B87EB599F16716B0B1022947733551FDE4050CA6804","evidence_hash":"049EE3EBA8C1600700EE1B87EB599F16716B0B1022947733551FDE4050CA6804","proposer_address":"06EBC607235127FDABA1DB1A9CE71A34E7B880084F7188B03E7A3A1F0334DDBD"},"commit":{"height":"2","round":0,"block_id":{"hash":"048A972F4E947BBBF4E9E0AF350AD233EC6E394903728B3D3F8488F168915C16","parts":{"total":1,"hash":"04A7BCD4D5AEED5C99B3530549E83C7DACA49102542B20E28C48FBD0E911A622"}},"signatures":[{"block_id_flag":2,"validator_address":"06EBC607235127FDABA1DB1A9CE71A34E7B880084F7188B03E7A3A1F0334DDBD","timestamp":"2022-11-04T17:43:46.755686Z","signature":"BWkRvub8iP9VltjYMrfDekOL/0WjijYEIUjbkVnSntQDMU0B4izOLRPcJqub0t0AHyDPCOvx+4w14gdeKSxfmQ=="}]}},"canonical":false}`
+	untrustedLightBlockString := `{"signed_header":{"header":{"version":{"block":"11","app":"1"},"chain_id":"test-chain-IrF74Y","height":"3","time":"2022-11-04T17:43:48.879554Z","last_block_id":{"hash":"048A972F4E947BBBF4E9E0AF350AD233EC6E394903728B3D3F8488F168915C16","parts":{"total":1,"hash":"04A7BCD4D5AEED5C99B3530549E83C7DACA49102542B20E28C48FBD0E911A622"}},"last_commit_hash":"03DEA59253B9502F1AEDEA3A4FADFFB57C3229266AA8601BCEC23BA292D5A347","data_hash":"049EE3EBA8C1600700EE1B87EB599F16716B0B1022947733551FDE4050CA6804","validators_hash":"0241C0593DCFA3154B864E19E3AB6C03D2B79181BE7DC6565C9B6C68EA4D47F6","next_validators_hash":"0241C0593DCFA3154B864E19E3AB6C03D2B79181BE7DC6565C9B6C68EA4D47F6","consensus_hash":"00848270D575B49884653D7B3ED720EB84CE99D064D3BD3210FE23BFB811CB66","app_hash":"0000000000000000000000000000000000000000000000000000000000000000","last_results_hash":"049EE3EBA8C1600700EE1B87EB599F16716B0B1022947733551FDE4050CA6804","evidence_hash":"049EE3EBA8C1600700EE1B87EB599F16716B0B1022947733551FDE4050CA6804","proposer_address":"06EBC607235127FDABA1DB1A9CE71A34E7B880084F7188B03E7A3A1F0334DDBD"},"commit":{"height":"3","round":0,"block_id":{"hash":"03054DF71090EE602E6C0A433B949B726DC20719BD8448E281679A4F78810B7B","parts":{"total":1,"hash":"0424FD36683BB2F85C88BF098088937769FD788C422E63D66714F939F7E77FB9"}},"signatures":[{"block_id_flag":2,"validator_address":"06EBC607235127FDABA1DB1A9CE71A34E7B880084F7188B03E7A3A1F0334DDBD","timestamp":"2022-11-04T17:43:50.41826Z","signature":"BC6w1ASm4R4jq2or6mjD2ROgaYr4WcAT2GskhCOcPQIGXVoVV7JfLXnRsQEaPSjmtjr4ZIOt06InimmziDjqTw=="}]}},"canonical":true}`
+	validatorSetString := `{"block_height":"3","validators":[{"address":"06EBC607235127FDABA1DB1A9CE71A34E7B880084F7188B03E7A3A1F0334DDBD","pub_key":{"type":"tendermint/PubKeyStark","value":"AHzA3ABEpcfPL3+Zfmdm4fGb1MBih2zMt0m1iyqS5KsAoJVUlan320a55nvQrj1ilGjRDSPZqeaLyKbEe6KT3g=="},"voting_power":"10","proposer_priority":"0"}],"count":"1","total":"1"}`
+	trustedLightBlock, untrustedLightBlock := loadFromStings(trustedLightBlockString, untrustedLightBlockString, validatorSetString)
+	untrustedLightBlock.ValidatorSet = trustedLightBlock.ValidatorSet
+	vc := VerificationConfig{
+		CurrentTime:    big.NewInt(1665753884507526850),
+		MaxClockDrift:  big.NewInt(10),
+		TrustingPeriod: big.NewInt(999999999999999999),
+		TrustLevel:     tmmath.Fraction{Numerator: 1, Denominator: 3},
+	}
+
+	// adjacent blocks keep the externalVerifyAdjacent calldata
+	require.Equal(t, AdjacentFunction, VerifyFunction(trustedLightBlock, untrustedLightBlock))
+	inputs, err := ParseInput(trustedLightBlock, untrustedLightBlock, vc)
+	require.NoError(t, err)
+	require.Len(t, inputs, 70)
+
+	// skip heights 3 and 4
+	untrustedLightBlock.Height = 5
+	untrustedLightBlock.Commit.Height = 5
+	require.Equal(t, NonAdjacentFunction, VerifyFunction(trustedLightBlock, untrustedLightBlock))
+	require.Equal(t, NonAdjacentFunction, vc.Function(trustedLightBlock, untrustedLightBlock))
+	renamed := vc
+	renamed.NonAdjacentFunction = "verifySkipping"
+	require.Equal(t, "verifySkipping", renamed.Function(trustedLightBlock, untrustedLightBlock))
+	require.Equal(t, NonAdjacentFunction, renamed.EntryPoint("verifySkipping"))
+	require.Equal(t, AdjacentFunction, renamed.EntryPoint(AdjacentFunction))
+	inputs, err = ParseInput(trustedLightBlock, untrustedLightBlock, vc)
+	require.NoError(t, err)
+
+	// arguments of externalVerifyNonAdjacent, in order
+	var expected []string
+	for _, arg := range []interface{}{
+		formatChainId(trustedLightBlock.ChainID),
+		formatCommitSigArray(trustedLightBlock.Commit.Signatures),
+		formatCommitSigArray(untrustedLightBlock.Commit.Signatures),
+		formatValidatorArray(trustedLightBlock.ValidatorSet.Validators),
+		formatValidatorArray(untrustedLightBlock.ValidatorSet.Validators),
+		formatSignedHeader(*trustedLightBlock.SignedHeader),
+		formatSignedHeader(*untrustedLightBlock.SignedHeader),
+		formatValidatorSet(trustedLightBlock.ValidatorSet),
+		formatValidatorSet(untrustedLightBlock.ValidatorSet),
+		formatVerificationArgs(vc),
+	} {
+		bigInts, err := serialize(arg)
+		require.NoError(t, err)
+		for _, b := range bigInts {
+			expected = append(expected, b.String())
+		}
+	}
+	expected = append(expected, "1", "3")
+
+	require.Len(t, inputs, 70+5+5+2)
+	require.Equal(t, expected, inputs)
+	require.Equal(t, "5", inputs[3+6+6+5+5+21+2], "untrusted height")
+}
+
+func TestParseInitInput(t *testing.T) {
+	trustedLightBlockString := `{"signed_header":{"header":{"version":{"block":"11","app":"1"},"chain_id":"test-chain-IrF74Y","height":"2","time":"2022-11-04T17:43:45.220479Z","last_block_id":{"hash":"038E1EFB6F2C0B4AA1051C0A9B4494B0A7CF34D81C76E6C161B164249A660ABF","parts":{"total":1,"hash":"06A9F404CEC26739C0E7FBDC46DAC64B9151D3A14FA4BB8B4DFD1785D3B14C15"}},"last_commit_hash":"06BE053E669912201CFE99C43884AB9AC38713AC888873B375588A4C401428F6","data_hash":"049EE3EBA8C1600700EE1B87EB599F16716B0B1022947733551FDE4050CA6804","validators_hash":"0241C0593DCFA3154B864E19E3AB6C03D2B79181BE7DC6565C9B6C68EA4D47F6","next_validators_hash":"0241C0593DCFA3154B864E19E3AB6C03D2B79181BE7DC6565C9B6C68EA4D47F6","consensus_hash":"00848270D575B49884653D7B3ED720EB84CE99D064D3BD3210FE23BFB811CB66","app_hash":"0000000000000000000000000000000000000000000000000000000000000000","last_results_hash":"049EE3EBA8C1600700EE1B87EB599F16716B0B1022947733551FDE4050CA6804","evidence_hash":"049EE3EBA8C1600700EE1B87EB599F16716B0B1022947733551FDE4050CA6804","proposer_address":"06EBC607235127FDABA1DB1A9CE71A34E7B880084F7188B03E7A3A1F0334DDBD"},"commit":{"height":"2","round":0,"block_id":{"hash":"048A972F4E947BBBF4E9E0AF350AD233EC6E394903728B3D3F8488F168915C16","parts":{"total":1,"hash":"04A7BCD4D5AEED5C99B3530549E83C7DACA49102542B20E28C48FBD0E911A622"}},"signatures":[{"block_id_flag":2,"validator_address":"06EBC607235127FDABA1DB1A9CE71A34E7B880084F7188B03E7A3A1F0334DDBD","timestamp":"2022-11-04T17:43:46.755686Z","signature":"BWkRvub8iP9VltjYMrfDekOL/0WjijYEIUjbkVnSntQDMU0B4izOLRPcJqub0t0AHyDPCOvx+4w14gdeKSxfmQ=="}]}},"canonical":false}`
+	validatorSetString := `{"block_height":"3","validators":[{"address":"06EBC607235127FDABA1DB1A9CE71A34E7B880084F7188B03E7A3A1F0334DDBD","pub_key":{"type":"tendermint/PubKeyStark","value":"AHzA3ABEpcfPL3+Zfmdm4fGb1MBih2zMt0m1iyqS5KsAoJVUlan320a55nvQrj1ilGjRDSPZqeaLyKbEe6KT3g=="},"voting_power":"10","proposer_priority":"0"}],"count":"1","total":"1"}`
+	trustedLightBlock, _ := loadFromStings(trustedLightBlockString, trustedLightBlockString, validatorSetString)
+
+	inputs, err := ParseInitInput(trustedLightBlock, 168*time.Hour)
+	require.NoError(t, err)
+
+	// arguments of initBlockData, in order
+	var expected []string
+	for _, arg := range []interface{}{
+		formatChainId(trustedLightBlock.ChainID),
+		formatCommitSigArray(trustedLightBlock.Commit.Signatures),
+		formatValidatorArray(trustedLightBlock.ValidatorSet.Validators),
+		formatSignedHeader(*trustedLightBlock.SignedHeader),
+		formatValidatorSet(trustedLightBlock.ValidatorSet),
+		formatDurationData(big.NewInt(int64(168 * time.Hour))),
+	} {
+		bigInts, err := serialize(arg)
+		require.NoError(t, err)
+		for _, b := range bigInts {
+			expected = append(expected, b.String())
+		}
+	}
+	require.Equal(t, expected, inputs)
+	require.Equal(t, "2", inputs[3+6+5+2], "trusted height")
+}
+
+func TestSettlementDataIsSubmitter(t *testing.T) {
+	testCases := []struct {
+		data     SettlementData
+		expected bool
+	}{
+		0: {SettlementData{CommitmentProposer: "A", ValidatorAddress: "A"}, true},
+		1: {SettlementData{CommitmentProposer: "A", ValidatorAddress: "B"}, false},
+		// full nodes never submit
+		2: {SettlementData{CommitmentProposer: "", ValidatorAddress: ""}, false},
+		// taken over from the proposer
+		3: {SettlementData{CommitmentProposer: "A", ValidatorAddress: "A", Submitter: "B"}, false},
+		4: {SettlementData{CommitmentProposer: "A", ValidatorAddress: "B", Submitter: "B"}, true},
+	}
+	for i, tc := range testCases {
+		assert.Equal(t, tc.expected, tc.data.IsSubmitter(), "testCase%d failed", i)
+	}
+}
@@ -0,0 +1,124 @@
+package settlement
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/settlement/parser"
+	tmmath "github.com/tendermint/tendermint/libs/math"
+	"github.com/tendermint/tendermint/types"
+)
+
+// Policy selects the heights that are settled. Heights in between are
+// skipped, and the next settled height is verified against the last settled
+// one with skipping verification. The zero value settles every height.
+type Policy struct {
+	// Mode is one of the config.SettlementMode* modes. Empty means
+	// config.SettlementModeEveryHeight.
+	Mode string
+	// Interval between settled heights in config.SettlementModeInterval.
+	Interval int64
+	// TrustLevel of the last settled validator set a skipped-to height must be
+	// signed by.
+	TrustLevel tmmath.Fraction
+	// FailoverTimeout each validator in the failover order of a height waits
+	// for the one before it. Zero leaves settlement to the proposer alone.
+	FailoverTimeout time.Duration
+	// StartHeight is the first height that is settled.
+	StartHeight int64
+
+	// Verification parameters of the verifier, see parser.VerificationConfig.
+	TrustingPeriod      time.Duration
+	MaxClockDrift       time.Duration
+	AdjacentFunction    string
+	NonAdjacentFunction string
+}
+
+// NewPolicy returns the policy configured in cfg.
+func NewPolicy(cfg *config.SettlementConfig) (Policy, error) {
+	trustLevel, err := cfg.TrustLevelFraction()
+	if err != nil {
+		return Policy{}, fmt.Errorf("invalid trust level: %w", err)
+	}
+	return Policy{
+		Mode:            cfg.Mode,
+		Interval:        cfg.Interval,
+		TrustLevel:      trustLevel,
+		FailoverTimeout: cfg.FailoverTimeout,
+		StartHeight:     cfg.StartHeight,
+
+		TrustingPeriod:      cfg.TrustingPeriod,
+		MaxClockDrift:       cfg.MaxClockDrift,
+		AdjacentFunction:    cfg.AdjacentFunction,
+		NonAdjacentFunction: cfg.NonAdjacentFunction,
+	}, nil
+}
+
+// NewTargetPolicy returns the policy configured in cfg, with the heights
+// selected by target, one of the settlement targets, and its start height.
+func NewTargetPolicy(cfg *config.SettlementConfig, target *config.SettlementTargetConfig) (Policy, error) {
+	policy, err := NewPolicy(cfg)
+	if err != nil {
+		return Policy{}, err
+	}
+	policy.Mode = target.Mode
+	policy.Interval = target.Interval
+	policy.StartHeight = target.StartHeight
+	return policy, nil
+}
+
+// VerificationConfig returns the parameters blocks are verified with at now.
+func (p Policy) VerificationConfig(now time.Time) parser.VerificationConfig {
+	return parser.VerificationConfig{
+		CurrentTime:         big.NewInt(now.UnixNano()),
+		MaxClockDrift:       big.NewInt(p.MaxClockDrift.Nanoseconds()),
+		TrustingPeriod:      big.NewInt(p.TrustingPeriod.Nanoseconds()),
+		TrustLevel:          p.TrustLevel,
+		AdjacentFunction:    p.AdjacentFunction,
+		NonAdjacentFunction: p.NonAdjacentFunction,
+	}
+}
+
+// ShouldSettle reports whether the block with header is settled. prev is the
+// header of the block before it.
+//
+// Heights at which the validator set changes are always settled in the
+// skipping modes: the last height signed by the old set, and the first one
+// signed by the new set. That way the new set never needs to be trusted
+// through the old one, which may not have trust level in common with it.
+// Heights committing evidence are always settled too, as their evidence is
+// submitted along with them.
+func (p Policy) ShouldSettle(prev, header *types.Header) bool {
+	if header.Height < p.StartHeight {
+		return false
+	}
+	switch p.Mode {
+	case config.SettlementModeInterval:
+		return header.Height%p.Interval == 0 || validatorSetChanged(prev, header) || hasEvidence(header)
+	case config.SettlementModeValidatorSetChange:
+		return validatorSetChanged(prev, header) || hasEvidence(header)
+	default:
+		return true
+	}
+}
+
+// validatorSetChanged reports whether header is the last one signed by a
+// validator set or the first one signed by a new set.
+func validatorSetChanged(prev, header *types.Header) bool {
+	return !bytes.Equal(header.ValidatorsHash, header.NextValidatorsHash) ||
+		!bytes.Equal(prev.ValidatorsHash, header.ValidatorsHash)
+}
+
+// emptyEvidenceHash returns the evidence hash of blocks without evidence,
+// under the commitment hash in use.
+func emptyEvidenceHash() []byte {
+	return types.EvidenceList(nil).Hash()
+}
+
+// hasEvidence reports whether the block of header commits evidence.
+func hasEvidence(header *types.Header) bool {
+	return len(header.EvidenceHash) > 0 && !bytes.Equal(header.EvidenceHash, emptyEvidenceHash())
+}
@@ -0,0 +1,122 @@
+package settlement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/internal/settlement/parser"
+	tmmath "github.com/tendermint/tendermint/libs/math"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestNewPolicy(t *testing.T) {
+	cfg := config.DefaultSettlementConfig()
+	cfg.Mode = config.SettlementModeInterval
+	cfg.Interval = 10
+	cfg.TrustLevel = "2/3"
+	cfg.FailoverTimeout = time.Minute
+
+	policy, err := NewPolicy(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, Policy{
+		Mode:                config.SettlementModeInterval,
+		Interval:            10,
+		TrustLevel:          tmmath.Fraction{Numerator: 2, Denominator: 3},
+		FailoverTimeout:     time.Minute,
+		TrustingPeriod:      cfg.TrustingPeriod,
+		MaxClockDrift:       cfg.MaxClockDrift,
+		AdjacentFunction:    cfg.AdjacentFunction,
+		NonAdjacentFunction: cfg.NonAdjacentFunction,
+	}, policy)
+
+	// targets select their own heights
+	target := &config.SettlementTargetConfig{Mode: config.SettlementModeValidatorSetChange, Interval: 5, StartHeight: 8}
+	targetPolicy, err := NewTargetPolicy(cfg, target)
+	require.NoError(t, err)
+	assert.Equal(t, config.SettlementModeValidatorSetChange, targetPolicy.Mode)
+	assert.EqualValues(t, 5, targetPolicy.Interval)
+	assert.EqualValues(t, 8, targetPolicy.StartHeight)
+	assert.Equal(t, policy.TrustLevel, targetPolicy.TrustLevel)
+
+	cfg.TrustLevel = "two thirds"
+	_, err = NewPolicy(cfg)
+	assert.Error(t, err)
+}
+
+func TestPolicyShouldSettle(t *testing.T) {
+	valsA, valsB := []byte("A"), []byte("B")
+	header := func(height int64, vals, nextVals []byte) *types.Header {
+		return &types.Header{Height: height, ValidatorsHash: vals, NextValidatorsHash: nextVals}
+	}
+
+	testCases := []struct {
+		mode     string
+		prev     *types.Header
+		header   *types.Header
+		expected bool
+	}{
+		0: {"", header(4, valsA, valsA), header(5, valsA, valsA), true},
+		1: {config.SettlementModeEveryHeight, header(4, valsA, valsA), header(5, valsA, valsA), true},
+		2: {config.SettlementModeInterval, header(4, valsA, valsA), header(5, valsA, valsA), false},
+		3: {config.SettlementModeInterval, header(9, valsA, valsA), header(10, valsA, valsA), true},
+		// last height of the old set
+		4: {config.SettlementModeInterval, header(4, valsA, valsA), header(5, valsA, valsB), true},
+		// first height of the new set
+		5: {config.SettlementModeInterval, header(5, valsA, valsB), header(6, valsB, valsB), true},
+		6: {config.SettlementModeValidatorSetChange, header(9, valsA, valsA), header(10, valsA, valsA), false},
+		7: {config.SettlementModeValidatorSetChange, header(4, valsA, valsA), header(5, valsA, valsB), true},
+		8: {config.SettlementModeValidatorSetChange, header(5, valsA, valsB), header(6, valsB, valsB), true},
+		9: {config.SettlementModeValidatorSetChange, header(6, valsB, valsB), header(7, valsB, valsB), false},
+	}
+
+	for i, tc := range testCases {
+		policy := Policy{Mode: tc.mode, Interval: 10}
+		assert.Equal(t, tc.expected, policy.ShouldSettle(tc.prev, tc.header), "testCase%d failed", i)
+	}
+
+	// nothing is settled before the start height
+	policy := Policy{StartHeight: 6}
+	assert.False(t, policy.ShouldSettle(header(4, valsA, valsA), header(5, valsA, valsB)))
+	assert.True(t, policy.ShouldSettle(header(5, valsA, valsA), header(6, valsA, valsA)))
+}
+
+func TestPolicyVerificationConfig(t *testing.T) {
+	policy, err := NewPolicy(config.DefaultSettlementConfig())
+	require.NoError(t, err)
+
+	now := time.Unix(1665753884, 507526850)
+	vc := policy.VerificationConfig(now)
+	assert.EqualValues(t, now.UnixNano(), vc.CurrentTime.Int64())
+	assert.EqualValues(t, 10*time.Second, vc.MaxClockDrift.Int64())
+	assert.EqualValues(t, 168*time.Hour, vc.TrustingPeriod.Int64())
+	assert.Equal(t, tmmath.Fraction{Numerator: 1, Denominator: 3}, vc.TrustLevel)
+	assert.Equal(t, parser.AdjacentFunction, vc.AdjacentFunction)
+	assert.Equal(t, parser.NonAdjacentFunction, vc.NonAdjacentFunction)
+}
+
+func TestPolicyShouldSettleEvidence(t *testing.T) {
+	vals := []byte("A")
+	header := func(height int64, evidenceHash []byte) *types.Header {
+		return &types.Header{Height: height, ValidatorsHash: vals, NextValidatorsHash: vals, EvidenceHash: evidenceHash}
+	}
+	evidenceHash := []byte("evidence")
+
+	for i, mode := range []string{config.SettlementModeInterval, config.SettlementModeValidatorSetChange} {
+		policy := Policy{Mode: mode, Interval: 10}
+		assert.False(t, policy.ShouldSettle(header(4, nil), header(5, emptyEvidenceHash())), "testCase%d failed", i)
+		assert.True(t, policy.ShouldSettle(header(4, nil), header(5, evidenceHash)), "testCase%d failed", i)
+	}
+
+	// the empty evidence hash follows the commitment hash of the chain
+	pedersenEmpty := emptyEvidenceHash()
+	require.NoError(t, crypto.SetCommitmentHash(crypto.CommitmentHashPoseidon))
+	t.Cleanup(func() { require.NoError(t, crypto.SetCommitmentHash(crypto.CommitmentHashPedersen)) })
+	policy := Policy{Mode: config.SettlementModeValidatorSetChange}
+	assert.False(t, policy.ShouldSettle(header(4, nil), header(5, emptyEvidenceHash())))
+	assert.True(t, policy.ShouldSettle(header(4, nil), header(5, pedersenEmpty)))
+}
@@ -1,15 +1,20 @@
 package protostar
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math/big"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
-	"time"
 
 	"github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/libs/log"
 
 	"github.com/tendermint/tendermint/internal/settlement/parser"
+	"github.com/tendermint/tendermint/internal/settlement/retry"
 	"github.com/tendermint/tendermint/internal/settlement/utils"
 )
 
@@ -54,12 +59,162 @@ func getMulticallTxHashFelt(rawStdout []byte) (string, error) {
 	return utils.RegexFunctionFactory(`(?m)^transaction hash: (0x[A-Fa-f0-9]*$)`, "multicall transaction hash")(rawStdout)
 }
 
-func Declare(logger log.Logger, pConf *config.ProtostarConfig, contractPath string) (classHashHex, transactionHashHex string, err error) {
-	commandArgs := []string{"protostar", "--no-color", "declare", contractPath, "--max-fee", "auto", "--wait-for-acceptance"}
+func getSettledHeight(rawStdout []byte) (string, error) {
+	return utils.RegexFunctionFactory(`(?m)"height": ([0-9]+)`, "settled height")(rawStdout)
+}
+
+func getTrustedHeaderHash(rawStdout []byte) (string, error) {
+	return utils.RegexFunctionFactory(`(?m)"hash": ([0-9]+)`, "trusted header hash")(rawStdout)
+}
+
+func getSettledHeaderHash(rawStdout []byte) (string, error) {
+	return utils.RegexFunctionFactory(`(?m)"hash": ([0-9]+)`, "settled header hash")(rawStdout)
+}
+
+// call calls the view function of the contract at contractAddress with
+// inputs.
+func call(pConf *config.ProtostarConfig, contractAddress, function string, inputs ...string) ([]byte, error) {
+	commandArgs := []string{"protostar", "--no-color", "call",
+		"--contract-address", contractAddress, "--function", function}
+	if len(inputs) > 0 {
+		commandArgs = append(append(commandArgs, "--inputs"), inputs...)
+	}
+
+	// calls are not sent from an account
+	var callArgs []string
+	callArgs = utils.AppendKeyWithValueIfNotEmpty(callArgs, "--chain-id", pConf.ChainId)
+	callArgs = utils.AppendKeyWithValueIfNotEmpty(callArgs, "--gateway-url", pConf.GatewayUrl)
+	callArgs = utils.AppendKeyWithValueIfNotEmpty(callArgs, "--network", pConf.Network)
+
+	stdout, err := utils.ExecuteCommand(commandArgs, callArgs)
+	if err != nil {
+		return nil, fmt.Errorf("protostar call command responded with an error: %w", err)
+	}
+	return stdout, nil
+}
+
+// LatestSettledHeight returns the height of the latest block verified by the
+// verifier at contractAddress.
+func LatestSettledHeight(pConf *config.ProtostarConfig, contractAddress string) (int64, error) {
+	stdout, err := call(pConf, contractAddress, parser.LatestHeightFunction)
+	if err != nil {
+		return 0, err
+	}
+	height, err := getSettledHeight(stdout)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(height, 10, 64)
+}
+
+// TrustedHeader returns the hash and height of the trusted header saved by
+// the verifier at contractAddress.
+func TrustedHeader(pConf *config.ProtostarConfig, contractAddress string) (hash *big.Int, height int64, err error) {
+	stdout, err := call(pConf, contractAddress, parser.TrustedHeaderFunction)
+	if err != nil {
+		return nil, 0, err
+	}
+	hashFelt, err := getTrustedHeaderHash(stdout)
+	if err != nil {
+		return nil, 0, err
+	}
+	hash, ok := new(big.Int).SetString(hashFelt, 10)
+	if !ok {
+		return nil, 0, fmt.Errorf("invalid trusted header hash %q", hashFelt)
+	}
+	heightFelt, err := getSettledHeight(stdout)
+	if err != nil {
+		return nil, 0, err
+	}
+	height, err = strconv.ParseInt(heightFelt, 10, 64)
+	return hash, height, err
+}
 
-	stdout, err := ExecuteCommandUntilNoGasFeeError(logger, commandArgs, networkArgs(pConf), "declare")
+// SettledHeader returns the hash of the header settled at height by the
+// verifier at contractAddress, zero if it settled none.
+func SettledHeader(pConf *config.ProtostarConfig, contractAddress string, height int64) (*big.Int, error) {
+	stdout, err := call(pConf, contractAddress, parser.SettledHeaderFunction, strconv.FormatInt(height, 10))
 	if err != nil {
-		err = fmt.Errorf("protostar declare command responded with an error:\n%s", err)
+		return nil, err
+	}
+	hashFelt, err := getSettledHeaderHash(stdout)
+	if err != nil {
+		return nil, err
+	}
+	hash, ok := new(big.Int).SetString(hashFelt, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid settled header hash %q", hashFelt)
+	}
+	return hash, nil
+}
+
+// acceptancePollAttempts bounds the number of times a multicall is looked up
+// while waiting for it to be accepted or rejected.
+const acceptancePollAttempts = 20
+
+// feeErrors are fragments of the output of protostar and starknet when a
+// transaction costs more than the fee it was sent with.
+var feeErrors = []string{
+	"Actual fee exceeded max fee",
+	"Max fee is smaller than the minimal transaction cost",
+}
+
+// transientErrors are fragments of the output of protostar and starknet on
+// errors that may go away on their own.
+var transientErrors = []string{
+	"Invalid transaction nonce",
+	"Cannot connect to host",
+	"ClientConnectorError",
+	"TimeoutError",
+	"429 Too Many Requests",
+	"503 Service Unavailable",
+}
+
+// errRejectedForFee is returned when a multicall is rejected for running out
+// of fee, so that it is sent again.
+var errRejectedForFee = errors.New("transaction rejected for exceeding its max fee")
+
+func containsAny(output string, fragments []string) bool {
+	for _, fragment := range fragments {
+		if strings.Contains(output, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// classifyError marks the errors of protostar and starknet commands that may
+// succeed when tried again as retryable.
+func classifyError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if containsAny(err.Error(), feeErrors) || containsAny(err.Error(), transientErrors) {
+		return retry.Retryable(err)
+	}
+	return err
+}
+
+// executeWithRetry executes the command as long as it fails with retryable
+// errors, within the bounds of policy.
+func executeWithRetry(ctx context.Context, logger log.Logger, policy retry.Policy, name string, commandArgs, networkArgs []string) ([]byte, error) {
+	var stdout []byte
+	err := policy.Do(ctx, logger, "protostar "+name, func(ctx context.Context, attempt int) error {
+		var err error
+		stdout, err = utils.ExecuteCommand(commandArgs, networkArgs)
+		return classifyError(err)
+	})
+	return stdout, err
+}
+
+// Declare declares the contract class at contractPath, waiting for the
+// transaction to be accepted.
+func Declare(ctx context.Context, logger log.Logger, pConf *config.ProtostarConfig, policy retry.Policy, contractPath string) (classHashHex, transactionHashHex string, err error) {
+	commandArgs := []string{"protostar", "--no-color", "declare", contractPath, "--max-fee", policy.FeeArg(), "--wait-for-acceptance"}
+
+	stdout, err := executeWithRetry(ctx, logger, policy, "declare", commandArgs, networkArgs(pConf))
+	if err != nil {
+		err = fmt.Errorf("protostar declare command responded with an error:\n%w", err)
 		return
 	}
 
@@ -72,12 +227,17 @@ func Declare(logger log.Logger, pConf *config.ProtostarConfig, contractPath stri
 	return
 }
 
-func Deploy(logger log.Logger, pConf *config.ProtostarConfig, classHashHex string) (contractAddressHex, transactionHashFelt string, err error) {
-	commandArgs := []string{"protostar", "--no-color", "deploy", classHashHex, "--max-fee", "auto", "--wait-for-acceptance"}
+// Deploy deploys a contract of the class classHashHex with the constructor
+// arguments inputs, waiting for the transaction to be accepted.
+func Deploy(ctx context.Context, logger log.Logger, pConf *config.ProtostarConfig, policy retry.Policy, classHashHex string, inputs []string) (contractAddressHex, transactionHashFelt string, err error) {
+	commandArgs := []string{"protostar", "--no-color", "deploy", classHashHex, "--max-fee", policy.FeeArg(), "--wait-for-acceptance"}
+	if len(inputs) > 0 {
+		commandArgs = append(append(commandArgs, "--inputs"), inputs...)
+	}
 
-	stdout, err := ExecuteCommandUntilNoGasFeeError(logger, commandArgs, networkArgs(pConf), "deploy")
+	stdout, err := executeWithRetry(ctx, logger, policy, "deploy", commandArgs, networkArgs(pConf))
 	if err != nil {
-		err = fmt.Errorf("protostar deploy command responded with an error:\n%s", err)
+		err = fmt.Errorf("protostar deploy command responded with an error:\n%w", err)
 		return
 	}
 
@@ -90,25 +250,46 @@ func Deploy(logger log.Logger, pConf *config.ProtostarConfig, classHashHex strin
 	return
 }
 
-// we cant use this for multicall, as that does not have a wait-for-acceptance flag. Check if it has been added, if so use it.
-func ExecuteCommandUntilNoGasFeeError(logger log.Logger, commandArgs, networkArgs []string, commandNameForPrinting string) ([]byte, error) {
-	commandExecutedOrNotGasError := false
-	var stdout []byte
-	var err error
-	for !commandExecutedOrNotGasError {
-		stdout, err = utils.ExecuteCommand(commandArgs, networkArgs)
-
-		if (err != nil) && (strings.Contains(err.Error(), "Actual fee exceeded max fee")) {
-			logger.Info("protostar "+commandNameForPrinting+" command responded a max fee error, trying again %w", err)
+// InvokeAndWait invokes function of the contract at contractAddress with
+// inputs, waiting for the transaction to be accepted.
+func InvokeAndWait(ctx context.Context, logger log.Logger, pConf *config.ProtostarConfig, policy retry.Policy, contractAddress, function string, inputs []string) (transactionHashHex string, err error) {
+	commandArgs := []string{"protostar", "--no-color", "invoke",
+		"--contract-address", contractAddress, "--function", function,
+		"--max-fee", policy.FeeArg(), "--wait-for-acceptance"}
+	if len(inputs) > 0 {
+		commandArgs = append(append(commandArgs, "--inputs"), inputs...)
+	}
 
-		} else {
-			commandExecutedOrNotGasError = true
-		}
+	stdout, err := executeWithRetry(ctx, logger, policy, "invoke", commandArgs, networkArgs(pConf))
+	if err != nil {
+		err = fmt.Errorf("protostar invoke command responded with an error:\n%w", err)
+		return
 	}
-	return stdout, err
+	return getTransactionHashHex(stdout)
 }
 
-func Invoke(logger log.Logger, pConf *config.ProtostarConfig, contractAddress string, invokedFunction string, inputs parser.SettlementData) (err error) {
+// Batcher collects invoke calls into multicall files of batchSize calls
+// each, and sends a multicall once its file is full.
+type Batcher struct {
+	dir                    string
+	batchSize              int
+	policy                 retry.Policy
+	currentMulticallNumber int
+	numberOfCalls          int
+}
+
+// NewBatcher returns a batcher keeping its multicall files in dir, sending
+// multicalls of batchSize calls within the fee and retry bounds of policy.
+// Files left over from a previous run are overwritten: the calls they hold
+// are not known to be sent, and are expected to be invoked again.
+func NewBatcher(dir string, batchSize int, policy retry.Policy) *Batcher {
+	return &Batcher{dir: dir, batchSize: batchSize, policy: policy}
+}
+
+// Invoke records the call for the next multicall and sends the multicall once
+// it is full. It returns the hash of the multicall transaction if this node
+// sent it, or an empty string if the call was only recorded.
+func (b *Batcher) Invoke(ctx context.Context, logger log.Logger, pConf *config.ProtostarConfig, contractAddress string, invokedFunction string, inputs parser.SettlementData) (txHash string, err error) {
 	callArgs := "[[call]]" + "\n" +
 		"type = \"invoke\" " + "\n" +
 		"contract-address = " + contractAddress + "\n" +
@@ -118,47 +299,32 @@ func Invoke(logger log.Logger, pConf *config.ProtostarConfig, contractAddress st
 
 	callArgs = callArgs + inputArrayString
 	logger.Info("block recorded for settlement")
-	err = AddInvokeToFile(logger, callArgs)
-	if err != nil {
-		return err
-	}
-	err = Multicall(logger, pConf, inputs)
+	err = b.AddInvokeToFile(logger, callArgs)
 	if err != nil {
-		return
+		return "", err
 	}
-	return nil
+	return b.Multicall(ctx, logger, pConf, inputs)
 }
 
-const maxCallNumber = 10
-
-var currentMulticallNumber = 0
-var numberOfCalls = []uint32{0}
-var callsTomlPath string
-
-func AddInvokeToFile(logger log.Logger, newInvoke string) (err error) {
-	if currentMulticallNumber == 0 && numberOfCalls[currentMulticallNumber] == 0 {
-		if pwd, _ := os.Getwd(); pwd == "/slush" {
-			nodeNum := os.Getenv("ID")
-			callsTomlPath = "/slush/node" + nodeNum + "/data/multicalls"
-		} else {
-			callsTomlPath = "./valdata/data/multicalls"
-		}
-		if err := os.Mkdir(callsTomlPath, config.DefaultDirPerm); err != nil {
-			return fmt.Errorf("failed to make directory: %w", err)
-		}
+func (b *Batcher) callsFile(number int) string {
+	return filepath.Join(b.dir, "call"+fmt.Sprint(number)+".toml")
+}
 
+func (b *Batcher) AddInvokeToFile(logger log.Logger, newInvoke string) (err error) {
+	if err := os.MkdirAll(b.dir, config.DefaultDirPerm); err != nil {
+		return fmt.Errorf("failed to make directory: %w", err)
 	}
 	// if we have no calls, we can remove the file. This also clears it if not empty
-	if numberOfCalls[currentMulticallNumber] == 0 {
-		if err := os.WriteFile(callsTomlPath+"/call"+fmt.Sprint(currentMulticallNumber)+".toml", []byte{}, 0777); err != nil {
+	if b.numberOfCalls == 0 {
+		if err := os.WriteFile(b.callsFile(b.currentMulticallNumber), []byte{}, 0777); err != nil {
 			return fmt.Errorf("failed to clear file: %w", err)
 		}
 	}
 
-	numberOfCalls[currentMulticallNumber] += 1
+	b.numberOfCalls += 1
 
 	// opening and writing to file
-	f, err := os.OpenFile(callsTomlPath+"/call"+fmt.Sprint(currentMulticallNumber)+".toml", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0777)
+	f, err := os.OpenFile(b.callsFile(b.currentMulticallNumber), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0777)
 	if err != nil {
 		return fmt.Errorf("failed to open file: %w", err)
 	}
@@ -174,75 +340,134 @@ func AddInvokeToFile(logger log.Logger, newInvoke string) (err error) {
 	return nil
 }
 
-func Multicall(logger log.Logger, pConf *config.ProtostarConfig, sData parser.SettlementData) (err error) {
+// Call is an invoke call of a multicall file.
+type Call struct {
+	ContractAddress string
+	Function        string
+	Inputs          []string
+}
+
+// ParseMulticallFile parses the calls of a multicall file written by a
+// Batcher. The inputs are kept as written, as they do not fit the integers of
+// TOML parsers.
+func ParseMulticallFile(data []byte) ([]Call, error) {
+	var (
+		calls []Call
+		call  *Call
+	)
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if line == "[[call]]" {
+			calls = append(calls, Call{})
+			call = &calls[len(calls)-1]
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok || call == nil {
+			return nil, fmt.Errorf("line %d: expected a key of a [[call]], got %q", i+1, line)
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "type":
+		case "contract-address":
+			call.ContractAddress = value
+		case "function":
+			call.Function = strings.Trim(value, `"`)
+		case "inputs":
+			if !strings.HasPrefix(value, "[") || !strings.HasSuffix(value, "]") {
+				return nil, fmt.Errorf("line %d: expected an array of inputs, got %q", i+1, value)
+			}
+			for _, input := range strings.Split(strings.Trim(value, "[]"), ",") {
+				if input = strings.TrimSpace(input); input != "" {
+					call.Inputs = append(call.Inputs, input)
+				}
+			}
+		default:
+			return nil, fmt.Errorf("line %d: unknown key %q", i+1, key)
+		}
+	}
+	return calls, nil
+}
+
+func (b *Batcher) Multicall(ctx context.Context, logger log.Logger, pConf *config.ProtostarConfig, sData parser.SettlementData) (txHash string, err error) {
 	// if we need to send the transaction, we should send it.
 
-	if numberOfCalls[currentMulticallNumber] == maxCallNumber {
-		thisMulticallNumber := currentMulticallNumber
-		currentMulticallNumber += 1
-		numberOfCalls = append(numberOfCalls, 0)
-		if sData.ValidatorAddress == sData.CommitmentProposer {
+	if b.numberOfCalls >= b.batchSize {
+		thisMulticallNumber := b.currentMulticallNumber
+		b.currentMulticallNumber += 1
+		b.numberOfCalls = 0
+		if sData.IsSubmitter() {
 			commandArgs := []string{
-				"protostar", "--no-color", "multicall", callsTomlPath + "/call" + fmt.Sprint(thisMulticallNumber) + ".toml",
-				"--max-fee", "auto"}
+				"protostar", "--no-color", "multicall", b.callsFile(thisMulticallNumber),
+				"--max-fee", b.policy.FeeArg()}
 
 			logger.Info("Sending multicall to starknet")
 
-			err = ExecuteUntilNoGasErrorReplacement(logger, commandArgs, pConf)
+			txHash, err = SendMulticallUntilAccepted(ctx, logger, b.policy, commandArgs, pConf)
 			if err != nil {
-				return fmt.Errorf("error querying tx until accepted error: %w", err)
+				return "", err
 			}
 		}
 
-		return nil
+		return txHash, nil
 	}
 
-	return nil
+	return "", nil
 }
 
-// we don't have waitforacceptance for multicall, so we query if the tx is accepted/rejected and has tx fee error, if yes we retry. If it is a proper error, we don't.
-func ExecuteUntilNoGasErrorReplacement(logger log.Logger, multicallCommandArgs []string, pConf *config.ProtostarConfig) (err error) {
-	txHash, err := SendMulticall(logger, multicallCommandArgs, pConf)
-
+// SendMulticallUntilAccepted sends the multicall and waits for it to be
+// accepted, as protostar multicall cannot wait for acceptance itself. The
+// multicall is sent again if sending it fails with a retryable error, or if it
+// is rejected for running out of fee, within the bounds of policy. Multicalls
+// rejected for any other reason are not sent again.
+func SendMulticallUntilAccepted(ctx context.Context, logger log.Logger, policy retry.Policy, multicallCommandArgs []string, pConf *config.ProtostarConfig) (txHash string, err error) {
+	err = policy.Do(ctx, logger, "protostar multicall", func(ctx context.Context, attempt int) error {
+		txHash, err = SendMulticall(logger, multicallCommandArgs, pConf)
+		if err != nil {
+			return classifyError(err)
+		}
+		err = waitForAcceptance(ctx, logger, policy, pConf, txHash)
+		if errors.Is(err, errRejectedForFee) {
+			return retry.Retryable(err)
+		}
+		return err
+	})
 	if err != nil {
-		return fmt.Errorf("sending multicall failed: %w", err)
+		return "", err
 	}
+	return txHash, nil
+}
 
-	txAcceptedOrRejected := false
-	i := 0
-	for !txAcceptedOrRejected {
-		i++
-		time.Sleep(5 * time.Second)
-		// query the transaction
+// waitForAcceptance polls the status of the transaction txHash until it is
+// accepted on L2 or rejected, backing off as configured by policy, for at most
+// acceptancePollAttempts polls.
+func waitForAcceptance(ctx context.Context, logger log.Logger, policy retry.Policy, pConf *config.ProtostarConfig, txHash string) error {
+	poll := policy
+	poll.MaxAttempts = acceptancePollAttempts
+	return poll.Do(ctx, logger, "waiting for transaction "+txHash, func(ctx context.Context, attempt int) error {
 		commandArgs := []string{"starknet", "get_transaction", "--hash", txHash}
-
 		stdout, err := utils.ExecuteCommand(commandArgs, starknetNetworkArgs(pConf))
 		if err != nil {
-			return fmt.Errorf("get transaction command responded with an error: %w", err)
+			return classifyError(fmt.Errorf("get transaction command responded with an error: %w", err))
 		}
 
-		if strings.Contains(string(stdout), "\"status\": \"ACCEPTED_ON_L2\"") {
-			txAcceptedOrRejected = true
-			logger.Info("Transaction is accepted")
-		} else if strings.Contains(string(stdout), "Actual fee exceeded max fee") {
-
-			logger.Info("Transaction had gas error, retrying:")
-			txHash, err = SendMulticall(logger, multicallCommandArgs, pConf)
-			if err != nil {
-				return err
-			}
-		} else if strings.Contains(string(stdout), "\"status\": \"REJECTED\"") {
-			txAcceptedOrRejected = true
-			logger.Info("Transaction failed with error:", string(stdout))
-			txHash, err = SendMulticall(logger, multicallCommandArgs, pConf)
-			if err != nil {
-				break
+		output := string(stdout)
+		switch {
+		case strings.Contains(output, "\"status\": \"ACCEPTED_ON_L2\""):
+			logger.Info("Transaction is accepted", "tx_hash", txHash)
+			return nil
+		case strings.Contains(output, "\"status\": \"REJECTED\""):
+			if containsAny(output, feeErrors) {
+				return fmt.Errorf("%w: %s", errRejectedForFee, txHash)
 			}
-		} else {
-			logger.Info("Transaction is still pending")
+			return fmt.Errorf("transaction %s rejected:\n%s", txHash, output)
+		default:
+			return retry.Retryable(fmt.Errorf("transaction %s is still pending", txHash))
 		}
-	}
-	return err
+	})
 }
 
 func SendMulticall(logger log.Logger, commandArgs []string, pConf *config.ProtostarConfig) (txhash string, err error) {
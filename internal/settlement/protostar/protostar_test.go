@@ -1,18 +1,23 @@
 package protostar
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"net/http"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/internal/settlement/parser"
+	"github.com/tendermint/tendermint/internal/settlement/retry"
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	"github.com/tendermint/tendermint/libs/log"
 
@@ -307,6 +312,128 @@ func TestGetContractAddress(t *testing.T) {
 	}
 }
 
+func TestGetSettledHeight(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		0: {input: "", wantErr: true},
+		1: {input: "Response:\n{\n    \"height\": 42\n}", expected: "42"},
+		2: {input: "Response:\n{\n    \"height\": 0\n}", expected: "0"},
+		3: {input: "Response:\n{\n    \"height\": -1\n}", wantErr: true},
+	}
+	for i, tc := range testCases {
+		got, err := getSettledHeight([]byte(tc.input))
+		if tc.wantErr {
+			require.Error(t, err, "testCase%d failed", i)
+			continue
+		}
+		require.NoError(t, err, "testCase%d failed", i)
+		require.Equal(t, tc.expected, got, "testCase%d failed", i)
+	}
+}
+
+func TestGetTrustedHeaderHash(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		0: {input: "", wantErr: true},
+		1: {input: "Response:\n{\n    \"hash\": 1234,\n    \"height\": 42\n}", expected: "1234"},
+		2: {input: "Response:\n{\n    \"height\": 42\n}", wantErr: true},
+	}
+	for i, tc := range testCases {
+		got, err := getTrustedHeaderHash([]byte(tc.input))
+		if tc.wantErr {
+			require.Error(t, err, "testCase%d failed", i)
+			continue
+		}
+		require.NoError(t, err, "testCase%d failed", i)
+		require.Equal(t, tc.expected, got, "testCase%d failed", i)
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	testCases := []struct {
+		err       error
+		retryable bool
+	}{
+		0: {errors.New("Actual fee exceeded max fee"), true},
+		1: {errors.New("Cannot connect to host 127.0.0.1:5050"), true},
+		2: {errors.New("Invalid transaction nonce"), true},
+		3: {errors.New("Contract not found"), false},
+	}
+	for i, tc := range testCases {
+		err := classifyError(tc.err)
+		require.ErrorIs(t, err, tc.err, "testCase%d failed", i)
+		require.Equal(t, tc.retryable, retry.IsRetryable(err), "testCase%d failed", i)
+	}
+	require.NoError(t, classifyError(nil))
+}
+
+func TestBatcherAddsCallsToFiles(t *testing.T) {
+	const batchSize = 4
+	dir := filepath.Join(t.TempDir(), "multicalls")
+	b := NewBatcher(dir, batchSize, retry.DefaultPolicy())
+	conf := config.DefaultProtostarConfig()
+
+	// this node did not propose the blocks, so full batches are not sent
+	data := parser.SettlementData{Data: []string{"1", "2"}, CommitmentProposer: "A", ValidatorAddress: "B"}
+	for i := 0; i < batchSize+1; i++ {
+		txHash, err := b.Invoke(context.Background(), log.NewNopLogger(), conf, "0x1234", "externalVerifyAdjacent", data)
+		require.NoError(t, err)
+		require.Empty(t, txHash)
+	}
+
+	calls, err := os.ReadFile(filepath.Join(dir, "call0.toml"))
+	require.NoError(t, err)
+	require.Equal(t, batchSize, strings.Count(string(calls), "[[call]]"))
+	require.Contains(t, string(calls), "inputs = [ 1,2]")
+
+	calls, err = os.ReadFile(filepath.Join(dir, "call1.toml"))
+	require.NoError(t, err)
+	require.Equal(t, 1, strings.Count(string(calls), "[[call]]"))
+
+	// a restarted node starts over, overwriting the old files
+	b = NewBatcher(dir, batchSize, retry.DefaultPolicy())
+	_, err = b.Invoke(context.Background(), log.NewNopLogger(), conf, "0x1234", "externalVerifyAdjacent", data)
+	require.NoError(t, err)
+	calls, err = os.ReadFile(filepath.Join(dir, "call0.toml"))
+	require.NoError(t, err)
+	require.Equal(t, 1, strings.Count(string(calls), "[[call]]"))
+}
+
+func TestParseMulticallFile(t *testing.T) {
+	dir := t.TempDir()
+	b := NewBatcher(dir, 10, retry.DefaultPolicy())
+	conf := config.DefaultProtostarConfig()
+
+	inputs := [][]string{{"1", "2"}, {"3618502788666131213697322783095070105623107215331596699973092056135872020480"}}
+	for _, in := range inputs {
+		data := parser.SettlementData{Data: in}
+		_, err := b.Invoke(context.Background(), log.NewNopLogger(), conf, "0x1234", "externalVerifyAdjacent", data)
+		require.NoError(t, err)
+	}
+
+	file, err := os.ReadFile(b.callsFile(0))
+	require.NoError(t, err)
+	calls, err := ParseMulticallFile(file)
+	require.NoError(t, err)
+	require.Len(t, calls, len(inputs))
+	for i, call := range calls {
+		require.Equal(t, "0x1234", call.ContractAddress)
+		require.Equal(t, "externalVerifyAdjacent", call.Function)
+		require.Equal(t, inputs[i], call.Inputs)
+	}
+
+	_, err = ParseMulticallFile([]byte("inputs = [ 1,2]"))
+	require.Error(t, err)
+	_, err = ParseMulticallFile([]byte("[[call]]\ninputs = 1"))
+	require.Error(t, err)
+}
+
 // Before running protostar tests make sure that
 // * protostar is installed
 // * starknet-devnet is running  on http://127.0.0.1:5050 with seed 42
@@ -338,7 +465,7 @@ func TestDeclare(t *testing.T) {
 	conf.AccountAddress = "0x347be35996a21f6bf0623e75dbce52baba918ad5ae8d83b6f416045ab22961a"
 
 	// Testing the Declare function
-	classHashHex, transactionHashHex, err := Declare(log.NewNopLogger(), conf, "../../../cairo/build/main.json")
+	classHashHex, transactionHashHex, err := Declare(context.Background(), log.NewNopLogger(), conf, retry.DefaultPolicy(), "../../../cairo/build/main.json")
 	require.NoError(t, err)
 	require.NotEmpty(t, classHashHex)
 	require.NotEmpty(t, transactionHashHex)
@@ -375,13 +502,13 @@ func TestDeploy(t *testing.T) {
 	conf.AccountAddress = "0x347be35996a21f6bf0623e75dbce52baba918ad5ae8d83b6f416045ab22961a"
 
 	// Calling the Declare to get the class hash for the Deploy function
-	ch, th, err := Declare(log.NewNopLogger(), conf, "../../../cairo/build/main.json")
+	ch, th, err := Declare(context.Background(), log.NewNopLogger(), conf, retry.DefaultPolicy(), "../../../cairo/build/main.json")
 	require.NoError(t, err)
 	require.NotEmpty(t, ch)
 	require.NotEmpty(t, th)
 
 	// Testing the Deploy function
-	contractAddressHex, transactionHashHex, err := Deploy(log.NewNopLogger(), conf, ch)
+	contractAddressHex, transactionHashHex, err := Deploy(context.Background(), log.NewNopLogger(), conf, retry.DefaultPolicy(), ch, []string{conf.AccountAddress})
 	require.NoError(t, err)
 	require.NotEmpty(t, contractAddressHex)
 	require.NotEmpty(t, transactionHashHex)
@@ -418,13 +545,13 @@ func TestInvoke(t *testing.T) {
 	conf.AccountAddress = "0x347be35996a21f6bf0623e75dbce52baba918ad5ae8d83b6f416045ab22961a"
 
 	// Calling the Declare function
-	chh, thh, err := Declare(log.NewNopLogger(), conf, "../../../cairo/build/main.json")
+	chh, thh, err := Declare(context.Background(), log.NewNopLogger(), conf, retry.DefaultPolicy(), "../../../cairo/build/main.json")
 	require.NoError(t, err)
 	require.NotEmpty(t, chh)
 	require.NotEmpty(t, thh)
 
 	// Calling the Deploy function
-	contractAddressHex, thf, err := Deploy(log.NewNopLogger(), conf, chh)
+	contractAddressHex, thf, err := Deploy(context.Background(), log.NewNopLogger(), conf, retry.DefaultPolicy(), chh, []string{conf.AccountAddress})
 	require.NoError(t, err)
 	require.NotEmpty(t, contractAddressHex)
 	require.NotEmpty(t, thf)
@@ -452,7 +579,14 @@ func TestInvoke(t *testing.T) {
 	})
 	require.NoError(t, err)
 
+	// The contract verifies against the headers it settled, so it must trust
+	// the trusted one first
+	initInputs, err := parser.ParseInitInput(trustedLightBlock, 168*time.Hour)
+	require.NoError(t, err)
+	_, err = InvokeAndWait(context.Background(), log.NewNopLogger(), conf, retry.DefaultPolicy(), contractAddressHex, parser.InitFunction, initInputs)
+	require.NoError(t, err)
+
 	// Testing the Invoke function
-	err = Invoke(log.NewNopLogger(), conf, contractAddressHex, "externalVerifyAdjacent", parser.SettlementData{Data: invokeInputs, CommitmentProposer: "0", ValidatorAddress: "0"})
+	_, err = NewBatcher(t.TempDir(), 10, retry.DefaultPolicy()).Invoke(context.Background(), log.NewNopLogger(), conf, contractAddressHex, "externalVerifyAdjacent", parser.SettlementData{Data: invokeInputs, CommitmentProposer: "0", ValidatorAddress: "0"})
 	require.NoError(t, err)
 }
@@ -1,84 +1,801 @@
 package settlement
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/internal/settlement/parser"
-	"github.com/tendermint/tendermint/internal/settlement/protostar"
+	"github.com/tendermint/tendermint/internal/settlement/verifier"
+	sm "github.com/tendermint/tendermint/internal/state"
 	"github.com/tendermint/tendermint/libs/log"
+	tmmath "github.com/tendermint/tendermint/libs/math"
+	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
 	"github.com/tendermint/tendermint/libs/service"
+	"github.com/tendermint/tendermint/types"
 )
 
-// Reactor handles light blocks sent for settlement. Slush addition, modelled of evidence reactor.
+const (
+	// statusCheckInterval is how often the status of submitted transactions is
+	// queried from the backend.
+	statusCheckInterval = 10 * time.Second
+
+	// pollInterval is how often the block store is checked for new heights.
+	// New blocks are picked up right away when an event bus is set, so this
+	// only matters without one, or if the subscription was dropped.
+	pollInterval = time.Second
+
+	// subscriber is the event bus subscriber name of the reactor
+	subscriber = "settlement"
+)
+
+// ErrPreflightFailed is returned for calldata the verifier contract would
+// reject, as checked off-chain with the verifier package before paying fees
+// for it.
+var ErrPreflightFailed = errors.New("calldata fails verification")
+
+// ErrSettledHeaderMismatch is returned when the verifier settled a header other
+// than the block of the local chain at its height. Heights are not recorded as
+// settled past it.
+var ErrSettledHeaderMismatch = errors.New("settled header doesn't match the local block")
+
+// Reactor follows the block and state stores and settles the commits of the
+// heights selected by its policy. It runs apart from consensus, so a slow
+// backend never holds up block production, and works the same on validators,
+// full nodes and over the stores of a stopped node. Slush addition, modelled
+// on the evidence reactor.
+//
+// Each height is sent by the proposer of its block. Should it fail to, the
+// other validators take over one after the other in proposer priority order,
+// each waiting the failover timeout of the policy for the one before it.
+// Reactors without a validator, on full nodes and in inspect mode, are read
+// only followers: they record the heights and follow their settlement, but
+// never submit anything to their backend.
+//
+// Evidence committed in a settled block is submitted to the verifier after
+// the commit of the block, so that the verifier can slash equivocating
+// validators and freeze on light client attacks.
+//
+// With an event bus set, the reactor publishes EventSettlementSubmitted and
+// EventSettlementAccepted as heights go through settlement.
+//
+// A reactor settles commits on a single target, a verifier contract reached
+// through its backend. Nodes settling on several targets run a reactor for
+// each, see Group.
 type Reactor struct {
 	service.BaseService
-	logger       log.Logger
-	cfg          *config.Config
-	SettlementCh <-chan parser.SettlementData
-	stopChan     chan bool
+	logger     log.Logger
+	target     string
+	backend    SettlementBackend
+	store      *Store
+	blockStore sm.BlockStore
+	stateStore sm.Store
+	policy     Policy
+
+	// address of this node's validator, empty on full nodes
+	validatorAddress string
+
+	metrics *Metrics
+
+	// optional, new blocks are polled for without it
+	eventBus     *types.EventBus
+	pollInterval time.Duration
+
+	// cancels in-flight submissions when the reactor stops
+	cancel context.CancelFunc
+	// tracks the goroutine following the block store
+	wg sync.WaitGroup
+
+	mtx     sync.Mutex
+	lastErr error // the last error settling, for status reporting
+	// heights whose submission failed, resubmitted on the status ticker
+	unsent map[int64]bool
 }
 
-// NewReactor returns a reference to a new evidence reactor, which implements the
-// service.Service interface. It accepts a p2p Channel dedicated for handling
-// envelopes with EvidenceList messages.
+// ReactorOption sets an optional parameter on the Reactor.
+type ReactorOption func(*Reactor)
+
+// NewReactor returns a reference to a new settlement reactor, which implements
+// the service.Service interface. It records the commits of the blocks in
+// blockStore selected by policy in store and submits them to backend.
+// validatorAddress is the address of the node's validator, if any.
 func NewReactor(
 	logger log.Logger,
-	cfg *config.Config,
-	SettlementCh <-chan parser.SettlementData,
+	backend SettlementBackend,
+	store *Store,
+	blockStore sm.BlockStore,
+	stateStore sm.Store,
+	policy Policy,
+	validatorAddress string,
+	options ...ReactorOption,
 ) *Reactor {
 	r := &Reactor{
-		logger:       logger,
-		cfg:          cfg,
-		SettlementCh: SettlementCh,
+		logger:           logger,
+		target:           config.DefaultSettlementTarget,
+		backend:          backend,
+		store:            store,
+		blockStore:       blockStore,
+		stateStore:       stateStore,
+		policy:           policy,
+		validatorAddress: validatorAddress,
+		metrics:          NopMetrics(),
+		pollInterval:     pollInterval,
+		unsent:           make(map[int64]bool),
 	}
 
 	r.BaseService = *service.NewBaseService(logger, "Settlement", r)
 
+	for _, option := range options {
+		option(r)
+	}
+	r.metrics = r.metrics.target(r.target)
+
 	return r
 }
 
-// OnStart starts separate go routines for each p2p Channel and listens for
-// envelopes on each. In addition, it also listens for peer updates and handles
-// messages on that p2p channel accordingly. The caller must be sure to execute
-// OnStop to ensure the outbound p2p Channels are closed. No error is returned.
+// ReactorMetrics sets the metrics of the reactor.
+func ReactorMetrics(metrics *Metrics) ReactorOption {
+	return func(r *Reactor) { r.metrics = metrics }
+}
+
+// ReactorTarget sets the name of the settlement target of the reactor, which
+// labels its logs, metrics, status and events. It defaults to
+// config.DefaultSettlementTarget.
+func ReactorTarget(name string) ReactorOption {
+	return func(r *Reactor) {
+		r.target = name
+		r.logger = r.logger.With("target", name)
+	}
+}
+
+// SetEventBus makes the reactor settle new blocks as soon as they are
+// committed, instead of polling the block store for them, and publish
+// settlement events. Must be called before the reactor is started.
+func (r *Reactor) SetEventBus(b *types.EventBus) {
+	r.eventBus = b
+}
+
+// Target returns the name of the settlement target of the reactor.
+func (r *Reactor) Target() string {
+	return r.target
+}
+
+// Backend returns the backend commits are submitted to.
+func (r *Reactor) Backend() SettlementBackend {
+	return r.backend
+}
+
+// Store returns the store the settlement state is recorded in.
+func (r *Reactor) Store() *Store {
+	return r.store
+}
+
+// Status is a summary of the progress of settlement on a target.
+type Status struct {
+	Target              string
+	LastEnqueuedHeight  int64
+	LastSubmittedHeight int64
+	LastAcceptedHeight  int64
+	// Pending are the records of the transactions that are not final.
+	Pending   []*Record
+	LastError error
+}
+
+// Status returns the progress of settlement, as recorded in the store.
+func (r *Reactor) Status() (Status, error) {
+	var (
+		status = Status{Target: r.target}
+		err    error
+	)
+	if status.LastEnqueuedHeight, err = r.store.LastHeight(); err != nil {
+		return status, err
+	}
+	if status.LastSubmittedHeight, err = r.store.LastSubmittedHeight(); err != nil {
+		return status, err
+	}
+	if status.LastAcceptedHeight, err = r.store.LastSettledHeight(); err != nil {
+		return status, err
+	}
+	records, err := r.store.Unsettled(status.LastAcceptedHeight)
+	if err != nil {
+		return status, err
+	}
+	for _, rec := range records {
+		if rec.Status == RecordSubmitted {
+			status.Pending = append(status.Pending, rec)
+		}
+	}
+
+	r.mtx.Lock()
+	status.LastError = r.lastErr
+	r.mtx.Unlock()
+	return status, nil
+}
+
+// logError logs err and keeps it as the last error of the reactor.
+func (r *Reactor) logError(msg string, err error, keyvals ...interface{}) {
+	r.mtx.Lock()
+	r.lastErr = err
+	r.mtx.Unlock()
+
+	r.logger.Error(msg, append([]interface{}{"err", err}, keyvals...)...)
+}
+
+// OnStart starts following the block store, after resubmitting the commits
+// left unsettled by a previous run. No error is returned.
 func (r *Reactor) OnStart() error {
-	go r.ListenInvokeBlocks(r.SettlementCh)
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+
+	r.wg.Add(1)
+	go r.followBlocks(ctx)
 
 	return nil
 }
 
-// OnStop stops the reactor by signaling to all spawned goroutines to exit and
-// blocking until they all exit.
+// OnStop cancels pending submissions and waits for the following goroutine to
+// exit, so that nothing is written to the store once the reactor is stopped.
+// A submission in flight is waited for when its backend does not honour
+// cancellation.
 func (r *Reactor) OnStop() {
-	r.stopChan <- true
+	r.cancel()
+	r.wg.Wait()
 }
 
-func (r *Reactor) ListenInvokeBlocks(SettlementCh <-chan parser.SettlementData) {
+// followBlocks settles new blocks until ctx is done.
+func (r *Reactor) followBlocks(ctx context.Context) {
+	defer r.wg.Done()
+
 	r.logger.Info("started settlement reactor")
+
+	if err := r.resume(ctx); err != nil {
+		r.logError("failed to resume settlement", err)
+	}
+
+	pollTicker := time.NewTicker(r.pollInterval)
+	defer pollTicker.Stop()
+	statusTicker := time.NewTicker(statusCheckInterval)
+	defer statusTicker.Stop()
+
+	if r.eventBus != nil {
+		defer func() {
+			if err := r.eventBus.UnsubscribeAll(context.Background(), subscriber); err != nil {
+				r.logger.Error("failed to unsubscribe from new blocks", "err", err)
+			}
+		}()
+	}
+
+	var sub types.Subscription
 	for {
-		select {
-		case newBlock := <-SettlementCh:
-			err := r.SendCommit(newBlock)
+		// new blocks are only used as a signal to look at the block store, so
+		// a subscription dropped for being too slow is simply renewed
+		if r.eventBus != nil && sub == nil {
+			var err error
+			sub, err = r.eventBus.Subscribe(ctx, subscriber, types.EventQueryNewBlock, 100)
 			if err != nil {
-				r.logger.Error("failed to send commit", "err", err)
+				r.logger.Error("failed to subscribe to new blocks", "err", err)
+				sub = nil
+			}
+		}
+		var (
+			newBlocks <-chan tmpubsub.Message
+			canceled  <-chan struct{}
+		)
+		if sub != nil {
+			newBlocks, canceled = sub.Out(), sub.Canceled()
+		}
+
+		select {
+		case <-newBlocks:
+		case <-canceled:
+			r.logger.Debug("new block subscription canceled", "err", sub.Err())
+			sub = nil
+		case <-pollTicker.C:
+		case <-statusTicker.C:
+			if err := r.resubmit(ctx); err != nil {
+				r.logError("failed to resubmit commit", err)
+			}
+			if err := r.checkSubmitted(ctx); err != nil {
+				r.logError("failed to check settlement transactions", err)
+			}
+			if err := r.checkEvidence(ctx); err != nil {
+				r.logError("failed to check evidence transactions", err)
 			}
-		case <-r.stopChan:
-			r.logger.Info("Stopping settlement reactor via stopChan")
+			if err := r.failover(ctx, time.Now()); err != nil {
+				r.logError("failed to take over settlement", err)
+			}
+			r.updateMetrics()
+			continue
+		case <-ctx.Done():
+			r.logger.Info("stopping settlement reactor")
 
 			return
 		}
+
+		if err := r.settleNewBlocks(ctx); err != nil {
+			r.logError("failed to settle new blocks", err)
+		}
+		r.updateMetrics()
+	}
+}
+
+// settleNewBlocks settles every height selected by the policy between the
+// last one handed to the backend and the last one with a commit in the block
+// store.
+func (r *Reactor) settleNewBlocks(ctx context.Context) error {
+	// blocks are verified against the last one handed to the backend, so none
+	// is handed over while one before it is still to be resubmitted
+	if r.hasUnsent() {
+		return nil
+	}
+
+	last, err := r.store.LastHeight()
+	if err != nil {
+		return err
+	}
+
+	// the commit of the latest block is only stored with the next block
+	latest := r.blockStore.Height() - 1
+	first := tmmath.MaxInt64(tmmath.MaxInt64(last+1, r.blockStore.Base()+1), r.policy.StartHeight)
+	for height := first; height <= latest; height++ {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		prevMeta := r.blockStore.LoadBlockMeta(height - 1)
+		meta := r.blockStore.LoadBlockMeta(height)
+		if prevMeta == nil || meta == nil {
+			return fmt.Errorf("missing block meta at height %d", height)
+		}
+		if !r.policy.ShouldSettle(&prevMeta.Header, &meta.Header) {
+			continue
+		}
+
+		data, err := r.settlementData(r.trustedHeight(last, height), height)
+		if errors.Is(err, ErrPreflightFailed) {
+			// the next height is verified against the last one handed to
+			// the backend instead
+			r.logError("skipping height", err, "height", height)
+			r.metrics.Submissions.With("outcome", OutcomeInvalid).Add(1)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err := r.SendCommit(ctx, data); err != nil {
+			// the record is kept and resubmitted on the status ticker, before
+			// the heights after it are handed over
+			r.logError("failed to send commit", err, "height", height)
+			return nil
+		}
+		last = height
+
+		if hasEvidence(&meta.Header) {
+			if err := r.settleEvidence(ctx, height, data); err != nil {
+				r.logError("failed to settle evidence", err, "height", height)
+			}
+		}
+	}
+	return nil
+}
+
+// trustedHeight returns the height the block at height is verified against,
+// last being the last height handed to the backend. The verifier only trusts
+// the headers it settled, or was initialized with: blocks are verified against
+// the last one handed to the backend, skipping the ones in between, and the
+// first one against the header before the start height, the one the verifier
+// is initialized with.
+func (r *Reactor) trustedHeight(last, height int64) int64 {
+	if last == 0 && r.policy.StartHeight > 1 {
+		last = r.policy.StartHeight - 1
+	}
+	if last > 0 && last < height-1 {
+		return last
+	}
+	return height - 1
+}
+
+// settlementData returns the calldata verifying the block at untrustedHeight
+// against the one at trustedHeight.
+func (r *Reactor) settlementData(trustedHeight, untrustedHeight int64) (parser.SettlementData, error) {
+	trustedLightBlock, err := r.lightBlock(trustedHeight)
+	if err != nil {
+		return parser.SettlementData{}, err
+	}
+	untrustedLightBlock, err := r.lightBlock(untrustedHeight)
+	if err != nil {
+		return parser.SettlementData{}, err
+	}
+
+	vc := r.policy.VerificationConfig(time.Now())
+	inputs, err := parser.ParseInput(trustedLightBlock, untrustedLightBlock, vc)
+	if err != nil {
+		return parser.SettlementData{}, fmt.Errorf("failed to format for settlement: %w", err)
+	}
+	function := parser.VerifyFunction(trustedLightBlock, untrustedLightBlock)
+	if err := verifier.Verify(function, inputs); err != nil {
+		return parser.SettlementData{}, fmt.Errorf("%w: %s of height %d against height %d: %v",
+			ErrPreflightFailed, function, untrustedHeight, trustedHeight, err)
+	}
+
+	return parser.SettlementData{
+		Height:             untrustedHeight,
+		CommitmentProposer: untrustedLightBlock.ProposerAddress.String(),
+		ValidatorAddress:   r.validatorAddress,
+		Submitters:         failoverOrder(untrustedLightBlock.ProposerAddress, untrustedLightBlock.ValidatorSet),
+		Function:           vc.Function(trustedLightBlock, untrustedLightBlock),
+		Data:               inputs,
+	}, nil
+}
+
+// lightBlock returns the light block at height, with the validator set that
+// signed it.
+func (r *Reactor) lightBlock(height int64) (types.LightBlock, error) {
+	return LoadLightBlock(r.blockStore, r.stateStore, height)
+}
+
+// LoadLightBlock returns the light block at height from the block and state
+// stores, with the validator set that signed it.
+func LoadLightBlock(blockStore sm.BlockStore, stateStore sm.Store, height int64) (types.LightBlock, error) {
+	meta := blockStore.LoadBlockMeta(height)
+	if meta == nil {
+		return types.LightBlock{}, fmt.Errorf("missing block meta at height %d", height)
+	}
+	commit := blockStore.LoadBlockCommit(height)
+	if commit == nil {
+		return types.LightBlock{}, fmt.Errorf("missing commit at height %d", height)
+	}
+	validators, err := stateStore.LoadValidators(height)
+	if err != nil {
+		return types.LightBlock{}, fmt.Errorf("failed to load validators at height %d: %w", height, err)
+	}
+
+	return types.LightBlock{
+		SignedHeader: &types.SignedHeader{Header: &meta.Header, Commit: commit},
+		ValidatorSet: validators,
+	}, nil
+}
+
+// SendCommit records inputs in the store and submits it to the backend.
+// Heights that are already settled are skipped.
+func (r *Reactor) SendCommit(ctx context.Context, inputs parser.SettlementData) (err error) {
+	rec, err := r.store.Enqueue(inputs)
+	if err != nil {
+		return fmt.Errorf("failed to record commit: %w", err)
 	}
+	if rec.Status == RecordAccepted {
+		r.logger.Debug("commit already settled", "height", inputs.Height)
+		return nil
+	}
+	return r.submit(ctx, rec)
 }
 
-func (r *Reactor) SendCommit(inputs parser.SettlementData) (err error) {
+// submit sends rec to the backend and records the outcome.
+func (r *Reactor) submit(ctx context.Context, rec *Record) error {
+	if r.validatorAddress == "" {
+		// followers leave every height to the validators
+		_, err := r.syncSettledHeight(ctx)
+		return err
+	}
+
 	logger := r.logger
-	logger.Info("settling commit")
+	logger.Info("settling commit", "height", rec.Height, "function", rec.Data.Function)
+
+	txHash, err := r.backend.Submit(ctx, rec.Data)
+	r.setUnsent(rec.Height, err != nil)
+	if err != nil {
+		if errors.Is(err, ErrMaxFeeExceeded) {
+			r.metrics.Submissions.With("outcome", OutcomeFeeRetry).Add(1)
+		}
+		return fmt.Errorf("failed to submit commit: %w", err)
+	}
+	if txHash != "" {
+		logger.Info("submitted commit", "height", rec.Height, "tx_hash", txHash)
+		rec.Status = RecordSubmitted
+		rec.TxHash = txHash
+		if err := r.store.Save(rec); err != nil {
+			return err
+		}
+		r.publish(types.EventSettlementSubmittedValue, rec, r.eventBus.PublishEventSettlementSubmitted)
+	}
+	_, err = r.syncSettledHeight(ctx)
+	return err
+}
+
+// syncSettledHeight records the outcome of the heights up to the latest one
+// the backend reports as settled, and returns the last settled height. The
+// heights sent in a transaction are recorded from its status, and the other
+// ones as accepted if the verifier settled them.
+func (r *Reactor) syncSettledHeight(ctx context.Context) (int64, error) {
+	height, err := r.backend.LatestSettledHeight(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query latest settled height: %w", err)
+	}
+	if err := r.checkSettledHeader(ctx, height); err != nil {
+		return 0, err
+	}
+	last, err := r.store.LastSettledHeight()
+	if err != nil {
+		return 0, err
+	}
+	records, err := r.store.Unsettled(last)
+	if err != nil {
+		return 0, err
+	}
+	for _, rec := range records {
+		if rec.Height > height {
+			break
+		}
+		if rec.Status != RecordSubmitted || rec.TxHash == "" {
+			continue
+		}
+		if _, err := r.updateStatus(ctx, rec); err != nil {
+			return 0, err
+		}
+	}
+	settled, err := r.store.SettleUpTo(height, func(height int64) (bool, error) {
+		return r.isSettled(ctx, height)
+	})
+	for _, rec := range settled {
+		r.accepted(ctx, rec)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return r.store.LastSettledHeight()
+}
+
+// isSettled reports whether the verifier settled a header at height. Heights
+// of backends that cannot read the settled headers are settled up to the
+// latest settled height.
+func (r *Reactor) isSettled(ctx context.Context, height int64) (bool, error) {
+	hb, ok := r.backend.(HeaderBackend)
+	if !ok {
+		return true, nil
+	}
+	hash, err := hb.SettledHeader(ctx, height)
+	if err != nil {
+		return false, err
+	}
+	return hash.Sign() != 0, nil
+}
+
+// setUnsent records whether the submission of height failed.
+func (r *Reactor) setUnsent(height int64, unsent bool) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if unsent {
+		r.unsent[height] = true
+	} else {
+		delete(r.unsent, height)
+	}
+}
+
+// hasUnsent reports whether a height is still to be resubmitted.
+func (r *Reactor) hasUnsent() bool {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	return len(r.unsent) > 0
+}
+
+// resubmit resubmits the heights whose submission failed, in order, with the
+// evidence committed in them. It stops at the first one that fails again, as
+// the heights after it are verified against it.
+func (r *Reactor) resubmit(ctx context.Context) error {
+	r.mtx.Lock()
+	heights := make([]int64, 0, len(r.unsent))
+	for height := range r.unsent {
+		heights = append(heights, height)
+	}
+	r.mtx.Unlock()
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+
+	for _, height := range heights {
+		rec, err := r.store.Load(height)
+		if err != nil {
+			return err
+		}
+		if rec == nil || rec.Status == RecordAccepted || rec.Status == RecordRejected {
+			r.setUnsent(height, false)
+			continue
+		}
+		if err := r.submit(ctx, rec); err != nil {
+			return err
+		}
+
+		if meta := r.blockStore.LoadBlockMeta(height); meta != nil && hasEvidence(&meta.Header) {
+			if err := r.settleEvidence(ctx, height, rec.Data); err != nil {
+				r.logError("failed to settle evidence", err, "height", height)
+			}
+		}
+	}
+	return nil
+}
+
+// checkSettledHeader checks that the header the verifier settled at height,
+// newly settled, is the block at height in the block store, before the heights
+// up to it are recorded as settled. Backends that cannot read the settled
+// headers, and heights above the block store, are not checked.
+func (r *Reactor) checkSettledHeader(ctx context.Context, height int64) error {
+	hb, ok := r.backend.(HeaderBackend)
+	if !ok || height == 0 {
+		return nil
+	}
+	last, err := r.store.LastSettledHeight()
+	if err != nil || height <= last {
+		return err
+	}
+	meta := r.blockStore.LoadBlockMeta(height)
+	if meta == nil {
+		return nil
+	}
+
+	hash, err := hb.SettledHeader(ctx, height)
+	if err != nil {
+		return err
+	}
+	if local := new(big.Int).SetBytes(meta.BlockID.Hash); hash.Cmp(local) != 0 {
+		return fmt.Errorf("%w: the verifier settled header %#x at height %d, the block store has %#x",
+			ErrSettledHeaderMismatch, hash, height, local)
+	}
+	return nil
+}
+
+// resume resubmits every height above the last settled one that was left
+// unsettled, in order, then the evidence left pending. Submitted transactions
+// are checked on-chain first, so that only the ones which were lost or
+// dropped are sent again. Rejected heights are not retried. Should a height
+// fail to be sent, it and the enqueued heights after it are resubmitted on the
+// status ticker.
+func (r *Reactor) resume(ctx context.Context) error {
+	last, err := r.syncSettledHeight(ctx)
+	if err != nil {
+		return err
+	}
+	records, err := r.store.Unsettled(last)
+	if err != nil {
+		return err
+	}
+	if len(records) > 0 {
+		r.logger.Info("resuming settlement", "last_settled_height", last, "unsettled", len(records))
+	}
+
+	for i, rec := range records {
+		switch rec.Status {
+		case RecordRejected:
+			r.logger.Error("settlement of height was rejected", "height", rec.Height, "tx_hash", rec.TxHash)
+			continue
+		case RecordSubmitted:
+			status, err := r.updateStatus(ctx, rec)
+			if err != nil {
+				return err
+			}
+			if status != StatusUnknown {
+				continue
+			}
+		}
+		if err := r.submit(ctx, rec); err != nil {
+			// the enqueued heights after it are resubmitted with it
+			for _, next := range records[i+1:] {
+				if next.Status == RecordEnqueued {
+					r.setUnsent(next.Height, true)
+				}
+			}
+			return err
+		}
+	}
+	return r.resumeEvidence(ctx)
+}
 
-	err = protostar.Invoke(logger, r.cfg.Protostar, r.cfg.VerifierAddress, "externalVerifyAdjacent", inputs)
+// checkSubmitted queries the status of every transaction that is not final.
+func (r *Reactor) checkSubmitted(ctx context.Context) error {
+	last, err := r.store.LastSettledHeight()
+	if err != nil {
+		return err
+	}
+	records, err := r.store.Unsettled(last)
 	if err != nil {
-		err = fmt.Errorf("failed to invoke starknet contract: %w", err)
+		return err
+	}
+	for _, rec := range records {
+		if rec.Status != RecordSubmitted {
+			continue
+		}
+		if _, err := r.updateStatus(ctx, rec); err != nil {
+			return err
+		}
+	}
+	_, err = r.syncSettledHeight(ctx)
+	return err
+}
+
+// updateStatus queries the status of the transaction of a submitted record,
+// and records it if it is final.
+func (r *Reactor) updateStatus(ctx context.Context, rec *Record) (SubmissionStatus, error) {
+	status, err := r.backend.Status(ctx, rec.TxHash)
+	if err != nil {
+		return status, fmt.Errorf("failed to query status of %s: %w", rec.TxHash, err)
+	}
+	switch status {
+	case StatusAccepted:
+		rec.Status = RecordAccepted
+	case StatusRejected:
+		r.logger.Error("settlement transaction rejected", "height", rec.Height, "tx_hash", rec.TxHash)
+		r.metrics.Submissions.With("outcome", OutcomeRejected).Add(1)
+		rec.Status = RecordRejected
+	default:
+		return status, nil
+	}
+	if err := r.store.Save(rec); err != nil {
+		return status, err
+	}
+	if rec.Status == RecordAccepted {
+		r.accepted(ctx, rec)
+	}
+	return status, nil
+}
+
+// accepted reports the settlement of rec, once it is recorded as accepted.
+func (r *Reactor) accepted(ctx context.Context, rec *Record) {
+	r.publish(types.EventSettlementAcceptedValue, rec, r.eventBus.PublishEventSettlementAccepted)
+
+	if meta := r.blockStore.LoadBlockMeta(rec.Height); meta != nil {
+		r.metrics.AcceptanceLatency.Observe(time.Since(meta.Header.Time).Seconds())
+	}
+
+	// heights settled by another node, or batched with a later one, were not
+	// submitted in a transaction of their own
+	if rec.TxHash == "" {
 		return
 	}
-	return
+	r.metrics.Submissions.With("outcome", OutcomeAccepted).Add(1)
+	if fb, ok := r.backend.(FeeBackend); ok {
+		fee, err := fb.ActualFee(ctx, rec.TxHash)
+		if err != nil {
+			r.logger.Debug("failed to query settlement fee", "tx_hash", rec.TxHash, "err", err)
+			return
+		}
+		feeWei, _ := new(big.Float).SetInt(fee).Float64()
+		r.metrics.FeesPaid.Add(feeWei)
+	}
+}
+
+// updateMetrics sets the gauges of the metrics to the current progress of
+// settlement.
+func (r *Reactor) updateMetrics() {
+	last, err := r.store.LastSettledHeight()
+	if err != nil {
+		return
+	}
+	records, err := r.store.Unsettled(last)
+	if err != nil {
+		return
+	}
+	r.metrics.SettledHeight.Set(float64(last))
+	r.metrics.QueueDepth.Set(float64(len(records)))
+	r.metrics.Lag.Set(float64(tmmath.MaxInt64(r.blockStore.Height()-last, 0)))
+}
+
+// publish fires the settlement event of rec with publishFn, one of the
+// settlement publishers of the event bus, if one is set.
+func (r *Reactor) publish(event string, rec *Record, publishFn func(types.EventDataSettlement) error) {
+	if r.eventBus == nil {
+		return
+	}
+	data := types.EventDataSettlement{
+		Target:   r.target,
+		Height:   rec.Height,
+		TxHash:   rec.TxHash,
+		Function: rec.Data.Function,
+	}
+	if err := publishFn(data); err != nil {
+		r.logger.Error("failed to publish settlement event", "event", event, "height", rec.Height, "err", err)
+	}
 }
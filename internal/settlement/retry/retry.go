@@ -0,0 +1,154 @@
+// Package retry implements the fee and retry policy of settlement
+// transactions, shared by the settlement backends.
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// ErrMaxFeeExceeded is returned when a transaction would cost more than the
+// maximum fee. It is not retried: the transaction can be sent again later,
+// when fees are lower.
+var ErrMaxFeeExceeded = errors.New("estimated fee exceeds the maximum fee")
+
+// Policy bounds the fee paid by settlement transactions, and how they are
+// retried.
+type Policy struct {
+	// Margin applied to the estimated fee, once more on every attempt.
+	FeeMultiplier float64
+	// Maximum fee in wei, nil if fees are not capped.
+	MaxFee *big.Int
+
+	// Number of attempts before giving up.
+	MaxAttempts int
+	// Wait before the first retry, doubled after each retry up to MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// DefaultPolicy returns the policy of the default settlement configuration.
+func DefaultPolicy() Policy {
+	p, err := NewPolicy(config.DefaultSettlementConfig())
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// NewPolicy returns the policy configured in cfg.
+func NewPolicy(cfg *config.SettlementConfig) (Policy, error) {
+	maxFee, err := cfg.MaxFeeWei()
+	if err != nil {
+		return Policy{}, err
+	}
+	return Policy{
+		FeeMultiplier: cfg.FeeMultiplier,
+		MaxFee:        maxFee,
+		MaxAttempts:   cfg.MaxAttempts,
+		Backoff:       cfg.RetryBackoff,
+		MaxBackoff:    cfg.RetryMaxBackoff,
+	}, nil
+}
+
+// Fee returns the maximum fee of the attempt-th try, counting from 0, of a
+// transaction estimated to cost estimate: the estimate with the multiplier
+// applied attempt+1 times, but no more than MaxFee. It returns
+// ErrMaxFeeExceeded if the estimate itself is above MaxFee.
+func (p Policy) Fee(estimate *big.Int, attempt int) (*big.Int, error) {
+	if p.MaxFee != nil && estimate.Cmp(p.MaxFee) > 0 {
+		return nil, fmt.Errorf("%w: %s > %s", ErrMaxFeeExceeded, estimate, p.MaxFee)
+	}
+
+	fee := new(big.Float).SetInt(estimate)
+	for i := 0; i <= attempt; i++ {
+		fee.Mul(fee, big.NewFloat(p.FeeMultiplier))
+	}
+	maxFee, _ := fee.Int(nil)
+	if p.MaxFee != nil && maxFee.Cmp(p.MaxFee) > 0 {
+		maxFee.Set(p.MaxFee)
+	}
+	return maxFee, nil
+}
+
+// FeeArg returns the maximum fee to pass to tools that estimate fees
+// themselves: MaxFee, or "auto" if fees are not capped.
+func (p Policy) FeeArg() string {
+	if p.MaxFee == nil {
+		return "auto"
+	}
+	return p.MaxFee.String()
+}
+
+// BackoffAfter returns the wait after the attempt-th try, counting from 0.
+func (p Policy) BackoffAfter(attempt int) time.Duration {
+	backoff := p.Backoff
+	for i := 0; i < attempt && backoff < p.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+	return backoff
+}
+
+// Do calls op until it succeeds, it returns an error that is not retryable,
+// the attempts of the policy run out or ctx is done. op is passed the number
+// of the attempt, counting from 0. The outcome is logged, and the last error
+// returned.
+func (p Policy) Do(ctx context.Context, logger log.Logger, name string, op func(ctx context.Context, attempt int) error) error {
+	var err error
+	for attempt := 0; attempt < p.MaxAttempts; attempt++ {
+		if attempt > 0 {
+			backoff := p.BackoffAfter(attempt - 1)
+			logger.Info("retrying "+name, "attempt", attempt+1, "backoff", backoff, "err", err)
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return fmt.Errorf("%s canceled after %d attempts: %w", name, attempt, err)
+			}
+		}
+
+		err = op(ctx, attempt)
+		switch {
+		case err == nil:
+			logger.Debug(name+" succeeded", "attempts", attempt+1)
+			return nil
+		case !IsRetryable(err):
+			logger.Error(name+" failed", "attempts", attempt+1, "err", err)
+			return err
+		}
+	}
+	logger.Error(name+" failed, giving up", "attempts", p.MaxAttempts, "err", err)
+	return fmt.Errorf("%s failed after %d attempts: %w", name, p.MaxAttempts, err)
+}
+
+// retryableError marks an error as transient.
+type retryableError struct {
+	err error
+}
+
+func (e retryableError) Error() string { return e.err.Error() }
+func (e retryableError) Unwrap() error { return e.err }
+
+// Retryable marks err as transient, so that Do tries again. Errors are fatal
+// unless marked.
+func Retryable(err error) error {
+	if err == nil {
+		return nil
+	}
+	return retryableError{err: err}
+}
+
+// IsRetryable returns whether err, or an error it wraps, was marked with
+// Retryable.
+func IsRetryable(err error) bool {
+	var r retryableError
+	return errors.As(err, &r)
+}
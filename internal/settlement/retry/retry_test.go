@@ -0,0 +1,113 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func TestPolicyFee(t *testing.T) {
+	testCases := []struct {
+		maxFee   *big.Int
+		estimate int64
+		attempt  int
+		expected int64
+		err      error
+	}{
+		0: {nil, 100, 0, 150, nil},
+		1: {nil, 100, 1, 225, nil},
+		2: {big.NewInt(200), 100, 0, 150, nil},
+		3: {big.NewInt(200), 100, 1, 200, nil},
+		4: {big.NewInt(200), 200, 0, 200, nil},
+		5: {big.NewInt(200), 201, 0, 0, ErrMaxFeeExceeded},
+	}
+	for i, tc := range testCases {
+		p := Policy{FeeMultiplier: 1.5, MaxFee: tc.maxFee}
+		fee, err := p.Fee(big.NewInt(tc.estimate), tc.attempt)
+		if tc.err != nil {
+			require.ErrorIs(t, err, tc.err, "testCase%d failed", i)
+			continue
+		}
+		require.NoError(t, err, "testCase%d failed", i)
+		assert.Equal(t, big.NewInt(tc.expected), fee, "testCase%d failed", i)
+	}
+}
+
+func TestPolicyFeeArg(t *testing.T) {
+	assert.Equal(t, "auto", Policy{}.FeeArg())
+	assert.Equal(t, "1000", Policy{MaxFee: big.NewInt(1000)}.FeeArg())
+}
+
+func TestPolicyBackoffAfter(t *testing.T) {
+	p := Policy{Backoff: time.Second, MaxBackoff: 5 * time.Second}
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for attempt, backoff := range expected {
+		assert.Equal(t, backoff, p.BackoffAfter(attempt), "attempt %d", attempt)
+	}
+}
+
+func TestPolicyDo(t *testing.T) {
+	errTransient := errors.New("transient")
+	errFatal := errors.New("fatal")
+
+	testCases := []struct {
+		errs          []error // returned by the attempts, nil once they run out
+		expectedCalls int
+		expectedErr   error
+	}{
+		0: {nil, 1, nil},
+		1: {[]error{Retryable(errTransient)}, 2, nil},
+		2: {[]error{Retryable(errTransient), errFatal}, 2, errFatal},
+		3: {[]error{Retryable(errTransient), Retryable(errTransient), Retryable(errTransient)}, 3, errTransient},
+	}
+	for i, tc := range testCases {
+		p := Policy{MaxAttempts: 3, Backoff: time.Millisecond, MaxBackoff: time.Millisecond}
+		calls := 0
+		err := p.Do(context.Background(), log.TestingLogger(), "test", func(ctx context.Context, attempt int) error {
+			require.Equal(t, calls, attempt, "testCase%d failed", i)
+			calls++
+			if attempt < len(tc.errs) {
+				return tc.errs[attempt]
+			}
+			return nil
+		})
+		assert.Equal(t, tc.expectedCalls, calls, "testCase%d failed", i)
+		if tc.expectedErr == nil {
+			assert.NoError(t, err, "testCase%d failed", i)
+		} else {
+			assert.ErrorIs(t, err, tc.expectedErr, "testCase%d failed", i)
+		}
+	}
+}
+
+func TestPolicyDoCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := Policy{MaxAttempts: 3, Backoff: time.Hour, MaxBackoff: time.Hour}
+	errTransient := errors.New("transient")
+	calls := 0
+	err := p.Do(ctx, log.TestingLogger(), "test", func(ctx context.Context, attempt int) error {
+		calls++
+		cancel()
+		return Retryable(errTransient)
+	})
+	assert.Equal(t, 1, calls)
+	assert.ErrorIs(t, err, errTransient)
+}
+
+func TestIsRetryable(t *testing.T) {
+	err := errors.New("transient")
+	assert.False(t, IsRetryable(err))
+	assert.False(t, IsRetryable(nil))
+	assert.Nil(t, Retryable(nil))
+	assert.True(t, IsRetryable(Retryable(err)))
+	assert.True(t, IsRetryable(fmt.Errorf("wrapped: %w", Retryable(err))))
+	assert.ErrorIs(t, Retryable(err), err)
+}
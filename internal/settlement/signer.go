@@ -0,0 +1,35 @@
+package settlement
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/tendermint/tendermint/internal/settlement/starknet"
+	"github.com/tendermint/tendermint/types"
+)
+
+// privValidatorSigner signs starknet transactions through the priv validator
+// of the node, which holds the key of the account, possibly in a remote
+// signer, and checks the calls of the transactions against its allow-list.
+type privValidatorSigner struct {
+	privVal types.StarknetSigner
+	chainID string
+}
+
+var _ starknet.Signer = (*privValidatorSigner)(nil)
+
+// NewPrivValidatorSigner returns a signer of the transactions of the
+// settlement account sending them to privVal, the priv validator of a node of
+// the chain chainID.
+func NewPrivValidatorSigner(privVal types.StarknetSigner, chainID string) starknet.Signer {
+	return &privValidatorSigner{privVal: privVal, chainID: chainID}
+}
+
+// SignInvoke implements starknet.Signer.
+func (s *privValidatorSigner) SignInvoke(ctx context.Context, tx *starknet.Invoke) ([]*big.Int, error) {
+	pb := tx.ToProto()
+	if err := s.privVal.SignStarknetTransaction(ctx, s.chainID, pb); err != nil {
+		return nil, err
+	}
+	return starknet.SignatureFromProto(pb.Signature)
+}
@@ -0,0 +1,90 @@
+package settlement
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto/stark"
+	"github.com/tendermint/tendermint/internal/settlement/parser"
+	"github.com/tendermint/tendermint/internal/settlement/retry"
+	"github.com/tendermint/tendermint/internal/settlement/starknet"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/privval"
+)
+
+// newStarknetFilePV returns a file validator allowed to settle adjacent
+// commits on the verifier at 0xc0de.
+func newStarknetFilePV(t *testing.T) (*privval.FilePV, stark.PrivKey) {
+	dir := t.TempDir()
+	pv, err := privval.GenFilePV(filepath.Join(dir, "key.json"), filepath.Join(dir, "state.json"), "")
+	require.NoError(t, err)
+	allowed, err := privval.ParseStarknetAllowList([]string{"0xc0de:" + parser.AdjacentFunction})
+	require.NoError(t, err)
+	limits, err := privval.ParseStarknetLimits("0xacc", "0x534e5f474f45524c49", "1000")
+	require.NoError(t, err)
+	key := stark.GenPrivKey()
+	pv.SetStarknetKey(key, allowed, limits)
+	return pv, key
+}
+
+func TestPrivValidatorSigner(t *testing.T) {
+	ctx := context.Background()
+	pv, key := newStarknetFilePV(t)
+
+	tx := &starknet.Invoke{
+		SenderAddress: big.NewInt(0xacc),
+		Calls: []starknet.FunctionCall{{
+			ContractAddress:    big.NewInt(0xc0de),
+			EntryPointSelector: starknet.Selector(parser.AdjacentFunction),
+			Calldata:           []*big.Int{big.NewInt(1)},
+		}},
+		MaxFee:  big.NewInt(1000),
+		Nonce:   big.NewInt(0),
+		ChainID: starknet.ShortString("SN_GOERLI"),
+	}
+
+	// the priv validator signs like the key
+	expected, err := starknet.NewKeySigner(key).SignInvoke(ctx, tx)
+	require.NoError(t, err)
+	signature, err := NewPrivValidatorSigner(pv, "chain").SignInvoke(ctx, tx)
+	require.NoError(t, err)
+	require.Equal(t, expected, signature)
+
+	tx.Calls[0].EntryPointSelector = starknet.Selector("upgrade")
+	_, err = NewPrivValidatorSigner(pv, "chain").SignInvoke(ctx, tx)
+	require.Error(t, err)
+}
+
+func TestStarknetBackendSigner(t *testing.T) {
+	ctx := context.Background()
+	cfg := config.TestStarknetConfig()
+	cfg.ChainID = "0x534e5f474f45524c49"
+	cfg.Signer = config.StarknetSignerPrivValidator
+
+	// the priv validator signer needs a validator
+	b, err := NewStarknetBackend(log.TestingLogger(), cfg, "0xc0de", nil, retry.Policy{})
+	require.NoError(t, err)
+	_, err = b.getAccount(ctx)
+	require.Error(t, err)
+
+	pv, _ := newStarknetFilePV(t)
+	signer := NewPrivValidatorSigner(pv, "chain")
+	b, err = NewStarknetBackend(log.TestingLogger(), cfg, "0xc0de", signer, retry.Policy{})
+	require.NoError(t, err)
+	account, err := b.getAccount(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, account)
+
+	// the key file signer ignores it
+	cfg.Signer = config.StarknetSignerKeyFile
+	cfg.PrivateKeyPath = filepath.Join(t.TempDir(), "missing")
+	b, err = NewStarknetBackend(log.TestingLogger(), cfg, "0xc0de", signer, retry.Policy{})
+	require.NoError(t, err)
+	_, err = b.getAccount(ctx)
+	require.Error(t, err)
+}
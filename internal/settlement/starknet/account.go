@@ -0,0 +1,245 @@
+package starknet
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	tmrand "github.com/tendermint/tendermint/libs/rand"
+
+	"github.com/tendermint/tendermint/crypto/stark"
+)
+
+// UniversalDeployerAddress is the address of the universal deployer contract,
+// which is deployed at the same address on every public network and devnet.
+var UniversalDeployerAddress, _ = new(big.Int).SetString("41a78e741e5af2fec34b695679bc6891742439f7afb8484ecd7766661ad02bf", 16)
+
+// declareSenderAddress is the sender of unsigned version 0 declare transactions.
+var declareSenderAddress = big.NewInt(1)
+
+// Account sends transactions on behalf of an account contract, signing them
+// with a Signer.
+type Account struct {
+	client  *Client
+	address *big.Int
+	signer  Signer
+	chainID *big.Int
+}
+
+// NewAccount returns an account at address signing with signer. If chainID
+// is nil, it is queried from the node.
+func NewAccount(ctx context.Context, client *Client, address *big.Int, signer Signer, chainID *big.Int) (*Account, error) {
+	if chainID == nil {
+		var err error
+		if chainID, err = client.ChainID(ctx); err != nil {
+			return nil, fmt.Errorf("failed to query chain id: %w", err)
+		}
+	}
+	return &Account{
+		client:  client,
+		address: address,
+		signer:  signer,
+		chainID: chainID,
+	}, nil
+}
+
+// LoadPrivateKey reads a hex encoded stark private key, as used by the
+// starknet and protostar CLIs, from a file.
+func LoadPrivateKey(path string) (stark.PrivKey, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read private key file: %w", err)
+	}
+	k, err := ParseFelt(strings.TrimSpace(string(bz)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	if k.Sign() == 0 {
+		return nil, errors.New("private key cannot be zero")
+	}
+	return stark.PrivKey(k.Bytes()), nil
+}
+
+// Address returns the address of the account contract.
+func (a *Account) Address() *big.Int {
+	return a.address
+}
+
+// Client returns the client the account sends transactions through.
+func (a *Account) Client() *Client {
+	return a.client
+}
+
+// ExecuteCalldata encodes calls as the calldata of the account's __execute__
+// entry point: the call array followed by the concatenated call data.
+func ExecuteCalldata(calls []FunctionCall) []*big.Int {
+	var (
+		callArray []*big.Int
+		data      []*big.Int
+	)
+	for _, call := range calls {
+		callArray = append(callArray,
+			call.ContractAddress,
+			call.EntryPointSelector,
+			big.NewInt(int64(len(data))),
+			big.NewInt(int64(len(call.Calldata))),
+		)
+		data = append(data, call.Calldata...)
+	}
+
+	calldata := []*big.Int{big.NewInt(int64(len(calls)))}
+	calldata = append(calldata, callArray...)
+	calldata = append(calldata, big.NewInt(int64(len(data))))
+	return append(calldata, data...)
+}
+
+// buildInvoke builds and signs the invoke transaction executing calls.
+func (a *Account) buildInvoke(ctx context.Context, calls []FunctionCall, maxFee *big.Int) (*InvokeTransaction, *big.Int, error) {
+	nonce, err := a.client.Nonce(ctx, a.address)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query nonce: %w", err)
+	}
+	invoke := &Invoke{
+		SenderAddress: a.address,
+		Calls:         calls,
+		MaxFee:        maxFee,
+		Nonce:         nonce,
+		ChainID:       a.chainID,
+	}
+	signature, err := a.signer.SignInvoke(ctx, invoke)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	return &InvokeTransaction{
+		Type:          "INVOKE",
+		SenderAddress: FeltHex(a.address),
+		Calldata:      feltsHex(ExecuteCalldata(calls)),
+		MaxFee:        FeltHex(maxFee),
+		Version:       "0x1",
+		Signature:     feltsHex(signature),
+		Nonce:         FeltHex(nonce),
+	}, invoke.Hash(), nil
+}
+
+// EstimateFee estimates the fee of executing calls from the account.
+func (a *Account) EstimateFee(ctx context.Context, calls []FunctionCall) (*FeeEstimate, error) {
+	tx, _, err := a.buildInvoke(ctx, calls, big.NewInt(0))
+	if err != nil {
+		return nil, err
+	}
+	return a.client.EstimateFee(ctx, tx)
+}
+
+// Execute sends an invoke transaction executing calls and returns its hash.
+// If maxFee is nil, the fee is estimated and increased by half to absorb
+// price movements before the transaction is included.
+func (a *Account) Execute(ctx context.Context, calls []FunctionCall, maxFee *big.Int) (*big.Int, error) {
+	if maxFee == nil {
+		estimate, err := a.EstimateFee(ctx, calls)
+		if err != nil {
+			return nil, fmt.Errorf("failed to estimate fee: %w", err)
+		}
+		maxFee = new(big.Int).Mul(estimate.OverallFee, big.NewInt(3))
+		maxFee.Div(maxFee, big.NewInt(2))
+	}
+
+	tx, txHash, err := a.buildInvoke(ctx, calls, maxFee)
+	if err != nil {
+		return nil, err
+	}
+	sentHash, err := a.client.AddInvokeTransaction(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	if sentHash.Cmp(txHash) != 0 {
+		return nil, fmt.Errorf("node computed transaction hash %s, expected %s", FeltHex(sentHash), FeltHex(txHash))
+	}
+	return txHash, nil
+}
+
+// Invoke calls function on the contract at contractAddress with calldata and
+// returns the transaction hash.
+func (a *Account) Invoke(ctx context.Context, contractAddress *big.Int, function string, calldata []*big.Int) (*big.Int, error) {
+	return a.Execute(ctx, []FunctionCall{{
+		ContractAddress:    contractAddress,
+		EntryPointSelector: Selector(function),
+		Calldata:           calldata,
+	}}, nil)
+}
+
+// LoadContractClass reads a contract compiled with starknet-compile or
+// protostar build and converts it to the format expected by the API.
+func LoadContractClass(path string) (*ContractClass, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read contract: %w", err)
+	}
+	var compiled struct {
+		ABI               json.RawMessage `json:"abi"`
+		EntryPointsByType json.RawMessage `json:"entry_points_by_type"`
+		Program           json.RawMessage `json:"program"`
+	}
+	if err := json.Unmarshal(bz, &compiled); err != nil {
+		return nil, fmt.Errorf("failed to decode contract: %w", err)
+	}
+	if len(compiled.Program) == 0 {
+		return nil, fmt.Errorf("contract %s has no program", path)
+	}
+
+	var program bytes.Buffer
+	zw := gzip.NewWriter(&program)
+	if _, err := zw.Write(compiled.Program); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return &ContractClass{
+		Program:           base64.StdEncoding.EncodeToString(program.Bytes()),
+		EntryPointsByType: compiled.EntryPointsByType,
+		ABI:               compiled.ABI,
+	}, nil
+}
+
+// Declare declares the contract class compiled at contractPath and returns
+// the class hash and the transaction hash. Version 0 declare transactions are
+// unsigned and free, so the class hash is left for the sequencer to compute.
+func (a *Account) Declare(ctx context.Context, contractPath string) (classHash, txHash *big.Int, err error) {
+	class, err := LoadContractClass(contractPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	tx := &DeclareTransaction{
+		Type:          "DECLARE",
+		ContractClass: *class,
+		SenderAddress: FeltHex(declareSenderAddress),
+		MaxFee:        "0x0",
+		Version:       "0x0",
+		Signature:     []string{},
+	}
+	txHash, classHash, err = a.client.AddDeclareTransaction(ctx, tx)
+	return classHash, txHash, err
+}
+
+// Deploy deploys an instance of classHash through the universal deployer and
+// returns the address of the new contract and the transaction hash.
+func (a *Account) Deploy(ctx context.Context, classHash *big.Int, constructorCalldata []*big.Int) (contractAddress, txHash *big.Int, err error) {
+	salt := new(big.Int).SetBytes(tmrand.Bytes(31))
+
+	calldata := []*big.Int{classHash, salt, big.NewInt(0), big.NewInt(int64(len(constructorCalldata)))}
+	calldata = append(calldata, constructorCalldata...)
+
+	txHash, err = a.Invoke(ctx, UniversalDeployerAddress, "deployContract", calldata)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ContractAddress(salt, classHash, constructorCalldata, big.NewInt(0)), txHash, nil
+}
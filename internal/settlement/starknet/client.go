@@ -0,0 +1,245 @@
+package starknet
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+const defaultHTTPTimeout = 30 * time.Second
+
+// BlockIDLatest and BlockIDPending are the block tags accepted by the API.
+const (
+	BlockIDLatest  = "latest"
+	BlockIDPending = "pending"
+)
+
+// Error codes returned by the StarkNet JSON-RPC API that callers may want to
+// act on.
+const (
+	ErrCodeContractNotFound        = 20
+	ErrCodeTxnHashNotFound         = 25
+	ErrCodeInvalidTransactionNonce = 52
+	ErrCodeInsufficientMaxFee      = 53
+)
+
+// RPCError is an error object returned by the JSON-RPC server.
+type RPCError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+func (e *RPCError) Error() string {
+	if len(e.Data) > 0 {
+		return fmt.Sprintf("starknet rpc error %d: %s (%s)", e.Code, e.Message, e.Data)
+	}
+	return fmt.Sprintf("starknet rpc error %d: %s", e.Code, e.Message)
+}
+
+type rpcRequest struct {
+	JSONRPC string      `json:"jsonrpc"`
+	ID      uint64      `json:"id"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      uint64          `json:"id"`
+	Result  json.RawMessage `json:"result"`
+	Error   *RPCError       `json:"error"`
+}
+
+// Client speaks the StarkNet JSON-RPC API over HTTP. It holds no account
+// state, see Account for sending transactions.
+type Client struct {
+	url        string
+	httpClient *http.Client
+	nextID     uint64
+}
+
+// NewClient returns a client for the JSON-RPC endpoint at url.
+func NewClient(url string) *Client {
+	return &Client{
+		url:        url,
+		httpClient: &http.Client{Timeout: defaultHTTPTimeout},
+	}
+}
+
+// call performs a single JSON-RPC request and decodes the result into result.
+func (c *Client) call(ctx context.Context, method string, params interface{}, result interface{}) error {
+	req := rpcRequest{
+		JSONRPC: "2.0",
+		ID:      atomic.AddUint64(&c.nextID, 1),
+		Method:  method,
+		Params:  params,
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s request: %w", method, err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("%s request failed: %w", method, err)
+	}
+	defer httpResp.Body.Close()
+
+	respBody, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read %s response: %w", method, err)
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s request failed with status %s: %s", method, httpResp.Status, respBody)
+	}
+
+	var resp rpcResponse
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", method, err)
+	}
+	if resp.Error != nil {
+		return resp.Error
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.Unmarshal(resp.Result, result); err != nil {
+		return fmt.Errorf("failed to decode %s result: %w", method, err)
+	}
+	return nil
+}
+
+// ChainID returns the chain id of the network the endpoint is connected to.
+func (c *Client) ChainID(ctx context.Context) (*big.Int, error) {
+	var chainID string
+	if err := c.call(ctx, "starknet_chainId", []interface{}{}, &chainID); err != nil {
+		return nil, err
+	}
+	return ParseFelt(chainID)
+}
+
+// Nonce returns the nonce of the contract at address in the pending block.
+func (c *Client) Nonce(ctx context.Context, address *big.Int) (*big.Int, error) {
+	var nonce string
+	params := map[string]interface{}{
+		"block_id":         BlockIDPending,
+		"contract_address": FeltHex(address),
+	}
+	if err := c.call(ctx, "starknet_getNonce", params, &nonce); err != nil {
+		return nil, err
+	}
+	return ParseFelt(nonce)
+}
+
+// Call executes a view function against the latest block and returns its
+// result felts.
+func (c *Client) Call(ctx context.Context, call FunctionCall) ([]*big.Int, error) {
+	var result []string
+	params := map[string]interface{}{
+		"request":  call.toRPC(),
+		"block_id": BlockIDLatest,
+	}
+	if err := c.call(ctx, "starknet_call", params, &result); err != nil {
+		return nil, err
+	}
+	return ParseFelts(result)
+}
+
+// EstimateFee estimates the fee of a broadcasted transaction.
+func (c *Client) EstimateFee(ctx context.Context, tx interface{}) (*FeeEstimate, error) {
+	var estimate rpcFeeEstimate
+	params := map[string]interface{}{
+		"request":  tx,
+		"block_id": BlockIDPending,
+	}
+	if err := c.call(ctx, "starknet_estimateFee", params, &estimate); err != nil {
+		return nil, err
+	}
+	return estimate.parse()
+}
+
+// AddInvokeTransaction broadcasts a signed invoke transaction and returns its
+// hash.
+func (c *Client) AddInvokeTransaction(ctx context.Context, tx *InvokeTransaction) (*big.Int, error) {
+	var result struct {
+		TransactionHash string `json:"transaction_hash"`
+	}
+	params := map[string]interface{}{"invoke_transaction": tx}
+	if err := c.call(ctx, "starknet_addInvokeTransaction", params, &result); err != nil {
+		return nil, err
+	}
+	return ParseFelt(result.TransactionHash)
+}
+
+// AddDeclareTransaction broadcasts a declare transaction and returns its hash
+// together with the class hash computed by the sequencer.
+func (c *Client) AddDeclareTransaction(ctx context.Context, tx *DeclareTransaction) (txHash, classHash *big.Int, err error) {
+	var result struct {
+		TransactionHash string `json:"transaction_hash"`
+		ClassHash       string `json:"class_hash"`
+	}
+	params := map[string]interface{}{"declare_transaction": tx}
+	if err = c.call(ctx, "starknet_addDeclareTransaction", params, &result); err != nil {
+		return
+	}
+	if txHash, err = ParseFelt(result.TransactionHash); err != nil {
+		return
+	}
+	classHash, err = ParseFelt(result.ClassHash)
+	return
+}
+
+// TransactionReceipt returns the receipt of the transaction with the given
+// hash. Unknown transactions are reported as an *RPCError with code
+// ErrCodeTxnHashNotFound.
+func (c *Client) TransactionReceipt(ctx context.Context, txHash *big.Int) (*Receipt, error) {
+	var receipt rpcReceipt
+	params := map[string]interface{}{"transaction_hash": FeltHex(txHash)}
+	if err := c.call(ctx, "starknet_getTransactionReceipt", params, &receipt); err != nil {
+		return nil, err
+	}
+	return receipt.parse()
+}
+
+// WaitForTransaction polls the receipt of txHash every interval until the
+// transaction is either accepted or rejected, or ctx is done.
+func (c *Client) WaitForTransaction(ctx context.Context, txHash *big.Int, interval time.Duration) (*Receipt, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		receipt, err := c.TransactionReceipt(ctx, txHash)
+		switch {
+		case err == nil && receipt.Status.Final():
+			return receipt, nil
+		case err != nil && !IsTxnHashNotFound(err):
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// IsTxnHashNotFound reports whether err is the error returned for unknown
+// transactions, which is also what freshly broadcasted ones may return.
+func IsTxnHashNotFound(err error) bool {
+	rpcErr, ok := err.(*RPCError)
+	return ok && rpcErr.Code == ErrCodeTxnHashNotFound
+}
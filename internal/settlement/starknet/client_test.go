@@ -0,0 +1,256 @@
+package starknet
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/stark"
+)
+
+// fakeNode is a minimal stand-in for a StarkNet JSON-RPC node. It checks the
+// signature of invoke transactions against the account key it is given.
+type fakeNode struct {
+	t       *testing.T
+	chainID *big.Int
+	account *big.Int
+	pubKey  stark.PublicKey
+
+	mtx      sync.Mutex
+	nonce    int64
+	txs      []*InvokeTransaction
+	declares []*DeclareTransaction
+	polls    int
+}
+
+func newFakeNode(t *testing.T, account *big.Int, key stark.PrivKey) *fakeNode {
+	return &fakeNode{
+		t:       t,
+		chainID: ShortString("SN_GOERLI"),
+		account: account,
+		pubKey:  key.MakeFull().PublicKey,
+	}
+}
+
+func (n *fakeNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	n.mtx.Lock()
+	defer n.mtx.Unlock()
+
+	var req struct {
+		ID     uint64          `json:"id"`
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params"`
+	}
+	require.NoError(n.t, json.NewDecoder(r.Body).Decode(&req))
+	params := map[string]json.RawMessage{}
+	if len(req.Params) > 0 && req.Params[0] == '{' {
+		require.NoError(n.t, json.Unmarshal(req.Params, &params))
+	}
+
+	var (
+		result interface{}
+		rpcErr *RPCError
+	)
+	switch req.Method {
+	case "starknet_chainId":
+		result = FeltHex(n.chainID)
+	case "starknet_getNonce":
+		result = FeltHex(big.NewInt(n.nonce))
+	case "starknet_estimateFee":
+		result = rpcFeeEstimate{GasConsumed: "0x10", GasPrice: "0x2", OverallFee: "0x20"}
+	case "starknet_addInvokeTransaction":
+		var tx InvokeTransaction
+		require.NoError(n.t, json.Unmarshal(params["invoke_transaction"], &tx))
+		txHash := n.checkInvoke(&tx)
+		n.txs = append(n.txs, &tx)
+		n.nonce++
+		result = map[string]string{"transaction_hash": FeltHex(txHash)}
+	case "starknet_addDeclareTransaction":
+		var tx DeclareTransaction
+		require.NoError(n.t, json.Unmarshal(params["declare_transaction"], &tx))
+		n.declares = append(n.declares, &tx)
+		result = map[string]string{"transaction_hash": "0x1234", "class_hash": "0x5678"}
+	case "starknet_getTransactionReceipt":
+		n.polls++
+		if n.polls == 1 {
+			rpcErr = &RPCError{Code: ErrCodeTxnHashNotFound, Message: "Transaction hash not found"}
+			break
+		}
+		var txHash string
+		require.NoError(n.t, json.Unmarshal(params["transaction_hash"], &txHash))
+		status := StatusPending
+		if n.polls > 2 {
+			status = StatusAcceptedOnL2
+		}
+		result = rpcReceipt{TransactionHash: txHash, Status: string(status), ActualFee: "0x1a", BlockNumber: 7}
+	case "starknet_call":
+		result = []string{"0x1", "0x2"}
+	default:
+		rpcErr = &RPCError{Code: -32601, Message: "method not found"}
+	}
+
+	resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+	if rpcErr != nil {
+		resp["error"] = rpcErr
+	} else {
+		resp["result"] = result
+	}
+	require.NoError(n.t, json.NewEncoder(w).Encode(resp))
+}
+
+// checkInvoke recomputes the transaction hash and verifies its signature.
+func (n *fakeNode) checkInvoke(tx *InvokeTransaction) *big.Int {
+	sender := mustFelt(n.t, tx.SenderAddress)
+	require.Equal(n.t, n.account, sender)
+	calldata, err := ParseFelts(tx.Calldata)
+	require.NoError(n.t, err)
+	signature, err := ParseFelts(tx.Signature)
+	require.NoError(n.t, err)
+	require.Len(n.t, signature, 2)
+
+	txHash := InvokeTransactionHash(sender, calldata, mustFelt(n.t, tx.MaxFee), n.chainID, mustFelt(n.t, tx.Nonce))
+	require.True(n.t, stark.Verify(&n.pubKey, txHash.FillBytes(make([]byte, 32)), signature[0], signature[1]),
+		"invalid transaction signature")
+	return txHash
+}
+
+func setupAccount(t *testing.T) (*fakeNode, *Account) {
+	key := stark.GenPrivKey()
+	address := big.NewInt(0xacc)
+	node := newFakeNode(t, address, key)
+	srv := httptest.NewServer(node)
+	t.Cleanup(srv.Close)
+
+	account, err := NewAccount(context.Background(), NewClient(srv.URL), address, NewKeySigner(key), nil)
+	require.NoError(t, err)
+	return node, account
+}
+
+func TestAccountInvoke(t *testing.T) {
+	node, account := setupAccount(t)
+	ctx := context.Background()
+
+	contract := big.NewInt(0xc0de)
+	for i := 0; i < 2; i++ {
+		txHash, err := account.Invoke(ctx, contract, "externalVerifyAdjacent", []*big.Int{big.NewInt(1), big.NewInt(2)})
+		require.NoError(t, err)
+		require.NotNil(t, txHash)
+	}
+
+	require.Len(t, node.txs, 2)
+	require.Equal(t, "0x0", node.txs[0].Nonce)
+	require.Equal(t, "0x1", node.txs[1].Nonce)
+	// estimated fee of 0x20 plus half
+	require.Equal(t, "0x30", node.txs[0].MaxFee)
+
+	require.Equal(t, feltsHex(ExecuteCalldata([]FunctionCall{{
+		ContractAddress:    contract,
+		EntryPointSelector: Selector("externalVerifyAdjacent"),
+		Calldata:           []*big.Int{big.NewInt(1), big.NewInt(2)},
+	}})), node.txs[0].Calldata)
+}
+
+func TestAccountExecuteWithMaxFee(t *testing.T) {
+	node, account := setupAccount(t)
+
+	_, err := account.Execute(context.Background(), []FunctionCall{{
+		ContractAddress:    big.NewInt(1),
+		EntryPointSelector: Selector("f"),
+	}}, big.NewInt(1000))
+	require.NoError(t, err)
+	require.Len(t, node.txs, 1)
+	require.Equal(t, "0x3e8", node.txs[0].MaxFee)
+}
+
+func TestWaitForTransaction(t *testing.T) {
+	node, account := setupAccount(t)
+
+	receipt, err := account.Client().WaitForTransaction(context.Background(), big.NewInt(0xabc), time.Millisecond)
+	require.NoError(t, err)
+	require.Equal(t, StatusAcceptedOnL2, receipt.Status)
+	require.Equal(t, int64(0x1a), receipt.ActualFee.Int64())
+	require.Equal(t, uint64(7), receipt.BlockNumber)
+	require.Equal(t, 3, node.polls)
+}
+
+func TestWaitForTransactionContextDone(t *testing.T) {
+	_, account := setupAccount(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := account.Client().WaitForTransaction(ctx, big.NewInt(0xabc), time.Hour)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestClientCall(t *testing.T) {
+	_, account := setupAccount(t)
+
+	res, err := account.Client().Call(context.Background(), FunctionCall{
+		ContractAddress:    big.NewInt(1),
+		EntryPointSelector: Selector("get"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, []*big.Int{big.NewInt(1), big.NewInt(2)}, res)
+}
+
+func TestClientRPCError(t *testing.T) {
+	_, account := setupAccount(t)
+
+	err := account.Client().call(context.Background(), "starknet_unknown", []interface{}{}, nil)
+	require.Error(t, err)
+	rpcErr, ok := err.(*RPCError)
+	require.True(t, ok)
+	require.Equal(t, -32601, rpcErr.Code)
+}
+
+func TestAccountDeclareAndDeploy(t *testing.T) {
+	node, account := setupAccount(t)
+	ctx := context.Background()
+
+	contractPath := filepath.Join(t.TempDir(), "main.json")
+	require.NoError(t, os.WriteFile(contractPath,
+		[]byte(`{"abi":[],"entry_points_by_type":{"CONSTRUCTOR":[],"EXTERNAL":[],"L1_HANDLER":[]},"program":{"data":[]}}`),
+		0600))
+
+	classHash, txHash, err := account.Declare(ctx, contractPath)
+	require.NoError(t, err)
+	require.Equal(t, "0x5678", FeltHex(classHash))
+	require.Equal(t, "0x1234", FeltHex(txHash))
+	require.Len(t, node.declares, 1)
+	require.NotEmpty(t, node.declares[0].ContractClass.Program)
+
+	address, _, err := account.Deploy(ctx, classHash, nil)
+	require.NoError(t, err)
+	require.Len(t, node.txs, 1)
+
+	calldata, err := ParseFelts(node.txs[0].Calldata)
+	require.NoError(t, err)
+	// call array: to, selector, offset, length; then the deployContract arguments
+	require.Equal(t, UniversalDeployerAddress, calldata[1])
+	require.Equal(t, Selector("deployContract"), calldata[2])
+	salt := calldata[7]
+	require.Equal(t, ContractAddress(salt, classHash, nil, big.NewInt(0)), address)
+}
+
+func TestLoadPrivateKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pkey")
+	require.NoError(t, os.WriteFile(path, []byte("0xbdd640fb06671ad11c80317fa3b1799d\n"), 0600))
+
+	key, err := LoadPrivateKey(path)
+	require.NoError(t, err)
+	expected, _ := new(big.Int).SetString("bdd640fb06671ad11c80317fa3b1799d", 16)
+	require.Equal(t, expected.Bytes(), key.Bytes())
+
+	require.NoError(t, os.WriteFile(path, []byte("0x0"), 0600))
+	_, err = LoadPrivateKey(path)
+	require.Error(t, err)
+}
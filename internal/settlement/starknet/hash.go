@@ -0,0 +1,137 @@
+package starknet
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+
+	"github.com/tendermint/tendermint/crypto/pedersen/felt"
+	"github.com/tendermint/tendermint/crypto/pedersen/hashing"
+	"github.com/tendermint/tendermint/crypto/weierstrass"
+)
+
+var (
+	// fieldPrime is the characteristic of the StarkNet field, 2²⁵¹ + 17·2¹⁹² + 1.
+	fieldPrime = weierstrass.Stark().Params().P
+
+	// mask250 keeps the lower 250 bits of a keccak digest, see starknet_keccak.
+	mask250 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 250), big.NewInt(1))
+
+	// l2AddressUpperBound is the exclusive upper bound of contract addresses.
+	l2AddressUpperBound = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 251), big.NewInt(256))
+
+	// prefixes of the transaction and address hashes, as short string felts.
+	invokePrefix          = ShortString("invoke")
+	contractAddressPrefix = ShortString("STARKNET_CONTRACT_ADDRESS")
+)
+
+// ShortString encodes an ASCII string of at most 31 characters as a felt.
+func ShortString(s string) *big.Int {
+	return new(big.Int).SetBytes([]byte(s))
+}
+
+// Keccak returns the starknet_keccak of the input, the keccak256 digest
+// truncated to 250 bits.
+func Keccak(data []byte) *big.Int {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(data)
+	k := new(big.Int).SetBytes(h.Sum(nil))
+	return k.And(k, mask250)
+}
+
+// Selector returns the entry point selector of the given function name.
+func Selector(name string) *big.Int {
+	return Keccak([]byte(name))
+}
+
+// PedersenArray returns compute_hash_on_elements of the given felts.
+func PedersenArray(elements ...*big.Int) *big.Int {
+	felts := make([]*felt.Felt, len(elements))
+	for i, e := range elements {
+		felts[i] = felt.New().SetBigInt(e)
+	}
+	return (*big.Int)(hashing.HashArray(felts...))
+}
+
+// Pedersen returns the pedersen hash of two felts.
+func Pedersen(a, b *big.Int) *big.Int {
+	return (*big.Int)(hashing.Hash2(felt.New().SetBigInt(a), felt.New().SetBigInt(b)))
+}
+
+// InvokeTransactionHash computes the hash of a version 1 invoke
+// transaction, which is what the account signs.
+func InvokeTransactionHash(senderAddress *big.Int, calldata []*big.Int, maxFee, chainID, nonce *big.Int) *big.Int {
+	return PedersenArray(
+		invokePrefix,
+		big.NewInt(1),
+		senderAddress,
+		big.NewInt(0),
+		PedersenArray(calldata...),
+		maxFee,
+		chainID,
+		nonce,
+	)
+}
+
+// ContractAddress computes the address of a contract deployed from the given
+// class hash, following calculate_contract_address_from_hash.
+func ContractAddress(salt, classHash *big.Int, constructorCalldata []*big.Int, deployerAddress *big.Int) *big.Int {
+	address := PedersenArray(
+		contractAddressPrefix,
+		deployerAddress,
+		salt,
+		classHash,
+		PedersenArray(constructorCalldata...),
+	)
+	return address.Mod(address, l2AddressUpperBound)
+}
+
+// ParseFelt parses a felt given either as a 0x prefixed hex string or as a
+// decimal string.
+func ParseFelt(s string) (*big.Int, error) {
+	var (
+		f  *big.Int
+		ok bool
+	)
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		f, ok = new(big.Int).SetString(s[2:], 16)
+	} else {
+		f, ok = new(big.Int).SetString(s, 10)
+	}
+	if !ok {
+		return nil, fmt.Errorf("invalid felt %q", s)
+	}
+	if f.Sign() < 0 || f.Cmp(fieldPrime) >= 0 {
+		return nil, fmt.Errorf("felt %q is out of range", s)
+	}
+	return f, nil
+}
+
+// ParseFelts parses a slice of felt strings, see ParseFelt.
+func ParseFelts(ss []string) ([]*big.Int, error) {
+	felts := make([]*big.Int, len(ss))
+	for i, s := range ss {
+		f, err := ParseFelt(s)
+		if err != nil {
+			return nil, err
+		}
+		felts[i] = f
+	}
+	return felts, nil
+}
+
+// FeltHex returns the 0x prefixed hex representation of a felt, as expected
+// by the JSON-RPC API.
+func FeltHex(f *big.Int) string {
+	return "0x" + f.Text(16)
+}
+
+func feltsHex(felts []*big.Int) []string {
+	hexes := make([]string, len(felts))
+	for i, f := range felts {
+		hexes[i] = FeltHex(f)
+	}
+	return hexes
+}
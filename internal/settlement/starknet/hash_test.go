@@ -0,0 +1,107 @@
+package starknet
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func mustFelt(t *testing.T, s string) *big.Int {
+	t.Helper()
+	f, err := ParseFelt(s)
+	require.NoError(t, err)
+	return f
+}
+
+func TestSelector(t *testing.T) {
+	testCases := []struct {
+		name     string
+		expected string
+	}{
+		0: {name: "__execute__", expected: "0x15d40a3d6ca2ac30f4031e42be28da9b056fef9bb7357ac5e85627ee876e5ad"},
+		1: {name: "transfer", expected: "0x83afd3f4caedc6eebf44246fe54e38c95e3179a5ec9ea81740eca5b482d12e"},
+	}
+	for i, tc := range testCases {
+		require.Equal(t, tc.expected, FeltHex(Selector(tc.name)), "testCase%d failed", i)
+	}
+}
+
+// the expected value is the pedersen test vector of cairo-lang
+func TestPedersen(t *testing.T) {
+	a := mustFelt(t, "0x3d937c035c878245caf64531a5756109c53068da139362728feb561405371cb")
+	b := mustFelt(t, "0x208a0a10250e382e1e4bbe2880906c2791bf6275695e02fbbc6aeff9cd8b31a")
+	expected := "0x30e480bed5fe53fa909cc0f8c4d99b8f9f2c016be4c41e13a4848797979c662"
+
+	require.Equal(t, expected, FeltHex(Pedersen(a, b)))
+}
+
+func TestPedersenArray(t *testing.T) {
+	// compute_hash_on_elements([]) == pedersen(0, 0)
+	require.Equal(t, Pedersen(big.NewInt(0), big.NewInt(0)), PedersenArray())
+
+	// compute_hash_on_elements([a]) == pedersen(pedersen(0, a), 1)
+	a := big.NewInt(104)
+	require.Equal(t, Pedersen(Pedersen(big.NewInt(0), a), big.NewInt(1)), PedersenArray(a))
+}
+
+func TestShortString(t *testing.T) {
+	require.Equal(t, "0x696e766f6b65", FeltHex(ShortString("invoke")))
+	require.Equal(t, "1536727068981429685321", ShortString("SN_GOERLI").String())
+}
+
+func TestParseFelt(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+		err      bool
+	}{
+		0: {input: "0x1f", expected: "31"},
+		1: {input: "31", expected: "31"},
+		2: {input: "0xzz", err: true},
+		3: {input: "-1", err: true},
+		4: {input: "0x800000000000011000000000000000000000000000000000000000000000001", err: true},
+	}
+	for i, tc := range testCases {
+		f, err := ParseFelt(tc.input)
+		if tc.err {
+			require.Error(t, err, "testCase%d failed", i)
+			continue
+		}
+		require.NoError(t, err, "testCase%d failed", i)
+		require.Equal(t, tc.expected, f.String(), "testCase%d failed", i)
+	}
+}
+
+func TestExecuteCalldata(t *testing.T) {
+	calls := []FunctionCall{
+		{ContractAddress: big.NewInt(10), EntryPointSelector: big.NewInt(11), Calldata: []*big.Int{big.NewInt(1), big.NewInt(2)}},
+		{ContractAddress: big.NewInt(20), EntryPointSelector: big.NewInt(21), Calldata: []*big.Int{big.NewInt(3)}},
+	}
+	expected := []int64{
+		2,
+		10, 11, 0, 2,
+		20, 21, 2, 1,
+		3,
+		1, 2, 3,
+	}
+
+	res := ExecuteCalldata(calls)
+	require.Len(t, res, len(expected))
+	for i, e := range expected {
+		require.Equal(t, e, res[i].Int64(), "felt %d does not match", i)
+	}
+}
+
+func TestContractAddress(t *testing.T) {
+	salt := big.NewInt(1)
+	classHash := big.NewInt(2)
+	calldata := []*big.Int{big.NewInt(3)}
+
+	expected := PedersenArray(contractAddressPrefix, big.NewInt(0), salt, classHash, PedersenArray(calldata...))
+	expected.Mod(expected, l2AddressUpperBound)
+
+	address := ContractAddress(salt, classHash, calldata, big.NewInt(0))
+	require.Equal(t, expected, address)
+	require.Equal(t, -1, address.Cmp(l2AddressUpperBound))
+}
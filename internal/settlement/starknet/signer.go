@@ -0,0 +1,162 @@
+package starknet
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/tendermint/tendermint/crypto/stark"
+	privvalproto "github.com/tendermint/tendermint/proto/tendermint/privval"
+)
+
+// Invoke is a version 1 invoke transaction of an account contract, before it
+// is signed.
+type Invoke struct {
+	SenderAddress *big.Int
+	Calls         []FunctionCall
+	MaxFee        *big.Int
+	Nonce         *big.Int
+	ChainID       *big.Int
+}
+
+// Hash returns the hash of the transaction, which is what the account signs.
+func (tx *Invoke) Hash() *big.Int {
+	return InvokeTransactionHash(tx.SenderAddress, ExecuteCalldata(tx.Calls), tx.MaxFee, tx.ChainID, tx.Nonce)
+}
+
+// ToProto converts the transaction to its privval representation, of which
+// felts are big-endian encoded on 32 bytes.
+func (tx *Invoke) ToProto() *privvalproto.StarknetInvokeTransaction {
+	calls := make([]privvalproto.StarknetFunctionCall, len(tx.Calls))
+	for i, call := range tx.Calls {
+		calls[i] = privvalproto.StarknetFunctionCall{
+			ContractAddress:    feltBytes(call.ContractAddress),
+			EntryPointSelector: feltBytes(call.EntryPointSelector),
+			Calldata:           feltsBytes(call.Calldata),
+		}
+	}
+	return &privvalproto.StarknetInvokeTransaction{
+		SenderAddress:   feltBytes(tx.SenderAddress),
+		Calls:           calls,
+		MaxFee:          feltBytes(tx.MaxFee),
+		Nonce:           feltBytes(tx.Nonce),
+		StarknetChainId: feltBytes(tx.ChainID),
+	}
+}
+
+// InvokeFromProto converts a privval transaction to an Invoke, checking that
+// all its values are felts. The signature of the transaction is ignored.
+func InvokeFromProto(pb *privvalproto.StarknetInvokeTransaction) (*Invoke, error) {
+	if pb == nil {
+		return nil, errors.New("nil transaction")
+	}
+
+	tx := new(Invoke)
+	var err error
+	if tx.SenderAddress, err = parseFeltBytes(pb.SenderAddress); err != nil {
+		return nil, fmt.Errorf("invalid sender address: %w", err)
+	}
+	if tx.MaxFee, err = parseFeltBytes(pb.MaxFee); err != nil {
+		return nil, fmt.Errorf("invalid max fee: %w", err)
+	}
+	if tx.Nonce, err = parseFeltBytes(pb.Nonce); err != nil {
+		return nil, fmt.Errorf("invalid nonce: %w", err)
+	}
+	if tx.ChainID, err = parseFeltBytes(pb.StarknetChainId); err != nil {
+		return nil, fmt.Errorf("invalid chain id: %w", err)
+	}
+
+	tx.Calls = make([]FunctionCall, len(pb.Calls))
+	for i, call := range pb.Calls {
+		c := &tx.Calls[i]
+		if c.ContractAddress, err = parseFeltBytes(call.ContractAddress); err != nil {
+			return nil, fmt.Errorf("call %d: invalid contract address: %w", i, err)
+		}
+		if c.EntryPointSelector, err = parseFeltBytes(call.EntryPointSelector); err != nil {
+			return nil, fmt.Errorf("call %d: invalid entry point selector: %w", i, err)
+		}
+		c.Calldata = make([]*big.Int, len(call.Calldata))
+		for j, bz := range call.Calldata {
+			if c.Calldata[j], err = parseFeltBytes(bz); err != nil {
+				return nil, fmt.Errorf("call %d: invalid calldata %d: %w", i, j, err)
+			}
+		}
+	}
+	return tx, nil
+}
+
+// SignatureToProto encodes a transaction signature as big-endian felts.
+func SignatureToProto(signature []*big.Int) [][]byte {
+	return feltsBytes(signature)
+}
+
+// SignatureFromProto decodes a transaction signature made of big-endian
+// felts.
+func SignatureFromProto(signature [][]byte) ([]*big.Int, error) {
+	felts := make([]*big.Int, len(signature))
+	for i, bz := range signature {
+		f, err := parseFeltBytes(bz)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signature: %w", err)
+		}
+		felts[i] = f
+	}
+	return felts, nil
+}
+
+func feltBytes(f *big.Int) []byte {
+	return f.FillBytes(make([]byte, 32))
+}
+
+func feltsBytes(felts []*big.Int) [][]byte {
+	bzs := make([][]byte, len(felts))
+	for i, f := range felts {
+		bzs[i] = feltBytes(f)
+	}
+	return bzs
+}
+
+func parseFeltBytes(bz []byte) (*big.Int, error) {
+	if len(bz) > 32 {
+		return nil, fmt.Errorf("felt of %d bytes", len(bz))
+	}
+	f := new(big.Int).SetBytes(bz)
+	if f.Cmp(fieldPrime) >= 0 {
+		return nil, fmt.Errorf("felt %s is out of range", FeltHex(f))
+	}
+	return f, nil
+}
+
+// Signer signs the transactions of an account contract. Implementations must
+// be safe for concurrent use.
+type Signer interface {
+	// SignInvoke returns the signature of tx expected by the account
+	// contract.
+	SignInvoke(ctx context.Context, tx *Invoke) ([]*big.Int, error)
+}
+
+// KeySigner is a Signer holding the stark key of an account contract which
+// checks ECDSA signatures of the transaction hash, like the OpenZeppelin and
+// Argent accounts.
+type KeySigner struct {
+	key stark.PrivKey
+}
+
+var _ Signer = (*KeySigner)(nil)
+
+// NewKeySigner returns a signer signing with key.
+func NewKeySigner(key stark.PrivKey) *KeySigner {
+	return &KeySigner{key: key}
+}
+
+// SignInvoke signs the hash of tx.
+func (s *KeySigner) SignInvoke(_ context.Context, tx *Invoke) ([]*big.Int, error) {
+	pv := s.key.MakeFull()
+	r, sig, err := stark.SignECDSA(&pv, tx.Hash().FillBytes(make([]byte, 32)), sha256.New)
+	if err != nil {
+		return nil, err
+	}
+	return []*big.Int{r, sig}, nil
+}
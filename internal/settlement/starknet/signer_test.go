@@ -0,0 +1,67 @@
+package starknet
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/stark"
+)
+
+func TestInvokeProto(t *testing.T) {
+	tx := &Invoke{
+		SenderAddress: big.NewInt(0xacc),
+		Calls: []FunctionCall{
+			{ContractAddress: big.NewInt(0xc0de), EntryPointSelector: Selector("verify"), Calldata: []*big.Int{big.NewInt(1)}},
+			{ContractAddress: big.NewInt(0xc0de), EntryPointSelector: Selector("latestHeight"), Calldata: []*big.Int{}},
+		},
+		MaxFee:  big.NewInt(1000),
+		Nonce:   big.NewInt(2),
+		ChainID: ShortString("SN_GOERLI"),
+	}
+
+	pb := tx.ToProto()
+	require.Len(t, pb.SenderAddress, 32)
+	decoded, err := InvokeFromProto(pb)
+	require.NoError(t, err)
+	require.Equal(t, tx.Hash(), decoded.Hash())
+	require.Equal(t, tx.Hash(), InvokeTransactionHash(tx.SenderAddress, ExecuteCalldata(tx.Calls), tx.MaxFee, tx.ChainID, tx.Nonce))
+
+	// values which are not felts
+	pb = tx.ToProto()
+	pb.Nonce = fieldPrime.Bytes()
+	_, err = InvokeFromProto(pb)
+	require.Error(t, err)
+	pb = tx.ToProto()
+	pb.Calls[1].Calldata = [][]byte{make([]byte, 33)}
+	_, err = InvokeFromProto(pb)
+	require.Error(t, err)
+	_, err = InvokeFromProto(nil)
+	require.Error(t, err)
+
+	_, err = SignatureFromProto([][]byte{fieldPrime.Bytes()})
+	require.Error(t, err)
+}
+
+func TestKeySigner(t *testing.T) {
+	key := stark.GenPrivKey()
+	tx := &Invoke{
+		SenderAddress: big.NewInt(0xacc),
+		Calls:         []FunctionCall{{ContractAddress: big.NewInt(0xc0de), EntryPointSelector: Selector("verify")}},
+		MaxFee:        big.NewInt(1000),
+		Nonce:         big.NewInt(0),
+		ChainID:       ShortString("SN_GOERLI"),
+	}
+
+	signature, err := NewKeySigner(key).SignInvoke(context.Background(), tx)
+	require.NoError(t, err)
+	require.Len(t, signature, 2)
+	pub := key.MakeFull().PublicKey
+	require.True(t, stark.Verify(&pub, tx.Hash().FillBytes(make([]byte, 32)), signature[0], signature[1]))
+
+	decoded, err := SignatureFromProto(SignatureToProto(signature))
+	require.NoError(t, err)
+	require.Equal(t, signature, decoded)
+}
@@ -0,0 +1,135 @@
+package starknet
+
+import (
+	"encoding/json"
+	"math/big"
+)
+
+// TransactionStatus is the status of a transaction as reported in its receipt.
+type TransactionStatus string
+
+const (
+	StatusPending      TransactionStatus = "PENDING"
+	StatusAcceptedOnL2 TransactionStatus = "ACCEPTED_ON_L2"
+	StatusAcceptedOnL1 TransactionStatus = "ACCEPTED_ON_L1"
+	StatusRejected     TransactionStatus = "REJECTED"
+)
+
+// Accepted reports whether the transaction made it into an L2 block.
+func (s TransactionStatus) Accepted() bool {
+	return s == StatusAcceptedOnL2 || s == StatusAcceptedOnL1
+}
+
+// Final reports whether the status will not change anymore from the point of
+// view of a sender, i.e. the transaction was either accepted or rejected.
+func (s TransactionStatus) Final() bool {
+	return s.Accepted() || s == StatusRejected
+}
+
+// FunctionCall is a call to an entry point of a contract.
+type FunctionCall struct {
+	ContractAddress    *big.Int
+	EntryPointSelector *big.Int
+	Calldata           []*big.Int
+}
+
+func (fc FunctionCall) toRPC() map[string]interface{} {
+	return map[string]interface{}{
+		"contract_address":     FeltHex(fc.ContractAddress),
+		"entry_point_selector": FeltHex(fc.EntryPointSelector),
+		"calldata":             feltsHex(fc.Calldata),
+	}
+}
+
+// InvokeTransaction is a broadcasted version 1 invoke transaction, encoded as
+// expected by starknet_addInvokeTransaction and starknet_estimateFee.
+type InvokeTransaction struct {
+	Type          string   `json:"type"`
+	SenderAddress string   `json:"sender_address"`
+	Calldata      []string `json:"calldata"`
+	MaxFee        string   `json:"max_fee"`
+	Version       string   `json:"version"`
+	Signature     []string `json:"signature"`
+	Nonce         string   `json:"nonce"`
+}
+
+// ContractClass is a compiled Cairo 0 contract, as sent in declare
+// transactions. Program is the base64 encoded, gzipped program.
+type ContractClass struct {
+	Program           string          `json:"program"`
+	EntryPointsByType json.RawMessage `json:"entry_points_by_type"`
+	ABI               json.RawMessage `json:"abi,omitempty"`
+}
+
+// DeclareTransaction is a broadcasted declare transaction.
+type DeclareTransaction struct {
+	Type          string        `json:"type"`
+	ContractClass ContractClass `json:"contract_class"`
+	SenderAddress string        `json:"sender_address"`
+	MaxFee        string        `json:"max_fee"`
+	Version       string        `json:"version"`
+	Signature     []string      `json:"signature"`
+	Nonce         string        `json:"nonce,omitempty"`
+}
+
+// FeeEstimate is the result of starknet_estimateFee.
+type FeeEstimate struct {
+	GasConsumed *big.Int
+	GasPrice    *big.Int
+	OverallFee  *big.Int
+}
+
+type rpcFeeEstimate struct {
+	GasConsumed string `json:"gas_consumed"`
+	GasPrice    string `json:"gas_price"`
+	OverallFee  string `json:"overall_fee"`
+}
+
+func (e rpcFeeEstimate) parse() (fe *FeeEstimate, err error) {
+	fe = new(FeeEstimate)
+	if fe.GasConsumed, err = ParseFelt(e.GasConsumed); err != nil {
+		return nil, err
+	}
+	if fe.GasPrice, err = ParseFelt(e.GasPrice); err != nil {
+		return nil, err
+	}
+	if fe.OverallFee, err = ParseFelt(e.OverallFee); err != nil {
+		return nil, err
+	}
+	return fe, nil
+}
+
+// Receipt is the part of a transaction receipt settlement cares about.
+type Receipt struct {
+	TransactionHash *big.Int
+	Status          TransactionStatus
+	ActualFee       *big.Int
+	BlockNumber     uint64
+	StatusData      string
+}
+
+type rpcReceipt struct {
+	TransactionHash string `json:"transaction_hash"`
+	Status          string `json:"status"`
+	ActualFee       string `json:"actual_fee"`
+	BlockNumber     uint64 `json:"block_number"`
+	StatusData      string `json:"status_data"`
+}
+
+func (r rpcReceipt) parse() (receipt *Receipt, err error) {
+	receipt = &Receipt{
+		Status:      TransactionStatus(r.Status),
+		BlockNumber: r.BlockNumber,
+		StatusData:  r.StatusData,
+		ActualFee:   big.NewInt(0),
+	}
+	if receipt.TransactionHash, err = ParseFelt(r.TransactionHash); err != nil {
+		return nil, err
+	}
+	if r.ActualFee != "" {
+		if receipt.ActualFee, err = ParseFelt(r.ActualFee); err != nil {
+			return nil, err
+		}
+	}
+	return receipt, nil
+}
@@ -0,0 +1,354 @@
+package settlement
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/orderedcode"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/internal/settlement/parser"
+)
+
+// RecordStatus is the progress of a height through settlement.
+type RecordStatus string
+
+const (
+	// RecordEnqueued heights were received but not handed to the backend, or
+	// the backend buffered them without sending a transaction yet.
+	RecordEnqueued RecordStatus = "enqueued"
+	// RecordSubmitted heights were sent in a transaction that is not final.
+	RecordSubmitted RecordStatus = "submitted"
+	// RecordAccepted heights are settled.
+	RecordAccepted RecordStatus = "accepted"
+	// RecordRejected heights were sent in a transaction the verifier rejected.
+	RecordRejected RecordStatus = "rejected"
+)
+
+// Record is the settlement state of a single height.
+type Record struct {
+	Height int64                 `json:"height"`
+	Status RecordStatus          `json:"status"`
+	TxHash string                `json:"tx_hash,omitempty"`
+	Data   parser.SettlementData `json:"data"`
+}
+
+// EvidenceRecord is the settlement state of a piece of evidence committed in
+// a block, which is submitted to the verifier apart from the commits.
+type EvidenceRecord struct {
+	// Hash of the evidence, as returned by types.Evidence.Hash.
+	Hash []byte `json:"hash"`
+	// Height of the block the evidence was committed in.
+	Height int64                 `json:"height"`
+	Status RecordStatus          `json:"status"`
+	TxHash string                `json:"tx_hash,omitempty"`
+	Data   parser.SettlementData `json:"data"`
+}
+
+// Store persists the settlement state of every height handed to the
+// reactor, so that nothing is lost when the node restarts.
+type Store struct {
+	db dbm.DB
+}
+
+// NewStore returns a settlement store backed by db.
+func NewStore(db dbm.DB) *Store {
+	return &Store{db: db}
+}
+
+// Enqueue records data as enqueued, unless its height is already known, in
+// which case the existing record is returned unchanged.
+func (s *Store) Enqueue(data parser.SettlementData) (*Record, error) {
+	rec, err := s.Load(data.Height)
+	if err != nil || rec != nil {
+		return rec, err
+	}
+	rec = &Record{Height: data.Height, Status: RecordEnqueued, Data: data}
+	return rec, s.Save(rec)
+}
+
+// Load returns the record of height, or nil if there is none.
+func (s *Store) Load(height int64) (*Record, error) {
+	bz, err := s.db.Get(recordKey(height))
+	if err != nil {
+		return nil, err
+	}
+	if len(bz) == 0 {
+		return nil, nil
+	}
+	rec := new(Record)
+	if err := json.Unmarshal(bz, rec); err != nil {
+		return nil, fmt.Errorf("failed to decode settlement record %d: %w", height, err)
+	}
+	return rec, nil
+}
+
+// Save writes rec. Saving an accepted record also advances the last settled
+// height, atomically with the record itself.
+func (s *Store) Save(rec *Record) error {
+	bz, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+
+	batch := s.db.NewBatch()
+	defer batch.Close()
+
+	if err := batch.Set(recordKey(rec.Height), bz); err != nil {
+		return err
+	}
+	if rec.Status == RecordAccepted {
+		last, err := s.LastSettledHeight()
+		if err != nil {
+			return err
+		}
+		if rec.Height > last {
+			if err := batch.Set(lastSettledKey(), encodeHeight(rec.Height)); err != nil {
+				return err
+			}
+		}
+	}
+	return batch.WriteSync()
+}
+
+// LastSettledHeight returns the highest accepted height, or 0 if none was.
+func (s *Store) LastSettledHeight() (int64, error) {
+	bz, err := s.db.Get(lastSettledKey())
+	if err != nil || len(bz) == 0 {
+		return 0, err
+	}
+	return decodeHeight(bz)
+}
+
+// LastHeight returns the highest height with a record, whatever its status,
+// or 0 if there is none.
+func (s *Store) LastHeight() (int64, error) {
+	iter, err := s.db.ReverseIterator(recordKey(0), recordKeyEnd())
+	if err != nil {
+		return 0, err
+	}
+	defer iter.Close()
+
+	if !iter.Valid() {
+		return 0, iter.Error()
+	}
+	var height int64
+	if _, err := orderedcode.Parse(string(iter.Key()), new(int64), &height); err != nil {
+		return 0, fmt.Errorf("failed to decode settlement record key: %w", err)
+	}
+	return height, nil
+}
+
+// LastSubmittedHeight returns the highest height sent in a transaction,
+// whatever its outcome, or 0 if none was.
+func (s *Store) LastSubmittedHeight() (int64, error) {
+	iter, err := s.db.ReverseIterator(recordKey(0), recordKeyEnd())
+	if err != nil {
+		return 0, err
+	}
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		rec := new(Record)
+		if err := json.Unmarshal(iter.Value(), rec); err != nil {
+			return 0, fmt.Errorf("failed to decode settlement record: %w", err)
+		}
+		if rec.TxHash != "" {
+			return rec.Height, nil
+		}
+	}
+	return 0, iter.Error()
+}
+
+// Unsettled returns the records above height that are not accepted, in
+// ascending order of height.
+func (s *Store) Unsettled(height int64) ([]*Record, error) {
+	iter, err := s.db.Iterator(recordKey(height+1), recordKeyEnd())
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	var records []*Record
+	for ; iter.Valid(); iter.Next() {
+		rec := new(Record)
+		if err := json.Unmarshal(iter.Value(), rec); err != nil {
+			return nil, fmt.Errorf("failed to decode settlement record: %w", err)
+		}
+		if rec.Status != RecordAccepted {
+			records = append(records, rec)
+		}
+	}
+	return records, iter.Error()
+}
+
+// SettleUpTo marks the unsettled records up to and including height that
+// were sent in no transaction of their own, and that settled reports as
+// settled, as accepted, and returns the records it marked. These are the
+// heights left to another validator, and the ones backends batch into the
+// transaction of a later height. The records sent in a transaction are only
+// accepted from its status.
+func (s *Store) SettleUpTo(height int64, settled func(height int64) (bool, error)) ([]*Record, error) {
+	last, err := s.LastSettledHeight()
+	if err != nil {
+		return nil, err
+	}
+	records, err := s.Unsettled(last)
+	if err != nil {
+		return nil, err
+	}
+	var marked []*Record
+	for _, rec := range records {
+		if rec.Height > height {
+			break
+		}
+		if rec.Status == RecordRejected || rec.TxHash != "" {
+			continue
+		}
+		ok, err := settled(rec.Height)
+		if err != nil {
+			return marked, err
+		}
+		if !ok {
+			continue
+		}
+		rec.Status = RecordAccepted
+		if err := s.Save(rec); err != nil {
+			return marked, err
+		}
+		marked = append(marked, rec)
+	}
+	return marked, nil
+}
+
+// EnqueueEvidence records data submitting the evidence of hash as enqueued,
+// unless the evidence is already known, in which case the existing record is
+// returned unchanged.
+func (s *Store) EnqueueEvidence(hash []byte, data parser.SettlementData) (*EvidenceRecord, error) {
+	rec, err := s.LoadEvidence(hash)
+	if err != nil || rec != nil {
+		return rec, err
+	}
+	rec = &EvidenceRecord{Hash: hash, Height: data.Height, Status: RecordEnqueued, Data: data}
+	return rec, s.SaveEvidence(rec)
+}
+
+// LoadEvidence returns the record of the evidence of hash, or nil if there is
+// none.
+func (s *Store) LoadEvidence(hash []byte) (*EvidenceRecord, error) {
+	bz, err := s.db.Get(evidenceKey(hash))
+	if err != nil {
+		return nil, err
+	}
+	if len(bz) == 0 {
+		return nil, nil
+	}
+	rec := new(EvidenceRecord)
+	if err := json.Unmarshal(bz, rec); err != nil {
+		return nil, fmt.Errorf("failed to decode evidence record %X: %w", hash, err)
+	}
+	return rec, nil
+}
+
+// SaveEvidence writes rec.
+func (s *Store) SaveEvidence(rec *EvidenceRecord) error {
+	bz, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	return s.db.SetSync(evidenceKey(rec.Hash), bz)
+}
+
+// PendingEvidence returns the evidence records that are neither accepted nor
+// rejected, in ascending order of hash.
+func (s *Store) PendingEvidence() ([]*EvidenceRecord, error) {
+	iter, err := s.db.Iterator(evidenceKey(nil), evidenceKeyEnd())
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	var records []*EvidenceRecord
+	for ; iter.Valid(); iter.Next() {
+		rec := new(EvidenceRecord)
+		if err := json.Unmarshal(iter.Value(), rec); err != nil {
+			return nil, fmt.Errorf("failed to decode evidence record: %w", err)
+		}
+		if rec.Status == RecordEnqueued || rec.Status == RecordSubmitted {
+			records = append(records, rec)
+		}
+	}
+	return records, iter.Error()
+}
+
+// Close closes the underlying database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+//---------------------------------------------------------------------------
+// key encoding
+
+const (
+	// prefixes are unique across all settlement db keys
+	prefixRecord      = int64(0)
+	prefixLastSettled = int64(1)
+	prefixEvidence    = int64(2)
+)
+
+func recordKey(height int64) []byte {
+	key, err := orderedcode.Append(nil, prefixRecord, height)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+// recordKeyEnd is the exclusive upper bound of all record keys.
+func recordKeyEnd() []byte {
+	key, err := orderedcode.Append(nil, prefixRecord+1)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+func lastSettledKey() []byte {
+	key, err := orderedcode.Append(nil, prefixLastSettled)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+func evidenceKey(hash []byte) []byte {
+	key, err := orderedcode.Append(nil, prefixEvidence, string(hash))
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+// evidenceKeyEnd is the exclusive upper bound of all evidence keys.
+func evidenceKeyEnd() []byte {
+	key, err := orderedcode.Append(nil, prefixEvidence+1)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+func encodeHeight(height int64) []byte {
+	key, err := orderedcode.Append(nil, height)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+func decodeHeight(bz []byte) (height int64, err error) {
+	if _, err = orderedcode.Parse(string(bz), &height); err != nil {
+		return 0, fmt.Errorf("failed to decode height: %w", err)
+	}
+	return height, nil
+}
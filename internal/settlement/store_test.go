@@ -0,0 +1,228 @@
+package settlement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/internal/settlement/parser"
+	"github.com/tendermint/tendermint/types"
+)
+
+func TestStoreEnqueue(t *testing.T) {
+	store := NewStore(dbm.NewMemDB())
+	height, err := store.LastHeight()
+	require.NoError(t, err)
+	require.Zero(t, height)
+
+	rec, err := store.Enqueue(parser.SettlementData{Height: 3, Data: []string{"1"}})
+	require.NoError(t, err)
+	require.Equal(t, RecordEnqueued, rec.Status)
+
+	rec.Status = RecordSubmitted
+	rec.TxHash = "0xabc"
+	require.NoError(t, store.Save(rec))
+	height, err = store.LastSubmittedHeight()
+	require.NoError(t, err)
+	require.EqualValues(t, 3, height)
+
+	// enqueueing a known height keeps its record
+	rec, err = store.Enqueue(parser.SettlementData{Height: 3})
+	require.NoError(t, err)
+	require.Equal(t, RecordSubmitted, rec.Status)
+	require.Equal(t, "0xabc", rec.TxHash)
+	require.Equal(t, []string{"1"}, rec.Data.Data)
+
+	rec, err = store.Load(4)
+	require.NoError(t, err)
+	require.Nil(t, rec)
+}
+
+func TestStoreSettleUpTo(t *testing.T) {
+	store := NewStore(dbm.NewMemDB())
+
+	records := []*Record{
+		{Height: 2, Status: RecordEnqueued},
+		{Height: 3, Status: RecordRejected, TxHash: "0x3"},
+		{Height: 4, Status: RecordSubmitted, TxHash: "0x4"},
+		// never sent, and skipped by the verifier
+		{Height: 5, Status: RecordEnqueued},
+		{Height: 6, Status: RecordEnqueued},
+		{Height: 7, Status: RecordEnqueued},
+	}
+	for _, rec := range records {
+		require.NoError(t, store.Save(rec))
+	}
+	// every height but 5 was settled on-chain, the records sent in a
+	// transaction are only accepted from its status
+	onChain := func(height int64) (bool, error) { return height != 5, nil }
+	height, err := store.LastSettledHeight()
+	require.NoError(t, err)
+	require.Zero(t, height)
+	height, err = store.LastHeight()
+	require.NoError(t, err)
+	require.EqualValues(t, 7, height)
+
+	height, err = store.LastSubmittedHeight()
+	require.NoError(t, err)
+	require.EqualValues(t, 4, height)
+
+	settled, err := store.SettleUpTo(6, onChain)
+	require.NoError(t, err)
+	require.Len(t, settled, 2)
+	require.EqualValues(t, 2, settled[0].Height)
+	require.EqualValues(t, 6, settled[1].Height)
+	height, err = store.LastSettledHeight()
+	require.NoError(t, err)
+	require.EqualValues(t, 6, height)
+
+	unsettled, err := store.Unsettled(0)
+	require.NoError(t, err)
+	require.Len(t, unsettled, 4)
+	for i, status := range []RecordStatus{RecordRejected, RecordSubmitted, RecordEnqueued, RecordEnqueued} {
+		require.Equal(t, status, unsettled[i].Status, "record %d", unsettled[i].Height)
+	}
+
+	unsettled, err = store.Unsettled(height)
+	require.NoError(t, err)
+	require.Len(t, unsettled, 1)
+}
+
+func TestReactorResume(t *testing.T) {
+	ctx := context.Background()
+	backend := NewMockBackend()
+	store := NewStore(dbm.NewMemDB())
+
+	// height 2 was sent and accepted before the restart
+	txHash, err := backend.Submit(ctx, parser.SettlementData{Height: 2})
+	require.NoError(t, err)
+
+	records := []*Record{
+		{Height: 1, Status: RecordAccepted},
+		{Height: 2, Status: RecordSubmitted, TxHash: txHash},
+		{Height: 3, Status: RecordSubmitted, TxHash: "0xdead"}, // dropped by the sequencer
+		{Height: 4, Status: RecordEnqueued},
+		{Height: 5, Status: RecordRejected, TxHash: "0xbeef"},
+	}
+	for _, rec := range records {
+		rec.Data = parser.SettlementData{Height: rec.Height}
+		require.NoError(t, store.Save(rec))
+	}
+
+	r := newTestReactor(backend, store)
+	require.NoError(t, r.Start())
+	t.Cleanup(func() { _ = r.Stop() })
+
+	require.Eventually(t, func() bool {
+		return len(backend.Submissions()) == 3
+	}, time.Second, 10*time.Millisecond)
+	submissions := backend.Submissions()
+	require.EqualValues(t, 3, submissions[1].Height)
+	require.EqualValues(t, 4, submissions[2].Height)
+
+	require.Eventually(t, func() bool {
+		height, err := store.LastSettledHeight()
+		return err == nil && height == 4
+	}, time.Second, 10*time.Millisecond)
+	rec, err := store.Load(5)
+	require.NoError(t, err)
+	require.Equal(t, RecordRejected, rec.Status)
+}
+
+func TestReactorStatusAndEvents(t *testing.T) {
+	ctx := context.Background()
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() { _ = eventBus.Stop() })
+	submitted, err := eventBus.Subscribe(ctx, "test", types.EventQuerySettlementSubmitted, 10)
+	require.NoError(t, err)
+	accepted, err := eventBus.Subscribe(ctx, "test", types.EventQuerySettlementAccepted, 10)
+	require.NoError(t, err)
+
+	backend := NewMockBackend()
+	store := NewStore(dbm.NewMemDB())
+	r := newTestReactor(backend, store)
+	r.SetEventBus(eventBus)
+
+	for _, height := range []int64{2, 3} {
+		data := parser.SettlementData{Height: height, Function: parser.AdjacentFunction}
+		require.NoError(t, r.SendCommit(ctx, data))
+	}
+	for _, height := range []int64{2, 3} {
+		for _, sub := range []types.Subscription{submitted, accepted} {
+			select {
+			case msg := <-sub.Out():
+				data := msg.Data().(types.EventDataSettlement)
+				require.Equal(t, config.DefaultSettlementTarget, data.Target)
+				require.Equal(t, height, data.Height)
+				require.NotEmpty(t, data.TxHash)
+				require.Equal(t, parser.AdjacentFunction, data.Function)
+			case <-time.After(time.Second):
+				t.Fatalf("no settlement event for height %d", height)
+			}
+		}
+	}
+
+	// a transaction that is not final
+	require.NoError(t, store.Save(&Record{Height: 5, Status: RecordSubmitted, TxHash: "0xdead"}))
+	r.logError("failed to settle new blocks", errors.New("sequencer unavailable"))
+
+	status, err := r.Status()
+	require.NoError(t, err)
+	require.Equal(t, config.DefaultSettlementTarget, status.Target)
+	require.EqualValues(t, 5, status.LastEnqueuedHeight)
+	require.EqualValues(t, 5, status.LastSubmittedHeight)
+	require.EqualValues(t, 3, status.LastAcceptedHeight)
+	require.Len(t, status.Pending, 1)
+	require.Equal(t, "0xdead", status.Pending[0].TxHash)
+	require.EqualError(t, status.LastError, "sequencer unavailable")
+}
+
+func TestReactorMetrics(t *testing.T) {
+	ctx := context.Background()
+	const namespace = "test_reactor_metrics"
+	backend := NewMockBackend()
+	store := NewStore(dbm.NewMemDB())
+	r := newTestReactor(backend, store)
+	registry := stdprometheus.NewRegistry()
+	r.metrics = prometheusMetrics(registry, namespace).target(r.Target())
+
+	for _, height := range []int64{2, 3} {
+		require.NoError(t, r.SendCommit(ctx, parser.SettlementData{Height: height}))
+	}
+	backend.SetSubmitError(fmt.Errorf("%w: 2 > 1", ErrMaxFeeExceeded))
+	require.ErrorIs(t, r.SendCommit(ctx, parser.SettlementData{Height: 4}), ErrMaxFeeExceeded)
+	r.updateMetrics()
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	values := make(map[string]float64)
+	for _, family := range families {
+		if !strings.HasPrefix(family.GetName(), namespace) {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			name := strings.TrimPrefix(family.GetName(), namespace+"_settlement_")
+			for _, label := range m.GetLabel() {
+				name += "/" + label.GetValue()
+			}
+			values[name] = m.GetCounter().GetValue() + m.GetGauge().GetValue()
+		}
+	}
+	require.Equal(t, map[string]float64{
+		"queue_depth/default":           1,
+		"lag/default":                   0,
+		"settled_height/default":        3,
+		"submissions/accepted/default":  2,
+		"submissions/fee_retry/default": 1,
+		"fees_paid/default":             2,
+	}, values)
+}
@@ -0,0 +1,514 @@
+package verifier
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/tendermint/tendermint/crypto/weierstrass"
+	"github.com/tendermint/tendermint/internal/settlement/parser"
+)
+
+// ErrMalformedCalldata is returned when calldata cannot be decoded into the
+// arguments of a verifier entry point.
+var ErrMalformedCalldata = errors.New("malformed calldata")
+
+// The types below mirror the structs of cairo/src/structs.cairo and the
+// arguments of the entry points of cairo/src/main.cairo. Every field is a
+// felt.
+
+// PartSetHeader mirrors PartSetHeaderData.
+type PartSetHeader struct {
+	Total *big.Int
+	Hash  *big.Int
+}
+
+// BlockID mirrors BlockIDData.
+type BlockID struct {
+	Hash          *big.Int
+	PartSetHeader PartSetHeader
+}
+
+// Consensus mirrors ConsensusData.
+type Consensus struct {
+	Block *big.Int
+	App   *big.Int
+}
+
+// CommitSig mirrors CommitSigData.
+type CommitSig struct {
+	BlockIDFlag      *big.Int
+	ValidatorAddress *big.Int
+	Timestamp        *big.Int
+	SignatureR       *big.Int
+	SignatureS       *big.Int
+}
+
+// Header mirrors LightHeaderData. The chain ID is split in 128 bit chunks.
+type Header struct {
+	Version            Consensus
+	ChainID            []*big.Int
+	Height             *big.Int
+	Time               *big.Int
+	LastBlockID        BlockID
+	LastCommitHash     *big.Int
+	DataHash           *big.Int
+	ValidatorsHash     *big.Int
+	NextValidatorsHash *big.Int
+	ConsensusHash      *big.Int
+	AppHash            *big.Int
+	LastResultsHash    *big.Int
+	EvidenceHash       *big.Int
+	ProposerAddress    *big.Int
+}
+
+// Commit mirrors CommitData.
+type Commit struct {
+	Height     *big.Int
+	Round      *big.Int
+	BlockID    BlockID
+	Signatures []CommitSig
+}
+
+// SignedHeader mirrors SignedHeaderData.
+type SignedHeader struct {
+	Header Header
+	Commit Commit
+}
+
+// Validator mirrors ValidatorData. PubKey is the x coordinate of the stark
+// public key.
+type Validator struct {
+	Address          *big.Int
+	PubKey           *big.Int
+	VotingPower      *big.Int
+	ProposerPriority *big.Int
+}
+
+// ValidatorSet mirrors ValidatorSetData.
+type ValidatorSet struct {
+	Validators       []Validator
+	Proposer         Validator
+	TotalVotingPower *big.Int
+}
+
+// VerificationArgs mirrors VerificationArgs, in nanoseconds.
+type VerificationArgs struct {
+	CurrentTime    *big.Int
+	MaxClockDrift  *big.Int
+	TrustingPeriod *big.Int
+}
+
+// Fraction mirrors FractionData.
+type Fraction struct {
+	Numerator   *big.Int
+	Denominator *big.Int
+}
+
+// AdjacentInput holds the arguments of externalVerifyAdjacent.
+type AdjacentInput struct {
+	Trusted          SignedHeader
+	Untrusted        SignedHeader
+	UntrustedVals    ValidatorSet
+	VerificationArgs VerificationArgs
+}
+
+// NonAdjacentInput holds the arguments of externalVerifyNonAdjacent.
+type NonAdjacentInput struct {
+	Trusted          SignedHeader
+	Untrusted        SignedHeader
+	TrustedVals      ValidatorSet
+	UntrustedVals    ValidatorSet
+	VerificationArgs VerificationArgs
+	TrustLevel       Fraction
+}
+
+// Vote mirrors VoteArgs.
+type Vote struct {
+	Type             *big.Int
+	Height           *big.Int
+	Round            *big.Int
+	BlockID          BlockID
+	Timestamp        *big.Int
+	ValidatorAddress *big.Int
+	SignatureR       *big.Int
+	SignatureS       *big.Int
+}
+
+// DuplicateVoteInput holds the arguments of submitDuplicateVote.
+type DuplicateVoteInput struct {
+	ChainID []*big.Int
+	Vals    ValidatorSet
+	VoteA   Vote
+	VoteB   Vote
+}
+
+// LightClientAttackInput holds the arguments of submitLightClientAttack,
+// which are the ones of externalVerifyNonAdjacent but the current time and
+// trusting period. Untrusted is the conflicting header.
+type LightClientAttackInput struct {
+	Trusted       SignedHeader
+	Untrusted     SignedHeader
+	TrustedVals   ValidatorSet
+	UntrustedVals ValidatorSet
+	MaxClockDrift *big.Int
+	TrustLevel    Fraction
+}
+
+// InclusionInput holds the arguments of verifyInclusion.
+type InclusionInput struct {
+	Height *big.Int
+	Field  *big.Int
+	// Leaf is split in 128 bit integers.
+	Leaf        []*big.Int
+	Index       *big.Int
+	Total       *big.Int
+	Aunts       []*big.Int
+	HeaderAunts []*big.Int
+}
+
+// Decode decodes the calldata of the verifier entry point function into an
+// *AdjacentInput, a *NonAdjacentInput, a *DuplicateVoteInput, a
+// *LightClientAttackInput or an *InclusionInput. It reverses
+// parser.ParseInput and the parse functions of the other entry points.
+func Decode(function string, calldata []string) (interface{}, error) {
+	switch function {
+	case parser.DuplicateVoteFunction:
+		in, err := DecodeDuplicateVote(calldata)
+		if err != nil {
+			return nil, err
+		}
+		return in, nil
+	case parser.LightClientAttackFunction:
+		in, err := DecodeLightClientAttack(calldata)
+		if err != nil {
+			return nil, err
+		}
+		return in, nil
+	case parser.InclusionFunction:
+		in, err := DecodeInclusion(calldata)
+		if err != nil {
+			return nil, err
+		}
+		return in, nil
+	case parser.AdjacentFunction:
+		in, err := DecodeAdjacent(calldata)
+		if err != nil {
+			return nil, err
+		}
+		return in, nil
+	case parser.NonAdjacentFunction:
+		in, err := DecodeNonAdjacent(calldata)
+		if err != nil {
+			return nil, err
+		}
+		return in, nil
+	default:
+		return nil, fmt.Errorf("unknown verifier entry point %q", function)
+	}
+}
+
+// DecodeAdjacent decodes the calldata of externalVerifyAdjacent, as built by
+// parser.ParseInput.
+func DecodeAdjacent(calldata []string) (*AdjacentInput, error) {
+	r, err := newReader(calldata)
+	if err != nil {
+		return nil, err
+	}
+
+	chainID := r.felts()
+	trustedSigs := r.commitSigs()
+	untrustedSigs := r.commitSigs()
+	validators := r.validators()
+	trusted := r.signedHeader(chainID, trustedSigs)
+	untrusted := r.signedHeader(chainID, untrustedSigs)
+	vals := r.validatorSet(validators)
+	args := r.verificationArgs()
+	if err := r.done(); err != nil {
+		return nil, err
+	}
+
+	return &AdjacentInput{
+		Trusted:          trusted,
+		Untrusted:        untrusted,
+		UntrustedVals:    vals,
+		VerificationArgs: args,
+	}, nil
+}
+
+// DecodeNonAdjacent decodes the calldata of externalVerifyNonAdjacent, as
+// built by parser.ParseInput.
+func DecodeNonAdjacent(calldata []string) (*NonAdjacentInput, error) {
+	r, err := newReader(calldata)
+	if err != nil {
+		return nil, err
+	}
+
+	chainID := r.felts()
+	trustedSigs := r.commitSigs()
+	untrustedSigs := r.commitSigs()
+	trustedValidators := r.validators()
+	untrustedValidators := r.validators()
+	trusted := r.signedHeader(chainID, trustedSigs)
+	untrusted := r.signedHeader(chainID, untrustedSigs)
+	trustedVals := r.validatorSet(trustedValidators)
+	untrustedVals := r.validatorSet(untrustedValidators)
+	args := r.verificationArgs()
+	trustLevel := Fraction{Numerator: r.felt(), Denominator: r.felt()}
+	if err := r.done(); err != nil {
+		return nil, err
+	}
+
+	return &NonAdjacentInput{
+		Trusted:          trusted,
+		Untrusted:        untrusted,
+		TrustedVals:      trustedVals,
+		UntrustedVals:    untrustedVals,
+		VerificationArgs: args,
+		TrustLevel:       trustLevel,
+	}, nil
+}
+
+// DecodeDuplicateVote decodes the calldata of submitDuplicateVote, as built
+// by parser.ParseDuplicateVoteInput.
+func DecodeDuplicateVote(calldata []string) (*DuplicateVoteInput, error) {
+	r, err := newReader(calldata)
+	if err != nil {
+		return nil, err
+	}
+
+	chainID := r.felts()
+	validators := r.validators()
+	vals := r.validatorSet(validators)
+	voteA := r.vote()
+	voteB := r.vote()
+	if err := r.done(); err != nil {
+		return nil, err
+	}
+
+	return &DuplicateVoteInput{
+		ChainID: chainID,
+		Vals:    vals,
+		VoteA:   voteA,
+		VoteB:   voteB,
+	}, nil
+}
+
+// DecodeLightClientAttack decodes the calldata of submitLightClientAttack, as
+// built by parser.ParseLightClientAttackInput.
+func DecodeLightClientAttack(calldata []string) (*LightClientAttackInput, error) {
+	r, err := newReader(calldata)
+	if err != nil {
+		return nil, err
+	}
+
+	chainID := r.felts()
+	trustedSigs := r.commitSigs()
+	untrustedSigs := r.commitSigs()
+	trustedValidators := r.validators()
+	untrustedValidators := r.validators()
+	trusted := r.signedHeader(chainID, trustedSigs)
+	untrusted := r.signedHeader(chainID, untrustedSigs)
+	trustedVals := r.validatorSet(trustedValidators)
+	untrustedVals := r.validatorSet(untrustedValidators)
+	maxClockDrift := r.felt()
+	trustLevel := Fraction{Numerator: r.felt(), Denominator: r.felt()}
+	if err := r.done(); err != nil {
+		return nil, err
+	}
+
+	return &LightClientAttackInput{
+		Trusted:       trusted,
+		Untrusted:     untrusted,
+		TrustedVals:   trustedVals,
+		UntrustedVals: untrustedVals,
+		MaxClockDrift: maxClockDrift,
+		TrustLevel:    trustLevel,
+	}, nil
+}
+
+// DecodeInclusion decodes the calldata of verifyInclusion, as built by
+// parser.ParseInclusionInput.
+func DecodeInclusion(calldata []string) (*InclusionInput, error) {
+	r, err := newReader(calldata)
+	if err != nil {
+		return nil, err
+	}
+
+	in := &InclusionInput{
+		Height:      r.felt(),
+		Field:       r.felt(),
+		Leaf:        r.felts(),
+		Index:       r.felt(),
+		Total:       r.felt(),
+		Aunts:       r.felts(),
+		HeaderAunts: r.felts(),
+	}
+	if err := r.done(); err != nil {
+		return nil, err
+	}
+	return in, nil
+}
+
+// reader reads felts off calldata in the order the entry points declare
+// their arguments. The first error is kept, and zeros are read after it.
+type reader struct {
+	calldata []*big.Int
+	pos      int
+	err      error
+}
+
+func newReader(calldata []string) (*reader, error) {
+	p := weierstrass.Stark().Params().P
+	felts := make([]*big.Int, len(calldata))
+	for i, s := range calldata {
+		f, ok := new(big.Int).SetString(s, 0)
+		if !ok || f.Sign() < 0 || f.Cmp(p) >= 0 {
+			return nil, fmt.Errorf("%w: felt %d is not a field element: %q", ErrMalformedCalldata, i, s)
+		}
+		felts[i] = f
+	}
+	return &reader{calldata: felts}, nil
+}
+
+func (r *reader) felt() *big.Int {
+	if r.err != nil {
+		return new(big.Int)
+	}
+	if r.pos >= len(r.calldata) {
+		r.err = fmt.Errorf("%w: calldata ends after %d felts", ErrMalformedCalldata, len(r.calldata))
+		return new(big.Int)
+	}
+	f := r.calldata[r.pos]
+	r.pos++
+	return f
+}
+
+// length reads the length of an array of elements of size felts.
+func (r *reader) length(size int) int {
+	n := r.felt()
+	if r.err != nil {
+		return 0
+	}
+	remaining := len(r.calldata) - r.pos
+	if !n.IsInt64() || n.Int64()*int64(size) > int64(remaining) {
+		r.err = fmt.Errorf("%w: array of %s elements at felt %d exceeds the calldata", ErrMalformedCalldata, n, r.pos-1)
+		return 0
+	}
+	return int(n.Int64())
+}
+
+func (r *reader) done() error {
+	if r.err == nil && r.pos != len(r.calldata) {
+		r.err = fmt.Errorf("%w: %d trailing felts", ErrMalformedCalldata, len(r.calldata)-r.pos)
+	}
+	return r.err
+}
+
+func (r *reader) felts() []*big.Int {
+	felts := make([]*big.Int, r.length(1))
+	for i := range felts {
+		felts[i] = r.felt()
+	}
+	return felts
+}
+
+func (r *reader) commitSigs() []CommitSig {
+	sigs := make([]CommitSig, r.length(5))
+	for i := range sigs {
+		sigs[i] = CommitSig{
+			BlockIDFlag:      r.felt(),
+			ValidatorAddress: r.felt(),
+			Timestamp:        r.felt(),
+			SignatureR:       r.felt(),
+			SignatureS:       r.felt(),
+		}
+	}
+	return sigs
+}
+
+func (r *reader) validator() Validator {
+	return Validator{
+		Address:          r.felt(),
+		PubKey:           r.felt(),
+		VotingPower:      r.felt(),
+		ProposerPriority: r.felt(),
+	}
+}
+
+func (r *reader) validators() []Validator {
+	vals := make([]Validator, r.length(4))
+	for i := range vals {
+		vals[i] = r.validator()
+	}
+	return vals
+}
+
+func (r *reader) blockID() BlockID {
+	return BlockID{
+		Hash: r.felt(),
+		PartSetHeader: PartSetHeader{
+			Total: r.felt(),
+			Hash:  r.felt(),
+		},
+	}
+}
+
+// signedHeader reads SignedHeaderArgs and completes it with the chain ID and
+// signatures passed separately, as createSignedHeader does.
+func (r *reader) signedHeader(chainID []*big.Int, sigs []CommitSig) SignedHeader {
+	header := Header{
+		Version:            Consensus{Block: r.felt(), App: r.felt()},
+		ChainID:            chainID,
+		Height:             r.felt(),
+		Time:               r.felt(),
+		LastBlockID:        r.blockID(),
+		LastCommitHash:     r.felt(),
+		DataHash:           r.felt(),
+		ValidatorsHash:     r.felt(),
+		NextValidatorsHash: r.felt(),
+		ConsensusHash:      r.felt(),
+		AppHash:            r.felt(),
+		LastResultsHash:    r.felt(),
+		EvidenceHash:       r.felt(),
+		ProposerAddress:    r.felt(),
+	}
+	commit := Commit{
+		Height:     r.felt(),
+		Round:      r.felt(),
+		BlockID:    r.blockID(),
+		Signatures: sigs,
+	}
+	return SignedHeader{Header: header, Commit: commit}
+}
+
+// validatorSet reads ValidatorSetArgs and completes it with the validators
+// passed separately.
+func (r *reader) validatorSet(validators []Validator) ValidatorSet {
+	return ValidatorSet{
+		Validators:       validators,
+		Proposer:         r.validator(),
+		TotalVotingPower: r.felt(),
+	}
+}
+
+func (r *reader) verificationArgs() VerificationArgs {
+	return VerificationArgs{
+		CurrentTime:    r.felt(),
+		MaxClockDrift:  r.felt(),
+		TrustingPeriod: r.felt(),
+	}
+}
+
+func (r *reader) vote() Vote {
+	return Vote{
+		Type:             r.felt(),
+		Height:           r.felt(),
+		Round:            r.felt(),
+		BlockID:          r.blockID(),
+		Timestamp:        r.felt(),
+		ValidatorAddress: r.felt(),
+		SignatureR:       r.felt(),
+		SignatureS:       r.felt(),
+	}
+}
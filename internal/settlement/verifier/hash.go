@@ -0,0 +1,267 @@
+package verifier
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/tendermint/tendermint/crypto/pedersen/felt"
+	"github.com/tendermint/tendermint/crypto/pedersen/hashing"
+)
+
+// Ports of cairo/src/hashing.cairo, cairo/src/merkle.cairo and
+// cairo/src/struct_hasher.cairo. Functions range checking their inputs as
+// 128 bit integers return an error where the contract fails.
+
+var int128Bound = new(big.Int).Lsh(big.NewInt(1), 128)
+
+func pedersen(a, b *big.Int) *big.Int {
+	return (*big.Int)(hashing.Hash2((*felt.Felt)(a), (*felt.Felt)(b)))
+}
+
+func checkInt128(x *big.Int) error {
+	if x.Sign() < 0 || x.Cmp(int128Bound) >= 0 {
+		return fmt.Errorf("%s is not a 128 bit integer", x)
+	}
+	return nil
+}
+
+// hashInt128 ports hash_int128.
+func hashInt128(x *big.Int) (*big.Int, error) {
+	if err := checkInt128(x); err != nil {
+		return nil, err
+	}
+	return pedersen(x, new(big.Int)), nil
+}
+
+// hashInt128Array ports hash_int128_array.
+func hashInt128Array(xs []*big.Int) (*big.Int, error) {
+	switch len(xs) {
+	case 0:
+		return hashInt128(new(big.Int))
+	case 1:
+		return hashInt128(xs[0])
+	}
+	for _, x := range xs {
+		if err := checkInt128(x); err != nil {
+			return nil, err
+		}
+	}
+	return hashFeltArray(xs), nil
+}
+
+// hashFeltArray ports hash_felt_array.
+func hashFeltArray(xs []*big.Int) *big.Int {
+	h := new(big.Int)
+	for _, x := range xs {
+		h = pedersen(h, x)
+	}
+	return pedersen(h, big.NewInt(int64(len(xs))))
+}
+
+// hashFeltArrayWithPrefix ports hash_felt_array_with_prefix.
+func hashFeltArrayWithPrefix(xs []*big.Int, prefix int64) *big.Int {
+	h := pedersen(new(big.Int), big.NewInt(prefix))
+	for _, x := range xs {
+		h = pedersen(h, x)
+	}
+	return pedersen(h, big.NewInt(int64(len(xs)+1)))
+}
+
+// hashInt128ArrayWithPrefix ports hash_int128_array_with_prefix.
+func hashInt128ArrayWithPrefix(xs []*big.Int, prefix int64) (*big.Int, error) {
+	for _, x := range xs {
+		if err := checkInt128(x); err != nil {
+			return nil, err
+		}
+	}
+	return hashFeltArrayWithPrefix(xs, prefix), nil
+}
+
+func leafHash(leaf *big.Int) *big.Int {
+	return hashFeltArrayWithPrefix([]*big.Int{leaf}, 0)
+}
+
+func innerHash(left, right *big.Int) *big.Int {
+	return hashFeltArrayWithPrefix([]*big.Int{left, right}, 1)
+}
+
+// leafHashInt128Array ports leafHash_int128_array.
+func leafHashInt128Array(leaf []*big.Int) (*big.Int, error) {
+	if len(leaf) == 0 {
+		return pedersen(new(big.Int), new(big.Int)), nil
+	}
+	return hashInt128ArrayWithPrefix(leaf, 0)
+}
+
+// innerHashSplit ports innerHash_split.
+func innerHashSplit(left, right *big.Int) *big.Int {
+	return hashFeltArrayWithPrefix([]*big.Int{
+		one,
+		new(big.Int).Rsh(left, 128), new(big.Int).Mod(left, int128Bound),
+		new(big.Int).Rsh(right, 128), new(big.Int).Mod(right, int128Bound),
+	}, 0)
+}
+
+// computeHashFromAunts ports computeHashFromAunts, innerHash hashing the
+// inner nodes.
+func computeHashFromAunts(index, total int64, leafHash *big.Int, aunts []*big.Int,
+	innerHash func(left, right *big.Int) *big.Int) (*big.Int, error) {
+	if index < 0 || index >= total {
+		return nil, fmt.Errorf("index %d is not within [0, %d)", index, total)
+	}
+	if total == 1 {
+		if len(aunts) != 0 {
+			return nil, fmt.Errorf("%d aunts left at the leaf", len(aunts))
+		}
+		return leafHash, nil
+	}
+	if len(aunts) == 0 {
+		return nil, fmt.Errorf("no aunt left for a tree of %d leaves", total)
+	}
+	aunt, aunts := aunts[len(aunts)-1], aunts[:len(aunts)-1]
+	k := int64(splitPoint(int(total)))
+	if index < k {
+		left, err := computeHashFromAunts(index, k, leafHash, aunts, innerHash)
+		if err != nil {
+			return nil, err
+		}
+		return innerHash(left, aunt), nil
+	}
+	right, err := computeHashFromAunts(index-k, total-k, leafHash, aunts, innerHash)
+	if err != nil {
+		return nil, err
+	}
+	return innerHash(aunt, right), nil
+}
+
+// splitPoint returns the largest power of two smaller than n, n > 1.
+func splitPoint(n int) int {
+	k := 1
+	for 2*k < n {
+		k *= 2
+	}
+	return k
+}
+
+// merkleRootHash ports merkleRootHash.
+func merkleRootHash(leaves []*big.Int) *big.Int {
+	switch len(leaves) {
+	case 0:
+		return new(big.Int)
+	case 1:
+		return leafHash(leaves[0])
+	}
+	k := splitPoint(len(leaves))
+	return innerHash(merkleRootHash(leaves[:k]), merkleRootHash(leaves[k:]))
+}
+
+func hashConsensus(version Consensus) (*big.Int, error) {
+	return hashInt128Array([]*big.Int{version.Block, version.App})
+}
+
+func hashPartSetHeader(psh PartSetHeader) (*big.Int, error) {
+	total, err := hashInt128(psh.Total)
+	if err != nil {
+		return nil, fmt.Errorf("part set header total: %w", err)
+	}
+	return hashFeltArray([]*big.Int{total, psh.Hash}), nil
+}
+
+func hashBlockID(blockID BlockID) (*big.Int, error) {
+	psh, err := hashPartSetHeader(blockID.PartSetHeader)
+	if err != nil {
+		return nil, err
+	}
+	return hashFeltArray([]*big.Int{blockID.Hash, psh}), nil
+}
+
+// HashHeader returns the hash of the header of sh computed by the verifier,
+// the hash it expects in the block ID of the commit.
+func HashHeader(sh SignedHeader) (*big.Int, error) {
+	h := sh.Header
+	version, err := hashConsensus(h.Version)
+	if err != nil {
+		return nil, fmt.Errorf("version: %w", err)
+	}
+	chainID, err := hashInt128Array(h.ChainID)
+	if err != nil {
+		return nil, fmt.Errorf("chain ID: %w", err)
+	}
+	height, err := hashInt128(h.Height)
+	if err != nil {
+		return nil, fmt.Errorf("height: %w", err)
+	}
+	time, err := hashInt128(h.Time)
+	if err != nil {
+		return nil, fmt.Errorf("time: %w", err)
+	}
+	lastBlockID, err := hashBlockID(h.LastBlockID)
+	if err != nil {
+		return nil, fmt.Errorf("last block ID: %w", err)
+	}
+
+	return merkleRootHash([]*big.Int{
+		version,
+		chainID,
+		height,
+		time,
+		lastBlockID,
+		h.LastCommitHash,
+		h.DataHash,
+		h.ValidatorsHash,
+		h.NextValidatorsHash,
+		h.ConsensusHash,
+		h.AppHash,
+		h.LastResultsHash,
+		h.EvidenceHash,
+		h.ProposerAddress,
+	}), nil
+}
+
+func hashValidator(val Validator) (*big.Int, error) {
+	votingPower, err := hashInt128(val.VotingPower)
+	if err != nil {
+		return nil, fmt.Errorf("voting power: %w", err)
+	}
+	return hashFeltArray([]*big.Int{val.PubKey, votingPower}), nil
+}
+
+// HashValidatorSet returns the hash of vals computed by the verifier, the
+// validators hash it expects in the header.
+func HashValidatorSet(vals ValidatorSet) (*big.Int, error) {
+	leaves := make([]*big.Int, len(vals.Validators))
+	for i, val := range vals.Validators {
+		h, err := hashValidator(val)
+		if err != nil {
+			return nil, fmt.Errorf("validator %d: %w", i, err)
+		}
+		leaves[i] = h
+	}
+	return merkleRootHash(leaves), nil
+}
+
+// hashCanonicalVoteNoTime ports hashCanonicalVoteNoTime for a vote of type
+// msgType for blockID.
+func hashCanonicalVoteNoTime(msgType, height, round *big.Int, blockID BlockID, chainID []*big.Int) (*big.Int, error) {
+	msgTypeHash, err := hashInt128(msgType)
+	if err != nil {
+		return nil, fmt.Errorf("type: %w", err)
+	}
+	heightHash, err := hashInt128(height)
+	if err != nil {
+		return nil, fmt.Errorf("height: %w", err)
+	}
+	roundHash, err := hashInt128(round)
+	if err != nil {
+		return nil, fmt.Errorf("round: %w", err)
+	}
+	blockIDHash, err := hashBlockID(blockID)
+	if err != nil {
+		return nil, fmt.Errorf("block ID: %w", err)
+	}
+	chain, err := hashInt128Array(chainID)
+	if err != nil {
+		return nil, fmt.Errorf("chain ID: %w", err)
+	}
+	return hashFeltArray([]*big.Int{msgTypeHash, heightHash, roundHash, blockIDHash, chain}), nil
+}
@@ -0,0 +1,572 @@
+// Package verifier is a Go reference implementation of the light client
+// verifier contract in cairo/src/main.cairo.
+//
+// It decodes the felt calldata of the externalVerifyAdjacent and
+// externalVerifyNonAdjacent entry points and runs the same checks as the
+// contract, with the same felt arithmetic and Pedersen hashing, so that
+// calldata the contract would reject is caught before paying fees for it.
+// The evidence entry points, submitDuplicateVote and submitLightClientAttack,
+// and the verifyInclusion view are covered too. The evidence entry points are
+// verified against an Anchor, the state of the contract they check their
+// calldata against. The checks against the headers the contract settled are
+// not covered: the trusted header of every entry point must be the one
+// settled at its height, and the validators of duplicate votes the ones
+// settled at the height of the votes.
+package verifier
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/tendermint/tendermint/crypto/stark"
+	"github.com/tendermint/tendermint/crypto/weierstrass"
+	"github.com/tendermint/tendermint/internal/settlement/parser"
+	"github.com/tendermint/tendermint/types"
+)
+
+// Constants of cairo/src/structs.cairo
+const (
+	blockIDFlagCommit = 2
+	precommitType     = 2
+)
+
+var (
+	maxTotalVotingPower = new(big.Int).Lsh(big.NewInt(1), 59)
+
+	curve      = weierstrass.Stark()
+	fieldPrime = curve.Params().P
+	one        = big.NewInt(1)
+)
+
+var (
+	// ErrInvalidHeader is returned when the untrusted header does not
+	// follow the trusted one.
+	ErrInvalidHeader = errors.New("invalid header")
+	// ErrHeaderHashMismatch is returned when the untrusted header does not
+	// hash to the block ID its commit signs.
+	ErrHeaderHashMismatch = errors.New("header hash does not match the block ID of the commit")
+	// ErrValidatorsHashMismatch is returned when a validator set does not
+	// hash to the validators hash of its header.
+	ErrValidatorsHashMismatch = errors.New("validator set does not match the validators hash of the header")
+	// ErrExpired is returned when the untrusted header is outside of the
+	// trusting period.
+	ErrExpired = errors.New("header is expired")
+	// ErrInvalidCommit is returned when the commit does not match the header
+	// or the validator set.
+	ErrInvalidCommit = errors.New("invalid commit")
+	// ErrInvalidSignature is returned when a commit signature does not
+	// verify.
+	ErrInvalidSignature = errors.New("invalid commit signature")
+	// ErrInvalidTrustLevel is returned when the trust level is not within
+	// [1/3, 1].
+	ErrInvalidTrustLevel = errors.New("trust level is not within [1/3, 1]")
+	// ErrNotEnoughVotingPower is returned when too little voting power
+	// signed the commit.
+	ErrNotEnoughVotingPower = errors.New("not enough voting power signed the commit")
+	// ErrInvalidEvidence is returned when evidence does not prove
+	// misbehavior.
+	ErrInvalidEvidence = errors.New("invalid evidence")
+	// ErrInvalidProof is returned when an inclusion proof does not hold.
+	ErrInvalidProof = errors.New("invalid inclusion proof")
+)
+
+// Anchor is the state of the contract the evidence entry points check their
+// calldata against: the chain ID and the trusting period, in nanoseconds, of
+// initBlockData, and the timestamp of the block the transaction is included
+// in, in nanoseconds.
+type Anchor struct {
+	ChainID        []*big.Int
+	TrustingPeriod *big.Int
+	CurrentTime    *big.Int
+}
+
+// NewAnchor returns the anchor of a contract initialized for the chain
+// chainID with the trusting period of vc, at the current time of vc.
+func NewAnchor(chainID string, vc parser.VerificationConfig) Anchor {
+	return Anchor{
+		ChainID:        parser.ChainIDFelts(chainID),
+		TrustingPeriod: vc.TrustingPeriod,
+		CurrentTime:    vc.CurrentTime,
+	}
+}
+
+// Verify runs the checks of the verifier entry point function, one of the
+// entry points Decode knows of but the evidence ones, on calldata.
+func Verify(function string, calldata []string) error {
+	in, err := Decode(function, calldata)
+	if err != nil {
+		return err
+	}
+	switch in := in.(type) {
+	case *AdjacentInput:
+		return VerifyAdjacent(in)
+	case *DuplicateVoteInput, *LightClientAttackInput:
+		return fmt.Errorf("%s is verified against an anchor, see VerifyEvidence", function)
+	case *InclusionInput:
+		_, err := InclusionHeaderHash(in)
+		return err
+	default:
+		return VerifyNonAdjacent(in.(*NonAdjacentInput))
+	}
+}
+
+// VerifyEvidence runs the checks of the evidence entry point function,
+// DuplicateVoteFunction or LightClientAttackFunction, on calldata, against
+// the state of the contract anchor.
+func VerifyEvidence(function string, calldata []string, anchor Anchor) error {
+	in, err := Decode(function, calldata)
+	if err != nil {
+		return err
+	}
+	switch in := in.(type) {
+	case *DuplicateVoteInput:
+		return VerifyDuplicateVote(in, anchor)
+	case *LightClientAttackInput:
+		return VerifyLightClientAttack(in, anchor)
+	default:
+		return fmt.Errorf("%s is not an evidence entry point", function)
+	}
+}
+
+// VerifyAdjacent ports verifyAdjacent.
+func VerifyAdjacent(in *AdjacentInput) error {
+	trusted, untrusted := in.Trusted, in.Untrusted
+	if untrusted.Header.Height.Cmp(add(trusted.Header.Height, one)) != 0 {
+		return fmt.Errorf("%w: height %s is not adjacent to height %s",
+			ErrInvalidHeader, untrusted.Header.Height, trusted.Header.Height)
+	}
+	if untrusted.Header.ValidatorsHash.Cmp(trusted.Header.NextValidatorsHash) != 0 {
+		return fmt.Errorf("%w: validators hash %#x is not the next validators hash %#x of the trusted header",
+			ErrInvalidHeader, untrusted.Header.ValidatorsHash, trusted.Header.NextValidatorsHash)
+	}
+	if err := checkExpired(untrusted.Header, in.VerificationArgs); err != nil {
+		return err
+	}
+	if err := verifyNewHeaderAndVals(untrusted, trusted, in.UntrustedVals, in.VerificationArgs); err != nil {
+		return err
+	}
+	return verifyCommitLight(in.UntrustedVals, trusted.Header.ChainID, untrusted.Commit.BlockID,
+		untrusted.Header.Height, untrusted.Commit)
+}
+
+// VerifyNonAdjacent ports verifyNonAdjacent.
+func VerifyNonAdjacent(in *NonAdjacentInput) error {
+	trusted, untrusted := in.Trusted, in.Untrusted
+	if untrusted.Header.Height.Cmp(add(trusted.Header.Height, one)) == 0 {
+		return fmt.Errorf("%w: height %s is adjacent to height %s",
+			ErrInvalidHeader, untrusted.Header.Height, trusted.Header.Height)
+	}
+	if err := checkValidatorsHash(trusted.Header, in.TrustedVals); err != nil {
+		return fmt.Errorf("trusted validators: %w", err)
+	}
+	if err := checkExpired(untrusted.Header, in.VerificationArgs); err != nil {
+		return err
+	}
+	if err := verifyNewHeaderAndVals(untrusted, trusted, in.UntrustedVals, in.VerificationArgs); err != nil {
+		return err
+	}
+	if err := verifyCommitLightTrusting(in.TrustedVals, trusted.Header.ChainID, untrusted.Commit, in.TrustLevel); err != nil {
+		return err
+	}
+	return verifyCommitLight(in.UntrustedVals, trusted.Header.ChainID, untrusted.Commit.BlockID,
+		untrusted.Header.Height, untrusted.Commit)
+}
+
+// VerifyDuplicateVote ports submitDuplicateVote: the votes must be for
+// different blocks of the chain of anchor at the same height, round and step,
+// and both be signed by the same validator of the validator set. That the
+// validator set was settled at the height of the votes is not checked.
+func VerifyDuplicateVote(in *DuplicateVoteInput, anchor Anchor) error {
+	if err := checkChainID(in.ChainID, anchor); err != nil {
+		return err
+	}
+	a, b := in.VoteA, in.VoteB
+	if a.Type.Cmp(b.Type) != 0 || a.Height.Cmp(b.Height) != 0 || a.Round.Cmp(b.Round) != 0 {
+		return fmt.Errorf("%w: votes are for different steps", ErrInvalidEvidence)
+	}
+	if a.ValidatorAddress.Cmp(b.ValidatorAddress) != 0 {
+		return fmt.Errorf("%w: votes are from different validators", ErrInvalidEvidence)
+	}
+	blockIDA, err := hashBlockID(a.BlockID)
+	if err != nil {
+		return fmt.Errorf("%w: vote A block ID: %v", ErrInvalidEvidence, err)
+	}
+	blockIDB, err := hashBlockID(b.BlockID)
+	if err != nil {
+		return fmt.Errorf("%w: vote B block ID: %v", ErrInvalidEvidence, err)
+	}
+	if blockIDA.Cmp(blockIDB) == 0 {
+		return fmt.Errorf("%w: votes are for the same block", ErrInvalidEvidence)
+	}
+
+	var val *Validator
+	for i := range in.Vals.Validators {
+		if in.Vals.Validators[i].Address.Cmp(a.ValidatorAddress) == 0 {
+			val = &in.Vals.Validators[i]
+			break
+		}
+	}
+	if val == nil {
+		return fmt.Errorf("%w: validator %s is not in the validator set", ErrInvalidEvidence, a.ValidatorAddress)
+	}
+	for i, vote := range []Vote{a, b} {
+		if err := verifyVoteSignature(vote, in.ChainID, *val); err != nil {
+			return fmt.Errorf("vote %c: %w", 'A'+i, err)
+		}
+	}
+	return nil
+}
+
+// verifyVoteSignature ports verifyVoteSig.
+func verifyVoteSignature(vote Vote, chainID []*big.Int, val Validator) error {
+	voteHash, err := hashCanonicalVoteNoTime(vote.Type, vote.Height, vote.Round, vote.BlockID, chainID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidEvidence, err)
+	}
+	msg, err := voteSignBytes(voteHash, vote.Timestamp)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidEvidence, err)
+	}
+	if !verifySignature(val.PubKey, msg, vote.SignatureR, vote.SignatureS) {
+		return fmt.Errorf("%w: vote by validator %s", ErrInvalidSignature, val.Address)
+	}
+	return nil
+}
+
+// VerifyLightClientAttack ports verifyLightClientAttack: the conflicting,
+// untrusted header must verify against the trusted one, adjacent or not, at
+// the current time and with the trusting period of anchor. The chain ID of the
+// headers must be the one of anchor, which the contract checks by hashing the
+// trusted header. That the trusted header was settled, and that the
+// conflicting one is not the one settled at its height, is not checked.
+func VerifyLightClientAttack(in *LightClientAttackInput, anchor Anchor) error {
+	if err := checkChainID(in.Trusted.Header.ChainID, anchor); err != nil {
+		return err
+	}
+	args := VerificationArgs{
+		CurrentTime:    anchor.CurrentTime,
+		MaxClockDrift:  in.MaxClockDrift,
+		TrustingPeriod: anchor.TrustingPeriod,
+	}
+	if in.Untrusted.Header.Height.Cmp(add(in.Trusted.Header.Height, one)) == 0 {
+		return VerifyAdjacent(&AdjacentInput{
+			Trusted:          in.Trusted,
+			Untrusted:        in.Untrusted,
+			UntrustedVals:    in.UntrustedVals,
+			VerificationArgs: args,
+		})
+	}
+	return VerifyNonAdjacent(&NonAdjacentInput{
+		Trusted:          in.Trusted,
+		Untrusted:        in.Untrusted,
+		TrustedVals:      in.TrustedVals,
+		UntrustedVals:    in.UntrustedVals,
+		VerificationArgs: args,
+		TrustLevel:       in.TrustLevel,
+	})
+}
+
+// checkChainID ports assert_chain_id.
+func checkChainID(chainID []*big.Int, anchor Anchor) error {
+	if len(chainID) != len(anchor.ChainID) {
+		return fmt.Errorf("%w: chain ID is not the one of the verifier", ErrInvalidEvidence)
+	}
+	for i := range chainID {
+		if chainID[i].Cmp(anchor.ChainID[i]) != 0 {
+			return fmt.Errorf("%w: chain ID is not the one of the verifier", ErrInvalidEvidence)
+		}
+	}
+	return nil
+}
+
+// VerifyInclusion ports verifyInclusion, settledHash being the header hash
+// the contract settled at in.Height.
+func VerifyInclusion(in *InclusionInput, settledHash *big.Int) error {
+	if settledHash.Sign() == 0 {
+		return fmt.Errorf("%w: height %s is not settled", ErrInvalidProof, in.Height)
+	}
+	headerHash, err := InclusionHeaderHash(in)
+	if err != nil {
+		return err
+	}
+	if headerHash.Cmp(settledHash) != 0 {
+		return fmt.Errorf("%w: header hash %s does not match the settled %s", ErrInvalidProof, headerHash, settledHash)
+	}
+	return nil
+}
+
+// InclusionHeaderHash returns the hash of the header the proof of in leads
+// to, which verifyInclusion compares to the settled one.
+func InclusionHeaderHash(in *InclusionInput) (*big.Int, error) {
+	switch in.Field.Int64() {
+	case types.HeaderFieldDataHash, types.HeaderFieldLastResultsHash, types.HeaderFieldAppHash:
+	default:
+		return nil, fmt.Errorf("%w: header field %s does not commit to a Merkle tree", ErrInvalidProof, in.Field)
+	}
+	if !in.Index.IsInt64() || !in.Total.IsInt64() {
+		return nil, fmt.Errorf("%w: index %s of %s leaves", ErrInvalidProof, in.Index, in.Total)
+	}
+
+	leaf, err := leafHashInt128Array(in.Leaf)
+	if err != nil {
+		return nil, fmt.Errorf("%w: leaf: %v", ErrInvalidProof, err)
+	}
+	root, err := computeHashFromAunts(in.Index.Int64(), in.Total.Int64(), leaf, in.Aunts, innerHashSplit)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidProof, err)
+	}
+	headerHash, err := computeHashFromAunts(in.Field.Int64(), types.HeaderFields, leafHash(root), in.HeaderAunts, innerHash)
+	if err != nil {
+		return nil, fmt.Errorf("%w: header: %v", ErrInvalidProof, err)
+	}
+	return headerHash, nil
+}
+
+// checkExpired ports the isExpired check: the header expires a trusting
+// period after its time.
+func checkExpired(header Header, args VerificationArgs) error {
+	expiration := add(header.Time, args.TrustingPeriod)
+	if greaterThan(args.CurrentTime, expiration) {
+		return fmt.Errorf("%w: expired at %s, now is %s", ErrExpired, expiration, args.CurrentTime)
+	}
+	return nil
+}
+
+// verifyNewHeaderAndVals ports verifyNewHeaderAndVals.
+func verifyNewHeaderAndVals(untrusted, trusted SignedHeader, untrustedVals ValidatorSet, args VerificationArgs) error {
+	if !equalFelts(untrusted.Header.ChainID, trusted.Header.ChainID) {
+		return fmt.Errorf("%w: chain IDs differ", ErrInvalidHeader)
+	}
+	if untrusted.Commit.Height.Cmp(untrusted.Header.Height) != 0 {
+		return fmt.Errorf("%w: commit height %s differs from header height %s",
+			ErrInvalidCommit, untrusted.Commit.Height, untrusted.Header.Height)
+	}
+
+	hash, err := HashHeader(untrusted)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidHeader, err)
+	}
+	if hash.Cmp(untrusted.Commit.BlockID.Hash) != 0 {
+		return fmt.Errorf("%w: computed %s, commit signs %s", ErrHeaderHashMismatch, hash, untrusted.Commit.BlockID.Hash)
+	}
+
+	if !isLe(add(trusted.Header.Height, one), untrusted.Header.Height) {
+		return fmt.Errorf("%w: height %s is not after trusted height %s",
+			ErrInvalidHeader, untrusted.Header.Height, trusted.Header.Height)
+	}
+	if !greaterThan(untrusted.Header.Time, trusted.Header.Time) {
+		return fmt.Errorf("%w: time %s is not after trusted time %s",
+			ErrInvalidHeader, untrusted.Header.Time, trusted.Header.Time)
+	}
+	if driftTime := add(args.CurrentTime, args.MaxClockDrift); !greaterThan(driftTime, untrusted.Header.Time) {
+		return fmt.Errorf("%w: time %s is not before the current time plus the clock drift %s",
+			ErrInvalidHeader, untrusted.Header.Time, driftTime)
+	}
+
+	return checkValidatorsHash(untrusted.Header, untrustedVals)
+}
+
+func checkValidatorsHash(header Header, vals ValidatorSet) error {
+	hash, err := HashValidatorSet(vals)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrValidatorsHashMismatch, err)
+	}
+	if hash.Cmp(header.ValidatorsHash) != 0 {
+		return fmt.Errorf("%w: computed %s, header has %s", ErrValidatorsHashMismatch, hash, header.ValidatorsHash)
+	}
+	return nil
+}
+
+// verifyCommitLight ports verifyCommitLight: more than 2/3 of the voting
+// power of vals, which signed the commit in the order of the set, must have
+// signed it.
+func verifyCommitLight(vals ValidatorSet, chainID []*big.Int, blockID BlockID, height *big.Int, commit Commit) error {
+	if len(vals.Validators) != len(commit.Signatures) {
+		return fmt.Errorf("%w: %d signatures for %d validators",
+			ErrInvalidCommit, len(commit.Signatures), len(vals.Validators))
+	}
+	if height.Cmp(commit.Height) != 0 {
+		return fmt.Errorf("%w: commit height %s differs from height %s", ErrInvalidCommit, commit.Height, height)
+	}
+	if !equalBlockIDs(blockID, commit.BlockID) {
+		return fmt.Errorf("%w: wrong block ID", ErrInvalidCommit)
+	}
+
+	tallied, err := talliedVotingPower(commit, chainID, func(i int, sig CommitSig) (Validator, bool, error) {
+		return vals.Validators[i], true, nil
+	})
+	if err != nil {
+		return err
+	}
+	total, err := totalVotingPower(vals.Validators)
+	if err != nil {
+		return err
+	}
+
+	needed := new(big.Int).Mul(total, big.NewInt(2))
+	needed.Div(needed, big.NewInt(3))
+	if tallied.Cmp(needed) <= 0 {
+		return fmt.Errorf("%w: %s of %s, more than %s needed", ErrNotEnoughVotingPower, tallied, total, needed)
+	}
+	return nil
+}
+
+// verifyCommitLightTrusting ports verifyCommitLightTrusting: more than
+// trustLevel of the voting power of trustedVals must have signed the commit.
+// Signers are looked up by address, and a trusted validator signing twice
+// is rejected, like seenVals in Tendermint.
+func verifyCommitLightTrusting(trustedVals ValidatorSet, chainID []*big.Int, commit Commit, trustLevel Fraction) error {
+	numerator, denominator := trustLevel.Numerator, trustLevel.Denominator
+	if !isLe(one, denominator) || !isLe(denominator, mul(numerator, big.NewInt(3))) || !isLe(numerator, denominator) {
+		return fmt.Errorf("%w: %s/%s", ErrInvalidTrustLevel, numerator, denominator)
+	}
+
+	seen := make(map[int]int)
+	tallied, err := talliedVotingPower(commit, chainID, func(i int, sig CommitSig) (Validator, bool, error) {
+		for j, val := range trustedVals.Validators {
+			if val.Address.Cmp(sig.ValidatorAddress) != 0 {
+				continue
+			}
+			if first, ok := seen[j]; ok {
+				return Validator{}, false, fmt.Errorf("%w: validator %s signed twice, signatures %d and %d",
+					ErrInvalidCommit, val.Address, first, i)
+			}
+			seen[j] = i
+			return val, true, nil
+		}
+		return Validator{}, false, nil
+	})
+	if err != nil {
+		return err
+	}
+	total, err := totalVotingPower(trustedVals.Validators)
+	if err != nil {
+		return err
+	}
+
+	needed := new(big.Int).Mul(total, numerator)
+	scaled := new(big.Int).Mul(tallied, denominator)
+	if scaled.Cmp(needed) <= 0 {
+		return fmt.Errorf("%w: %s of %s trusted, more than %s/%s needed",
+			ErrNotEnoughVotingPower, tallied, total, numerator, denominator)
+	}
+	return nil
+}
+
+// talliedVotingPower ports get_tallied_voting_power: it verifies the
+// signatures of the commit for its block, and returns the voting power of
+// their signers. signer returns the validator of the i-th signature, and
+// false if it does not count.
+func talliedVotingPower(
+	commit Commit,
+	chainID []*big.Int,
+	signer func(i int, sig CommitSig) (Validator, bool, error),
+) (*big.Int, error) {
+	voteHash, err := hashCanonicalVoteNoTime(big.NewInt(precommitType), commit.Height, commit.Round, commit.BlockID, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCommit, err)
+	}
+
+	tallied := new(big.Int)
+	for i, sig := range commit.Signatures {
+		if sig.BlockIDFlag.Cmp(big.NewInt(blockIDFlagCommit)) != 0 {
+			continue
+		}
+		val, ok, err := signer(i, sig)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		msg, err := voteSignBytes(voteHash, sig.Timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("%w: signature %d: %v", ErrInvalidCommit, i, err)
+		}
+		if !verifySignature(val.PubKey, msg, sig.SignatureR, sig.SignatureS) {
+			return nil, fmt.Errorf("%w: signature %d by validator %s", ErrInvalidSignature, i, val.Address)
+		}
+		tallied = add(tallied, val.VotingPower)
+	}
+	return tallied, nil
+}
+
+// voteSignBytes ports the message signed by a validator, built from
+// voteSignBytes: the hash of the vote timestamp and of the vote without it,
+// split in 128 bit halves.
+func voteSignBytes(voteHash, timestamp *big.Int) (*big.Int, error) {
+	high := new(big.Int).Rsh(voteHash, 128)
+	low := new(big.Int).Sub(voteHash, new(big.Int).Lsh(high, 128))
+	return hashInt128Array([]*big.Int{new(big.Int), timestamp, high, low})
+}
+
+// verifySignature ports verify_ecdsa_signature, which only takes the x
+// coordinate of the public key.
+func verifySignature(pubKey, msg, r, s *big.Int) bool {
+	compressed := make([]byte, 33)
+	compressed[0] = 2
+	pubKey.FillBytes(compressed[1:])
+	x, y := weierstrass.UnmarshalCompressed(curve, compressed)
+	if x == nil {
+		return false
+	}
+	// Verify accepts the signatures of both points with this x coordinate
+	pub := stark.PublicKey{Curve: curve, X: x, Y: y}
+	return stark.Verify(&pub, msg.Bytes(), r, s)
+}
+
+// totalVotingPower ports get_total_voting_power.
+func totalVotingPower(vals []Validator) (*big.Int, error) {
+	total := new(big.Int)
+	for i := len(vals) - 1; i >= 0; i-- {
+		if !isLe(add(total, one), maxTotalVotingPower) {
+			return nil, fmt.Errorf("%w: total voting power exceeds %s", ErrInvalidCommit, maxTotalVotingPower)
+		}
+		total = add(total, vals[i].VotingPower)
+	}
+	return total, nil
+}
+
+// add returns a+b in the field.
+func add(a, b *big.Int) *big.Int {
+	sum := new(big.Int).Add(a, b)
+	return sum.Mod(sum, fieldPrime)
+}
+
+// mul returns a*b in the field.
+func mul(a, b *big.Int) *big.Int {
+	product := new(big.Int).Mul(a, b)
+	return product.Mod(product, fieldPrime)
+}
+
+// isLe ports is_le: a <= b if b-a, in the field, is a 128 bit integer.
+func isLe(a, b *big.Int) bool {
+	diff := new(big.Int).Sub(b, a)
+	diff.Mod(diff, fieldPrime)
+	return diff.Cmp(int128Bound) < 0
+}
+
+// greaterThan ports time_greater_than and greater_than.
+func greaterThan(a, b *big.Int) bool {
+	return isLe(add(b, one), a)
+}
+
+func equalFelts(a, b []*big.Int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Cmp(b[i]) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func equalBlockIDs(a, b BlockID) bool {
+	return a.Hash.Cmp(b.Hash) == 0 &&
+		a.PartSetHeader.Total.Cmp(b.PartSetHeader.Total) == 0 &&
+		a.PartSetHeader.Hash.Cmp(b.PartSetHeader.Hash) == 0
+}
@@ -0,0 +1,442 @@
+package verifier
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/internal/settlement/parser"
+	"github.com/tendermint/tendermint/internal/test/factory"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	tmmath "github.com/tendermint/tendermint/libs/math"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// fixtureLightBlocks returns two adjacent blocks of a chain with a single
+// validator, shared with the parser tests.
+func fixtureLightBlocks(t *testing.T) (trusted, untrusted types.LightBlock) {
+	trustedLightBlockString := `{"signed_header":{"header":{"version":{"block":"11","app":"1"},"chain_id":"test-chain-IrF74Y","height":"2","time":"2022-11-04T17:43:45.220479Z","last_block_id":{"hash":"038E1EFB6F2C0B4AA1051C0A9B4494B0A7CF34D81C76E6C161B164249A660ABF","parts":{"total":1,"hash":"06A9F404CEC26739C0E7FBDC46DAC64B9151D3A14FA4BB8B4DFD1785D3B14C15"}},"last_commit_hash":"06BE053E669912201CFE99C43884AB9AC38713AC888873B375588A4C401428F6","data_hash":"049EE3EBA8C1600700EE1B87EB599F16716B0B1022947733551FDE4050CA6804","validators_hash":"0241C0593DCFA3154B864E19E3AB6C03D2B79181BE7DC6565C9B6C68EA4D47F6","next_validators_hash":"0241C0593DCFA3154B864E19E3AB6C03D2B79181BE7DC6565C9B6C68EA4D47F6","consensus_hash":"00848270D575B49884653D7B3ED720EB84CE99D064D3BD3210FE23BFB811CB66","app_hash":"0000000000000000000000000000000000000000000000000000000000000000","last_results_hash":"049EE3EBA8C1600700EE1B87EB599F16716B0B1022947733551FDE4050CA6804","evidence_hash":"049EE3EBA8C1600700EE1B87EB599F16716B0B1022947733551FDE4050CA6804","proposer_address":"06EBC607235127FDABA1DB1A9CE71A34E7B880084F7188B03E7A3A1F0334DDBD"},"commit":{"height":"2","round":0,"block_id":{"hash":"048A972F4E947BBBF4E9E0AF350AD233EC6E394903728B3D3F8488F168915C16","parts":{"total":1,"hash":"04A7BCD4D5AEED5C99B3530549E83C7DACA49102542B20E28C48FBD0E911A622"}},"signatures":[{"block_id_flag":2,"validator_address":"06EBC607235127FDABA1DB1A9CE71A34E7B880084F7188B03E7A3A1F0334DDBD","timestamp":"2022-11-04T17:43:46.755686Z","signature":"BWkRvub8iP9VltjYMrfDekOL/0WjijYEIUjbkVnSntQDMU0B4izOLRPcJqub0t0AHyDPCOvx+4w14gdeKSxfmQ=="}]}},"canonical":false}`
+	untrustedLightBlockString := `{"signed_header":{"header":{"version":{"block":"11","app":"1"},"chain_id":"test-chain-IrF74Y","height":"3","time":"2022-11-04T17:43:48.879554Z","last_block_id":{"hash":"048A972F4E947BBBF4E9E0AF350AD233EC6E394903728B3D3F8488F168915C16","parts":{"total":1,"hash":"04A7BCD4D5AEED5C99B3530549E83C7DACA49102542B20E28C48FBD0E911A622"}},"last_commit_hash":"03DEA59253B9502F1AEDEA3A4FADFFB57C3229266AA8601BCEC23BA292D5A347","data_hash":"049EE3EBA8C1600700EE1B87EB599F16716B0B1022947733551FDE4050CA6804","validators_hash":"0241C0593DCFA3154B864E19E3AB6C03D2B79181BE7DC6565C9B6C68EA4D47F6","next_validators_hash":"0241C0593DCFA3154B864E19E3AB6C03D2B79181BE7DC6565C9B6C68EA4D47F6","consensus_hash":"00848270D575B49884653D7B3ED720EB84CE99D064D3BD3210FE23BFB811CB66","app_hash":"0000000000000000000000000000000000000000000000000000000000000000","last_results_hash":"049EE3EBA8C1600700EE1B87EB599F16716B0B1022947733551FDE4050CA6804","evidence_hash":"049EE3EBA8C1600700EE1B87EB599F16716B0B1022947733551FDE4050CA6804","proposer_address":"06EBC607235127FDABA1DB1A9CE71A34E7B880084F7188B03E7A3A1F0334DDBD"},"commit":{"height":"3","round":0,"block_id":{"hash":"03054DF71090EE602E6C0A433B949B726DC20719BD8448E281679A4F78810B7B","parts":{"total":1,"hash":"0424FD36683BB2F85C88BF098088937769FD788C422E63D66714F939F7E77FB9"}},"signatures":[{"block_id_flag":2,"validator_address":"06EBC607235127FDABA1DB1A9CE71A34E7B880084F7188B03E7A3A1F0334DDBD","timestamp":"2022-11-04T17:43:50.41826Z","signature":"BC6w1ASm4R4jq2or6mjD2ROgaYr4WcAT2GskhCOcPQIGXVoVV7JfLXnRsQEaPSjmtjr4ZIOt06InimmziDjqTw=="}]}},"canonical":true}`
+	validatorSetString := `{"block_height":"3","validators":[{"address":"06EBC607235127FDABA1DB1A9CE71A34E7B880084F7188B03E7A3A1F0334DDBD","pub_key":{"type":"tendermint/PubKeyStark","value":"AHzA3ABEpcfPL3+Zfmdm4fGb1MBih2zMt0m1iyqS5KsAoJVUlan320a55nvQrj1ilGjRDSPZqeaLyKbEe6KT3g=="},"voting_power":"10","proposer_priority":"0"}],"count":"1","total":"1"}`
+	require.NoError(t, tmjson.Unmarshal([]byte(trustedLightBlockString), &trusted))
+	require.NoError(t, tmjson.Unmarshal([]byte(validatorSetString), &trusted.ValidatorSet))
+	trusted.ValidatorSet.Proposer = trusted.ValidatorSet.Validators[0]
+	require.NoError(t, tmjson.Unmarshal([]byte(untrustedLightBlockString), &untrusted))
+	untrusted.ValidatorSet = trusted.ValidatorSet
+	return trusted, untrusted
+}
+
+// makeLightBlock returns the light block at height signed by all of privVals.
+func makeLightBlock(t *testing.T, height int64, blockTime time.Time, lastBlockID types.BlockID,
+	vals *types.ValidatorSet, privVals []types.PrivValidator) types.LightBlock {
+	t.Helper()
+	return makeLightBlockWithNextVals(t, height, blockTime, lastBlockID, vals, privVals, vals)
+}
+
+// makeLightBlockWithNextVals returns the light block at height signed by all
+// of privVals, after which nextVals take over.
+func makeLightBlockWithNextVals(t *testing.T, height int64, blockTime time.Time, lastBlockID types.BlockID,
+	vals *types.ValidatorSet, privVals []types.PrivValidator, nextVals *types.ValidatorSet) types.LightBlock {
+	t.Helper()
+
+	header, err := factory.MakeHeader(&types.Header{
+		Height:             height,
+		Time:               blockTime,
+		LastBlockID:        lastBlockID,
+		ValidatorsHash:     vals.Hash(),
+		NextValidatorsHash: nextVals.Hash(),
+		ProposerAddress:    vals.Proposer.Address,
+	})
+	require.NoError(t, err)
+	blockID := factory.MakeBlockIDWithHash(header.Hash())
+	voteSet := types.NewVoteSet(header.ChainID, height, 0, tmproto.PrecommitType, vals)
+	commit, err := factory.MakeCommit(blockID, height, 0, voteSet, privVals, blockTime.Add(time.Second))
+	require.NoError(t, err)
+
+	return types.LightBlock{
+		SignedHeader: &types.SignedHeader{Header: header, Commit: commit},
+		ValidatorSet: vals,
+	}
+}
+
+func verificationConfig(now time.Time) parser.VerificationConfig {
+	return parser.VerificationConfig{
+		CurrentTime:    big.NewInt(now.UnixNano()),
+		MaxClockDrift:  big.NewInt(int64(10 * time.Second)),
+		TrustingPeriod: big.NewInt(int64(24 * time.Hour)),
+		TrustLevel:     tmmath.Fraction{Numerator: 1, Denominator: 3},
+	}
+}
+
+func TestVerifyFixture(t *testing.T) {
+	trusted, untrusted := fixtureLightBlocks(t)
+	now := untrusted.Time.Add(time.Minute)
+
+	testCases := []struct {
+		now    time.Time
+		modify func(calldata []string)
+		err    error
+	}{
+		0: {now, nil, nil},
+		// the header is checked against the hash signed by the commit
+		1: {now, func(calldata []string) { calldata[45] = "1" }, ErrHeaderHashMismatch},
+		2: {now, func(calldata []string) { calldata[13] = "1" }, ErrInvalidSignature},
+		3: {now, func(calldata []string) { calldata[18] = "1000" }, ErrValidatorsHashMismatch},
+		4: {untrusted.Time.Add(48 * time.Hour), nil, ErrExpired},
+		5: {untrusted.Time.Add(-time.Minute), nil, ErrInvalidHeader},
+	}
+	for i, tc := range testCases {
+		calldata, err := parser.ParseInput(trusted, untrusted, verificationConfig(tc.now))
+		require.NoError(t, err)
+		if tc.modify != nil {
+			tc.modify(calldata)
+		}
+		err = Verify(parser.AdjacentFunction, calldata)
+		if tc.err == nil {
+			assert.NoError(t, err, "testCase%d failed", i)
+			continue
+		}
+		assert.ErrorIs(t, err, tc.err, "testCase%d failed", i)
+	}
+}
+
+func TestDecodeMalformedCalldata(t *testing.T) {
+	trusted, untrusted := fixtureLightBlocks(t)
+	calldata, err := parser.ParseInput(trusted, untrusted, verificationConfig(untrusted.Time))
+	require.NoError(t, err)
+
+	testCases := [][]string{
+		0: calldata[:len(calldata)-1],
+		1: append(append([]string{}, calldata...), "0"),
+		2: append([]string{"1000"}, calldata[1:]...),
+		3: append([]string{"-1"}, calldata[1:]...),
+		4: append([]string{"x"}, calldata[1:]...),
+	}
+	for i, tc := range testCases {
+		_, err := DecodeAdjacent(tc)
+		assert.ErrorIs(t, err, ErrMalformedCalldata, "testCase%d failed", i)
+	}
+	_, err = DecodeNonAdjacent(calldata)
+	assert.ErrorIs(t, err, ErrMalformedCalldata)
+}
+
+func TestVerifyGeneratedBlocks(t *testing.T) {
+	vals, privVals := factory.RandValidatorSet(4, 10)
+	start := time.Now().Add(-time.Hour).Truncate(time.Millisecond)
+	first := makeLightBlock(t, 2, start, factory.MakeBlockID(), vals, privVals)
+	next := makeLightBlock(t, 3, start.Add(time.Minute), first.Commit.BlockID, vals, privVals)
+	later := makeLightBlock(t, 10, start.Add(10*time.Minute), factory.MakeBlockID(), vals, privVals)
+	vc := verificationConfig(time.Now())
+
+	testCases := []struct {
+		trusted, untrusted types.LightBlock
+		absent             int // signatures dropped from the untrusted commit
+		err                error
+	}{
+		0: {first, next, 0, nil},
+		1: {first, next, 1, nil},
+		2: {first, next, 2, ErrNotEnoughVotingPower},
+		3: {first, later, 0, nil},
+		4: {first, later, 1, nil},
+		5: {first, later, 2, ErrNotEnoughVotingPower},
+	}
+	for i, tc := range testCases {
+		untrusted := tc.untrusted
+		commit := *untrusted.Commit
+		commit.Signatures = append([]types.CommitSig{}, commit.Signatures...)
+		for j := 0; j < tc.absent; j++ {
+			commit.Signatures[j] = types.NewCommitSigAbsent()
+		}
+		untrusted.SignedHeader = &types.SignedHeader{Header: untrusted.Header, Commit: &commit}
+
+		calldata, err := parser.ParseInput(tc.trusted, untrusted, vc)
+		require.NoError(t, err)
+		err = Verify(parser.VerifyFunction(tc.trusted, untrusted), calldata)
+		if tc.err == nil {
+			assert.NoError(t, err, "testCase%d failed", i)
+			continue
+		}
+		assert.ErrorIs(t, err, tc.err, "testCase%d failed", i)
+	}
+}
+
+func TestVerifyValidatorSetChange(t *testing.T) {
+	vals, privVals := factory.RandValidatorSet(4, 10)
+	newVal, newPrivVal := factory.RandValidator(false, 10)
+	newVals := vals.Copy()
+	require.NoError(t, newVals.UpdateWithChangeSet([]*types.Validator{newVal}))
+	newPrivVals := append([]types.PrivValidator{newPrivVal}, privVals...)
+	sort.Sort(types.PrivValidatorsByAddress(newPrivVals))
+
+	start := time.Now().Add(-time.Hour).Truncate(time.Millisecond)
+	// the validator joins at the height following first
+	first := makeLightBlockWithNextVals(t, 2, start, factory.MakeBlockID(), vals, privVals, newVals)
+	next := makeLightBlock(t, 3, start.Add(time.Minute), first.Commit.BlockID, newVals, newPrivVals)
+	later := makeLightBlock(t, 10, start.Add(10*time.Minute), factory.MakeBlockID(), newVals, newPrivVals)
+	// a block announcing no change, followed by the new validators
+	unannounced := makeLightBlock(t, 2, start, factory.MakeBlockID(), vals, privVals)
+	// the validators of first
+	stale := next
+	stale.ValidatorSet = vals
+	vc := verificationConfig(time.Now())
+
+	testCases := []struct {
+		trusted, untrusted types.LightBlock
+		err                error
+	}{
+		0: {first, next, nil},
+		1: {first, later, nil},
+		2: {unannounced, next, ErrInvalidHeader},
+		3: {first, stale, ErrValidatorsHashMismatch},
+	}
+	for i, tc := range testCases {
+		calldata, err := parser.ParseInput(tc.trusted, tc.untrusted, vc)
+		require.NoError(t, err, "testCase%d failed", i)
+		err = Verify(parser.VerifyFunction(tc.trusted, tc.untrusted), calldata)
+		if tc.err == nil {
+			assert.NoError(t, err, "testCase%d failed", i)
+			continue
+		}
+		assert.ErrorIs(t, err, tc.err, "testCase%d failed", i)
+	}
+}
+
+func TestVerifyNonAdjacentTrustLevel(t *testing.T) {
+	vals, privVals := factory.RandValidatorSet(4, 10)
+	start := time.Now().Add(-time.Hour).Truncate(time.Millisecond)
+	trusted := makeLightBlock(t, 2, start, factory.MakeBlockID(), vals, privVals)
+
+	// half of the trusted validators are replaced
+	newVals, newPrivVals := factory.RandValidatorSet(2, 10)
+	newVals = types.NewValidatorSet(append(newVals.Validators, vals.Validators[0].Copy(), vals.Validators[1].Copy()))
+	for _, pv := range privVals {
+		pubKey, err := pv.GetPubKey(context.Background())
+		require.NoError(t, err)
+		if newVals.HasAddress(pubKey.Address()) {
+			newPrivVals = append(newPrivVals, pv)
+		}
+	}
+	sort.Sort(types.PrivValidatorsByAddress(newPrivVals))
+	untrusted := makeLightBlock(t, 10, start.Add(time.Minute), factory.MakeBlockID(), newVals, newPrivVals)
+
+	testCases := []struct {
+		trustLevel tmmath.Fraction
+		err        error
+	}{
+		0: {tmmath.Fraction{Numerator: 1, Denominator: 3}, nil},
+		1: {tmmath.Fraction{Numerator: 2, Denominator: 3}, ErrNotEnoughVotingPower},
+		2: {tmmath.Fraction{Numerator: 1, Denominator: 4}, ErrInvalidTrustLevel},
+		3: {tmmath.Fraction{Numerator: 4, Denominator: 3}, ErrInvalidTrustLevel},
+	}
+	for i, tc := range testCases {
+		vc := verificationConfig(time.Now())
+		vc.TrustLevel = tc.trustLevel
+		calldata, err := parser.ParseInput(trusted, untrusted, vc)
+		require.NoError(t, err)
+		err = Verify(parser.NonAdjacentFunction, calldata)
+		if tc.err == nil {
+			assert.NoError(t, err, "testCase%d failed", i)
+			continue
+		}
+		assert.ErrorIs(t, err, tc.err, "testCase%d failed", i)
+	}
+}
+
+func TestVerifyNonAdjacentRepeatedSignature(t *testing.T) {
+	vals, privVals := factory.RandValidatorSet(4, 10)
+	start := time.Now().Add(-time.Hour).Truncate(time.Millisecond)
+	trusted := makeLightBlock(t, 2, start, factory.MakeBlockID(), vals, privVals)
+	untrusted := makeLightBlock(t, 10, start.Add(time.Minute), factory.MakeBlockID(), vals, privVals)
+
+	// one trusted signature fills every slot of the commit, which would
+	// reach any trust level if it counted more than once
+	commit := *untrusted.Commit
+	commit.Signatures = make([]types.CommitSig, len(untrusted.Commit.Signatures))
+	for i := range commit.Signatures {
+		commit.Signatures[i] = untrusted.Commit.Signatures[0]
+	}
+	untrusted.SignedHeader = &types.SignedHeader{Header: untrusted.Header, Commit: &commit}
+
+	vc := verificationConfig(time.Now())
+	vc.TrustLevel = tmmath.Fraction{Numerator: 2, Denominator: 3}
+	calldata, err := parser.ParseInput(trusted, untrusted, vc)
+	require.NoError(t, err)
+	err = Verify(parser.NonAdjacentFunction, calldata)
+	assert.ErrorIs(t, err, ErrInvalidCommit)
+	assert.Contains(t, err.Error(), "signed twice")
+}
+
+func TestVerifyDuplicateVote(t *testing.T) {
+	vals, privVals := factory.RandValidatorSet(4, 10)
+	otherVals, otherPrivVals := factory.RandValidatorSet(1, 10)
+	chainID := factory.DefaultTestChainID
+	now := time.Now().Truncate(time.Millisecond)
+
+	// the votes of privVals[0] for the blocks of ids at the given steps
+	makeEvidence := func(pvs []types.PrivValidator, steps [2]int, heights [2]int64, ids [2]types.BlockID) *types.DuplicateVoteEvidence {
+		var votes [2]*types.Vote
+		for i := range votes {
+			vote, err := factory.MakeVote(pvs[0], chainID, 0, heights[i], 0, steps[i], ids[i], now)
+			require.NoError(t, err)
+			votes[i] = vote
+		}
+		return &types.DuplicateVoteEvidence{VoteA: votes[0], VoteB: votes[1]}
+	}
+	blockA, blockB := factory.MakeBlockID(), factory.MakeBlockID()
+	prevote, precommit := int(tmproto.PrevoteType), int(tmproto.PrecommitType)
+
+	anchor := NewAnchor(chainID, verificationConfig(now))
+	otherAnchor := NewAnchor("other-chain", verificationConfig(now))
+
+	testCases := []struct {
+		ev     *types.DuplicateVoteEvidence
+		vals   *types.ValidatorSet
+		tamper bool
+		anchor Anchor
+		err    error
+	}{
+		0: {makeEvidence(privVals, [2]int{prevote, prevote}, [2]int64{5, 5}, [2]types.BlockID{blockA, blockB}), vals, false, anchor, nil},
+		1: {makeEvidence(privVals, [2]int{precommit, precommit}, [2]int64{5, 5}, [2]types.BlockID{blockA, blockB}), vals, false, anchor, nil},
+		2: {makeEvidence(privVals, [2]int{prevote, prevote}, [2]int64{5, 5}, [2]types.BlockID{blockA, blockA}), vals, false, anchor, ErrInvalidEvidence},
+		3: {makeEvidence(privVals, [2]int{prevote, precommit}, [2]int64{5, 5}, [2]types.BlockID{blockA, blockB}), vals, false, anchor, ErrInvalidEvidence},
+		4: {makeEvidence(privVals, [2]int{prevote, prevote}, [2]int64{5, 6}, [2]types.BlockID{blockA, blockB}), vals, false, anchor, ErrInvalidEvidence},
+		5: {makeEvidence(otherPrivVals, [2]int{prevote, prevote}, [2]int64{5, 5}, [2]types.BlockID{blockA, blockB}), vals, false, anchor, ErrInvalidEvidence},
+		6: {makeEvidence(otherPrivVals, [2]int{prevote, prevote}, [2]int64{5, 5}, [2]types.BlockID{blockA, blockB}), otherVals, false, anchor, nil},
+		7: {makeEvidence(privVals, [2]int{prevote, prevote}, [2]int64{5, 5}, [2]types.BlockID{blockA, blockB}), vals, true, anchor, ErrInvalidSignature},
+		// votes of another chain
+		8: {makeEvidence(privVals, [2]int{prevote, prevote}, [2]int64{5, 5}, [2]types.BlockID{blockA, blockB}), vals, false, otherAnchor, ErrInvalidEvidence},
+	}
+	for i, tc := range testCases {
+		if tc.tamper {
+			tc.ev.VoteB.Signature = append([]byte{}, tc.ev.VoteA.Signature...)
+		}
+		calldata, err := parser.ParseDuplicateVoteInput(chainID, tc.ev, tc.vals)
+		require.NoError(t, err, "testCase%d failed", i)
+
+		in, err := DecodeDuplicateVote(calldata)
+		require.NoError(t, err, "testCase%d failed", i)
+		assert.EqualValues(t, 5, in.VoteA.Height.Int64(), "testCase%d failed", i)
+
+		err = VerifyEvidence(parser.DuplicateVoteFunction, calldata, tc.anchor)
+		if tc.err == nil {
+			assert.NoError(t, err, "testCase%d failed", i)
+			continue
+		}
+		assert.ErrorIs(t, err, tc.err, "testCase%d failed", i)
+	}
+}
+
+func TestVerifyLightClientAttack(t *testing.T) {
+	vals, privVals := factory.RandValidatorSet(4, 10)
+	start := time.Now().Add(-time.Hour).Truncate(time.Millisecond)
+	common := makeLightBlock(t, 2, start, factory.MakeBlockID(), vals, privVals)
+	// blocks signed by the validators of the common block, which are not the
+	// ones of the chain
+	conflicting := makeLightBlock(t, 3, start.Add(time.Minute), common.Commit.BlockID, vals, privVals)
+	later := makeLightBlock(t, 10, start.Add(10*time.Minute), factory.MakeBlockID(), vals, privVals)
+	// signed by validators unknown to the common block
+	otherVals, otherPrivVals := factory.RandValidatorSet(4, 10)
+	lunatic := makeLightBlock(t, 10, start.Add(10*time.Minute), factory.MakeBlockID(), otherVals, otherPrivVals)
+	vc := verificationConfig(time.Now())
+	anchor := NewAnchor(common.ChainID, vc)
+	// the trusting period of the verifier, not the one of the caller, is the
+	// one the headers are checked against
+	expired := anchor
+	expired.TrustingPeriod = big.NewInt(int64(time.Minute))
+
+	testCases := []struct {
+		conflicting types.LightBlock
+		anchor      Anchor
+		err         error
+	}{
+		0: {conflicting, anchor, nil},
+		1: {later, anchor, nil},
+		2: {lunatic, anchor, ErrNotEnoughVotingPower},
+		3: {later, expired, ErrExpired},
+		4: {later, NewAnchor("other-chain", vc), ErrInvalidEvidence},
+	}
+	for i, tc := range testCases {
+		ev := &types.LightClientAttackEvidence{ConflictingBlock: &tc.conflicting, CommonHeight: common.Height}
+		calldata, err := parser.ParseLightClientAttackInput(common, ev, vc)
+		require.NoError(t, err, "testCase%d failed", i)
+
+		in, err := Decode(parser.LightClientAttackFunction, calldata)
+		require.NoError(t, err, "testCase%d failed", i)
+		require.IsType(t, &LightClientAttackInput{}, in, "testCase%d failed", i)
+		assert.Error(t, Verify(parser.LightClientAttackFunction, calldata), "testCase%d failed", i)
+
+		err = VerifyEvidence(parser.LightClientAttackFunction, calldata, tc.anchor)
+		if tc.err == nil {
+			assert.NoError(t, err, "testCase%d failed", i)
+			continue
+		}
+		assert.ErrorIs(t, err, tc.err, "testCase%d failed", i)
+	}
+}
+
+func TestVerifyInclusion(t *testing.T) {
+	_, untrusted := fixtureLightBlocks(t)
+	header := *untrusted.Header
+	txs := types.Txs{types.Tx("a=1"), types.Tx("b=2"), types.Tx("c=3")}
+	header.DataHash = txs.Hash()
+	results := types.NewResults([]*abci.ResponseDeliverTx{{Data: []byte("ok")}, {}})
+	header.LastResultsHash = results.Hash()
+	settledHash := new(big.Int).SetBytes(header.Hash())
+
+	txProof := func(i int) types.FeltProof {
+		proof, err := txs.Proof(i).FeltProof(&header)
+		require.NoError(t, err)
+		return proof
+	}
+	resultProof := func(i int) types.FeltProof {
+		proof, err := results.FeltProof(&header, i)
+		require.NoError(t, err)
+		return proof
+	}
+	tamperedLeaf := txProof(1)
+	tamperedLeaf.Leaf = append([]byte{1}, tamperedLeaf.Leaf...)
+	otherField := txProof(0)
+	otherField.Field = types.HeaderFieldAppHash
+	shortAunts := resultProof(0)
+	shortAunts.Proof.Aunts = nil
+
+	testCases := []struct {
+		proof   types.FeltProof
+		settled *big.Int
+		err     error
+	}{
+		0: {txProof(0), settledHash, nil},
+		1: {txProof(2), settledHash, nil},
+		2: {resultProof(0), settledHash, nil},
+		3: {resultProof(1), settledHash, nil}, // empty leaf
+		4: {txProof(0), new(big.Int), ErrInvalidProof},
+		5: {txProof(0), big.NewInt(1), ErrInvalidProof},
+		6: {tamperedLeaf, settledHash, ErrInvalidProof},
+		7: {otherField, settledHash, ErrInvalidProof},
+		8: {shortAunts, settledHash, ErrInvalidProof},
+	}
+	for i, tc := range testCases {
+		calldata, err := parser.ParseInclusionInput(tc.proof)
+		require.NoError(t, err, "testCase%d failed", i)
+
+		in, err := Decode(parser.InclusionFunction, calldata)
+		require.NoError(t, err, "testCase%d failed", i)
+		require.IsType(t, &InclusionInput{}, in, "testCase%d failed", i)
+
+		err = VerifyInclusion(in.(*InclusionInput), tc.settled)
+		if tc.err == nil {
+			assert.NoError(t, err, "testCase%d failed", i)
+			assert.NoError(t, tc.proof.Verify(header.Hash()), "testCase%d failed", i)
+			assert.NoError(t, Verify(parser.InclusionFunction, calldata), "testCase%d failed", i)
+			continue
+		}
+		assert.ErrorIs(t, err, tc.err, "testCase%d failed", i)
+	}
+}
@@ -0,0 +1,166 @@
+// Package starknet provides a light client provider backed by the verifier
+// contract the chain settles to on StarkNet.
+//
+// The verifier records the header hash and validators hash of every height
+// it verifies. The provider serves the light blocks of a full node, the
+// source, at the heights settled on StarkNet, after checking them against
+// these hashes. Used as a witness, it lets the detector of the light client
+// catch a primary serving headers that diverge from the settled ones.
+//
+// The provider only serves settled heights: at the heights skipped by the
+// settlement policy, and above the latest settled one, the detector gets no
+// light block to compare with and counts the witness as not responding. A
+// light client verifying such heights needs other witnesses to cross-check
+// them, and fails with ErrFailedHeaderCrossReferencing if this is its only
+// one. The provider doesn't fall back to the closest settled height, as the
+// detector would take a light block at another height for a conflicting one.
+package starknet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/tendermint/tendermint/internal/settlement/parser"
+	starknetrpc "github.com/tendermint/tendermint/internal/settlement/starknet"
+	"github.com/tendermint/tendermint/light/provider"
+	"github.com/tendermint/tendermint/light/provider/http"
+	"github.com/tendermint/tendermint/types"
+)
+
+// Caller calls view functions of StarkNet contracts. It is implemented by the
+// StarkNet JSON-RPC client of the settlement package.
+type Caller interface {
+	Call(ctx context.Context, call starknetrpc.FunctionCall) ([]*big.Int, error)
+}
+
+// starknet provider checks the light blocks of its source against the verifier
+// contract.
+type starknet struct {
+	chainID  string
+	caller   Caller
+	verifier *big.Int
+	source   provider.Provider
+}
+
+// New creates a StarkNet provider reading the verifier at verifierAddress
+// through the StarkNet JSON-RPC node at starknetURL, and fetching light blocks
+// from the full node at remote.
+func New(chainID, starknetURL, verifierAddress, remote string) (provider.Provider, error) {
+	address, err := starknetrpc.ParseFelt(verifierAddress)
+	if err != nil {
+		return nil, fmt.Errorf("invalid verifier address: %w", err)
+	}
+	source, err := http.New(chainID, remote)
+	if err != nil {
+		return nil, err
+	}
+	return NewWithCaller(chainID, starknetrpc.NewClient(starknetURL), address, source), nil
+}
+
+// NewWithCaller allows you to provide a custom caller and source.
+func NewWithCaller(chainID string, caller Caller, verifierAddress *big.Int, source provider.Provider) provider.Provider {
+	return &starknet{
+		chainID:  chainID,
+		caller:   caller,
+		verifier: verifierAddress,
+		source:   source,
+	}
+}
+
+func (p *starknet) String() string {
+	return fmt.Sprintf("starknet{%s, %v}", starknetrpc.FeltHex(p.verifier), p.source)
+}
+
+// LightBlock fetches the LightBlock at the given height from the source and
+// checks it against the hashes settled on StarkNet. Heights above the latest
+// settled one are too high, and heights that were skipped by settlement are
+// not found.
+func (p *starknet) LightBlock(ctx context.Context, height int64) (*types.LightBlock, error) {
+	if height < 0 {
+		return nil, provider.ErrBadLightBlock{Reason: errors.New("expected height >= 0")}
+	}
+
+	latest, err := p.call(ctx, parser.LatestHeightFunction)
+	if err != nil {
+		return nil, err
+	}
+	if !latest[0].IsInt64() {
+		return nil, provider.ErrUnreliableProvider{Reason: fmt.Sprintf("invalid settled height %s", latest[0])}
+	}
+	settledHeight := latest[0].Int64()
+	if settledHeight == 0 {
+		return nil, provider.ErrLightBlockNotFound
+	}
+	if height == 0 {
+		height = settledHeight
+	}
+	if height > settledHeight {
+		return nil, provider.ErrHeightTooHigh
+	}
+
+	settled, err := p.call(ctx, parser.SettledHeaderFunction, big.NewInt(height))
+	if err != nil {
+		return nil, err
+	}
+	if len(settled) < 2 {
+		return nil, provider.ErrUnreliableProvider{Reason: fmt.Sprintf("%s returned %d felts", parser.SettledHeaderFunction, len(settled))}
+	}
+	headerHash, validatorsHash := settled[0], settled[1]
+	if headerHash.Sign() == 0 {
+		// skipped by the settlement policy
+		return nil, provider.ErrLightBlockNotFound
+	}
+
+	lb, err := p.source.LightBlock(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	if lb.Height != height {
+		return nil, provider.ErrBadLightBlock{
+			Reason: fmt.Errorf("height %d responded doesn't match height %d requested", lb.Height, height),
+		}
+	}
+	if err := lb.ValidateBasic(p.chainID); err != nil {
+		return nil, provider.ErrBadLightBlock{Reason: err}
+	}
+	if hash := new(big.Int).SetBytes(lb.Hash()); hash.Cmp(headerHash) != 0 {
+		return nil, provider.ErrBadLightBlock{
+			Reason: fmt.Errorf("header hash %v doesn't match %s settled at height %d",
+				lb.Hash(), starknetrpc.FeltHex(headerHash), height),
+		}
+	}
+	if hash := new(big.Int).SetBytes(lb.ValidatorsHash); hash.Cmp(validatorsHash) != 0 {
+		return nil, provider.ErrBadLightBlock{
+			Reason: fmt.Errorf("validators hash %v doesn't match %s settled at height %d",
+				lb.ValidatorsHash, starknetrpc.FeltHex(validatorsHash), height),
+		}
+	}
+
+	return lb, nil
+}
+
+// ReportEvidence reports the evidence to the source.
+func (p *starknet) ReportEvidence(ctx context.Context, ev types.Evidence) error {
+	return p.source.ReportEvidence(ctx, ev)
+}
+
+// call calls the view function of the verifier. Errors from the StarkNet node
+// are reported as no response, as the light client expects.
+func (p *starknet) call(ctx context.Context, function string, calldata ...*big.Int) ([]*big.Int, error) {
+	res, err := p.caller.Call(ctx, starknetrpc.FunctionCall{
+		ContractAddress:    p.verifier,
+		EntryPointSelector: starknetrpc.Selector(function),
+		Calldata:           calldata,
+	})
+	switch {
+	case errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded):
+		return nil, err
+	case err != nil:
+		return nil, provider.ErrNoResponse
+	case len(res) == 0:
+		return nil, provider.ErrUnreliableProvider{Reason: fmt.Sprintf("%s returned no felts", function)}
+	}
+	return res, nil
+}
@@ -0,0 +1,139 @@
+package starknet_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/internal/settlement/parser"
+	starknetrpc "github.com/tendermint/tendermint/internal/settlement/starknet"
+	"github.com/tendermint/tendermint/internal/test/factory"
+	"github.com/tendermint/tendermint/light/provider"
+	"github.com/tendermint/tendermint/light/provider/mocks"
+	"github.com/tendermint/tendermint/light/provider/starknet"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	"github.com/tendermint/tendermint/types"
+)
+
+// verifier fakes the views of the verifier contract.
+type verifier struct {
+	latest  int64
+	settled map[int64][2]*big.Int
+	err     error
+}
+
+func (v *verifier) Call(ctx context.Context, call starknetrpc.FunctionCall) ([]*big.Int, error) {
+	if v.err != nil {
+		return nil, v.err
+	}
+	switch call.EntryPointSelector.Cmp(starknetrpc.Selector(parser.SettledHeaderFunction)) {
+	case 0:
+		h, ok := v.settled[call.Calldata[0].Int64()]
+		if !ok {
+			return []*big.Int{big.NewInt(0), big.NewInt(0)}, nil
+		}
+		return h[:], nil
+	default:
+		return []*big.Int{big.NewInt(v.latest)}, nil
+	}
+}
+
+func (v *verifier) settle(lb *types.LightBlock) {
+	v.settled[lb.Height] = [2]*big.Int{
+		new(big.Int).SetBytes(lb.Hash()),
+		new(big.Int).SetBytes(lb.ValidatorsHash),
+	}
+	if lb.Height > v.latest {
+		v.latest = lb.Height
+	}
+}
+
+func genLightBlocks(t *testing.T, numBlocks int64) []*types.LightBlock {
+	t.Helper()
+
+	vals, privVals := factory.RandValidatorSet(4, 10)
+	blockTime := time.Now().Add(-time.Minute)
+	var (
+		lastBlockID types.BlockID
+		lbs         []*types.LightBlock
+	)
+	for height := int64(1); height <= numBlocks; height++ {
+		header, err := factory.MakeHeader(&types.Header{
+			Height:             height,
+			Time:               blockTime,
+			LastBlockID:        lastBlockID,
+			ValidatorsHash:     vals.Hash(),
+			NextValidatorsHash: vals.Hash(),
+			ProposerAddress:    vals.Proposer.Address,
+		})
+		require.NoError(t, err)
+		blockID := factory.MakeBlockIDWithHash(header.Hash())
+		voteSet := types.NewVoteSet(header.ChainID, height, 0, tmproto.PrecommitType, vals)
+		commit, err := factory.MakeCommit(blockID, height, 0, voteSet, privVals, blockTime.Add(time.Second))
+		require.NoError(t, err)
+
+		lbs = append(lbs, &types.LightBlock{
+			SignedHeader: &types.SignedHeader{Header: header, Commit: commit},
+			ValidatorSet: vals,
+		})
+		lastBlockID = blockID
+		blockTime = blockTime.Add(time.Second)
+	}
+	return lbs
+}
+
+func TestProvider(t *testing.T) {
+	ctx := context.Background()
+	lbs := genLightBlocks(t, 4)
+	forged := genLightBlocks(t, 4)
+	chainID := lbs[0].ChainID
+
+	v := &verifier{settled: make(map[int64][2]*big.Int)}
+	v.settle(lbs[1])
+	v.settle(lbs[2])
+
+	source := &mocks.Provider{}
+	source.On("LightBlock", mock.Anything, int64(2)).Return(forged[1], nil)
+	source.On("LightBlock", mock.Anything, int64(3)).Return(lbs[2], nil)
+	p := starknet.NewWithCaller(chainID, v, big.NewInt(0x1234), source)
+	require.Equal(t, fmt.Sprintf("starknet{0x1234, %v}", source), fmt.Sprintf("%s", p))
+
+	testCases := []struct {
+		height int64
+		lb     *types.LightBlock
+		err    error
+	}{
+		0: {0, lbs[2], nil},
+		1: {3, lbs[2], nil},
+		2: {4, nil, provider.ErrHeightTooHigh},
+		3: {1, nil, provider.ErrLightBlockNotFound},
+	}
+	for i, tc := range testCases {
+		lb, err := p.LightBlock(ctx, tc.height)
+		require.Equal(t, tc.err, err, "testCase%d failed", i)
+		require.Equal(t, tc.lb, lb, "testCase%d failed", i)
+	}
+
+	// the source serves a block other than the settled one
+	_, err := p.LightBlock(ctx, 2)
+	require.IsType(t, provider.ErrBadLightBlock{}, err)
+	require.Contains(t, err.Error(), "doesn't match")
+
+	v.err = errors.New("connection refused")
+	_, err = p.LightBlock(ctx, 3)
+	require.Equal(t, provider.ErrNoResponse, err)
+
+	v.err = context.DeadlineExceeded
+	_, err = p.LightBlock(ctx, 3)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	v.err, v.latest, v.settled = nil, 0, map[int64][2]*big.Int{}
+	_, err = p.LightBlock(ctx, 0)
+	require.Equal(t, provider.ErrLightBlockNotFound, err)
+}
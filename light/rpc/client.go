@@ -578,6 +578,17 @@ func (c *Client) Validators(
 	}, nil
 }
 
+// SettlementStatus calls rpcclient#SettlementStatus and returns the result.
+// Settlement progress is specific to the node and cannot be verified.
+func (c *Client) SettlementStatus(ctx context.Context, target string) (*coretypes.ResultSettlementStatus, error) {
+	return c.next.SettlementStatus(ctx, target)
+}
+
+// SettlementTx calls rpcclient#SettlementTx and returns the result.
+func (c *Client) SettlementTx(ctx context.Context, height *int64, target string) (*coretypes.ResultSettlementTx, error) {
+	return c.next.SettlementTx(ctx, height, target)
+}
+
 func (c *Client) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*coretypes.ResultBroadcastEvidence, error) {
 	return c.next.BroadcastEvidence(ctx, ev)
 }
@@ -77,7 +77,7 @@ type nodeImpl struct {
 	consensusReactor  *consensus.Reactor // for participating in the consensus
 	pexReactor        service.Service    // for exchanging peer addresses
 	evidenceReactor   service.Service
-	settlementReactor settlement.Reactor
+	settlementTargets *settlement.Group
 	rpcListeners      []net.Listener // rpc servers
 	shutdownOps       closer
 	indexerService    service.Service
@@ -104,7 +104,7 @@ func newDefaultNode(cfg *config.Config, logger log.Logger) (service.Service, err
 
 	var pval *privval.FilePV
 	if cfg.Mode == config.ModeValidator {
-		pval, err = privval.LoadOrGenFilePV(cfg.PrivValidator.KeyFile(), cfg.PrivValidator.StateFile())
+		pval, err = loadOrGenFilePV(cfg.PrivValidator)
 		if err != nil {
 			return nil, err
 		}
@@ -156,6 +156,10 @@ func makeNode(cfg *config.Config,
 			makeCloser(closers))
 	}
 
+	if err := crypto.SetCommitmentHash(genDoc.CommitmentHash); err != nil {
+		return nil, combineCloseError(err, makeCloser(closers))
+	}
+
 	state, err := loadStateFromDBOrGenesisDocProvider(stateStore, genDoc)
 	if err != nil {
 		return nil, combineCloseError(err, makeCloser(closers))
@@ -311,17 +315,18 @@ func makeNode(cfg *config.Config,
 		sm.BlockExecutorWithMetrics(nodeMetrics.state),
 	)
 
-	settlementCh := CreateSettlementChan()
-	settlementReactor, err := CreateSettlementReactor(logger, cfg, settlementCh)
+	settlementTargets, settlementCloser, err := createSettlementGroup(
+		cfg, dbProvider, blockStore, stateStore, eventBus, pubKey, privValidator, genDoc.ChainID,
+		nodeMetrics.settlement, logger,
+	)
+	closers = append(closers, settlementCloser)
 	if err != nil {
 		return nil, combineCloseError(err, makeCloser(closers))
-
 	}
-	settlementReactor.OnStart()
 
 	csReactorShim, csReactor, csState := createConsensusReactor(
 		cfg, state, blockExec, blockStore, mp, evPool,
-		privValidator, nodeMetrics.consensus, stateSync || blockSync, eventBus, settlementCh,
+		privValidator, nodeMetrics.consensus, stateSync || blockSync, eventBus,
 		peerManager, router, consensusLogger,
 	)
 
@@ -492,7 +497,7 @@ func makeNode(cfg *config.Config,
 		stateSync:         stateSync,
 		pexReactor:        pexReactor,
 		evidenceReactor:   evReactor,
-		settlementReactor: *settlementReactor,
+		settlementTargets: settlementTargets,
 		indexerService:    indexerService,
 		eventBus:          eventBus,
 		eventSinks:        eventSinks,
@@ -508,8 +513,9 @@ func makeNode(cfg *config.Config,
 			EvidencePool:   evPool,
 			ConsensusState: csState,
 
-			ConsensusReactor: csReactor,
-			BlockSyncReactor: bcReactor.(consensus.BlockSyncReactor),
+			ConsensusReactor:  csReactor,
+			BlockSyncReactor:  bcReactor.(consensus.BlockSyncReactor),
+			SettlementTargets: settlementTargets,
 
 			P2PPeers:    sw,
 			PeerManager: peerManager,
@@ -557,6 +563,10 @@ func makeSeedNode(cfg *config.Config,
 		return nil, err
 	}
 
+	if err := crypto.SetCommitmentHash(genDoc.CommitmentHash); err != nil {
+		return nil, err
+	}
+
 	state, err := sm.MakeGenesisState(genDoc)
 	if err != nil {
 		return nil, err
@@ -735,6 +745,10 @@ func (n *nodeImpl) OnStart() error {
 		if err := n.evidenceReactor.Start(); err != nil {
 			return err
 		}
+
+		if err := n.settlementTargets.Start(); err != nil {
+			return err
+		}
 	}
 
 	if n.config.P2P.UseLegacy {
@@ -861,10 +875,9 @@ func (n *nodeImpl) OnStop() {
 			n.Logger.Error("failed to stop the evidence reactor", "err", err)
 		}
 
-	}
-
-	if err := n.settlementReactor.Stop(); err != nil {
-		n.Logger.Error("failed to stop the settlement reactor", "err", err)
+		if err := n.settlementTargets.Stop(); err != nil {
+			n.Logger.Error("failed to stop the settlement reactors", "err", err)
+		}
 	}
 
 	if err := n.pexReactor.Stop(); err != nil {
@@ -1132,16 +1145,18 @@ func defaultGenesisDocProviderFunc(cfg *config.Config) genesisDocProvider {
 }
 
 type nodeMetrics struct {
-	consensus *consensus.Metrics
-	indexer   *indexer.Metrics
-	mempool   *mempool.Metrics
-	p2p       *p2p.Metrics
-	state     *sm.Metrics
-	statesync *statesync.Metrics
-	proxy     *proxy.Metrics
+	consensus  *consensus.Metrics
+	indexer    *indexer.Metrics
+	mempool    *mempool.Metrics
+	p2p        *p2p.Metrics
+	state      *sm.Metrics
+	statesync  *statesync.Metrics
+	proxy      *proxy.Metrics
+	settlement *settlement.Metrics
 }
 
-// metricsProvider returns consensus, p2p, mempool, state, statesync Metrics.
+// metricsProvider returns consensus, p2p, mempool, state, statesync and
+// settlement Metrics.
 type metricsProvider func(chainID string) *nodeMetrics
 
 // defaultMetricsProvider returns Metrics build using Prometheus client library
@@ -1150,23 +1165,25 @@ func defaultMetricsProvider(cfg *config.InstrumentationConfig) metricsProvider {
 	return func(chainID string) *nodeMetrics {
 		if cfg.Prometheus {
 			return &nodeMetrics{
-				consensus: consensus.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
-				indexer:   indexer.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
-				mempool:   mempool.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
-				p2p:       p2p.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
-				state:     sm.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
-				statesync: statesync.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
-				proxy:     proxy.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
+				consensus:  consensus.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
+				indexer:    indexer.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
+				mempool:    mempool.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
+				p2p:        p2p.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
+				state:      sm.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
+				statesync:  statesync.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
+				proxy:      proxy.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
+				settlement: settlement.PrometheusMetrics(cfg.Namespace, "chain_id", chainID),
 			}
 		}
 		return &nodeMetrics{
-			consensus: consensus.NopMetrics(),
-			indexer:   indexer.NopMetrics(),
-			mempool:   mempool.NopMetrics(),
-			p2p:       p2p.NopMetrics(),
-			state:     sm.NopMetrics(),
-			statesync: statesync.NopMetrics(),
-			proxy:     proxy.NopMetrics(),
+			consensus:  consensus.NopMetrics(),
+			indexer:    indexer.NopMetrics(),
+			mempool:    mempool.NopMetrics(),
+			p2p:        p2p.NopMetrics(),
+			state:      sm.NopMetrics(),
+			statesync:  statesync.NopMetrics(),
+			proxy:      proxy.NopMetrics(),
+			settlement: settlement.NopMetrics(),
 		}
 	}
 }
@@ -1198,6 +1215,30 @@ func loadStateFromDBOrGenesisDocProvider(
 	return state, nil
 }
 
+// loadOrGenFilePV loads the file validator of the node, or generates it,
+// decrypting its key with the configured passphrase, and gives it the
+// starknet key of the settlement account if one is configured.
+func loadOrGenFilePV(cfg *config.PrivValidatorConfig) (*privval.FilePV, error) {
+	passphrase, err := cfg.ReadKeyPassphrase()
+	if err != nil {
+		return nil, err
+	}
+	pval, err := privval.LoadOrGenFilePVWithPassphrase(cfg.KeyFile(), cfg.StateFile(), passphrase)
+	if err != nil {
+		return nil, err
+	}
+	if keyFile := cfg.StarknetKeyFile(); keyFile != "" {
+		limits, err := privval.ParseStarknetLimits(cfg.StarknetAccountAddress, cfg.StarknetChainID, cfg.StarknetMaxFee)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the starknet key of the private validator: %w", err)
+		}
+		if err := pval.LoadStarknetKey(keyFile, cfg.StarknetAllowedCalls, limits); err != nil {
+			return nil, fmt.Errorf("failed to load the starknet key of the private validator: %w", err)
+		}
+	}
+	return pval, nil
+}
+
 func createAndStartPrivValidatorSocketClient(
 	listenAddr,
 	chainID string,
@@ -26,7 +26,7 @@ import (
 	"github.com/tendermint/tendermint/internal/p2p/pex"
 	"github.com/tendermint/tendermint/internal/proxy"
 	"github.com/tendermint/tendermint/internal/settlement"
-	"github.com/tendermint/tendermint/internal/settlement/parser"
+	"github.com/tendermint/tendermint/internal/settlement/starknet"
 	sm "github.com/tendermint/tendermint/internal/state"
 	"github.com/tendermint/tendermint/internal/state/indexer"
 	"github.com/tendermint/tendermint/internal/state/indexer/sink"
@@ -395,7 +395,6 @@ func createConsensusReactor(
 	csMetrics *consensus.Metrics,
 	waitSync bool,
 	eventBus *types.EventBus,
-	settlementChan chan parser.SettlementData,
 	peerManager *p2p.PeerManager,
 	router *p2p.Router,
 	logger log.Logger,
@@ -408,7 +407,6 @@ func createConsensusReactor(
 		blockStore,
 		mp,
 		evidencePool,
-		settlementChan,
 		consensus.StateMetrics(csMetrics),
 	)
 	consensusState.SetLogger(logger)
@@ -470,22 +468,43 @@ func createTransport(logger log.Logger, cfg *config.Config) *p2p.MConnTransport
 	)
 }
 
-func CreateSettlementChan() chan parser.SettlementData {
-	ch := make(chan parser.SettlementData, 100)
-	return ch
-}
-
-func CreateSettlementReactor(
-	logger log.Logger,
+func createSettlementGroup(
 	cfg *config.Config,
-	SettlementCh <-chan parser.SettlementData,
-) (*settlement.Reactor, error) {
+	dbProvider config.DBProvider,
+	blockStore sm.BlockStore,
+	stateStore sm.Store,
+	eventBus *types.EventBus,
+	pubKey crypto.PubKey,
+	privValidator types.PrivValidator,
+	chainID string,
+	metrics *settlement.Metrics,
+	logger log.Logger,
+) (*settlement.Group, closer, error) {
 
 	logger = logger.With("module", "settlement")
 
-	settlementReactor := settlement.NewReactor(logger, cfg, SettlementCh)
+	// full nodes follow the chain without a validator of their own, their
+	// reactors never submit
+	var (
+		validatorAddress string
+		signer           starknet.Signer
+	)
+	if pubKey != nil {
+		validatorAddress = pubKey.Address().String()
+		if pv, ok := privValidator.(types.StarknetSigner); ok {
+			signer = settlement.NewPrivValidatorSigner(pv, chainID)
+		}
+	}
+
+	group, err := settlement.NewGroupFromConfig(
+		logger, cfg, dbProvider, blockStore, stateStore, validatorAddress, signer, metrics,
+	)
+	if err != nil {
+		return nil, func() error { return nil }, err
+	}
+	group.SetEventBus(eventBus)
 
-	return settlementReactor, nil
+	return group, group.Close, nil
 }
 
 func createPeerManager(
@@ -17,10 +17,12 @@ import (
 	"github.com/tendermint/tendermint/crypto/stark"
 	"github.com/tendermint/tendermint/internal/libs/protoio"
 	"github.com/tendermint/tendermint/internal/libs/tempfile"
+	"github.com/tendermint/tendermint/internal/settlement/starknet"
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	tmos "github.com/tendermint/tendermint/libs/os"
 	tmtime "github.com/tendermint/tendermint/libs/time"
+	privvalproto "github.com/tendermint/tendermint/proto/tendermint/privval"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/types"
 )
@@ -54,16 +56,42 @@ type FilePVKey struct {
 	PrivKey crypto.PrivKey `json:"priv_key"`
 
 	filePath string
+	// the passphrase the key is encrypted with when saved, nil if it is
+	// saved in the clear
+	passphrase []byte
 }
 
-// Save persists the FilePVKey to its filePath.
+// SetPassphrase sets the passphrase the key is encrypted with when saved, or
+// saves it in the clear if passphrase is empty.
+func (pvKey *FilePVKey) SetPassphrase(passphrase []byte) {
+	if len(passphrase) == 0 {
+		passphrase = nil
+	}
+	pvKey.passphrase = passphrase
+}
+
+// IsEncrypted reports whether the key is encrypted when saved.
+func (pvKey FilePVKey) IsEncrypted() bool {
+	return pvKey.passphrase != nil
+}
+
+// Save persists the FilePVKey to its filePath, encrypted if it has a
+// passphrase.
 func (pvKey FilePVKey) Save() {
 	outFile := pvKey.filePath
 	if outFile == "" {
 		panic("cannot save PrivValidator key: filePath not set")
 	}
 
-	jsonBytes, err := tmjson.MarshalIndent(pvKey, "", "  ")
+	var (
+		jsonBytes []byte
+		err       error
+	)
+	if pvKey.passphrase != nil {
+		jsonBytes, err = encryptFilePVKey(pvKey, pvKey.passphrase, defaultScryptParams)
+	} else {
+		jsonBytes, err = tmjson.MarshalIndent(pvKey, "", "  ")
+	}
 	if err != nil {
 		panic(err)
 	}
@@ -154,9 +182,18 @@ func (lss *FilePVLastSignState) Save() {
 type FilePV struct {
 	Key           FilePVKey
 	LastSignState FilePVLastSignState
+
+	// the signer of the settlement account, the calls it may make and the
+	// limits of its transactions, see SetStarknetKey
+	starknetSigner  starknet.Signer
+	starknetAllowed *StarknetAllowList
+	starknetLimits  *StarknetLimits
 }
 
-var _ types.PrivValidator = (*FilePV)(nil)
+var (
+	_ types.PrivValidator  = (*FilePV)(nil)
+	_ types.StarknetSigner = (*FilePV)(nil)
+)
 
 // NewFilePV generates a new validator from the given key and paths.
 func NewFilePV(privKey crypto.PrivKey, keyFilePath, stateFilePath string) *FilePV {
@@ -193,25 +230,51 @@ func GenFilePV(keyFilePath, stateFilePath, keyType string) (*FilePV, error) {
 // signing prevention by persisting data to the stateFilePath.  If either file path
 // does not exist, the program will exit.
 func LoadFilePV(keyFilePath, stateFilePath string) (*FilePV, error) {
-	return loadFilePV(keyFilePath, stateFilePath, true)
+	return loadFilePV(keyFilePath, stateFilePath, nil, true)
+}
+
+// LoadFilePVWithPassphrase is like LoadFilePV, but decrypts the key file
+// with passphrase if it is encrypted. The key is saved encrypted with the
+// same passphrase.
+func LoadFilePVWithPassphrase(keyFilePath, stateFilePath string, passphrase []byte) (*FilePV, error) {
+	return loadFilePV(keyFilePath, stateFilePath, passphrase, true)
 }
 
 // LoadFilePVEmptyState loads a FilePV from the given keyFilePath, with an empty LastSignState.
 // If the keyFilePath does not exist, the program will exit.
 func LoadFilePVEmptyState(keyFilePath, stateFilePath string) (*FilePV, error) {
-	return loadFilePV(keyFilePath, stateFilePath, false)
+	return loadFilePV(keyFilePath, stateFilePath, nil, false)
+}
+
+// LoadFilePVEmptyStateWithPassphrase is like LoadFilePVEmptyState, but
+// decrypts the key file with passphrase if it is encrypted.
+func LoadFilePVEmptyStateWithPassphrase(keyFilePath, stateFilePath string, passphrase []byte) (*FilePV, error) {
+	return loadFilePV(keyFilePath, stateFilePath, passphrase, false)
 }
 
 // If loadState is true, we load from the stateFilePath. Otherwise, we use an empty LastSignState.
-func loadFilePV(keyFilePath, stateFilePath string, loadState bool) (*FilePV, error) {
+// An encrypted key file is decrypted with passphrase, ErrKeyEncrypted is
+// returned if it is empty.
+func loadFilePV(keyFilePath, stateFilePath string, passphrase []byte, loadState bool) (*FilePV, error) {
 	keyJSONBytes, err := ioutil.ReadFile(keyFilePath)
 	if err != nil {
 		return nil, err
 	}
 	pvKey := FilePVKey{}
-	err = tmjson.Unmarshal(keyJSONBytes, &pvKey)
-	if err != nil {
-		return nil, fmt.Errorf("error reading PrivValidator key from %v: %w", keyFilePath, err)
+	if isEncryptedKey(keyJSONBytes) {
+		if len(passphrase) == 0 {
+			return nil, fmt.Errorf("error reading PrivValidator key from %v: %w", keyFilePath, ErrKeyEncrypted)
+		}
+		pvKey, err = decryptFilePVKey(keyJSONBytes, passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("error reading PrivValidator key from %v: %w", keyFilePath, err)
+		}
+		pvKey.SetPassphrase(passphrase)
+	} else {
+		err = tmjson.Unmarshal(keyJSONBytes, &pvKey)
+		if err != nil {
+			return nil, fmt.Errorf("error reading PrivValidator key from %v: %w", keyFilePath, err)
+		}
 	}
 
 	// overwrite pubkey and address for convenience
@@ -243,14 +306,25 @@ func loadFilePV(keyFilePath, stateFilePath string, loadState bool) (*FilePV, err
 // LoadOrGenFilePV loads a FilePV from the given filePaths
 // or else generates a new one and saves it to the filePaths.
 func LoadOrGenFilePV(keyFilePath, stateFilePath string) (*FilePV, error) {
+	return LoadOrGenFilePVWithPassphrase(keyFilePath, stateFilePath, nil)
+}
+
+// LoadOrGenFilePVWithPassphrase is like LoadOrGenFilePV, but decrypts the
+// key file with passphrase if it is encrypted, and encrypts the key it
+// generates with passphrase if it is not empty.
+func LoadOrGenFilePVWithPassphrase(keyFilePath, stateFilePath string, passphrase []byte) (*FilePV, error) {
 	var (
 		pv  *FilePV
 		err error
 	)
 	if tmos.FileExists(keyFilePath) {
-		pv, err = LoadFilePV(keyFilePath, stateFilePath)
+		pv, err = LoadFilePVWithPassphrase(keyFilePath, stateFilePath, passphrase)
 	} else {
 		pv, err = GenFilePV(keyFilePath, stateFilePath, "")
+		if err != nil {
+			return nil, err
+		}
+		pv.Key.SetPassphrase(passphrase)
 		pv.Save()
 	}
 	return pv, err
@@ -286,6 +360,47 @@ func (pv *FilePV) SignProposal(ctx context.Context, chainID string, proposal *tm
 	return nil
 }
 
+// SetStarknetKey sets the stark key of the Starknet account settling
+// commits, the calls its transactions may make and their limits. The key is
+// not persisted with the FilePV.
+func (pv *FilePV) SetStarknetKey(key stark.PrivKey, allowed *StarknetAllowList, limits *StarknetLimits) {
+	pv.starknetSigner = starknet.NewKeySigner(key)
+	pv.starknetAllowed = allowed
+	pv.starknetLimits = limits
+}
+
+// LoadStarknetKey reads the hex encoded stark key of the Starknet account
+// settling commits from keyFilePath, see SetStarknetKey.
+func (pv *FilePV) LoadStarknetKey(keyFilePath string, allowedCalls []string, limits *StarknetLimits) error {
+	key, err := starknet.LoadPrivateKey(keyFilePath)
+	if err != nil {
+		return err
+	}
+	allowed, err := ParseStarknetAllowList(allowedCalls)
+	if err != nil {
+		return err
+	}
+	pv.SetStarknetKey(key, allowed, limits)
+	return nil
+}
+
+// SignStarknetTransaction signs a Starknet transaction of the settlement
+// account, if it is within the limits and all its calls are in the
+// allow-list. Implements StarknetSigner.
+func (pv *FilePV) SignStarknetTransaction(
+	ctx context.Context,
+	chainID string,
+	tx *privvalproto.StarknetInvokeTransaction,
+) error {
+	if pv.starknetSigner == nil {
+		return errors.New("error signing starknet transaction: no starknet key")
+	}
+	if err := signStarknetTransaction(ctx, pv.starknetSigner, pv.starknetAllowed, pv.starknetLimits, tx); err != nil {
+		return fmt.Errorf("error signing starknet transaction: %w", err)
+	}
+	return nil
+}
+
 // Save persists the FilePV to disk.
 func (pv *FilePV) Save() {
 	pv.Key.Save()
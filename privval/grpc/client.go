@@ -24,7 +24,10 @@ type SignerClient struct {
 	chainID string
 }
 
-var _ types.PrivValidator = (*SignerClient)(nil)
+var (
+	_ types.PrivValidator  = (*SignerClient)(nil)
+	_ types.StarknetSigner = (*SignerClient)(nil)
+)
 
 // NewSignerClient returns an instance of SignerClient.
 // it will start the endpoint (if not already started)
@@ -99,3 +102,24 @@ func (sc *SignerClient) SignProposal(ctx context.Context, chainID string, propos
 
 	return nil
 }
+
+// SignStarknetTransaction requests a remote signer to sign a transaction of
+// the settlement account
+func (sc *SignerClient) SignStarknetTransaction(
+	ctx context.Context,
+	chainID string,
+	tx *privvalproto.StarknetInvokeTransaction,
+) error {
+	resp, err := sc.client.SignStarknetTransaction(
+		ctx, &privvalproto.SignStarknetTransactionRequest{ChainId: chainID, Transaction: tx})
+
+	if err != nil {
+		errStatus, _ := status.FromError(err)
+		sc.logger.Error("SignerClient::SignStarknetTransaction", "err", errStatus.Message())
+		return errStatus.Err()
+	}
+
+	tx.Signature = resp.Transaction.Signature
+
+	return nil
+}
@@ -2,6 +2,7 @@ package grpc_test
 
 import (
 	"context"
+	"math/big"
 	"net"
 	"testing"
 	"time"
@@ -177,3 +178,39 @@ func TestSignerClient_SignProposal(t *testing.T) {
 
 	assert.Equal(t, pbWant.Signature, pbHave.Signature)
 }
+
+func TestSignerClient_SignStarknetTransaction(t *testing.T) {
+
+	ctx := context.Background()
+	contract := big.NewInt(0xc0de)
+	pv, _ := newStarknetPV(t, contract)
+	logger := log.TestingLogger()
+	srv, dialer := dialer(pv, logger)
+	defer srv.Stop()
+
+	conn, err := grpc.DialContext(ctx, "",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(dialer),
+	)
+	if err != nil {
+		panic(err)
+	}
+	defer conn.Close()
+
+	client, err := tmgrpc.NewSignerClient(conn, chainID, logger)
+	require.NoError(t, err)
+
+	want := newStarknetTransaction(contract, "verify").ToProto()
+	require.NoError(t, pv.SignStarknetTransaction(ctx, chainID, want))
+
+	have := newStarknetTransaction(contract, "verify").ToProto()
+	require.NoError(t, client.SignStarknetTransaction(ctx, chainID, have))
+	require.Len(t, have.Signature, 2)
+
+	// signatures are deterministic
+	assert.Equal(t, want.Signature, have.Signature)
+
+	tx := newStarknetTransaction(contract, "upgrade").ToProto()
+	require.Error(t, client.SignStarknetTransaction(ctx, chainID, tx))
+	assert.Nil(t, tx.Signature)
+}
@@ -85,3 +85,27 @@ func (ss *SignerServer) SignProposal(ctx context.Context, req *privvalproto.Sign
 
 	return &privvalproto.SignedProposalResponse{Proposal: *proposal}, nil
 }
+
+// SignStarknetTransaction receives a Starknet transaction sign requests,
+// attempts to sign it
+// returns SignStarknetTransactionResponse on success and error on failure
+func (ss *SignerServer) SignStarknetTransaction(ctx context.Context, req *privvalproto.SignStarknetTransactionRequest) (
+	*privvalproto.SignStarknetTransactionResponse, error) {
+	starknetSigner, ok := ss.privVal.(types.StarknetSigner)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "signer does not sign starknet transactions")
+	}
+	if req.Transaction == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "error signing starknet transaction: nil transaction")
+	}
+	tx := req.Transaction
+
+	err := starknetSigner.SignStarknetTransaction(ctx, req.ChainId, tx)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "error signing starknet transaction: %v", err)
+	}
+
+	ss.logger.Info("SignerServer: SignStarknetTransaction Success", "calls", len(tx.Calls))
+
+	return &privvalproto.SignStarknetTransactionResponse{Transaction: *tx}, nil
+}
@@ -2,6 +2,8 @@ package grpc_test
 
 import (
 	"context"
+	"math/big"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -10,7 +12,9 @@ import (
 
 	"github.com/tendermint/tendermint/crypto/pedersen"
 	"github.com/tendermint/tendermint/crypto/stark"
+	"github.com/tendermint/tendermint/internal/settlement/starknet"
 	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/privval"
 
 	tmgrpc "github.com/tendermint/tendermint/privval/grpc"
 	privvalproto "github.com/tendermint/tendermint/proto/tendermint/privval"
@@ -186,3 +190,70 @@ func TestSignProposal(t *testing.T) {
 		})
 	}
 }
+
+// newStarknetPV returns a file validator signing the starknet transactions
+// which call verify on contract.
+func newStarknetPV(t *testing.T, contract *big.Int) (*privval.FilePV, stark.PrivKey) {
+	dir := t.TempDir()
+	pv, err := privval.GenFilePV(filepath.Join(dir, "key.json"), filepath.Join(dir, "state.json"), "")
+	require.NoError(t, err)
+	allowed, err := privval.ParseStarknetAllowList([]string{starknet.FeltHex(contract) + ":verify"})
+	require.NoError(t, err)
+	limits, err := privval.ParseStarknetLimits("0xacc", "0x534e5f474f45524c49", "1000")
+	require.NoError(t, err)
+	key := stark.GenPrivKey()
+	pv.SetStarknetKey(key, allowed, limits)
+	return pv, key
+}
+
+func newStarknetTransaction(contract *big.Int, function string) *starknet.Invoke {
+	return &starknet.Invoke{
+		SenderAddress: big.NewInt(0xacc),
+		Calls: []starknet.FunctionCall{{
+			ContractAddress:    contract,
+			EntryPointSelector: starknet.Selector(function),
+			Calldata:           []*big.Int{big.NewInt(1)},
+		}},
+		MaxFee:  big.NewInt(1000),
+		Nonce:   big.NewInt(1),
+		ChainID: starknet.ShortString("SN_GOERLI"),
+	}
+}
+
+func TestSignStarknetTransaction(t *testing.T) {
+	contract := big.NewInt(0xc0de)
+	starknetPV, key := newStarknetPV(t, contract)
+
+	testCases := []struct {
+		name string
+		pv   types.PrivValidator
+		tx   *privvalproto.StarknetInvokeTransaction
+		err  bool
+	}{
+		{name: "valid", pv: starknetPV, tx: newStarknetTransaction(contract, "verify").ToProto(), err: false},
+		{name: "call not allowed", pv: starknetPV, tx: newStarknetTransaction(contract, "upgrade").ToProto(), err: true},
+		{name: "nil transaction", pv: starknetPV, tx: nil, err: true},
+		{name: "no starknet key", pv: types.NewMockPV(), tx: newStarknetTransaction(contract, "verify").ToProto(), err: true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			s := tmgrpc.NewSignerServer(ChainID, tc.pv, log.TestingLogger())
+
+			req := &privvalproto.SignStarknetTransactionRequest{ChainId: ChainID, Transaction: tc.tx}
+			resp, err := s.SignStarknetTransaction(context.Background(), req)
+			if tc.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				sig, err := starknet.SignatureFromProto(resp.Transaction.Signature)
+				require.NoError(t, err)
+				require.Len(t, sig, 2)
+				pub := key.MakeFull().PublicKey
+				hash := newStarknetTransaction(contract, "verify").Hash()
+				assert.True(t, stark.Verify(&pub, hash.FillBytes(make([]byte, 32)), sig[0], sig[1]))
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package privval
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/xchacha20poly1305"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	"github.com/tendermint/tendermint/types"
+)
+
+// Keystore errors.
+var (
+	ErrKeyEncrypted    = errors.New("private validator key is encrypted, a passphrase is required")
+	ErrWrongPassphrase = errors.New("could not decrypt private validator key: wrong passphrase or corrupted file")
+)
+
+const (
+	keystoreKDF    = "scrypt"
+	keystoreCipher = "xchacha20poly1305"
+
+	keystoreSaltSize = 32
+	// the maximal memory, in bytes, the key derivation of a key file may
+	// use, so that a crafted file cannot exhaust the memory of the node
+	keystoreMaxKDFMemory = 1 << 30
+)
+
+// scryptParams are the parameters of the key derivation of an encrypted key.
+type scryptParams struct {
+	N    int              `json:"n"`
+	R    int              `json:"r"`
+	P    int              `json:"p"`
+	Salt tmbytes.HexBytes `json:"salt"`
+}
+
+// defaultScryptParams are the parameters of the keys encrypted by FilePVKey,
+// the "standard" ones of the Ethereum keystore: deriving a key takes about a
+// second and 256MB of memory.
+var defaultScryptParams = scryptParams{N: 1 << 18, R: 8, P: 1}
+
+// keystoreCrypto is the encrypted private key of an encrypted key file.
+type keystoreCrypto struct {
+	KDF        string           `json:"kdf"`
+	KDFParams  scryptParams     `json:"kdf_params"`
+	Cipher     string           `json:"cipher"`
+	Nonce      tmbytes.HexBytes `json:"nonce"`
+	Ciphertext tmbytes.HexBytes `json:"ciphertext"`
+}
+
+// encryptedFilePVKey is the content of an encrypted key file. The address
+// and public key are left in the clear, so that the key can be identified
+// without the passphrase.
+type encryptedFilePVKey struct {
+	Address types.Address   `json:"address"`
+	PubKey  crypto.PubKey   `json:"pub_key"`
+	Crypto  *keystoreCrypto `json:"crypto"`
+}
+
+// keystorePlaintext is what the ciphertext of an encrypted key file
+// decrypts to.
+type keystorePlaintext struct {
+	PrivKey crypto.PrivKey `json:"priv_key"`
+}
+
+// isEncryptedKey reports whether keyJSONBytes is an encrypted key file.
+func isEncryptedKey(keyJSONBytes []byte) bool {
+	var key struct {
+		Crypto *struct{} `json:"crypto"`
+	}
+	return tmjson.Unmarshal(keyJSONBytes, &key) == nil && key.Crypto != nil
+}
+
+// encryptFilePVKey returns the encrypted key file of pvKey, of which the
+// key is derived from passphrase with params and a random salt.
+func encryptFilePVKey(pvKey FilePVKey, passphrase []byte, params scryptParams) ([]byte, error) {
+	params.Salt = crypto.CRandBytes(keystoreSaltSize)
+	aead, err := keystoreAEAD(passphrase, params)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := tmjson.Marshal(keystorePlaintext{PrivKey: pvKey.PrivKey})
+	if err != nil {
+		return nil, err
+	}
+	nonce := crypto.CRandBytes(xchacha20poly1305.NonceSize)
+
+	return tmjson.MarshalIndent(encryptedFilePVKey{
+		Address: pvKey.Address,
+		PubKey:  pvKey.PubKey,
+		Crypto: &keystoreCrypto{
+			KDF:        keystoreKDF,
+			KDFParams:  params,
+			Cipher:     keystoreCipher,
+			Nonce:      nonce,
+			Ciphertext: aead.Seal(nil, nonce, plaintext, nil),
+		},
+	}, "", "  ")
+}
+
+// decryptFilePVKey decrypts the encrypted key file keyJSONBytes with
+// passphrase.
+func decryptFilePVKey(keyJSONBytes, passphrase []byte) (FilePVKey, error) {
+	var encrypted encryptedFilePVKey
+	if err := tmjson.Unmarshal(keyJSONBytes, &encrypted); err != nil {
+		return FilePVKey{}, err
+	}
+	c := encrypted.Crypto
+	if c == nil {
+		return FilePVKey{}, errors.New("key is not encrypted")
+	}
+	if c.KDF != keystoreKDF {
+		return FilePVKey{}, fmt.Errorf("unsupported key derivation function %q", c.KDF)
+	}
+	if c.Cipher != keystoreCipher {
+		return FilePVKey{}, fmt.Errorf("unsupported cipher %q", c.Cipher)
+	}
+	if p := c.KDFParams; p.N <= 0 || p.R <= 0 || p.P <= 0 || p.R > keystoreMaxKDFMemory/128/p.N {
+		return FilePVKey{}, fmt.Errorf("invalid or too expensive key derivation parameters n=%d, r=%d", p.N, p.R)
+	}
+	if len(c.Nonce) != xchacha20poly1305.NonceSize {
+		return FilePVKey{}, fmt.Errorf("invalid nonce of %d bytes", len(c.Nonce))
+	}
+
+	aead, err := keystoreAEAD(passphrase, c.KDFParams)
+	if err != nil {
+		return FilePVKey{}, err
+	}
+	plaintextBytes, err := aead.Open(nil, c.Nonce, c.Ciphertext, nil)
+	if err != nil {
+		return FilePVKey{}, ErrWrongPassphrase
+	}
+	var plaintext keystorePlaintext
+	if err := tmjson.Unmarshal(plaintextBytes, &plaintext); err != nil {
+		return FilePVKey{}, fmt.Errorf("invalid private key: %w", err)
+	}
+	if plaintext.PrivKey == nil {
+		return FilePVKey{}, errors.New("missing private key")
+	}
+
+	pubKey := plaintext.PrivKey.PubKey()
+	if !bytes.Equal(pubKey.Address(), encrypted.Address) {
+		return FilePVKey{}, fmt.Errorf("address %v does not match the private key, of address %v",
+			encrypted.Address, pubKey.Address())
+	}
+	return FilePVKey{
+		Address: pubKey.Address(),
+		PubKey:  pubKey,
+		PrivKey: plaintext.PrivKey,
+	}, nil
+}
+
+// keystoreAEAD returns the cipher of the key derived from passphrase.
+func keystoreAEAD(passphrase []byte, params scryptParams) (cipher.AEAD, error) {
+	key, err := scrypt.Key(passphrase, params.Salt, params.N, params.R, params.P, xchacha20poly1305.KeySize)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key derivation parameters: %w", err)
+	}
+	return xchacha20poly1305.New(key)
+}
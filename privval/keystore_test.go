@@ -0,0 +1,145 @@
+package privval
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	"github.com/tendermint/tendermint/types"
+)
+
+// useLightScrypt makes the keys encrypted by the test cheap to derive.
+func useLightScrypt(t *testing.T) {
+	params := defaultScryptParams
+	defaultScryptParams = scryptParams{N: 1 << 10, R: 8, P: 1}
+	t.Cleanup(func() { defaultScryptParams = params })
+}
+
+func TestEncryptedFilePV(t *testing.T) {
+	useLightScrypt(t)
+	passphrase := []byte("correct horse battery staple")
+
+	for _, keyType := range []string{types.ABCIPubKeyTypeStark, types.ABCIPubKeyTypeEd25519, types.ABCIPubKeyTypeSecp256k1} {
+		dir := t.TempDir()
+		keyFile, stateFile := filepath.Join(dir, "key.json"), filepath.Join(dir, "state.json")
+		pv, err := GenFilePV(keyFile, stateFile, keyType)
+		require.NoError(t, err)
+		pv.Key.SetPassphrase(passphrase)
+		pv.LastSignState.Height = 10
+		pv.Save()
+
+		bz, err := os.ReadFile(keyFile)
+		require.NoError(t, err)
+		assert.NotContains(t, string(bz), base64.StdEncoding.EncodeToString(pv.Key.PrivKey.Bytes()),
+			"%s: the private key should not be saved in the clear", keyType)
+
+		_, err = LoadFilePV(keyFile, stateFile)
+		assert.ErrorIs(t, err, ErrKeyEncrypted, keyType)
+		_, err = LoadFilePVWithPassphrase(keyFile, stateFile, []byte("wrong"))
+		assert.ErrorIs(t, err, ErrWrongPassphrase, keyType)
+
+		loaded, err := LoadFilePVWithPassphrase(keyFile, stateFile, passphrase)
+		require.NoError(t, err, keyType)
+		assert.Equal(t, pv.Key.PrivKey, loaded.Key.PrivKey, keyType)
+		assert.Equal(t, pv.Key.Address, loaded.Key.Address, keyType)
+		assert.EqualValues(t, 10, loaded.LastSignState.Height, keyType)
+		assert.True(t, loaded.Key.IsEncrypted(), keyType)
+
+		// a loaded key stays encrypted when saved
+		loaded.Reset()
+		_, err = LoadFilePV(keyFile, stateFile)
+		assert.ErrorIs(t, err, ErrKeyEncrypted, keyType)
+
+		loaded.Key.SetPassphrase(nil)
+		loaded.Save()
+		loaded, err = LoadFilePV(keyFile, stateFile)
+		require.NoError(t, err, keyType)
+		assert.Equal(t, pv.Key.PrivKey, loaded.Key.PrivKey, keyType)
+	}
+}
+
+func TestLoadFilePVWithPassphrasePlaintext(t *testing.T) {
+	dir := t.TempDir()
+	keyFile, stateFile := filepath.Join(dir, "key.json"), filepath.Join(dir, "state.json")
+	pv, err := GenFilePV(keyFile, stateFile, "")
+	require.NoError(t, err)
+	pv.Save()
+
+	// a plaintext key is loaded as it is, and stays in the clear
+	loaded, err := LoadFilePVWithPassphrase(keyFile, stateFile, []byte("passphrase"))
+	require.NoError(t, err)
+	assert.Equal(t, pv.Key.PrivKey, loaded.Key.PrivKey)
+	assert.False(t, loaded.Key.IsEncrypted())
+}
+
+func TestLoadOrGenFilePVWithPassphrase(t *testing.T) {
+	useLightScrypt(t)
+	dir := t.TempDir()
+	keyFile, stateFile := filepath.Join(dir, "key.json"), filepath.Join(dir, "state.json")
+	passphrase := []byte("passphrase")
+
+	pv, err := LoadOrGenFilePVWithPassphrase(keyFile, stateFile, passphrase)
+	require.NoError(t, err)
+	assert.True(t, pv.Key.IsEncrypted())
+
+	loaded, err := LoadOrGenFilePVWithPassphrase(keyFile, stateFile, passphrase)
+	require.NoError(t, err)
+	assert.Equal(t, pv.Key.PrivKey, loaded.Key.PrivKey)
+
+	_, err = LoadOrGenFilePV(keyFile, stateFile)
+	assert.ErrorIs(t, err, ErrKeyEncrypted)
+}
+
+func TestDecryptFilePVKey(t *testing.T) {
+	passphrase := []byte("passphrase")
+	pv, err := GenFilePV("", "", "")
+	require.NoError(t, err)
+	other, err := GenFilePV("", "", "")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		malleate func(key *encryptedFilePVKey)
+		expErr   bool
+	}{
+		0: {func(key *encryptedFilePVKey) {}, false},
+		1: {func(key *encryptedFilePVKey) { key.Address = other.Key.Address }, true},
+		2: {func(key *encryptedFilePVKey) { key.Crypto.KDF = "pbkdf2" }, true},
+		3: {func(key *encryptedFilePVKey) { key.Crypto.Cipher = "aes-128-ctr" }, true},
+		4: {func(key *encryptedFilePVKey) { key.Crypto.Nonce = key.Crypto.Nonce[1:] }, true},
+		5: {func(key *encryptedFilePVKey) { key.Crypto.Ciphertext[0] ^= 1 }, true},
+		6: {func(key *encryptedFilePVKey) { key.Crypto.KDFParams.Salt[0] ^= 1 }, true},
+		7: {func(key *encryptedFilePVKey) { key.Crypto.KDFParams.N = 3 }, true},
+		8: {func(key *encryptedFilePVKey) { key.Crypto.KDFParams.N = 1 << 40 }, true},
+		9: {func(key *encryptedFilePVKey) { key.Crypto.KDFParams.R = 0 }, true},
+	}
+
+	for i, tc := range testCases {
+		bz, err := encryptFilePVKey(pv.Key, passphrase, scryptParams{N: 1 << 10, R: 8, P: 1})
+		require.NoError(t, err, "testCase%d failed", i)
+		require.True(t, isEncryptedKey(bz), "testCase%d failed", i)
+
+		var key encryptedFilePVKey
+		require.NoError(t, tmjson.Unmarshal(bz, &key), "testCase%d failed", i)
+		tc.malleate(&key)
+		bz, err = tmjson.Marshal(key)
+		require.NoError(t, err, "testCase%d failed", i)
+
+		decrypted, err := decryptFilePVKey(bz, passphrase)
+		if tc.expErr {
+			assert.Error(t, err, "testCase%d failed", i)
+			continue
+		}
+		require.NoError(t, err, "testCase%d failed", i)
+		assert.Equal(t, pv.Key.PrivKey, decrypted.PrivKey, "testCase%d failed", i)
+		assert.Equal(t, pv.Key.PubKey, decrypted.PubKey, "testCase%d failed", i)
+	}
+
+	plaintext, err := tmjson.Marshal(pv.Key)
+	require.NoError(t, err)
+	assert.False(t, isEncryptedKey(plaintext))
+}
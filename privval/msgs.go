@@ -32,6 +32,10 @@ func mustWrapMsg(pb proto.Message) privvalproto.Message {
 		msg.Sum = &privvalproto.Message_PingRequest{PingRequest: pb}
 	case *privvalproto.PingResponse:
 		msg.Sum = &privvalproto.Message_PingResponse{PingResponse: pb}
+	case *privvalproto.SignStarknetTransactionRequest:
+		msg.Sum = &privvalproto.Message_SignStarknetTransactionRequest{SignStarknetTransactionRequest: pb}
+	case *privvalproto.SignStarknetTransactionResponse:
+		msg.Sum = &privvalproto.Message_SignStarknetTransactionResponse{SignStarknetTransactionResponse: pb}
 	default:
 		panic(fmt.Errorf("unknown message type %T", pb))
 	}
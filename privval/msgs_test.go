@@ -63,6 +63,21 @@ func TestPrivvalVectors(t *testing.T) {
 	proposal := exampleProposal()
 	proposalpb := proposal.ToProto()
 
+	// Generate a simple starknet transaction
+	starknetTx := &privproto.StarknetInvokeTransaction{
+		SenderAddress: []byte{0x0a, 0xcc},
+		Calls: []privproto.StarknetFunctionCall{{
+			ContractAddress:    []byte{0xc0, 0xde},
+			EntryPointSelector: []byte{0x01},
+			Calldata:           [][]byte{{0x02}, {0x03}},
+		}},
+		MaxFee:          []byte{0x04},
+		Nonce:           []byte{0x05},
+		StarknetChainId: []byte{0x06},
+	}
+	signedStarknetTx := *starknetTx
+	signedStarknetTx.Signature = [][]byte{{0x07}, {0x08}}
+
 	// Create a Reuseable remote error
 	remoteError := &privproto.RemoteSignerError{Code: 1, Description: "it's a error"}
 
@@ -82,6 +97,9 @@ func TestPrivvalVectors(t *testing.T) {
 		{"Proposal Request", &privproto.SignProposalRequest{Proposal: proposalpb}, "2a700a6e08011003180220022a4a0a2003e7a58a7e2d9ebe51da3a5fe11c4795b464e84d9b29e293efa41331bae8e094122608c0843d122001031d61b8e12a45dfdda50a056cbd5281f024574a304d4bb53baa7a3bfa5599320608f49a8ded053a10697427732061207369676e6174757265"},
 		{"Proposal Response", &privproto.SignedProposalResponse{Proposal: *proposalpb, Error: nil}, "32700a6e08011003180220022a4a0a2003e7a58a7e2d9ebe51da3a5fe11c4795b464e84d9b29e293efa41331bae8e094122608c0843d122001031d61b8e12a45dfdda50a056cbd5281f024574a304d4bb53baa7a3bfa5599320608f49a8ded053a10697427732061207369676e6174757265"},
 		{"Proposal Response with error", &privproto.SignedProposalResponse{Proposal: tmproto.Proposal{}, Error: remoteError}, "32250a112a021200320b088092b8c398feffffff0112100801120c697427732061206572726f72"},
+		{"Starknet Transaction Request", &privproto.SignStarknetTransactionRequest{Transaction: starknetTx, ChainId: "chain"}, "4a250a1c0a020acc120d0a02c0de1201011a01021a01031a01042201052a01061205636861696e"},
+		{"Starknet Transaction Response", &privproto.SignStarknetTransactionResponse{Transaction: signedStarknetTx, Error: nil}, "52240a220a020acc120d0a02c0de1201011a01021a01031a01042201052a0106320107320108"},
+		{"Starknet Transaction Response with error", &privproto.SignStarknetTransactionResponse{Error: remoteError}, "52140a0012100801120c697427732061206572726f72"},
 	}
 
 	for _, tc := range testCases {
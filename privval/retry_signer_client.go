@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/tendermint/tendermint/crypto"
+	privvalproto "github.com/tendermint/tendermint/proto/tendermint/privval"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	"github.com/tendermint/tendermint/types"
 )
@@ -24,7 +25,10 @@ func NewRetrySignerClient(sc *SignerClient, retries int, timeout time.Duration)
 	return &RetrySignerClient{sc, retries, timeout}
 }
 
-var _ types.PrivValidator = (*RetrySignerClient)(nil)
+var (
+	_ types.PrivValidator  = (*RetrySignerClient)(nil)
+	_ types.StarknetSigner = (*RetrySignerClient)(nil)
+)
 
 func (sc *RetrySignerClient) Close() error {
 	return sc.next.Close()
@@ -102,3 +106,23 @@ func (sc *RetrySignerClient) SignProposal(ctx context.Context, chainID string, p
 	}
 	return fmt.Errorf("exhausted all attempts to sign proposal: %w", err)
 }
+
+func (sc *RetrySignerClient) SignStarknetTransaction(
+	ctx context.Context,
+	chainID string,
+	tx *privvalproto.StarknetInvokeTransaction,
+) error {
+	var err error
+	for i := 0; i < sc.retries || sc.retries == 0; i++ {
+		err = sc.next.SignStarknetTransaction(ctx, chainID, tx)
+		if err == nil {
+			return nil
+		}
+		// If remote signer errors, we don't retry.
+		if _, ok := err.(*RemoteSignerError); ok {
+			return err
+		}
+		time.Sleep(sc.timeout)
+	}
+	return fmt.Errorf("exhausted all attempts to sign starknet transaction: %w", err)
+}
@@ -19,7 +19,10 @@ type SignerClient struct {
 	chainID  string
 }
 
-var _ types.PrivValidator = (*SignerClient)(nil)
+var (
+	_ types.PrivValidator  = (*SignerClient)(nil)
+	_ types.StarknetSigner = (*SignerClient)(nil)
+)
 
 // NewSignerClient returns an instance of SignerClient.
 // it will start the endpoint (if not already started)
@@ -132,3 +135,30 @@ func (sc *SignerClient) SignProposal(ctx context.Context, chainID string, propos
 
 	return nil
 }
+
+// SignStarknetTransaction requests a remote signer to sign a transaction of
+// the settlement account
+func (sc *SignerClient) SignStarknetTransaction(
+	ctx context.Context,
+	chainID string,
+	tx *privvalproto.StarknetInvokeTransaction,
+) error {
+	response, err := sc.endpoint.SendRequest(mustWrapMsg(
+		&privvalproto.SignStarknetTransactionRequest{Transaction: tx, ChainId: chainID},
+	))
+	if err != nil {
+		return err
+	}
+
+	resp := response.GetSignStarknetTransactionResponse()
+	if resp == nil {
+		return ErrUnexpectedResponse
+	}
+	if resp.Error != nil {
+		return &RemoteSignerError{Code: int(resp.Error.Code), Description: resp.Error.Description}
+	}
+
+	tx.Signature = resp.Transaction.Signature
+
+	return nil
+}
@@ -85,6 +85,34 @@ func DefaultValidationRequestHandler(
 		} else {
 			res = mustWrapMsg(&privvalproto.SignedProposalResponse{Proposal: *proposal, Error: nil})
 		}
+	case *privvalproto.Message_SignStarknetTransactionRequest:
+		if r.SignStarknetTransactionRequest.GetChainId() != chainID {
+			res = mustWrapMsg(&privvalproto.SignStarknetTransactionResponse{
+				Error: &privvalproto.RemoteSignerError{
+					Code:        0,
+					Description: "unable to sign starknet transaction"}})
+			return res, fmt.Errorf("want chainID: %s, got chainID: %s",
+				r.SignStarknetTransactionRequest.GetChainId(), chainID)
+		}
+
+		starknetSigner, ok := privVal.(types.StarknetSigner)
+		if !ok {
+			res = mustWrapMsg(&privvalproto.SignStarknetTransactionResponse{
+				Error: &privvalproto.RemoteSignerError{
+					Code:        0,
+					Description: "signer does not sign starknet transactions"}})
+			return res, fmt.Errorf("%T does not sign starknet transactions", privVal)
+		}
+
+		tx := r.SignStarknetTransactionRequest.Transaction
+
+		err = starknetSigner.SignStarknetTransaction(ctx, chainID, tx)
+		if err != nil {
+			res = mustWrapMsg(&privvalproto.SignStarknetTransactionResponse{
+				Error: &privvalproto.RemoteSignerError{Code: 0, Description: err.Error()}})
+		} else {
+			res = mustWrapMsg(&privvalproto.SignStarknetTransactionResponse{Transaction: *tx, Error: nil})
+		}
 	case *privvalproto.Message_PingRequest:
 		err, res = nil, mustWrapMsg(&privvalproto.PingResponse{})
 
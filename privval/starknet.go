@@ -0,0 +1,155 @@
+package privval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/tendermint/tendermint/internal/settlement/starknet"
+	privvalproto "github.com/tendermint/tendermint/proto/tendermint/privval"
+)
+
+// anyFunction allows all the functions of a contract in a StarknetAllowList.
+const anyFunction = "*"
+
+// StarknetAllowList is the set of calls the Starknet transactions signed by
+// a validator may make, so that a compromised node cannot spend the funds of
+// the settlement account. It allows nothing when empty.
+type StarknetAllowList struct {
+	// contract address -> allowed selectors, nil if all are allowed
+	contracts map[string]map[string]bool
+}
+
+// ParseStarknetAllowList parses entries of the form
+// "<contract address>:<function name>", or "<contract address>:*" to allow
+// all the functions of a contract.
+func ParseStarknetAllowList(entries []string) (*StarknetAllowList, error) {
+	l := &StarknetAllowList{contracts: make(map[string]map[string]bool)}
+	for _, entry := range entries {
+		parts := strings.Split(entry, ":")
+		if len(parts) != 2 || parts[1] == "" {
+			return nil, fmt.Errorf("invalid starknet allow-list entry %q, want <contract address>:<function>", entry)
+		}
+		address, err := starknet.ParseFelt(strings.TrimSpace(parts[0]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid starknet allow-list entry %q: %w", entry, err)
+		}
+
+		contract := starknet.FeltHex(address)
+		function := strings.TrimSpace(parts[1])
+		selectors, exists := l.contracts[contract]
+		switch {
+		case function == anyFunction:
+			l.contracts[contract] = nil
+		case exists && selectors == nil:
+			// all the functions are allowed already
+		default:
+			if selectors == nil {
+				selectors = make(map[string]bool)
+				l.contracts[contract] = selectors
+			}
+			selectors[starknet.FeltHex(starknet.Selector(function))] = true
+		}
+	}
+	return l, nil
+}
+
+// Allows reports whether call is in the allow-list.
+func (l *StarknetAllowList) Allows(call starknet.FunctionCall) bool {
+	if l == nil {
+		return false
+	}
+	selectors, ok := l.contracts[starknet.FeltHex(call.ContractAddress)]
+	if !ok {
+		return false
+	}
+	return selectors == nil || selectors[starknet.FeltHex(call.EntryPointSelector)]
+}
+
+// StarknetLimits pins the account and the chain of the Starknet transactions
+// signed by a validator, and caps their fee, so that a compromised node can
+// neither replay the signature on another account or chain, nor burn the
+// funds of the settlement account in fees. It allows nothing when nil.
+type StarknetLimits struct {
+	AccountAddress *big.Int
+	ChainID        *big.Int
+	// maximum fee of a transaction, in wei
+	MaxFee *big.Int
+}
+
+// ParseStarknetLimits parses the account address and the chain id, hex or
+// decimal felts, and the maximum fee in wei of the Starknet transactions a
+// validator signs. All are required.
+func ParseStarknetLimits(accountAddress, chainID, maxFee string) (*StarknetLimits, error) {
+	if accountAddress == "" || chainID == "" || maxFee == "" {
+		return nil, errors.New("the starknet account address, chain id and max fee must all be set")
+	}
+	l := new(StarknetLimits)
+	var err error
+	if l.AccountAddress, err = starknet.ParseFelt(accountAddress); err != nil {
+		return nil, fmt.Errorf("invalid starknet account address: %w", err)
+	}
+	if l.ChainID, err = starknet.ParseFelt(chainID); err != nil {
+		return nil, fmt.Errorf("invalid starknet chain id: %w", err)
+	}
+	fee, ok := new(big.Int).SetString(maxFee, 0)
+	if !ok || fee.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid starknet max fee %q", maxFee)
+	}
+	l.MaxFee = fee
+	return l, nil
+}
+
+// Check returns an error if tx is not sent by the pinned account on the
+// pinned chain, or may cost more than the maximum fee.
+func (l *StarknetLimits) Check(tx *starknet.Invoke) error {
+	switch {
+	case l == nil:
+		return errors.New("no starknet account is pinned")
+	case tx.SenderAddress.Cmp(l.AccountAddress) != 0:
+		return fmt.Errorf("transaction is sent by account %s, not %s",
+			starknet.FeltHex(tx.SenderAddress), starknet.FeltHex(l.AccountAddress))
+	case tx.ChainID.Cmp(l.ChainID) != 0:
+		return fmt.Errorf("transaction is for chain %s, not %s",
+			starknet.FeltHex(tx.ChainID), starknet.FeltHex(l.ChainID))
+	case tx.MaxFee.Cmp(l.MaxFee) > 0:
+		return fmt.Errorf("transaction max fee %s exceeds the cap of %s", tx.MaxFee, l.MaxFee)
+	}
+	return nil
+}
+
+// signStarknetTransaction checks that tx is within limits and its calls are
+// allowed, and sets its signature.
+func signStarknetTransaction(
+	ctx context.Context,
+	signer starknet.Signer,
+	allowed *StarknetAllowList,
+	limits *StarknetLimits,
+	tx *privvalproto.StarknetInvokeTransaction,
+) error {
+	invoke, err := starknet.InvokeFromProto(tx)
+	if err != nil {
+		return err
+	}
+	if err := limits.Check(invoke); err != nil {
+		return err
+	}
+	if len(invoke.Calls) == 0 {
+		return errors.New("transaction makes no calls")
+	}
+	for _, call := range invoke.Calls {
+		if !allowed.Allows(call) {
+			return fmt.Errorf("call of %s on contract %s is not allowed",
+				starknet.FeltHex(call.EntryPointSelector), starknet.FeltHex(call.ContractAddress))
+		}
+	}
+
+	signature, err := signer.SignInvoke(ctx, invoke)
+	if err != nil {
+		return err
+	}
+	tx.Signature = starknet.SignatureToProto(signature)
+	return nil
+}
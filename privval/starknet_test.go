@@ -0,0 +1,216 @@
+package privval
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/stark"
+	"github.com/tendermint/tendermint/internal/settlement/starknet"
+	"github.com/tendermint/tendermint/types"
+)
+
+var (
+	testVerifier = big.NewInt(0xc0de)
+	testToken    = big.NewInt(0x70c3)
+)
+
+// newStarknetFilePV returns a FilePV signing the starknet transactions of
+// account 0xacc on SN_GOERLI, of a fee up to 1000, which settle commits on
+// testVerifier, or call any function of testToken.
+func newStarknetFilePV(t *testing.T) (*FilePV, stark.PrivKey) {
+	t.Helper()
+	dir := t.TempDir()
+	pv, err := GenFilePV(filepath.Join(dir, "key.json"), filepath.Join(dir, "state.json"), "")
+	require.NoError(t, err)
+
+	key := stark.GenPrivKey()
+	keyFile := filepath.Join(dir, "pkey")
+	require.NoError(t, os.WriteFile(keyFile, []byte(starknet.FeltHex(new(big.Int).SetBytes(key))+"\n"), 0600))
+	limits, err := ParseStarknetLimits("0xacc", starknet.FeltHex(starknet.ShortString("SN_GOERLI")), "1000")
+	require.NoError(t, err)
+	require.NoError(t, pv.LoadStarknetKey(keyFile, []string{
+		starknet.FeltHex(testVerifier) + ":externalVerifyAdjacent",
+		starknet.FeltHex(testVerifier) + ":externalVerifyNonAdjacent",
+		starknet.FeltHex(testToken) + ":*",
+	}, limits))
+	return pv, key
+}
+
+// newStarknetTransaction returns a transaction calling function on contract.
+func newStarknetTransaction(contract *big.Int, function string) *starknet.Invoke {
+	return &starknet.Invoke{
+		SenderAddress: big.NewInt(0xacc),
+		Calls: []starknet.FunctionCall{{
+			ContractAddress:    contract,
+			EntryPointSelector: starknet.Selector(function),
+			Calldata:           []*big.Int{big.NewInt(1), big.NewInt(2)},
+		}},
+		MaxFee:  big.NewInt(1000),
+		Nonce:   big.NewInt(3),
+		ChainID: starknet.ShortString("SN_GOERLI"),
+	}
+}
+
+// verifyStarknetSignature checks that signature is the signature of tx by
+// key.
+func verifyStarknetSignature(t *testing.T, key stark.PrivKey, tx *starknet.Invoke, signature [][]byte) {
+	t.Helper()
+	require.Len(t, signature, 2)
+	sig, err := starknet.SignatureFromProto(signature)
+	require.NoError(t, err)
+	pub := key.MakeFull().PublicKey
+	assert.True(t, stark.Verify(&pub, tx.Hash().FillBytes(make([]byte, 32)), sig[0], sig[1]),
+		"invalid transaction signature")
+}
+
+func TestParseStarknetAllowList(t *testing.T) {
+	verifier := starknet.FeltHex(testVerifier)
+	testCases := []struct {
+		entries []string
+		call    starknet.FunctionCall
+		allowed bool
+		expErr  bool
+	}{
+		0: {nil, starknet.FunctionCall{ContractAddress: testVerifier, EntryPointSelector: starknet.Selector("f")}, false, false},
+		1: {[]string{verifier + ":f"}, starknet.FunctionCall{ContractAddress: testVerifier, EntryPointSelector: starknet.Selector("f")}, true, false},
+		2: {[]string{verifier + ":f"}, starknet.FunctionCall{ContractAddress: testVerifier, EntryPointSelector: starknet.Selector("g")}, false, false},
+		3: {[]string{verifier + ":f"}, starknet.FunctionCall{ContractAddress: testToken, EntryPointSelector: starknet.Selector("f")}, false, false},
+		4: {[]string{verifier + ":*"}, starknet.FunctionCall{ContractAddress: testVerifier, EntryPointSelector: starknet.Selector("g")}, true, false},
+		5: {[]string{verifier + ":*", verifier + ":f"}, starknet.FunctionCall{ContractAddress: testVerifier, EntryPointSelector: starknet.Selector("g")}, true, false},
+		// addresses are compared as felts
+		6:  {[]string{"0x000C0DE:f"}, starknet.FunctionCall{ContractAddress: testVerifier, EntryPointSelector: starknet.Selector("f")}, true, false},
+		7:  {[]string{"49374:f"}, starknet.FunctionCall{ContractAddress: testVerifier, EntryPointSelector: starknet.Selector("f")}, true, false},
+		8:  {[]string{verifier}, starknet.FunctionCall{}, false, true},
+		9:  {[]string{verifier + ":"}, starknet.FunctionCall{}, false, true},
+		10: {[]string{"0xzz:f"}, starknet.FunctionCall{}, false, true},
+		11: {[]string{verifier + ":f:g"}, starknet.FunctionCall{}, false, true},
+	}
+
+	for i, tc := range testCases {
+		l, err := ParseStarknetAllowList(tc.entries)
+		if tc.expErr {
+			assert.Error(t, err, "testCase%d failed", i)
+			continue
+		}
+		require.NoError(t, err, "testCase%d failed", i)
+		assert.Equal(t, tc.allowed, l.Allows(tc.call), "testCase%d failed", i)
+	}
+}
+
+func TestParseStarknetLimits(t *testing.T) {
+	testCases := []struct {
+		account, chainID, maxFee string
+		expErr                   bool
+	}{
+		0: {"0xacc", "0x534e5f474f45524c49", "1000", false},
+		1: {"2764", "1", "0x3e8", false},
+		2: {"", "0x1", "1000", true},
+		3: {"0xacc", "", "1000", true},
+		4: {"0xacc", "0x1", "", true},
+		5: {"0xzz", "0x1", "1000", true},
+		6: {"0xacc", "0x1", "-1", true},
+		7: {"0xacc", "0x1", "0", true},
+		8: {"0xacc", "0x1", "fee", true},
+	}
+
+	for i, tc := range testCases {
+		l, err := ParseStarknetLimits(tc.account, tc.chainID, tc.maxFee)
+		if tc.expErr {
+			assert.Error(t, err, "testCase%d failed", i)
+			continue
+		}
+		require.NoError(t, err, "testCase%d failed", i)
+		assert.EqualValues(t, 0xacc, l.AccountAddress.Int64(), "testCase%d failed", i)
+		assert.EqualValues(t, 1000, l.MaxFee.Int64(), "testCase%d failed", i)
+	}
+}
+
+func TestFilePVSignStarknetTransaction(t *testing.T) {
+	ctx := context.Background()
+	pv, key := newStarknetFilePV(t)
+
+	for _, function := range []string{"externalVerifyAdjacent", "externalVerifyNonAdjacent"} {
+		tx := newStarknetTransaction(testVerifier, function)
+		pb := tx.ToProto()
+		require.NoError(t, pv.SignStarknetTransaction(ctx, "chain", pb))
+		verifyStarknetSignature(t, key, tx, pb.Signature)
+	}
+
+	// all the calls of a transaction must be allowed
+	tx := newStarknetTransaction(testToken, "transfer")
+	require.NoError(t, pv.SignStarknetTransaction(ctx, "chain", tx.ToProto()))
+	tx.Calls = append(tx.Calls, newStarknetTransaction(testVerifier, "upgrade").Calls...)
+	pb := tx.ToProto()
+	assert.Error(t, pv.SignStarknetTransaction(ctx, "chain", pb))
+	assert.Nil(t, pb.Signature)
+
+	pb = newStarknetTransaction(testToken, "transfer").ToProto()
+	pb.Calls = nil
+	assert.Error(t, pv.SignStarknetTransaction(ctx, "chain", pb), "a transaction without calls should not be signed")
+
+	// the account, chain and max fee are limited
+	tx = newStarknetTransaction(testToken, "transfer")
+	tx.SenderAddress = big.NewInt(0xbad)
+	assert.Error(t, pv.SignStarknetTransaction(ctx, "chain", tx.ToProto()), "another account should be refused")
+	tx = newStarknetTransaction(testToken, "transfer")
+	tx.ChainID = starknet.ShortString("SN_MAIN")
+	assert.Error(t, pv.SignStarknetTransaction(ctx, "chain", tx.ToProto()), "another chain should be refused")
+	tx = newStarknetTransaction(testToken, "transfer")
+	tx.MaxFee = big.NewInt(1001)
+	assert.Error(t, pv.SignStarknetTransaction(ctx, "chain", tx.ToProto()), "a fee over the cap should be refused")
+
+	pb = newStarknetTransaction(testToken, "transfer").ToProto()
+	pb.MaxFee = make([]byte, 33)
+	assert.Error(t, pv.SignStarknetTransaction(ctx, "chain", pb), "values should be felts")
+	assert.Error(t, pv.SignStarknetTransaction(ctx, "chain", nil))
+
+	// without a starknet key
+	pv, err := GenFilePV(filepath.Join(t.TempDir(), "key.json"), filepath.Join(t.TempDir(), "state.json"), "")
+	require.NoError(t, err)
+	assert.Error(t, pv.SignStarknetTransaction(ctx, "chain", newStarknetTransaction(testToken, "transfer").ToProto()))
+}
+
+func TestSignerSignStarknetTransaction(t *testing.T) {
+	for _, dtc := range getDialerTestCases(t) {
+		chainID := "test-chain"
+		pv, key := newStarknetFilePV(t)
+
+		sl, sd := getMockEndpoints(t, dtc.addr, dtc.dialer)
+		sc, err := NewSignerClient(sl, chainID)
+		require.NoError(t, err)
+		ss := NewSignerServer(sd, chainID, pv)
+		require.NoError(t, ss.Start())
+
+		t.Cleanup(func() {
+			if err := ss.Stop(); err != nil {
+				t.Error(err)
+			}
+		})
+		t.Cleanup(func() {
+			if err := sc.Close(); err != nil {
+				t.Error(err)
+			}
+		})
+
+		ctx := context.Background()
+		tx := newStarknetTransaction(testVerifier, "externalVerifyAdjacent")
+		pb := tx.ToProto()
+		require.NoError(t, sc.SignStarknetTransaction(ctx, chainID, pb))
+		verifyStarknetSignature(t, key, tx, pb.Signature)
+
+		err = sc.SignStarknetTransaction(ctx, chainID, newStarknetTransaction(testVerifier, "upgrade").ToProto())
+		require.IsType(t, &RemoteSignerError{}, err)
+		assert.Contains(t, err.(*RemoteSignerError).Description, "is not allowed")
+
+		// signers which hold no starknet key refuse the request
+		ss.privVal = types.NewMockPV()
+		err = sc.SignStarknetTransaction(ctx, chainID, tx.ToProto())
+		require.IsType(t, &RemoteSignerError{}, err)
+	}
+}
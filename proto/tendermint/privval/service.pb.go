@@ -27,23 +27,25 @@ const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 func init() { proto.RegisterFile("tendermint/privval/service.proto", fileDescriptor_7afe74f9f46d3dc9) }
 
 var fileDescriptor_7afe74f9f46d3dc9 = []byte{
-	// 251 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x52, 0x28, 0x49, 0xcd, 0x4b,
-	0x49, 0x2d, 0xca, 0xcd, 0xcc, 0x2b, 0xd1, 0x2f, 0x28, 0xca, 0x2c, 0x2b, 0x4b, 0xcc, 0xd1, 0x2f,
-	0x4e, 0x2d, 0x2a, 0xcb, 0x4c, 0x4e, 0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x12, 0x42, 0xa8,
-	0xd0, 0x83, 0xaa, 0x90, 0x92, 0xc3, 0xa2, 0xab, 0xa4, 0xb2, 0x20, 0xb5, 0x18, 0xa2, 0xc7, 0x68,
-	0x09, 0x13, 0x97, 0x40, 0x40, 0x51, 0x66, 0x59, 0x58, 0x62, 0x4e, 0x66, 0x4a, 0x62, 0x49, 0x7e,
-	0x91, 0x63, 0x80, 0xa7, 0x50, 0x10, 0x17, 0xa7, 0x7b, 0x6a, 0x49, 0x40, 0x69, 0x92, 0x77, 0x6a,
-	0xa5, 0x90, 0xa2, 0x1e, 0xa6, 0xb1, 0x7a, 0x10, 0xb9, 0xa0, 0xd4, 0xc2, 0xd2, 0xd4, 0xe2, 0x12,
-	0x29, 0x25, 0x7c, 0x4a, 0x8a, 0x0b, 0xf2, 0xf3, 0x8a, 0x53, 0x85, 0xc2, 0xb9, 0x38, 0x82, 0x33,
-	0xd3, 0xf3, 0xc2, 0xf2, 0x4b, 0x52, 0x85, 0x94, 0xb1, 0xa9, 0x87, 0xc9, 0xc2, 0x0c, 0x55, 0xc3,
-	0xa5, 0x28, 0x35, 0x05, 0xa2, 0x0c, 0x6a, 0x70, 0x32, 0x17, 0x0f, 0x48, 0x34, 0xa0, 0x28, 0xbf,
-	0x20, 0xbf, 0x38, 0x31, 0x47, 0x48, 0x1d, 0x97, 0x3e, 0x98, 0x0a, 0x98, 0x05, 0x5a, 0xb8, 0x2d,
-	0x40, 0x28, 0x85, 0x58, 0xe2, 0x14, 0x7c, 0xe2, 0x91, 0x1c, 0xe3, 0x85, 0x47, 0x72, 0x8c, 0x0f,
-	0x1e, 0xc9, 0x31, 0x4e, 0x78, 0x2c, 0xc7, 0x70, 0xe1, 0xb1, 0x1c, 0xc3, 0x8d, 0xc7, 0x72, 0x0c,
-	0x51, 0x96, 0xe9, 0x99, 0x25, 0x19, 0xa5, 0x49, 0x7a, 0xc9, 0xf9, 0xb9, 0xfa, 0x48, 0x61, 0x8d,
-	0x12, 0xec, 0xf9, 0x25, 0xf9, 0xfa, 0x98, 0xf1, 0x90, 0xc4, 0x06, 0x96, 0x31, 0x06, 0x04, 0x00,
-	0x00, 0xff, 0xff, 0x42, 0x60, 0x24, 0x48, 0xda, 0x01, 0x00, 0x00,
+	// 287 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x92, 0xbd, 0x4a, 0xc4, 0x40,
+	0x14, 0x85, 0x37, 0x08, 0xa2, 0x83, 0x85, 0x4c, 0x23, 0x6c, 0x31, 0xf8, 0x03, 0x0a, 0x16, 0x09,
+	0xec, 0x56, 0x96, 0xda, 0x88, 0xd8, 0x84, 0x8d, 0xac, 0x60, 0x37, 0x49, 0x2e, 0xeb, 0x60, 0x76,
+	0x6e, 0x9c, 0xb9, 0x09, 0x6c, 0xeb, 0x13, 0xf8, 0x1a, 0xbe, 0x89, 0xe5, 0x96, 0x96, 0x92, 0xbc,
+	0x88, 0xac, 0xc9, 0xb0, 0xca, 0x26, 0x82, 0xed, 0x9c, 0xef, 0x9c, 0x33, 0x70, 0x2e, 0x3b, 0x24,
+	0xd0, 0x29, 0x98, 0xb9, 0xd2, 0x14, 0xe4, 0x46, 0x95, 0xa5, 0xcc, 0x02, 0x0b, 0xa6, 0x54, 0x09,
+	0xf8, 0xb9, 0x41, 0x42, 0xce, 0xd7, 0x84, 0xdf, 0x12, 0x43, 0xd1, 0xe1, 0xa2, 0x45, 0x0e, 0xb6,
+	0xf1, 0x8c, 0xde, 0xb6, 0xd8, 0x7e, 0x68, 0x54, 0x39, 0x95, 0x99, 0x4a, 0x25, 0xa1, 0xb9, 0x0c,
+	0x6f, 0xf8, 0x84, 0xed, 0x5e, 0x03, 0x85, 0x45, 0x7c, 0x0b, 0x0b, 0x7e, 0xe4, 0x6f, 0xc6, 0xfa,
+	0x8d, 0x36, 0x81, 0xe7, 0x02, 0x2c, 0x0d, 0x8f, 0xff, 0x42, 0x6c, 0x8e, 0xda, 0x02, 0xbf, 0x67,
+	0x3b, 0x91, 0x9a, 0xe9, 0x29, 0x12, 0xf0, 0x93, 0x2e, 0xde, 0xa9, 0x2e, 0xf4, 0xb4, 0x0f, 0x82,
+	0xb4, 0xc1, 0xda, 0xe0, 0x84, 0xed, 0xad, 0x5e, 0x43, 0x83, 0x39, 0x5a, 0x99, 0xf1, 0xb3, 0x3e,
+	0x9f, 0x23, 0x5c, 0xc1, 0x79, 0x7f, 0xc1, 0x1a, 0x6d, 0x4b, 0x5e, 0x3c, 0x76, 0xb0, 0x92, 0x22,
+	0x92, 0xe6, 0x49, 0x03, 0xdd, 0x19, 0xa9, 0xad, 0x4c, 0x48, 0xa1, 0xe6, 0xa3, 0xbe, 0x9c, 0x0e,
+	0xd8, 0x75, 0x8f, 0xff, 0xe5, 0x69, 0x3e, 0x71, 0x15, 0xbd, 0x57, 0xc2, 0x5b, 0x56, 0xc2, 0xfb,
+	0xac, 0x84, 0xf7, 0x5a, 0x8b, 0xc1, 0xb2, 0x16, 0x83, 0x8f, 0x5a, 0x0c, 0x1e, 0x2e, 0x66, 0x8a,
+	0x1e, 0x8b, 0xd8, 0x4f, 0x70, 0x1e, 0xfc, 0x18, 0xfc, 0xd7, 0xf6, 0x48, 0x18, 0x6c, 0x1e, 0x43,
+	0xbc, 0xfd, 0xad, 0x8c, 0xbf, 0x06, 0x00, 0x8b, 0x1c, 0x92, 0x83, 0x5f, 0x02, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -61,6 +63,7 @@ type PrivValidatorAPIClient interface {
 	GetPubKey(ctx context.Context, in *PubKeyRequest, opts ...grpc.CallOption) (*PubKeyResponse, error)
 	SignVote(ctx context.Context, in *SignVoteRequest, opts ...grpc.CallOption) (*SignedVoteResponse, error)
 	SignProposal(ctx context.Context, in *SignProposalRequest, opts ...grpc.CallOption) (*SignedProposalResponse, error)
+	SignStarknetTransaction(ctx context.Context, in *SignStarknetTransactionRequest, opts ...grpc.CallOption) (*SignStarknetTransactionResponse, error)
 }
 
 type privValidatorAPIClient struct {
@@ -98,11 +101,21 @@ func (c *privValidatorAPIClient) SignProposal(ctx context.Context, in *SignPropo
 	return out, nil
 }
 
+func (c *privValidatorAPIClient) SignStarknetTransaction(ctx context.Context, in *SignStarknetTransactionRequest, opts ...grpc.CallOption) (*SignStarknetTransactionResponse, error) {
+	out := new(SignStarknetTransactionResponse)
+	err := c.cc.Invoke(ctx, "/tendermint.privval.PrivValidatorAPI/SignStarknetTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PrivValidatorAPIServer is the server API for PrivValidatorAPI service.
 type PrivValidatorAPIServer interface {
 	GetPubKey(context.Context, *PubKeyRequest) (*PubKeyResponse, error)
 	SignVote(context.Context, *SignVoteRequest) (*SignedVoteResponse, error)
 	SignProposal(context.Context, *SignProposalRequest) (*SignedProposalResponse, error)
+	SignStarknetTransaction(context.Context, *SignStarknetTransactionRequest) (*SignStarknetTransactionResponse, error)
 }
 
 // UnimplementedPrivValidatorAPIServer can be embedded to have forward compatible implementations.
@@ -118,6 +131,9 @@ func (*UnimplementedPrivValidatorAPIServer) SignVote(ctx context.Context, req *S
 func (*UnimplementedPrivValidatorAPIServer) SignProposal(ctx context.Context, req *SignProposalRequest) (*SignedProposalResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SignProposal not implemented")
 }
+func (*UnimplementedPrivValidatorAPIServer) SignStarknetTransaction(ctx context.Context, req *SignStarknetTransactionRequest) (*SignStarknetTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignStarknetTransaction not implemented")
+}
 
 func RegisterPrivValidatorAPIServer(s *grpc.Server, srv PrivValidatorAPIServer) {
 	s.RegisterService(&_PrivValidatorAPI_serviceDesc, srv)
@@ -177,6 +193,24 @@ func _PrivValidatorAPI_SignProposal_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PrivValidatorAPI_SignStarknetTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignStarknetTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrivValidatorAPIServer).SignStarknetTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tendermint.privval.PrivValidatorAPI/SignStarknetTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrivValidatorAPIServer).SignStarknetTransaction(ctx, req.(*SignStarknetTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _PrivValidatorAPI_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "tendermint.privval.PrivValidatorAPI",
 	HandlerType: (*PrivValidatorAPIServer)(nil),
@@ -193,6 +227,10 @@ var _PrivValidatorAPI_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SignProposal",
 			Handler:    _PrivValidatorAPI_SignProposal_Handler,
 		},
+		{
+			MethodName: "SignStarknetTransaction",
+			Handler:    _PrivValidatorAPI_SignStarknetTransaction_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "tendermint/privval/service.proto",
@@ -235,7 +235,8 @@ func (c *baseRPCClient) ABCIQueryWithOptions(
 	opts rpcclient.ABCIQueryOptions) (*coretypes.ResultABCIQuery, error) {
 	result := new(coretypes.ResultABCIQuery)
 	_, err := c.caller.Call(ctx, "abci_query",
-		map[string]interface{}{"path": path, "data": data, "height": opts.Height, "prove": opts.Prove},
+		map[string]interface{}{"path": path, "data": data, "height": opts.Height, "prove": opts.Prove,
+			"format": opts.Format},
 		result)
 	if err != nil {
 		return nil, err
@@ -585,6 +586,35 @@ func (c *baseRPCClient) Validators(
 	return result, nil
 }
 
+func (c *baseRPCClient) SettlementStatus(ctx context.Context, target string) (*coretypes.ResultSettlementStatus, error) {
+	result := new(coretypes.ResultSettlementStatus)
+	params := make(map[string]interface{})
+	if target != "" {
+		params["target"] = target
+	}
+	_, err := c.caller.Call(ctx, "settlement_status", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) SettlementTx(ctx context.Context, height *int64, target string) (*coretypes.ResultSettlementTx, error) {
+	result := new(coretypes.ResultSettlementTx)
+	params := make(map[string]interface{})
+	if height != nil {
+		params["height"] = height
+	}
+	if target != "" {
+		params["target"] = target
+	}
+	_, err := c.caller.Call(ctx, "settlement_tx", params, result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) BroadcastEvidence(
 	ctx context.Context,
 	ev types.Evidence,
@@ -52,6 +52,7 @@ type Client interface {
 	StatusClient
 	EvidenceClient
 	MempoolClient
+	SettlementClient
 }
 
 // ABCIClient groups together the functionality that principally affects the
@@ -157,6 +158,13 @@ type EvidenceClient interface {
 	BroadcastEvidence(context.Context, types.Evidence) (*coretypes.ResultBroadcastEvidence, error)
 }
 
+// SettlementClient is used to follow the settlement of the chain's commits
+// on Starknet. An empty target is the first settlement target of the node.
+type SettlementClient interface {
+	SettlementStatus(ctx context.Context, target string) (*coretypes.ResultSettlementStatus, error)
+	SettlementTx(ctx context.Context, height *int64, target string) (*coretypes.ResultSettlementTx, error)
+}
+
 // RemoteClient is a Client, which can also return the remote network address.
 type RemoteClient interface {
 	Client
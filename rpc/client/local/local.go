@@ -89,7 +89,7 @@ func (c *Local) ABCIQueryWithOptions(
 	path string,
 	data bytes.HexBytes,
 	opts rpcclient.ABCIQueryOptions) (*coretypes.ResultABCIQuery, error) {
-	return c.env.ABCIQuery(c.ctx, path, data, opts.Height, opts.Prove)
+	return c.env.ABCIQuery(c.ctx, path, data, opts.Height, opts.Prove, opts.Format)
 }
 
 func (c *Local) BroadcastTxCommit(ctx context.Context, tx types.Tx) (*coretypes.ResultBroadcastTxCommit, error) {
@@ -195,7 +195,7 @@ func (c *Local) Validators(ctx context.Context, height *int64, page, perPage *in
 }
 
 func (c *Local) Tx(ctx context.Context, hash bytes.HexBytes, prove bool) (*coretypes.ResultTx, error) {
-	return c.env.Tx(c.ctx, hash, prove)
+	return c.env.Tx(c.ctx, hash, prove, "")
 }
 
 func (c *Local) TxSearch(
@@ -222,6 +222,14 @@ func (c *Local) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*core
 	return c.env.BroadcastEvidence(c.ctx, ev)
 }
 
+func (c *Local) SettlementStatus(ctx context.Context, target string) (*coretypes.ResultSettlementStatus, error) {
+	return c.env.SettlementStatus(c.ctx, target)
+}
+
+func (c *Local) SettlementTx(ctx context.Context, height *int64, target string) (*coretypes.ResultSettlementTx, error) {
+	return c.env.SettlementTx(c.ctx, height, target)
+}
+
 func (c *Local) Subscribe(
 	ctx context.Context,
 	subscriber,
@@ -92,7 +92,7 @@ func (c Client) ABCIQueryWithOptions(
 	path string,
 	data bytes.HexBytes,
 	opts client.ABCIQueryOptions) (*coretypes.ResultABCIQuery, error) {
-	return c.env.ABCIQuery(&rpctypes.Context{}, path, data, opts.Height, opts.Prove)
+	return c.env.ABCIQuery(&rpctypes.Context{}, path, data, opts.Height, opts.Prove, opts.Format)
 }
 
 func (c Client) BroadcastTxCommit(ctx context.Context, tx types.Tx) (*coretypes.ResultBroadcastTxCommit, error) {
@@ -169,6 +169,14 @@ func (c Client) Validators(ctx context.Context, height *int64, page, perPage *in
 	return c.env.Validators(&rpctypes.Context{}, height, page, perPage)
 }
 
+func (c Client) SettlementStatus(ctx context.Context, target string) (*coretypes.ResultSettlementStatus, error) {
+	return c.env.SettlementStatus(&rpctypes.Context{}, target)
+}
+
+func (c Client) SettlementTx(ctx context.Context, height *int64, target string) (*coretypes.ResultSettlementTx, error) {
+	return c.env.SettlementTx(&rpctypes.Context{}, height, target)
+}
+
 func (c Client) BroadcastEvidence(ctx context.Context, ev types.Evidence) (*coretypes.ResultBroadcastEvidence, error) {
 	return c.env.BroadcastEvidence(&rpctypes.Context{}, ev)
 }
@@ -600,6 +600,52 @@ func (_m *Client) RemoveTx(_a0 context.Context, _a1 types.TxKey) error {
 	return r0
 }
 
+// SettlementStatus provides a mock function with given fields: ctx, target
+func (_m *Client) SettlementStatus(ctx context.Context, target string) (*coretypes.ResultSettlementStatus, error) {
+	ret := _m.Called(ctx, target)
+
+	var r0 *coretypes.ResultSettlementStatus
+	if rf, ok := ret.Get(0).(func(context.Context, string) *coretypes.ResultSettlementStatus); ok {
+		r0 = rf(ctx, target)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultSettlementStatus)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, target)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SettlementTx provides a mock function with given fields: ctx, height, target
+func (_m *Client) SettlementTx(ctx context.Context, height *int64, target string) (*coretypes.ResultSettlementTx, error) {
+	ret := _m.Called(ctx, height, target)
+
+	var r0 *coretypes.ResultSettlementTx
+	if rf, ok := ret.Get(0).(func(context.Context, *int64, string) *coretypes.ResultSettlementTx); ok {
+		r0 = rf(ctx, height, target)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.ResultSettlementTx)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *int64, string) error); ok {
+		r1 = rf(ctx, height, target)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Start provides a mock function with given fields:
 func (_m *Client) Start() error {
 	ret := _m.Called()
@@ -427,6 +427,43 @@ func TestBlockchainInfo(t *testing.T) {
 	}
 }
 
+func TestSettlement(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	n, conf := NodeSuite(t)
+
+	for i, c := range GetClients(t, n, conf) {
+		// the commit of height 2 is stored with block 3
+		err := client.WaitForHeight(c, 4, nil)
+		require.NoError(t, err)
+
+		var status *coretypes.ResultSettlementStatus
+		require.Eventually(t, func() bool {
+			status, err = c.SettlementStatus(ctx, "")
+			require.Nil(t, err, "%d: %+v", i, err)
+			return status.LastAcceptedHeight >= 2
+		}, 10*time.Second, 100*time.Millisecond)
+		assert.GreaterOrEqual(t, status.LastEnqueuedHeight, status.LastAcceptedHeight)
+		assert.Equal(t, config.DefaultSettlementTarget, status.Target)
+		assert.Equal(t, []string{config.DefaultSettlementTarget}, status.Targets)
+
+		height := int64(2)
+		tx, err := c.SettlementTx(ctx, &height, config.DefaultSettlementTarget)
+		require.Nil(t, err, "%d: %+v", i, err)
+		assert.EqualValues(t, 2, tx.Height)
+		assert.Equal(t, "accepted", tx.Status)
+		assert.NotEmpty(t, tx.Calldata)
+
+		height = status.LastEnqueuedHeight + 1000
+		_, err = c.SettlementTx(ctx, &height, "")
+		require.Error(t, err)
+
+		_, err = c.SettlementStatus(ctx, "unknown")
+		require.Error(t, err)
+	}
+}
+
 func TestBroadcastTxSync(t *testing.T) {
 	n, conf := NodeSuite(t)
 	ctx, cancel := context.WithCancel(context.Background())
@@ -624,6 +661,46 @@ func TestTx(t *testing.T) {
 	}
 }
 
+func TestTxFeltProof(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, conf := NodeSuite(t)
+	c := getHTTPClient(t, conf)
+	caller, err := rpcclient.New(conf.RPC.ListenAddress)
+	require.NoError(t, err)
+
+	_, _, tx := MakeTxKV()
+	bres, err := c.BroadcastTxCommit(ctx, tx)
+	require.NoError(t, err)
+	// the next block commits to the result
+	require.NoError(t, client.WaitForHeight(c, bres.Height+1, nil))
+
+	result := new(coretypes.ResultTx)
+	_, err = caller.Call(ctx, "tx", map[string]interface{}{"hash": bres.Hash, "prove": true, "format": "felt"}, result)
+	require.NoError(t, err)
+	require.NotNil(t, result.FeltProof)
+	require.NotNil(t, result.FeltResultProof)
+	assert.NotEmpty(t, result.FeltProof.Calldata)
+
+	for _, proof := range []types.FeltProof{result.FeltProof.Proof, result.FeltResultProof.Proof} {
+		block, err := c.Block(ctx, &proof.Height)
+		require.NoError(t, err)
+		assert.NoError(t, proof.Verify(block.Block.Hash()))
+	}
+	assert.EqualValues(t, bres.Height, result.FeltProof.Proof.Height)
+	assert.EqualValues(t, bres.Height+1, result.FeltResultProof.Proof.Height)
+
+	// felt proofs require prove
+	_, err = caller.Call(ctx, "tx", map[string]interface{}{"hash": bres.Hash, "format": "felt"}, result)
+	assert.Error(t, err)
+	_, err = caller.Call(ctx, "tx", map[string]interface{}{"hash": bres.Hash, "prove": true, "format": "json"}, result)
+	assert.Error(t, err)
+	// the kvstore does not prove its values with simple:v operations
+	_, err = c.ABCIQueryWithOptions(ctx, "/key", []byte("key"), client.ABCIQueryOptions{Prove: true, Format: "felt"})
+	assert.Error(t, err)
+}
+
 func TestTxSearchWithTimeout(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -5,6 +5,9 @@ package client
 type ABCIQueryOptions struct {
 	Height int64
 	Prove  bool
+	// Format of the proof, "felt" to also return it in the layout of the
+	// verifier contract. Slush addition.
+	Format string
 }
 
 // DefaultABCIQueryOptions are latest height (0) and prove false.
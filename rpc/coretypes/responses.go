@@ -227,6 +227,19 @@ type ResultTx struct {
 	TxResult abci.ResponseDeliverTx `json:"tx_result"`
 	Tx       types.Tx               `json:"tx"`
 	Proof    types.TxProof          `json:"proof,omitempty"`
+	// FeltProof proves Tx against the header at Height, and FeltResultProof
+	// TxResult against the next header, once it is stored. They are set when
+	// the felt proof format is requested. Slush addition.
+	FeltProof       *FeltProof `json:"felt_proof,omitempty"`
+	FeltResultProof *FeltProof `json:"felt_result_proof,omitempty"`
+}
+
+// FeltProof is an inclusion proof and its calldata for the verifyInclusion
+// view of the verifier contract, which checks it against the settled header
+// at Proof.Height. Slush addition.
+type FeltProof struct {
+	Proof    types.FeltProof `json:"proof"`
+	Calldata []string        `json:"calldata"`
 }
 
 // Result of searching for txs
@@ -257,6 +270,10 @@ type ResultABCIInfo struct {
 // Query abci msg
 type ResultABCIQuery struct {
 	Response abci.ResponseQuery `json:"response"`
+	// FeltProof proves the value of the response against the app hash of
+	// the header following its height, when the felt proof format is
+	// requested. Slush addition.
+	FeltProof *FeltProof `json:"felt_proof,omitempty"`
 }
 
 // Result of broadcasting evidence
@@ -264,6 +281,32 @@ type ResultBroadcastEvidence struct {
 	Hash []byte `json:"hash"`
 }
 
+// ResultSettlementTx is the settlement record of a height. Slush addition.
+type ResultSettlementTx struct {
+	Height int64 `json:"height"`
+	// Status is one of enqueued, submitted, accepted and rejected.
+	Status    string   `json:"status"`
+	TxHash    string   `json:"tx_hash,omitempty"`
+	Proposer  string   `json:"proposer"`
+	Submitter string   `json:"submitter,omitempty"`
+	Function  string   `json:"function"`
+	Calldata  []string `json:"calldata,omitempty"`
+}
+
+// ResultSettlementStatus is the progress of settlement on a target. Heights
+// are 0 until one reached the step. Slush addition.
+type ResultSettlementStatus struct {
+	Target string `json:"target"`
+	// Targets are the names of every settlement target of the node.
+	Targets             []string `json:"targets"`
+	LastEnqueuedHeight  int64    `json:"last_enqueued_height"`
+	LastSubmittedHeight int64    `json:"last_submitted_height"`
+	LastAcceptedHeight  int64    `json:"last_accepted_height"`
+	// PendingTxs are the submitted transactions that are not final.
+	PendingTxs []ResultSettlementTx `json:"pending_txs"`
+	LastError  string               `json:"last_error,omitempty"`
+}
+
 // empty results
 type (
 	ResultUnsafeFlushMempool struct{}
@@ -106,6 +106,9 @@ func NewTestHarness(logger log.Logger, cfg TestHarnessConfig) (*TestHarness, err
 		return nil, newTestHarnessError(ErrFailedToLoadGenesisFile, err, genesisFile)
 	}
 	logger.Info("Loaded genesis file", "chainID", st.ChainID)
+	if err := crypto.SetCommitmentHash(st.CommitmentHash); err != nil {
+		return nil, newTestHarnessError(ErrFailedToLoadGenesisFile, err, genesisFile)
+	}
 
 	spv, err := newTestHarnessListener(logger, cfg)
 	if err != nil {
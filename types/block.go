@@ -439,6 +439,17 @@ func (h Header) ValidateBasic() error {
 	return nil
 }
 
+// Indices of the fields of a header in the Merkle tree Hash computes, for
+// FieldProof.
+const (
+	HeaderFieldDataHash        = 6
+	HeaderFieldAppHash         = 10
+	HeaderFieldLastResultsHash = 11
+
+	// HeaderFields is the number of leaves of the tree.
+	HeaderFields = 14
+)
+
 // Hash returns the hash of the header.
 // It computes a Merkle tree from the header fields
 // ordered as they appear in the Header.
@@ -449,6 +460,22 @@ func (h *Header) Hash() tmbytes.HexBytes {
 	if h == nil || len(h.ValidatorsHash) == 0 {
 		return nil
 	}
+	return merkle.HashFromByteSlicesFelt(h.fieldHashes())
+}
+
+// FieldProof returns the proof of the field at index in the Merkle tree of
+// the header, one of the HeaderField constants. It returns nil where Hash
+// does.
+func (h *Header) FieldProof(index int) *merkle.Proof {
+	if h == nil || len(h.ValidatorsHash) == 0 || index < 0 || index >= HeaderFields {
+		return nil
+	}
+	_, proofs := merkle.ProofsFromByteSlicesFelt(h.fieldHashes())
+	return proofs[index]
+}
+
+// fieldHashes returns the leaves of the Merkle tree of the header.
+func (h *Header) fieldHashes() [][]byte {
 	hbz := h.Version.Hash()
 
 	pbt := HashTime(h.Time)
@@ -467,7 +494,7 @@ func (h *Header) Hash() tmbytes.HexBytes {
 
 	heightB_hash := crypto.Checksum128(heightB_int64)
 
-	return merkle.HashFromByteSlicesFelt([][]byte{
+	return [][]byte{
 		utils.ByteRounder(32)(hbz),
 		utils.ByteRounder(32)(crypto.Checksum128(chainIDB)),
 		utils.ByteRounder(32)(heightB_hash[:]),
@@ -482,7 +509,7 @@ func (h *Header) Hash() tmbytes.HexBytes {
 		utils.ByteRounder(32)([]byte(h.LastResultsHash)),
 		utils.ByteRounder(32)([]byte(h.EvidenceHash)),
 		utils.ByteRounder(32)([]byte(h.ProposerAddress)),
-	})
+	}
 }
 
 // StringIndented returns an indented string representation of the header.
@@ -237,6 +237,14 @@ func (b *EventBus) PublishEventValidatorSetUpdates(data EventDataValidatorSetUpd
 	return b.Publish(EventValidatorSetUpdatesValue, data)
 }
 
+func (b *EventBus) PublishEventSettlementSubmitted(data EventDataSettlement) error {
+	return b.Publish(EventSettlementSubmittedValue, data)
+}
+
+func (b *EventBus) PublishEventSettlementAccepted(data EventDataSettlement) error {
+	return b.Publish(EventSettlementAcceptedValue, data)
+}
+
 //-----------------------------------------------------------------------------
 type NopEventBus struct{}
 
@@ -324,3 +332,11 @@ func (NopEventBus) PublishEventBlockSyncStatus(data EventDataBlockSyncStatus) er
 func (NopEventBus) PublishEventStateSyncStatus(data EventDataStateSyncStatus) error {
 	return nil
 }
+
+func (NopEventBus) PublishEventSettlementSubmitted(data EventDataSettlement) error {
+	return nil
+}
+
+func (NopEventBus) PublishEventSettlementAccepted(data EventDataSettlement) error {
+	return nil
+}
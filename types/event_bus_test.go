@@ -325,7 +325,7 @@ func TestEventBusPublish(t *testing.T) {
 		}
 	})
 
-	const numEventsExpected = 14
+	const numEventsExpected = 16
 
 	sub, err := eventBus.Subscribe(context.Background(), "test", tmquery.All, numEventsExpected)
 	require.NoError(t, err)
@@ -374,6 +374,10 @@ func TestEventBusPublish(t *testing.T) {
 	require.NoError(t, err)
 	err = eventBus.PublishEventStateSyncStatus(EventDataStateSyncStatus{})
 	require.NoError(t, err)
+	err = eventBus.PublishEventSettlementSubmitted(EventDataSettlement{})
+	require.NoError(t, err)
+	err = eventBus.PublishEventSettlementAccepted(EventDataSettlement{})
+	require.NoError(t, err)
 
 	select {
 	case <-done:
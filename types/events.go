@@ -41,6 +41,11 @@ const (
 	EventUnlockValue          = "Unlock"
 	EventValidBlockValue      = "ValidBlock"
 	EventVoteValue            = "Vote"
+
+	// Settlement events, fired by the settlement reactor as commits are
+	// submitted to and accepted by the verifier on Starknet. Slush addition.
+	EventSettlementAcceptedValue  = "SettlementAccepted"
+	EventSettlementSubmittedValue = "SettlementSubmitted"
 )
 
 // Pre-populated ABCI Tendermint-reserved events
@@ -106,6 +111,7 @@ func init() {
 	tmjson.RegisterType(EventDataString(""), "tendermint/event/ProposalString")
 	tmjson.RegisterType(EventDataBlockSyncStatus{}, "tendermint/event/FastSyncStatus")
 	tmjson.RegisterType(EventDataStateSyncStatus{}, "tendermint/event/StateSyncStatus")
+	tmjson.RegisterType(EventDataSettlement{}, "tendermint/event/Settlement")
 }
 
 // Most event messages are basic types (a block, a transaction)
@@ -190,6 +196,17 @@ type EventDataStateSyncStatus struct {
 	Height   int64 `json:"height"`
 }
 
+// EventDataSettlement is fired when the settlement transaction of a height is
+// submitted, and when the height is settled, on each settlement target.
+// Heights settled by a transaction of another node, or batched with a later
+// height, have no transaction hash.
+type EventDataSettlement struct {
+	Target   string `json:"target"`
+	Height   int64  `json:"height"`
+	TxHash   string `json:"tx_hash,omitempty"`
+	Function string `json:"function,omitempty"`
+}
+
 // PUBSUB
 
 const (
@@ -229,6 +246,8 @@ var (
 	EventQueryVote                = QueryForEvent(EventVoteValue)
 	EventQueryBlockSyncStatus     = QueryForEvent(EventBlockSyncStatusValue)
 	EventQueryStateSyncStatus     = QueryForEvent(EventStateSyncStatusValue)
+	EventQuerySettlementAccepted  = QueryForEvent(EventSettlementAcceptedValue)
+	EventQuerySettlementSubmitted = QueryForEvent(EventSettlementSubmittedValue)
 )
 
 func EventQueryTxFor(tx Tx) tmpubsub.Query {
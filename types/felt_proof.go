@@ -0,0 +1,96 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/crypto/utils"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+)
+
+// FeltProof is a Merkle proof that Leaf is committed to by a field of the
+// header at Height, which Starknet contracts can verify against the header
+// hash settled by the verifier contract. Proof is the proof of Leaf in the
+// tree the field is the root of, and HeaderProof the proof of the field in
+// the tree of the header.
+//
+// Transactions are proven against the DataHash of the block that includes
+// them. The results of a block and the application state after it are
+// committed to by the next block, under LastResultsHash and AppHash.
+type FeltProof struct {
+	Height      int64            `json:"height"`
+	Field       int              `json:"field"`
+	Leaf        tmbytes.HexBytes `json:"leaf"`
+	Proof       merkle.Proof     `json:"proof"`
+	HeaderProof merkle.Proof     `json:"header_proof"`
+}
+
+// NewFeltProof returns the FeltProof of leaf, whose proof is the one of an
+// Int128 tree whose root is the given field of header. field is one of
+// HeaderFieldDataHash, HeaderFieldLastResultsHash and HeaderFieldAppHash.
+func NewFeltProof(header *Header, field int, leaf []byte, proof merkle.Proof) (FeltProof, error) {
+	switch field {
+	case HeaderFieldDataHash, HeaderFieldLastResultsHash, HeaderFieldAppHash:
+	default:
+		return FeltProof{}, fmt.Errorf("header field %d does not commit to a Merkle tree", field)
+	}
+	headerProof := header.FieldProof(field)
+	if headerProof == nil {
+		return FeltProof{}, errors.New("header has no hash")
+	}
+
+	root := proof.ComputeRootHashInt128()
+	if root == nil {
+		return FeltProof{}, errors.New("proof is not internally consistent")
+	}
+	if !bytes.Equal(utils.ByteRounder(32)(root), header.fieldHashes()[field]) {
+		return FeltProof{}, fmt.Errorf("proof matches different root than header field %d", field)
+	}
+	if err := proof.VerifyInt128(root, leaf); err != nil {
+		return FeltProof{}, err
+	}
+
+	return FeltProof{
+		Height:      header.Height,
+		Field:       field,
+		Leaf:        leaf,
+		Proof:       proof,
+		HeaderProof: *headerProof,
+	}, nil
+}
+
+// FeltProof returns the FeltProof of the transaction against the DataHash of
+// header, the header of the block that includes it.
+func (tp TxProof) FeltProof(header *Header) (FeltProof, error) {
+	return NewFeltProof(header, HeaderFieldDataHash, tp.Leaf(), tp.Proof)
+}
+
+// FeltProof returns the FeltProof of the result at index i against the
+// LastResultsHash of header, the header of the block following the results.
+func (a ABCIResults) FeltProof(header *Header, i int) (FeltProof, error) {
+	if i < 0 || i >= len(a) {
+		return FeltProof{}, fmt.Errorf("result index %d out of range [0, %d)", i, len(a))
+	}
+	return NewFeltProof(header, HeaderFieldLastResultsHash, a.toByteSlices()[i], a.ProveResult(i))
+}
+
+// Verify checks that the proof is internally consistent, and that it
+// matches headerHash, the hash of the header at Height.
+func (fp FeltProof) Verify(headerHash []byte) error {
+	if fp.HeaderProof.Index != int64(fp.Field) || fp.HeaderProof.Total != HeaderFields {
+		return fmt.Errorf("header proof is not the one of header field %d", fp.Field)
+	}
+	root := fp.Proof.ComputeRootHashInt128()
+	if root == nil {
+		return errors.New("proof is not internally consistent")
+	}
+	if err := fp.Proof.VerifyInt128(root, fp.Leaf); err != nil {
+		return err
+	}
+	if err := fp.HeaderProof.VerifyFelt(headerHash, utils.ByteRounder(32)(root)); err != nil {
+		return fmt.Errorf("header proof: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	ctest "github.com/tendermint/tendermint/internal/libs/test"
+)
+
+func TestTxFeltProof(t *testing.T) {
+	txs := makeTxs(5, 17)
+	header := makeFeltProofHeader(t)
+	header.DataHash = txs.Hash()
+
+	for i := range txs {
+		proof, err := txs.Proof(i).FeltProof(header)
+		require.NoError(t, err, "testCase%d failed", i)
+		assert.Equal(t, header.Height, proof.Height)
+		assert.Equal(t, HeaderFieldDataHash, proof.Field)
+		require.NoError(t, proof.Verify(header.Hash()), "testCase%d failed", i)
+
+		assert.Error(t, proof.Verify(ctest.MutateByteSlice(header.Hash())), "testCase%d failed", i)
+		tampered := proof
+		tampered.Leaf = ctest.MutateByteSlice(proof.Leaf)
+		assert.Error(t, tampered.Verify(header.Hash()), "testCase%d failed", i)
+		tampered = proof
+		tampered.Field = HeaderFieldAppHash
+		assert.Error(t, tampered.Verify(header.Hash()), "testCase%d failed", i)
+	}
+
+	// the transactions are not the ones of the header
+	_, err := makeTxs(5, 17).Proof(0).FeltProof(header)
+	assert.Error(t, err)
+}
+
+func TestResultFeltProof(t *testing.T) {
+	results := NewResults([]*abci.ResponseDeliverTx{
+		{Code: 0, Data: []byte("first")},
+		{},
+		{Code: 14, Data: []byte("third"), GasWanted: 10, GasUsed: 5},
+	})
+	header := makeFeltProofHeader(t)
+	header.LastResultsHash = results.Hash()
+
+	for i := range results {
+		proof, err := results.FeltProof(header, i)
+		require.NoError(t, err, "testCase%d failed", i)
+		assert.Equal(t, HeaderFieldLastResultsHash, proof.Field)
+		require.NoError(t, proof.Verify(header.Hash()), "testCase%d failed", i)
+	}
+
+	_, err := results.FeltProof(header, len(results))
+	assert.Error(t, err)
+}
+
+func TestValueFeltProof(t *testing.T) {
+	keys := [][]byte{[]byte("a"), []byte("b"), []byte("c")}
+	values := [][]byte{[]byte("1"), []byte("2"), []byte("3")}
+	leaves := make([][]byte, len(keys))
+	for i, key := range keys {
+		leaves[i] = merkle.NewValueOp(key, nil).Leaf(values[i])
+	}
+	appHash, proofs := merkle.ProofsFromByteSlicesInt128(leaves)
+	header := makeFeltProofHeader(t)
+	header.AppHash = appHash
+
+	for i, key := range keys {
+		op := merkle.NewValueOp(key, proofs[i])
+		root, err := op.Run([][]byte{values[i]})
+		require.NoError(t, err, "testCase%d failed", i)
+		require.Equal(t, appHash, root[0], "testCase%d failed", i)
+
+		proof, err := NewFeltProof(header, HeaderFieldAppHash, op.Leaf(values[i]), *op.Proof)
+		require.NoError(t, err, "testCase%d failed", i)
+		require.NoError(t, proof.Verify(header.Hash()), "testCase%d failed", i)
+
+		_, err = NewFeltProof(header, HeaderFieldAppHash, op.Leaf([]byte("other")), *op.Proof)
+		assert.Error(t, err, "testCase%d failed", i)
+		_, err = NewFeltProof(header, HeaderFieldDataHash, op.Leaf(values[i]), *op.Proof)
+		assert.Error(t, err, "testCase%d failed", i)
+		// the EvidenceHash
+		_, err = NewFeltProof(header, 12, op.Leaf(values[i]), *op.Proof)
+		assert.Error(t, err, "testCase%d failed", i)
+	}
+}
+
+func TestHeaderFieldProof(t *testing.T) {
+	header := makeFeltProofHeader(t)
+	leaves := header.fieldHashes()
+	require.Len(t, leaves, HeaderFields)
+	for i, leaf := range leaves {
+		proof := header.FieldProof(i)
+		require.NotNil(t, proof, "testCase%d failed", i)
+		require.NoError(t, proof.VerifyFelt(header.Hash(), leaf), "testCase%d failed", i)
+	}
+	assert.Nil(t, header.FieldProof(HeaderFields))
+	assert.Nil(t, (&Header{}).FieldProof(HeaderFieldDataHash))
+}
+
+// makeFeltProofHeader returns a header with a hash, for the trees of its
+// fields to be set.
+func makeFeltProofHeader(t *testing.T) *Header {
+	t.Helper()
+	vals, _ := randValidatorPrivValSet(1, 10)
+	return &Header{
+		ChainID:         "test",
+		Height:          3,
+		ValidatorsHash:  vals.Hash(),
+		ProposerAddress: vals.Validators[0].Address,
+	}
+}
@@ -42,6 +42,13 @@ type GenesisDoc struct {
 	Validators      []GenesisValidator `json:"validators,omitempty"`
 	AppHash         tmbytes.HexBytes   `json:"app_hash"`
 	AppState        json.RawMessage    `json:"app_state,omitempty"`
+
+	// CommitmentHash is the hash committing to the headers, votes,
+	// transactions and merkle trees of the chain, which stark keys also sign
+	// with: pedersen, the default if empty, or poseidon. It is selected for
+	// the whole process, see crypto.SetCommitmentHash, and poseidon chains
+	// cannot settle. Slush addition.
+	CommitmentHash string `json:"commitment_hash,omitempty"`
 }
 
 // SaveAs is a utility method for saving GenensisDoc as a JSON file.
@@ -80,6 +87,10 @@ func (genDoc *GenesisDoc) ValidateAndComplete() error {
 		genDoc.InitialHeight = 1
 	}
 
+	if err := crypto.ValidateCommitmentHash(genDoc.CommitmentHash); err != nil {
+		return err
+	}
+
 	if genDoc.ConsensusParams == nil {
 		genDoc.ConsensusParams = DefaultConsensusParams()
 	} else if err := genDoc.ConsensusParams.ValidateConsensusParams(); err != nil {
@@ -20,8 +20,9 @@ func TestGenesisBad(t *testing.T) {
 		{},              // empty
 		{1, 1, 1, 1, 1}, // junk
 		[]byte(`{}`),    // empty
-		[]byte(`{"chain_id":"mychain","validators":[{}]}`),   // invalid validator
-		[]byte(`{"chain_id":"chain","initial_height":"-1"}`), // negative initial height
+		[]byte(`{"chain_id":"mychain","validators":[{}]}`),        // invalid validator
+		[]byte(`{"chain_id":"chain","initial_height":"-1"}`),      // negative initial height
+		[]byte(`{"chain_id":"chain","commitment_hash":"sha256"}`), // unknown commitment hash
 		// missing pub_key type
 		[]byte(
 			`{"validators":[{"pub_key":{"value":"AT/+8f10f86d337f7d1b98b43027e0b99164adaa06b03801c9686fc4643875ee25a7="},"power":"10","name":""}]}`,
@@ -122,10 +123,11 @@ func TestGenesisGood(t *testing.T) {
 
 	// Genesis doc from raw json
 	missingValidatorsTestCases := [][]byte{
-		[]byte(`{"chain_id":"mychain"}`),                   // missing validators
-		[]byte(`{"chain_id":"mychain","validators":[]}`),   // missing validators
-		[]byte(`{"chain_id":"mychain","validators":null}`), // nil validator
-		[]byte(`{"chain_id":"mychain"}`),                   // missing validators
+		[]byte(`{"chain_id":"mychain"}`),                              // missing validators
+		[]byte(`{"chain_id":"mychain","validators":[]}`),              // missing validators
+		[]byte(`{"chain_id":"mychain","validators":null}`),            // nil validator
+		[]byte(`{"chain_id":"mychain"}`),                              // missing validators
+		[]byte(`{"chain_id":"mychain","commitment_hash":"poseidon"}`), // missing validators
 	}
 
 	for _, tc := range missingValidatorsTestCases {
@@ -11,15 +11,19 @@ import (
 	"github.com/tendermint/tendermint/crypto/ed25519"
 )
 
-// NodeIDByteLength is the length of a crypto.Address. Currently only 20.
-// FIXME: support other length addresses?
-const NodeIDByteLength = ed25519.AddressSize
+const (
+	// NodeIDByteLength is the length of the address of an ed25519 node key.
+	NodeIDByteLength = ed25519.AddressSize
+	// StarkNodeIDByteLength is the length of the address of a stark node key.
+	StarkNodeIDByteLength = crypto.AddressSize
+)
 
 // reNodeID is a regexp for valid node IDs.
-var reNodeID = regexp.MustCompile(`^[0-9a-fA-F]{` + fmt.Sprint(2*NodeIDByteLength) + `}$`)
+var reNodeID = regexp.MustCompile(`^[0-9a-fA-F]+$`)
 
 // NodeID is a hex-encoded crypto.Address. It must be lowercased
-// (for uniqueness) and of length 2*NodeIDByteLength.
+// (for uniqueness) and of length 2*NodeIDByteLength, or 2*StarkNodeIDByteLength
+// for a stark node key.
 type NodeID string
 
 // NewNodeID returns a lowercased (normalized) NodeID, or errors if the
@@ -56,8 +60,9 @@ func (id NodeID) Validate() error {
 	case len(id) == 0:
 		return errors.New("empty node ID")
 
-	case len(id) != 2*NodeIDByteLength:
-		return fmt.Errorf("invalid node ID length %d, expected %d", len(id), 2*NodeIDByteLength)
+	case len(id) != 2*NodeIDByteLength && len(id) != 2*StarkNodeIDByteLength:
+		return fmt.Errorf("invalid node ID length %d, expected %d or %d",
+			len(id), 2*NodeIDByteLength, 2*StarkNodeIDByteLength)
 
 	case !reNodeID.MatchString(string(id)):
 		return fmt.Errorf("node ID can only contain lowercased hex digits")
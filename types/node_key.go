@@ -1,10 +1,12 @@
 package types
 
 import (
+	"fmt"
 	"io/ioutil"
 
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/stark"
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	tmos "github.com/tendermint/tendermint/libs/os"
 )
@@ -56,9 +58,25 @@ func LoadOrGenNodeKey(filePath string) (NodeKey, error) {
 	return nodeKey, nil
 }
 
-// GenNodeKey generates a new node key.
+// GenNodeKey generates a new ed25519 node key.
 func GenNodeKey() NodeKey {
-	privKey := ed25519.GenPrivKey()
+	return newNodeKey(ed25519.GenPrivKey())
+}
+
+// GenNodeKeyOfType generates a new node key of the given type, ed25519 if
+// keyType is empty.
+func GenNodeKeyOfType(keyType string) (NodeKey, error) {
+	switch keyType {
+	case "", ABCIPubKeyTypeEd25519:
+		return GenNodeKey(), nil
+	case ABCIPubKeyTypeStark:
+		return newNodeKey(stark.GenPrivKey()), nil
+	default:
+		return NodeKey{}, fmt.Errorf("node key type: %s is not supported", keyType)
+	}
+}
+
+func newNodeKey(privKey crypto.PrivKey) NodeKey {
 	return NodeKey{
 		ID:      NodeIDFromPubKey(privKey.PubKey()),
 		PrivKey: privKey,
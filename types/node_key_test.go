@@ -3,8 +3,10 @@ package types_test
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/tendermint/tendermint/types"
@@ -43,3 +45,56 @@ func TestNodeKeySaveAs(t *testing.T) {
 	require.NoError(t, nodeKey.SaveAs(filePath))
 	require.FileExists(t, filePath)
 }
+
+func TestGenNodeKeyOfType(t *testing.T) {
+	testCases := []struct {
+		keyType string
+		idLen   int
+		expErr  bool
+	}{
+		0: {"", 2 * types.NodeIDByteLength, false},
+		1: {types.ABCIPubKeyTypeEd25519, 2 * types.NodeIDByteLength, false},
+		2: {types.ABCIPubKeyTypeStark, 2 * types.StarkNodeIDByteLength, false},
+		3: {types.ABCIPubKeyTypeSecp256k1, 0, true},
+	}
+
+	for i, tc := range testCases {
+		nodeKey, err := types.GenNodeKeyOfType(tc.keyType)
+		if tc.expErr {
+			assert.Error(t, err, "testCase%d failed", i)
+			continue
+		}
+		require.NoError(t, err, "testCase%d failed", i)
+		assert.Len(t, nodeKey.ID, tc.idLen, "testCase%d failed", i)
+		assert.NoError(t, nodeKey.ID.Validate(), "testCase%d failed", i)
+
+		// the ID of a saved key is the same once loaded
+		filePath := filepath.Join(t.TempDir(), "node_key.json")
+		require.NoError(t, nodeKey.SaveAs(filePath), "testCase%d failed", i)
+		loaded, err := types.LoadNodeKey(filePath)
+		require.NoError(t, err, "testCase%d failed", i)
+		assert.Equal(t, nodeKey, loaded, "testCase%d failed", i)
+	}
+}
+
+func TestNodeIDValidate(t *testing.T) {
+	testCases := []struct {
+		id     types.NodeID
+		expErr bool
+	}{
+		0: {types.NodeID(strings.Repeat("a", 2*types.NodeIDByteLength)), false},
+		1: {types.NodeID(strings.Repeat("a", 2*types.StarkNodeIDByteLength)), false},
+		2: {"", true},
+		3: {types.NodeID(strings.Repeat("a", 2*types.NodeIDByteLength+2)), true},
+		4: {types.NodeID(strings.Repeat("g", 2*types.NodeIDByteLength)), true},
+	}
+
+	for i, tc := range testCases {
+		err := tc.id.Validate()
+		if tc.expErr {
+			assert.Error(t, err, "testCase%d failed", i)
+		} else {
+			assert.NoError(t, err, "testCase%d failed", i)
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/stark"
+	privvalproto "github.com/tendermint/tendermint/proto/tendermint/privval"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 )
 
@@ -32,6 +33,13 @@ type PrivValidator interface {
 	SignProposal(ctx context.Context, chainID string, proposal *tmproto.Proposal) error
 }
 
+// StarknetSigner is implemented by the PrivValidators which also hold the key
+// of the Starknet account settling commits. SignStarknetTransaction sets the
+// signature of tx, and fails if a call of tx is not allowed by the signer.
+type StarknetSigner interface {
+	SignStarknetTransaction(ctx context.Context, chainID string, tx *privvalproto.StarknetInvokeTransaction) error
+}
+
 type PrivValidatorsByAddress []PrivValidator
 
 func (pvs PrivValidatorsByAddress) Len() int {
@@ -8,6 +8,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/poseidon"
 	ctest "github.com/tendermint/tendermint/internal/libs/test"
 	tmrand "github.com/tendermint/tendermint/libs/rand"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
@@ -99,6 +101,28 @@ func TestValidTxProof(t *testing.T) {
 	}
 }
 
+func TestTxCommitmentHash(t *testing.T) {
+	t.Cleanup(func() { require.NoError(t, crypto.SetCommitmentHash(crypto.CommitmentHashPedersen)) })
+
+	txs := Txs{Tx("foo"), Tx("bar"), Tx("baz")}
+	pedersenLeaf := txs[0].Hash()
+	pedersenRoot := txs.Hash()
+
+	require.NoError(t, crypto.SetCommitmentHash(crypto.CommitmentHashPoseidon))
+	leaf := poseidon.Sum128(txs[0])
+	require.Equal(t, leaf[:], txs[0].Hash())
+	require.Equal(t, TxKey(leaf), txs[0].Key())
+	require.NotEqual(t, pedersenLeaf, txs[0].Hash())
+
+	root := txs.Hash()
+	require.NotEqual(t, pedersenRoot, root)
+	for i := range txs {
+		proof := txs.Proof(i)
+		assert.Nil(t, proof.Validate(root), "%d", i)
+		assert.NotNil(t, proof.Validate(pedersenRoot), "%d", i)
+	}
+}
+
 func TestTxProofUnchangable(t *testing.T) {
 	// run the other test a bunch...
 	for i := 0; i < 40; i++ {